@@ -11,6 +11,7 @@ import {
 import { drawBackground } from "./render/drawBackground";
 import { drawExplosion } from "./render/drawExplosion";
 import { drawLaser } from "./render/drawLaser";
+import { drawPickup } from "./render/drawPickup";
 import { drawRocket } from "./render/drawRocket";
 import { drawSpaceship } from "./render/drawSpaceship";
 import { getScoreboard, getSpaceship } from "./utils";
@@ -23,6 +24,7 @@ import spaceshipFactories, {
   isAsteroid,
   isExplosion,
   isLaser,
+  isPickup,
   isRocket,
   isSpaceship,
 } from "../../../spaceships";
@@ -237,6 +239,12 @@ export const engine = async ({ canvasId, width, height, fps }: EngineProps) => {
             scale: 0.75,
             showCollider,
           });
+        } else if (isPickup(gameObject)) {
+          drawPickup({
+            render,
+            pickup: gameObject,
+            elapsedTimeMs,
+          });
         } else if (isExplosion(gameObject)) {
           drawExplosion({
             render,
@@ -0,0 +1,39 @@
+import type { Pickup } from "../../../../spaceships/types";
+import { Render } from "./render";
+import { COLOR_ENERGY, COLOR_HEALTH } from ".";
+
+const PICKUP_COLORS: Record<Pickup["pickupType"], string> = {
+  health: COLOR_HEALTH,
+  energy: COLOR_ENERGY,
+  weaponBoost: "orange",
+  shield: "#b388ff",
+};
+
+export const drawPickup = ({
+  render,
+  pickup,
+  elapsedTimeMs,
+}: {
+  render: Render;
+  pickup: Pickup;
+  elapsedTimeMs: number;
+}) => {
+  // Pulses, faster in the last seconds of the lifespan
+  const rate = pickup.lifespanSec < 3 ? 100 : 300;
+  const pulse = 1 + Math.sin(elapsedTimeMs / rate) * 0.15;
+  const color = PICKUP_COLORS[pickup.pickupType];
+
+  render.drawCircle(
+    color,
+    2,
+    pickup.position.x,
+    pickup.position.y,
+    pickup.collider.radius * pulse
+  );
+  render.drawCircleFilled(
+    color,
+    pickup.position.x,
+    pickup.position.y,
+    pickup.collider.radius / 3
+  );
+};
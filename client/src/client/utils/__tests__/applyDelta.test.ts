@@ -0,0 +1,39 @@
+import { GameState } from "../../../../../spaceships";
+import { applyDelta } from "../applyDelta";
+
+const state = {
+  status: "running",
+  gameObjects: [
+    { id: 1, type: "spaceship" },
+    { id: 2, type: "asteroid" },
+    { id: 3, type: "laser" },
+  ],
+} as GameState;
+
+describe("client / utils / applyDelta", () => {
+  test("tick only", () => {
+    expect(applyDelta(state, { tick: 5 })).toBe(state);
+  });
+
+  test("changed, spawned and removed", () => {
+    const delta = {
+      tick: 6,
+      status: "running" as const,
+      gameObjects: [{ id: 1, type: "spaceship", score: 10 }, { id: 4, type: "rocket" }] as GameState["gameObjects"],
+      removed: [3],
+    };
+    const applied = applyDelta(state, delta);
+
+    expect(applied.gameObjects.map((gameObject) => gameObject.id)).toEqual([1, 2, 4]);
+    expect(applied.gameObjects[0]).toBe(delta.gameObjects[0]);
+    expect(state.gameObjects).toHaveLength(3);
+  });
+
+  test("full", () => {
+    const gameObjects = [{ id: 7, type: "asteroid" }] as GameState["gameObjects"];
+    const applied = applyDelta(state, { tick: 1, full: true, status: "ended", gameObjects });
+
+    expect(applied.gameObjects).toEqual(gameObjects);
+    expect(applied.status).toBe("ended");
+  });
+});
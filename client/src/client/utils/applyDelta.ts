@@ -0,0 +1,37 @@
+import type { GameState } from "../../../../spaceships";
+
+export type StateDelta = {
+  tick: number;
+  since?: number;
+  full?: boolean;
+  status?: GameState["status"];
+  elapsedMs?: number;
+  gameObjects?: GameState["gameObjects"];
+  removed?: number[];
+};
+
+// Mirrors the kernel's ApplyDelta: the changed game objects replace the ones of the same id,
+// the new ones are appended and the removed ones dropped.
+export const applyDelta = (state: GameState, delta: StateDelta): GameState => {
+  if (!delta.gameObjects) {
+    return state;
+  }
+  const status = delta.status ?? state.status;
+  if (delta.full) {
+    return { ...state, status, gameObjects: [...delta.gameObjects] };
+  }
+
+  const removed = new Set(delta.removed ?? []);
+  const gameObjects = state.gameObjects.filter((gameObject) => !removed.has(gameObject.id));
+  const indexes = new Map(gameObjects.map((gameObject, index) => [gameObject.id, index]));
+  for (const gameObject of delta.gameObjects) {
+    const index = indexes.get(gameObject.id);
+    if (index === undefined) {
+      indexes.set(gameObject.id, gameObjects.length);
+      gameObjects.push(gameObject);
+    } else {
+      gameObjects[index] = gameObject;
+    }
+  }
+  return { ...state, status, gameObjects };
+};
@@ -4,5 +4,7 @@ export { isCollisionLog, isGameStateLog, isKillLog, isDamageLog } from "./log";
 export { getStartLocations } from "./startLocations";
 export { getScoreboard } from "./scoreboard";
 export { getSpaceship } from "./getSpaceship";
+export { applyDelta } from "./applyDelta";
 export type { Vector2 } from "./vector2";
-export type { ScoreboardEntry } from "./scoreboard";
\ No newline at end of file
+export type { ScoreboardEntry } from "./scoreboard";
+export type { StateDelta } from "./applyDelta";
\ No newline at end of file
@@ -9,6 +9,9 @@ declare namespace spaceWars {
   function pause(): void;
   function reset(): void;
   function state(): import('../../spaceships').GameState;
+  // The GameState message of kernel/game/proto/game_state.proto
+  function binaryState(): Uint8Array;
+  function stateDelta(since: number): import('./client/utils').StateDelta;
   function fromState(state: string): void;
   function addSpaceship(
     name: string,
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"plugin"
+	"sort"
+	"strings"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/sandbox"
+	"github.com/davidhorak/space-wars/kernel/scripting"
+)
+
+// PluginSymbol is the constructor a bot plugin exports, a func() game.Bot called once per match.
+const PluginSymbol = "NewBot"
+
+// BotSpec is a spaceship of the match and what controls it, parsed from "name=kind". The kinds are
+// the reference bots (idle, chaser, orbiter), "script:<file>" for the scripted actions, "lua:<file>"
+// for a Lua bot, "wasm:<file>" for a WebAssembly bot run in the sandbox and "plugin:<file>" for a Go
+// plugin exporting PluginSymbol.
+type BotSpec struct {
+	Name      string
+	Kind      string
+	Path      string           // Script, Lua, WebAssembly or plugin file
+	script    []ScriptedAction // Loaded once, replayed in every match
+	lua       *scripting.Script
+	wasm      *sandbox.Module
+	newPlugin func() game.Bot
+}
+
+// ScriptedAction is a command issued once the game reaches the tick,
+// e.g. {"tick": 0, "action": "setEngineThrust", "args": [100, 0, 0]}.
+type ScriptedAction struct {
+	Tick   uint64    `json:"tick"`
+	Action string    `json:"action"`
+	Args   []float64 `json:"args,omitempty"`
+}
+
+// ParseBotSpecs parses the comma separated specs, e.g. "alpha=chaser,beta=script:beta.json".
+func ParseBotSpecs(value string) ([]BotSpec, error) {
+	var specs []BotSpec
+	names := map[string]bool{}
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		name, kind, ok := strings.Cut(field, "=")
+		if !ok || name == "" || kind == "" {
+			return nil, fmt.Errorf("invalid bot %q, expected name=kind", field)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("duplicate bot name: %s", name)
+		}
+		names[name] = true
+
+		spec := BotSpec{Name: name, Kind: kind}
+		if prefix, path, ok := strings.Cut(kind, ":"); ok {
+			spec.Kind, spec.Path = prefix, path
+		}
+		if err := spec.load(); err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	if len(specs) < 2 {
+		return nil, errors.New("a match needs at least two bots")
+	}
+	return specs, nil
+}
+
+func (spec *BotSpec) load() error {
+	switch spec.Kind {
+	case "idle", "chaser", "orbiter":
+		return nil
+	case "script":
+		data, err := os.ReadFile(spec.Path)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &spec.script); err != nil {
+			return fmt.Errorf("invalid script %s: %w", spec.Path, err)
+		}
+		sort.SliceStable(spec.script, func(i, j int) bool { return spec.script[i].Tick < spec.script[j].Tick })
+		for _, action := range spec.script {
+			if err := (game.Command{Ship: spec.Name, Action: action.Action, Args: action.Args}).Validate(); err != nil {
+				return fmt.Errorf("invalid script %s: %w", spec.Path, err)
+			}
+		}
+		return nil
+	case "lua":
+		source, err := os.ReadFile(spec.Path)
+		if err != nil {
+			return err
+		}
+		if spec.lua, err = scripting.Compile(spec.Name, string(source)); err != nil {
+			return err
+		}
+		// Fails early on the script not defining think, its loading runs the same in every match
+		_, err = spec.lua.NewBot(nil)
+		return err
+	case "wasm":
+		binary, err := os.ReadFile(spec.Path)
+		if err != nil {
+			return err
+		}
+		if spec.wasm, err = sandbox.Compile(binary); err != nil {
+			return err
+		}
+		_, err = spec.wasm.NewBot(sandbox.DefaultLimits(), nil)
+		return err
+	case "plugin":
+		loaded, err := plugin.Open(spec.Path)
+		if err != nil {
+			return err
+		}
+		symbol, err := loaded.Lookup(PluginSymbol)
+		if err != nil {
+			return err
+		}
+		newBot, ok := symbol.(func() game.Bot)
+		if !ok {
+			return fmt.Errorf("plugin %s: %s is not a func() game.Bot", spec.Path, PluginSymbol)
+		}
+		spec.newPlugin = newBot
+		return nil
+	default:
+		return fmt.Errorf("invalid bot kind: %s", spec.Kind)
+	}
+}
+
+// Strategy returns a fresh strategy for a match, the bots do not carry any state between the matches.
+func (spec *BotSpec) Strategy(size physics.Size) game.BotStrategy {
+	switch spec.Kind {
+	case "chaser":
+		return game.NewBotStrategy(game.ChaserBot{})
+	case "orbiter":
+		radius := min(size.Width, size.Height) / 3
+		return game.NewBotStrategy(game.OrbiterBot{Radius: radius, Thrust: game.MaxThrust / 2})
+	case "script":
+		return newScriptStrategy(spec.Name, spec.script)
+	case "lua":
+		// A bot over the memory limits is disqualified, its spaceship disabled
+		if bot, err := spec.lua.NewBot(nil); err == nil {
+			return bot.Strategy()
+		}
+		return game.NewBotStrategy(game.IdleBot{})
+	case "wasm":
+		// A bot exceeding its limits is disqualified, its spaceship disabled
+		if bot, err := spec.wasm.NewBot(sandbox.DefaultLimits(), nil); err == nil {
+			return bot.Strategy()
+		}
+		return game.NewBotStrategy(game.IdleBot{})
+	case "plugin":
+		return game.NewBotStrategy(spec.newPlugin())
+	default:
+		return game.NewBotStrategy(game.IdleBot{})
+	}
+}
+
+// newScriptStrategy applies the scripted actions once the game reaches their ticks.
+func newScriptStrategy(name string, script []ScriptedAction) game.BotStrategy {
+	next := 0
+	return game.BotStrategyFunc(func(spaceship *game.Spaceship, gameManager *game.GameManager, deltaTimeMs float64) {
+		tick := gameManager.CurrentTick()
+		for next < len(script) && script[next].Tick <= tick {
+			action := script[next]
+			next++
+			named, err := game.Command{Ship: name, Action: action.Action, Args: action.Args}.NamedAction()
+			if err != nil {
+				continue
+			}
+			named.Action(spaceship, gameManager)
+		}
+	})
+}
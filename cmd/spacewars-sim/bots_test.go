@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeScript(t *testing.T, script string) string {
+	path := filepath.Join(t.TempDir(), "script.json")
+	assert.NoError(t, os.WriteFile(path, []byte(script), 0o644))
+	return path
+}
+
+func TestParseBotSpecs(t *testing.T) {
+	specs, err := ParseBotSpecs("alpha=chaser, beta=orbiter,gamma=idle")
+	assert.NoError(t, err)
+	assert.Equal(t, []BotSpec{{Name: "alpha", Kind: "chaser"}, {Name: "beta", Kind: "orbiter"}, {Name: "gamma", Kind: "idle"}}, specs)
+
+	path := writeScript(t, `[{"tick": 5, "action": "fireLaser"}, {"tick": 0, "action": "setEngineThrust", "args": [100, 0, 0]}]`)
+	specs, err = ParseBotSpecs("alpha=chaser,beta=script:" + path)
+	assert.NoError(t, err)
+	assert.Equal(t, path, specs[1].Path)
+	assert.Equal(t, []ScriptedAction{
+		{Tick: 0, Action: game.CommandSetEngineThrust, Args: []float64{100, 0, 0}},
+		{Tick: 5, Action: game.CommandFireLaser},
+	}, specs[1].script)
+
+	for value, message := range map[string]string{
+		"alpha=chaser":               "a match needs at least two bots",
+		"alpha=chaser,beta":          `invalid bot "beta", expected name=kind`,
+		"alpha=chaser,alpha=idle":    "duplicate bot name: alpha",
+		"alpha=chaser,beta=kamikaze": "invalid bot kind: kamikaze",
+		"alpha=chaser,beta=script:" + writeScript(t, `[{"tick": 0, "action": "selfDestruct"}]`): "invalid action: selfDestruct",
+	} {
+		_, err := ParseBotSpecs(value)
+		assert.ErrorContains(t, err, message, value)
+	}
+
+	_, err = ParseBotSpecs("alpha=chaser,beta=plugin:missing.so")
+	assert.Error(t, err)
+
+	specs, err = ParseBotSpecs("alpha=chaser,beta=lua:" + writeScript(t, "function think(self) thrust(100) end"))
+	assert.NoError(t, err)
+	assert.IsType(t, game.BotStrategyFunc(nil), specs[1].Strategy(physics.Size{Width: 1000, Height: 1000}))
+	_, err = ParseBotSpecs("alpha=chaser,beta=lua:" + writeScript(t, "x = 1"))
+	assert.EqualError(t, err, "beta:0: the think function is not defined")
+	_, err = ParseBotSpecs("alpha=chaser,beta=wasm:" + writeScript(t, "\x00asm\x01\x00\x00\x00"))
+	assert.EqualError(t, err, "missing export: think")
+}
+
+func TestScriptStrategy(t *testing.T) {
+	instance := game.NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	strategy := newScriptStrategy("alpha", []ScriptedAction{
+		{Tick: 0, Action: game.CommandSetEngineThrust, Args: []float64{100, 0, 0}},
+		{Tick: 3, Action: game.CommandFireLaser},
+	})
+	ship, err := instance.AddSpaceshipWithBot(game.SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 100}}, strategy)
+	assert.NoError(t, err)
+	instance.Start()
+
+	projectiles := func() int {
+		count := 0
+		_ = instance.SpaceshipAction("alpha", func(spaceShip *game.Spaceship, gameManager *game.GameManager) {
+			count = spaceShip.ProjectileCount(gameManager)
+		})
+		return count
+	}
+
+	instance.Update(16)
+	assert.Greater(t, ship.Speed(), 0.0)
+	assert.Equal(t, 0, projectiles())
+	instance.Update(16)
+	instance.Update(16)
+	assert.Equal(t, 1, projectiles())
+}
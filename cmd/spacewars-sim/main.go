@@ -0,0 +1,78 @@
+// Command spacewars-sim runs headless matches between bots and writes a JSON line per match,
+// e.g. for evaluating a bot change over thousands of matches:
+//
+//	spacewars-sim -matches 1000 -seed 1 -bots "alpha=chaser,beta=plugin:beta.so" > results.jsonl
+//
+// Match i is played with the seed + i, so a run is reproducible and a single match can be replayed
+// by its seed alone.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, output io.Writer) error {
+	flags := flag.NewFlagSet("spacewars-sim", flag.ContinueOnError)
+	matches := flags.Int("matches", 1, "number of the matches")
+	seed := flags.Int64("seed", 1, "seed of the first match, the next matches increment it")
+	width := flags.Float64("width", 1920, "arena width")
+	height := flags.Float64("height", 1080, "arena height")
+	timeLimitMs := flags.Float64("time-limit-ms", 180000, "game time limit of a match")
+	tickMs := flags.Float64("tick-ms", 16, "simulated time per tick")
+	arena := flags.String("arena", "wrap", "arena edges, wrap or bounded")
+	bots := flags.String("bots", "alpha=chaser,beta=orbiter",
+		"comma separated name=kind, the kinds are idle, chaser, orbiter, script:<file.json>, lua:<file.lua>, wasm:<file.wasm> and plugin:<file.so>")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *matches < 1 {
+		return fmt.Errorf("invalid number of matches: %d", *matches)
+	}
+	if *tickMs <= 0 || *timeLimitMs <= 0 {
+		return fmt.Errorf("the tick and the time limit must be positive")
+	}
+
+	specs, err := ParseBotSpecs(*bots)
+	if err != nil {
+		return err
+	}
+	config := MatchConfig{
+		Size:        physics.Size{Width: *width, Height: *height},
+		TimeLimitMs: *timeLimitMs,
+		TickMs:      *tickMs,
+		Arena:       game.ArenaMode(*arena),
+		Bots:        specs,
+	}
+	if err := config.gameConfig().Validate(); err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(output)
+	defer writer.Flush()
+	encoder := json.NewEncoder(writer)
+	for match := 0; match < *matches; match++ {
+		if err := encoder.Encode(RunMatch(config, match, *seed+int64(match))); err != nil {
+			return err
+		}
+		// Flushed per match, an interrupted overnight run keeps the finished matches
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
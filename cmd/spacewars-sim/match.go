@@ -0,0 +1,87 @@
+package main
+
+import (
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// MatchConfig is shared by all the matches of a run, only the seed differs.
+type MatchConfig struct {
+	Size        physics.Size
+	TimeLimitMs float64
+	TickMs      float64
+	Arena       game.ArenaMode // Wrap when empty
+	Bots        []BotSpec
+}
+
+// MatchResult is a line of the output.
+type MatchResult struct {
+	Match      int                      `json:"match"`
+	Seed       int64                    `json:"seed"`
+	Winner     string                   `json:"winner"`
+	EndReason  string                   `json:"endReason"`
+	DurationMs float64                  `json:"durationMs"`
+	Ticks      uint64                   `json:"ticks"`
+	Scoreboard []map[string]interface{} `json:"scoreboard"`
+	Error      string                   `json:"error,omitempty"`
+}
+
+// RunMatch plays a match to its end. The spaceships start evenly spread on a circle around
+// the center, facing it, so no bot is favored by its starting position.
+func RunMatch(config MatchConfig, match int, seed int64) MatchResult {
+	result := MatchResult{Match: match, Seed: seed}
+
+	instance, err := game.NewGameWithConfig(config.Size, seed, config.gameConfig())
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer instance.Close()
+	instance.SeedAsteroids()
+
+	center := physics.Vector2{X: config.Size.Width / 2, Y: config.Size.Height / 2}
+	radius := math.Min(config.Size.Width, config.Size.Height) * 0.4
+	for i, spec := range config.Bots {
+		angle := 2 * math.Pi * float64(i) / float64(len(config.Bots))
+		offset := physics.FromAngle(angle)
+		shipConfig := game.SpaceshipConfig{
+			Name:     spec.Name,
+			Position: center.Add(offset.Multiply(radius)),
+			Rotation: angle + math.Pi,
+		}
+		if _, err := instance.AddSpaceshipWithBot(shipConfig, spec.Strategy(config.Size)); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+	}
+
+	instance.Start()
+	for instance.Status() != game.Ended {
+		instance.Update(config.TickMs)
+	}
+
+	scoreboard := instance.Scoreboard()
+	result.Winner, _ = scoreboard.Winner()
+	result.EndReason = instance.EndReason()
+	if result.EndReason == "" {
+		result.EndReason = "lastShipStanding"
+	}
+	summary, _ := instance.Summary()
+	result.DurationMs = summary.Duration
+	result.Ticks = uint64(summary.Rounds)
+	for _, entry := range scoreboard.Serialize() {
+		result.Scoreboard = append(result.Scoreboard, entry.(map[string]interface{}))
+	}
+	return result
+}
+
+func (config MatchConfig) gameConfig() game.GameConfig {
+	gameConfig := game.DefaultGameConfig()
+	gameConfig.TimeLimitMs = config.TimeLimitMs
+	if config.Arena != "" {
+		gameConfig.Arena = config.Arena
+	}
+	return gameConfig
+}
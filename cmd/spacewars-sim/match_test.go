@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunMatch(t *testing.T) {
+	specs, err := ParseBotSpecs("alpha=chaser,beta=idle")
+	assert.NoError(t, err)
+	config := MatchConfig{Size: physics.Size{Width: 1000, Height: 1000}, TimeLimitMs: 60000, TickMs: 16, Bots: specs}
+
+	result := RunMatch(config, 3, 42)
+	assert.Equal(t, 3, result.Match)
+	assert.Equal(t, int64(42), result.Seed)
+	assert.Equal(t, "alpha", result.Winner)
+	assert.NotEmpty(t, result.EndReason)
+	assert.Greater(t, result.Ticks, uint64(0))
+	assert.Len(t, result.Scoreboard, 2)
+	assert.Empty(t, result.Error)
+
+	// Reproducible by the seed
+	assert.Equal(t, result, RunMatch(config, 3, 42))
+}
+
+func TestRun(t *testing.T) {
+	var output bytes.Buffer
+	err := run([]string{"-matches", "3", "-seed", "10", "-time-limit-ms", "1000", "-bots", "alpha=idle,beta=idle"}, &output)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	assert.Len(t, lines, 3)
+	for i, line := range lines {
+		var result MatchResult
+		assert.NoError(t, json.Unmarshal([]byte(line), &result))
+		assert.Equal(t, i, result.Match)
+		assert.Equal(t, int64(10+i), result.Seed)
+		assert.Equal(t, "timeLimitReached", result.EndReason)
+	}
+
+	assert.EqualError(t, run([]string{"-matches", "0"}, &output), "invalid number of matches: 0")
+	assert.Error(t, run([]string{"-bots", "alpha=idle"}, &output))
+	assert.EqualError(t, run([]string{"-arena", "sphere"}, &output), "invalid game config field: Arena")
+
+	output.Reset()
+	assert.NoError(t, run([]string{"-arena", "bounded", "-time-limit-ms", "1000", "-bots", "alpha=chaser,beta=orbiter"}, &output))
+	var result MatchResult
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &result))
+	assert.Empty(t, result.Error)
+}
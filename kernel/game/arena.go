@@ -0,0 +1,112 @@
+package game
+
+import "github.com/davidhorak/space-wars/kernel/physics"
+
+// wall is an edge of the bounded arena, the normal points into the arena.
+type wall struct {
+	normal  physics.Vector2
+	overlap float64 // Depth of the circle behind the wall, positive when crossed
+}
+
+// crossedWalls returns the walls of the arena crossed by the circle.
+func crossedWalls(position physics.Vector2, radius float64, size physics.Size) []wall {
+	var crossed []wall
+	for _, edge := range []wall{
+		{normal: physics.Vector2{X: 1}, overlap: radius - position.X},
+		{normal: physics.Vector2{X: -1}, overlap: position.X + radius - size.Width},
+		{normal: physics.Vector2{Y: 1}, overlap: radius - position.Y},
+		{normal: physics.Vector2{Y: -1}, overlap: position.Y + radius - size.Height},
+	} {
+		if edge.overlap > 0 {
+			crossed = append(crossed, edge)
+		}
+	}
+	return crossed
+}
+
+// bounce pushes the circle back into the arena and reflects the velocity off the crossed walls.
+// Returns the speed into the walls, 0 when only touching them or moving away.
+func bounce(position, velocity *physics.Vector2, radius float64, size physics.Size) float64 {
+	impact := 0.0
+	for _, edge := range crossedWalls(*position, radius, size) {
+		*position = position.Add(edge.normal.Multiply(edge.overlap))
+		speed := -velocity.Dot(edge.normal)
+		if speed <= 0 {
+			continue
+		}
+		*velocity = velocity.Add(edge.normal.Multiply(speed * (1 + WallRestitution)))
+		impact += speed
+	}
+	return impact
+}
+
+// wrap moves the object leaving the arena to the opposite edge.
+func (game *Game) wrap(gameObject GameObject) {
+	position := gameObject.Position()
+	if position.X < 0 {
+		gameObject.SetPosition(physics.Vector2{X: game.size.Width - position.X, Y: position.Y})
+	} else if position.X > game.size.Width {
+		gameObject.SetPosition(physics.Vector2{X: position.X - game.size.Width, Y: position.Y})
+	}
+	if position.Y < 0 {
+		gameObject.SetPosition(physics.Vector2{X: position.X, Y: game.size.Height - position.Y})
+	} else if position.Y > game.size.Height {
+		gameObject.SetPosition(physics.Vector2{X: position.X, Y: position.Y - game.size.Height})
+	}
+}
+
+// confine resolves the object against the walls of the bounded arena. The spaceships, the drones and the
+// asteroids bounce off them, the spaceships take the damage of the impact. The projectiles are despawned once the collisions
+// of the step are resolved, see despawnEscapedProjectiles, the other objects don't move.
+func (game *Game) confine(gameObject GameObject) {
+	switch object := gameObject.(type) {
+	case *Spaceship:
+		object.hitWalls(game.size, &game.manager)
+	case *Asteroid:
+		position, velocity := object.position, object.velocity
+		bounce(&position, &velocity, object.radius, game.size)
+		object.velocity = velocity
+		object.SetPosition(position)
+	case *Drone:
+		position, velocity := object.position, object.velocity
+		if bounce(&position, &velocity, DroneSize/2, game.size) > 0 {
+			object.SetVelocity(velocity)
+		}
+		object.SetPosition(position)
+	}
+}
+
+// despawnEscapedProjectiles removes the projectiles outside of the bounded arena, without the explosion.
+func (game *Game) despawnEscapedProjectiles() {
+	// Copied, the despawned projectiles are removed from the game objects
+	gameObjects := append([]GameObject{}, game.manager.GameObjects()...)
+	for _, gameObject := range gameObjects {
+		projectile, ok := gameObject.(*Projectile)
+		if !ok || !projectile.enabled {
+			continue
+		}
+		position := projectile.position
+		if position.X < 0 || position.X > game.size.Width || position.Y < 0 || position.Y > game.size.Height {
+			projectile.Destroy(&game.manager, false)
+			game.manager.recycle(projectile)
+		}
+	}
+}
+
+// hitWalls bounces the spaceship off the crossed walls of the bounded arena. The speed into the walls
+// damages the hull zone facing them, the kill is credited to no one.
+func (ship *Spaceship) hitWalls(size physics.Size, gameManager *GameManager) {
+	walls := crossedWalls(ship.position, ShipSize/2, size)
+	if len(walls) == 0 {
+		return
+	}
+	// The zone facing the first crossed wall, before the ship is pushed back
+	contact := ship.position.Subtract(walls[0].normal.Multiply(ShipSize / 2))
+	zone := ship.HitZone(contact)
+
+	impact := bounce(&ship.position, &ship.velocity, ShipSize/2, size)
+	ship.collider.SetPosition(ship.position)
+	if impact > 0 {
+		ship.TakeZoneDamage(zone, impact*WallDamageCoefficient, gameManager, nil)
+	}
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBoundedGame(t *testing.T) *Game {
+	config := DefaultGameConfig()
+	config.Arena = ArenaBounded
+	game, err := NewGameWithConfig(physics.Size{Width: 1000, Height: 1000}, 1234567890, config)
+	assert.NoError(t, err)
+	game.AddSpaceship("other", physics.Vector2{X: 500, Y: 500}, 0)
+	return game
+}
+
+func TestBounce(t *testing.T) {
+	size := physics.Size{Width: 100, Height: 100}
+	position, velocity := physics.Vector2{X: 5, Y: 98}, physics.Vector2{X: -10, Y: 20}
+	impact := bounce(&position, &velocity, 10, size)
+	assert.Equal(t, physics.Vector2{X: 10, Y: 90}, position)
+	assert.Equal(t, physics.Vector2{X: 10 * WallRestitution, Y: -20 * WallRestitution}, velocity)
+	assert.Equal(t, 30.0, impact)
+
+	// Moving away from the wall, only pushed back
+	position, velocity = physics.Vector2{X: 5, Y: 50}, physics.Vector2{X: 10, Y: 0}
+	assert.Equal(t, 0.0, bounce(&position, &velocity, 10, size))
+	assert.Equal(t, physics.Vector2{X: 10, Y: 50}, position)
+	assert.Equal(t, physics.Vector2{X: 10, Y: 0}, velocity)
+}
+
+func TestGame_BoundedArena(t *testing.T) {
+	t.Run("Spaceship bounces and takes damage", func(t *testing.T) {
+		game := newBoundedGame(t)
+		ship, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "ship", Position: physics.Vector2{X: 16, Y: 500}, Rotation: math.Pi})
+		ship.velocity = physics.Vector2{X: -MaxVelocitySec}
+		game.Start()
+		game.Update(16)
+
+		assert.Equal(t, ShipSize/2.0, ship.Position().X)
+		assert.Greater(t, ship.Velocity().X, 0.0)
+		zones := ship.HullZones()
+		assert.Less(t, zones[HullZoneFront], MaxHealth*1.0)
+		assert.Equal(t, MaxHealth*1.0, zones[HullZoneRear])
+	})
+
+	t.Run("Projectile despawns", func(t *testing.T) {
+		game := newBoundedGame(t)
+		ship, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "ship", Position: physics.Vector2{X: 40, Y: 500}, Rotation: math.Pi})
+		game.Start()
+		assert.NoError(t, ship.FireLaser(&game.manager))
+		for i := 0; i < 10; i++ {
+			game.Update(16)
+		}
+
+		for _, gameObject := range game.manager.GameObjects() {
+			assert.IsType(t, &Spaceship{}, gameObject)
+		}
+	})
+
+	t.Run("Drone bounces", func(t *testing.T) {
+		game := newBoundedGame(t)
+		owner, _ := game.manager.GetSpaceship("other")
+		drone := NewDrone(NewUUID(), owner, physics.Vector2{X: 500, Y: 998}, 0, nil)
+		drone.SetVelocity(physics.Vector2{Y: 100})
+		_, _ = game.manager.AddGameObject(drone)
+		game.Start()
+		game.Update(16)
+
+		assert.Equal(t, 1000-DroneSize/2.0, drone.Position().Y)
+		assert.Less(t, drone.Velocity().Y, 0.0)
+	})
+
+	t.Run("Wrap by default", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+		game.AddSpaceship("other", physics.Vector2{X: 500, Y: 500}, 0)
+		ship, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "ship", Position: physics.Vector2{X: 1, Y: 500}, Rotation: math.Pi})
+		ship.velocity = physics.Vector2{X: -MaxVelocitySec}
+		game.Start()
+		game.Update(16)
+
+		assert.Greater(t, ship.Position().X, 900.0)
+		assert.Equal(t, MaxHealth*1.0, ship.Health())
+	})
+}
@@ -9,7 +9,9 @@ type Asteroid struct {
 	id       int64
 	enabled  bool
 	position physics.Vector2
+	velocity physics.Vector2 // Per second, the seeded asteroids stay in place, the fragments drift
 	radius   float64
+	health   float64
 	collider collider.CircleCollider
 }
 
@@ -19,6 +21,7 @@ func NewAsteroid(id int64, position physics.Vector2, radius float64) *Asteroid {
 		enabled:  true,
 		position: position,
 		radius:   radius,
+		health:   radius * AsteroidHealthPerRadius,
 		collider: *collider.NewCircleCollider(position, radius),
 	}
 }
@@ -41,15 +44,69 @@ func (asteroid *Asteroid) Position() physics.Vector2 {
 
 func (asteroid *Asteroid) SetPosition(position physics.Vector2) {
 	asteroid.position = position
+	asteroid.collider.SetPosition(position)
 }
 
-func (asteroid *Asteroid) Update(deltaTimeMs float64, gameManager *GameManager) {}
+func (asteroid *Asteroid) Velocity() physics.Vector2 {
+	return asteroid.velocity
+}
+
+func (asteroid *Asteroid) SetVelocity(velocity physics.Vector2) {
+	asteroid.velocity = velocity
+}
+
+func (asteroid *Asteroid) Radius() float64 {
+	return asteroid.radius
+}
+
+func (asteroid *Asteroid) Health() float64 {
+	return asteroid.health
+}
+
+func (asteroid *Asteroid) Update(deltaTimeMs float64, gameManager *GameManager) {
+	if asteroid.velocity == (physics.Vector2{}) {
+		return
+	}
+	asteroid.position = asteroid.position.Add(asteroid.velocity.Multiply(deltaTimeMs / 1000))
+	asteroid.collider.SetPosition(asteroid.position)
+}
+
+func (asteroid *Asteroid) updatesConcurrently(deltaTimeMs float64) bool {
+	return true
+}
 
 func (asteroid *Asteroid) Collider() collider.Collider {
 	return &asteroid.collider
 }
 
-func (asteroid *Asteroid) OnCollision(other GameObject, gameManager *GameManager, order int) {}
+// OnCollision takes the damage of the projectiles and the collision damage of the spaceships and the asteroids,
+// computed by the DamageCalculator. The default FlatDamageCalculator leaves the asteroid intact on a collision.
+func (asteroid *Asteroid) OnCollision(other GameObject, gameManager *GameManager, order int) {
+	switch other := other.(type) {
+	case *Projectile:
+		asteroid.TakeDamage(other.damage, gameManager, other.owner)
+	case *Spaceship:
+		asteroid.TakeDamage(asteroid.collisionDamage(other, gameManager), gameManager, other)
+	case *Asteroid:
+		asteroid.TakeDamage(asteroid.collisionDamage(other, gameManager), gameManager, nil)
+	}
+}
+
+func (asteroid *Asteroid) collisionDamage(other GameObject, gameManager *GameManager) float64 {
+	return gameManager.DamageCalculator().Calculate(other, asteroid, relativeVelocity(other, asteroid))
+}
+
+// TakeDamage breaks the asteroid once its health is depleted, see GameManager.destroyAsteroid.
+// The destroyer is nil when no spaceship dealt the final blow.
+func (asteroid *Asteroid) TakeDamage(damage float64, gameManager *GameManager, destroyer *Spaceship) {
+	if !asteroid.enabled || damage <= 0 {
+		return
+	}
+	asteroid.health -= damage
+	if asteroid.health <= 0 {
+		gameManager.destroyAsteroid(asteroid, destroyer)
+	}
+}
 
 func (asteroid *Asteroid) Serialize() map[string]interface{} {
 	return map[string]interface{}{
@@ -60,7 +117,36 @@ func (asteroid *Asteroid) Serialize() map[string]interface{} {
 			"x": asteroid.position.X,
 			"y": asteroid.position.Y,
 		},
+		"velocity": map[string]interface{}{
+			"x": asteroid.velocity.X,
+			"y": asteroid.velocity.Y,
+		},
 		"radius":   asteroid.radius,
+		"health":   asteroid.health,
 		"collider": asteroid.collider.Serialize(),
 	}
 }
+
+// DeserializeAsteroid restores the asteroid from its Serialize output.
+func DeserializeAsteroid(data map[string]interface{}) (*Asteroid, error) {
+	id, enabled, position, err := gameObjectHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	radius, err := number(data, "radius")
+	if err != nil {
+		return nil, err
+	}
+
+	asteroid := NewAsteroid(id, position, radius)
+	asteroid.enabled = enabled
+	if _, ok := data["velocity"]; ok {
+		if asteroid.velocity, err = vector(data, "velocity"); err != nil {
+			return nil, err
+		}
+	}
+	if asteroid.health, err = optionalNumber(data, "health", asteroid.health); err != nil {
+		return nil, err
+	}
+	return asteroid, nil
+}
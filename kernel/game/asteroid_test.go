@@ -55,7 +55,94 @@ func TestAsteroid_Serialize(t *testing.T) {
 			"x": 10.0,
 			"y": 20.0,
 		},
+		"velocity": map[string]interface{}{
+			"x": 0.0,
+			"y": 0.0,
+		},
 		"radius":   radius,
+		"health":   radius * AsteroidHealthPerRadius,
 		"collider": asteroid.collider.Serialize(),
 	}, asteroid.Serialize())
 }
+
+func TestDeserializeAsteroid(t *testing.T) {
+	asteroid := NewAsteroid(1, physics.Vector2{X: 10, Y: 20}, 5)
+	asteroid.SetEnabled(false)
+	asteroid.SetVelocity(physics.Vector2{X: 3, Y: -4})
+	asteroid.health = 2
+
+	deserialized, err := DeserializeAsteroid(asteroid.Serialize())
+	assert.NoError(t, err)
+	assert.Equal(t, asteroid, deserialized)
+
+	serialized := asteroid.Serialize()
+	delete(serialized, "radius")
+	_, err = DeserializeAsteroid(serialized)
+	assert.Equal(t, ErrInvalidState{Field: "radius"}, err)
+}
+
+func TestAsteroid_Update(t *testing.T) {
+	gameManager := NewGameManager()
+	asteroid := NewAsteroid(1, physics.Vector2{X: 10, Y: 20}, 5)
+	asteroid.SetVelocity(physics.Vector2{X: 100, Y: -50})
+	asteroid.Update(100, &gameManager)
+
+	assert.Equal(t, physics.Vector2{X: 20, Y: 15}, asteroid.Position())
+	assert.Equal(t, asteroid.Position(), asteroid.collider.Position())
+}
+
+func TestAsteroid_TakeDamage(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 900, Y: 900}, 0)
+	_ = gameManager.AddSpaceship(ship)
+	asteroid := NewAsteroid(2, physics.Vector2{X: 500, Y: 500}, MaxAsteroidSize)
+	asteroid.SetVelocity(physics.Vector2{X: 10, Y: 0})
+	_, _ = gameManager.AddGameObject(asteroid)
+
+	var events []AsteroidDestroyed
+	gameManager.Events().Subscribe(func(event GameEvent) {
+		if event, ok := event.(AsteroidDestroyed); ok {
+			events = append(events, event)
+		}
+	})
+
+	// The laser hits take their toll
+	laser := NewLaserProjectile(3, physics.Vector2{X: 500, Y: 500}, 0, ship)
+	asteroid.OnCollision(laser, &gameManager, 1)
+	assert.Equal(t, float64(MaxAsteroidSize*AsteroidHealthPerRadius-LaserDamage), asteroid.Health())
+	assert.Empty(t, events)
+
+	asteroid.TakeDamage(1000, &gameManager, ship)
+	assert.False(t, asteroid.Enabled())
+	assert.Len(t, events, 1)
+	assert.Equal(t, ship, events[0].Destroyer)
+	assert.Equal(t, 1, gameManager.AsteroidsDestroyed())
+	assert.Equal(t, 1, gameManager.Scoreboard()[0].AsteroidsDestroyed)
+
+	fragments := events[0].Fragments
+	assert.GreaterOrEqual(t, len(fragments), 2)
+	assert.LessOrEqual(t, len(fragments), 3)
+	assert.Len(t, gameManager.Asteroids(), len(fragments))
+	for _, fragment := range fragments {
+		assert.Equal(t, MaxAsteroidSize*AsteroidFragmentRadiusFactor, fragment.Radius())
+		// Inherited velocity plus the drift away from the center
+		velocity := fragment.Velocity()
+		drift := velocity.Subtract(asteroid.Velocity())
+		assert.InDelta(t, AsteroidFragmentSpeedSec, drift.Magnitude(), 1e-9)
+		position := fragment.Position()
+		away := position.Subtract(asteroid.Position())
+		assert.Greater(t, away.Dot(drift), 0.0)
+	}
+
+	t.Run("Smallest size despawns", func(t *testing.T) {
+		small := NewAsteroid(4, physics.Vector2{X: 100, Y: 100}, MinAsteroidSize)
+		_, _ = gameManager.AddGameObject(small)
+		asteroids := len(gameManager.Asteroids())
+
+		small.TakeDamage(small.Health(), &gameManager, nil)
+		assert.Len(t, events, 2)
+		assert.Nil(t, events[1].Destroyer)
+		assert.Empty(t, events[1].Fragments)
+		assert.Len(t, gameManager.Asteroids(), asteroids-1)
+	})
+}
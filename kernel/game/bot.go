@@ -0,0 +1,122 @@
+package game
+
+import "github.com/davidhorak/space-wars/kernel/physics"
+
+// BotStrategy controls a spaceship, it is invoked once per Update
+// for every enabled spaceship it is registered to.
+type BotStrategy interface {
+	Update(spaceship *Spaceship, gameManager *GameManager, deltaTimeMs float64)
+}
+
+// BotStrategyFunc adapts a plain function to the BotStrategy.
+type BotStrategyFunc func(spaceship *Spaceship, gameManager *GameManager, deltaTimeMs float64)
+
+func (fn BotStrategyFunc) Update(spaceship *Spaceship, gameManager *GameManager, deltaTimeMs float64) {
+	fn(spaceship, gameManager, deltaTimeMs)
+}
+
+type bot struct {
+	name     string
+	strategy BotStrategy
+}
+
+// Bot is an AI competitor deciding on the commands from the game state, unlike the BotStrategy
+// it cannot touch the game directly. Register it with AddBot(name, NewBotStrategy(bot)).
+type Bot interface {
+	Think(state GameState) []Command
+}
+
+// SpaceshipState is a read-only snapshot of a spaceship.
+type SpaceshipState struct {
+	Name     string
+	Team     string
+	Position physics.Vector2
+	Velocity physics.Vector2
+	Rotation float64
+	// Radians per second, positive counterclockwise, 0 unless of the inertial flight model
+	AngularVelocity float64
+	Health          float64
+	Energy          float64
+	Rockets         int32
+}
+
+// GameState is the game as seen by a bot: its own spaceship, the other enabled spaceships
+// and the asteroids. With a radar, see WithRadar, only the objects of the last radar sweep are seen,
+// at their noisy positions, and the other spaceships' rotation, health, energy and rockets are unknown.
+type GameState struct {
+	Self        SpaceshipState
+	Spaceships  []SpaceshipState
+	Asteroids   []physics.Circle
+	Contacts    []Contact // Nil without a radar
+	Size        physics.Size
+	ElapsedMs   float64
+	DeltaTimeMs float64
+}
+
+func newSpaceshipState(spaceship *Spaceship) SpaceshipState {
+	return SpaceshipState{
+		Name:            spaceship.name,
+		Team:            spaceship.team,
+		Position:        spaceship.position,
+		Velocity:        spaceship.velocity,
+		Rotation:        spaceship.rotation,
+		AngularVelocity: spaceship.angularVelocity,
+		Health:          spaceship.Health(),
+		Energy:          spaceship.energy,
+		Rockets:         spaceship.rockets,
+	}
+}
+
+func newGameState(spaceship *Spaceship, gameManager *GameManager, deltaTimeMs float64) GameState {
+	state := GameState{
+		Self:        newSpaceshipState(spaceship),
+		Size:        gameManager.Size(),
+		ElapsedMs:   gameManager.ElapsedMs(),
+		DeltaTimeMs: deltaTimeMs,
+	}
+	if spaceship.radar.Equipped() {
+		state.Contacts = spaceship.Scan()
+		for _, contact := range state.Contacts {
+			switch contact.Type {
+			case "spaceship":
+				other := SpaceshipState{Name: contact.Name, Position: contact.Position, Velocity: contact.Velocity}
+				if detected, err := gameManager.GetSpaceship(contact.Name); err == nil {
+					other.Team = detected.team
+				}
+				state.Spaceships = append(state.Spaceships, other)
+			case "asteroid":
+				state.Asteroids = append(state.Asteroids, physics.Circle{Center: contact.Position, Radius: contact.Radius})
+			}
+		}
+		return state
+	}
+
+	for _, other := range gameManager.Spaceships() {
+		if other != spaceship && other.Enabled() {
+			state.Spaceships = append(state.Spaceships, newSpaceshipState(other))
+		}
+	}
+	for _, asteroid := range gameManager.Asteroids() {
+		if asteroid.Enabled() {
+			state.Asteroids = append(state.Asteroids, physics.Circle{Center: asteroid.position, Radius: asteroid.radius})
+		}
+	}
+	return state
+}
+
+type botStrategy struct {
+	bot Bot
+}
+
+// NewBotStrategy adapts the bot to the BotStrategy run by the game loop. The commands are enqueued to the
+// bot's own spaceship, subject to its command delay, the ones naming another spaceship and the invalid
+// ones are dropped.
+func NewBotStrategy(bot Bot) BotStrategy {
+	return botStrategy{bot: bot}
+}
+
+func (strategy botStrategy) Update(spaceship *Spaceship, gameManager *GameManager, deltaTimeMs float64) {
+	for _, command := range strategy.bot.Think(newGameState(spaceship, gameManager, deltaTimeMs)) {
+		_ = spaceship.EnqueueCommand(command)
+	}
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBotStrategyFunc_Update(t *testing.T) {
+	spaceship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	manager := NewGameManager()
+
+	var calledWith *Spaceship
+	var calledDelta float64
+	strategy := BotStrategyFunc(func(spaceship *Spaceship, gameManager *GameManager, deltaTimeMs float64) {
+		calledWith = spaceship
+		calledDelta = deltaTimeMs
+	})
+	strategy.Update(spaceship, &manager, 16)
+
+	assert.Equal(t, spaceship, calledWith)
+	assert.Equal(t, float64(16), calledDelta)
+}
+
+type recordingBot struct {
+	states   []GameState
+	commands []Command
+}
+
+func (bot *recordingBot) Think(state GameState) []Command {
+	bot.states = append(bot.states, state)
+	return bot.commands
+}
+
+func TestNewBotStrategy(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game.AddSpaceship("self", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("other", physics.Vector2{X: 500, Y: 500}, 0)
+	game.AddSpaceship("disabled", physics.Vector2{X: 900, Y: 500}, 0)
+	game.manager.AddGameObject(NewAsteroid(NewUUID(), physics.Vector2{X: 300, Y: 300}, 20))
+	disabled, _ := game.manager.GetSpaceship("disabled")
+	disabled.SetEnabled(false)
+
+	bot := &recordingBot{commands: []Command{
+		{Action: CommandSetEngineThrust, Args: []float64{100, 0, 50}},
+		{Ship: "other", Action: CommandSetEngineThrust, Args: []float64{100, 100, 100}},
+		{Action: CommandFireLaser, Args: []float64{1}},
+		{Ship: "self", Action: CommandFireRocket},
+	}}
+	assert.NoError(t, game.AddBot("self", NewBotStrategy(bot)))
+	game.Update(16)
+
+	assert.Len(t, bot.states, 1)
+	state := bot.states[0]
+	assert.Equal(t, "self", state.Self.Name)
+	assert.Equal(t, physics.Vector2{X: 100, Y: 100}, state.Self.Position)
+	assert.Equal(t, float64(MaxEnergy), state.Self.Energy)
+	assert.Len(t, state.Spaceships, 1)
+	assert.Equal(t, "other", state.Spaceships[0].Name)
+	assert.Equal(t, []physics.Circle{{Center: physics.Vector2{X: 300, Y: 300}, Radius: 20}}, state.Asteroids)
+	assert.Equal(t, physics.Size{Width: 1024, Height: 768}, state.Size)
+	assert.Equal(t, 16.0, state.DeltaTimeMs)
+
+	self, _ := game.manager.GetSpaceship("self")
+	other, _ := game.manager.GetSpaceship("other")
+	assert.Equal(t, 100.0, self.engine.mainThrust)
+	assert.Equal(t, 50.0, self.engine.rightThrust)
+	assert.Equal(t, int32(MaxRockets-1), self.rockets)
+	assert.Equal(t, 0.0, other.engine.mainThrust)
+	// The laser command has an invalid argument count
+	assert.Equal(t, 0.0, self.laserReloadTimerSec)
+}
@@ -0,0 +1,30 @@
+package game
+
+import "time"
+
+type ChatMessage struct {
+	From            string
+	Message         string
+	SimulatedTimeMs float64 // Game time at the message creation
+}
+
+// BroadcastMessage logs the chat message and appends it to the chat log,
+// which keeps the last ChatLogCapacity messages.
+func (manager *GameManager) BroadcastMessage(from string, message string) {
+	manager.logger.Chat(time.Now(), from, message)
+
+	chatMessage := ChatMessage{From: from, Message: message, SimulatedTimeMs: manager.elapsedMs}
+	if len(manager.chatLog) < ChatLogCapacity {
+		manager.chatLog = append(manager.chatLog, chatMessage)
+		return
+	}
+	manager.chatLog[manager.chatHead] = chatMessage
+	manager.chatHead = (manager.chatHead + 1) % ChatLogCapacity
+}
+
+// ChatLog returns the chat messages, the oldest first.
+func (manager *GameManager) ChatLog() []ChatMessage {
+	chatLog := make([]ChatMessage, 0, len(manager.chatLog))
+	chatLog = append(chatLog, manager.chatLog[manager.chatHead:]...)
+	return append(chatLog, manager.chatLog[:manager.chatHead]...)
+}
@@ -0,0 +1,43 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameManager_BroadcastMessage(t *testing.T) {
+	manager := NewGameManager()
+	manager.Tick(250)
+
+	manager.BroadcastMessage("alpha", "hello")
+	manager.BroadcastMessage("beta", "hi")
+
+	assert.Equal(t, []ChatMessage{
+		{From: "alpha", Message: "hello", SimulatedTimeMs: 250},
+		{From: "beta", Message: "hi", SimulatedTimeMs: 250},
+	}, manager.ChatLog())
+
+	// Logged as the chat event
+	logs := manager.Logger().Logs()
+	assert.Equal(t, 2, len(logs))
+	assert.Equal(t, LogTypeChat, logs[0].logType)
+	assert.Equal(t, "alpha: hello", logs[0].message)
+	assert.Equal(t, map[string]interface{}{"from": "alpha", "message": "hello"}, logs[0].meta)
+	assert.Equal(t, 250.0, logs[0].tickMs)
+}
+
+func TestGameManager_ChatLog_Capacity(t *testing.T) {
+	manager := NewGameManager()
+	assert.Empty(t, manager.ChatLog())
+
+	for i := 0; i < ChatLogCapacity+5; i++ {
+		manager.BroadcastMessage("alpha", fmt.Sprintf("message %d", i))
+	}
+
+	chatLog := manager.ChatLog()
+	assert.Equal(t, ChatLogCapacity, len(chatLog))
+	assert.Equal(t, "message 5", chatLog[0].Message)
+	assert.Equal(t, fmt.Sprintf("message %d", ChatLogCapacity+4), chatLog[len(chatLog)-1].Message)
+}
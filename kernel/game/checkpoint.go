@@ -0,0 +1,138 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type MigrationFunc func(state map[string]interface{}) map[string]interface{}
+
+type migration struct {
+	fromVersion int
+	toVersion   int
+	migrate     MigrationFunc
+}
+
+// MigrationChain holds the migrations between the serialized state versions
+// and upgrades the saved states to the current version.
+type MigrationChain struct {
+	currentVersion int
+	migrations     map[int][]migration
+}
+
+func NewMigrationChain(currentVersion int) *MigrationChain {
+	return &MigrationChain{
+		currentVersion: currentVersion,
+		migrations:     map[int][]migration{},
+	}
+}
+
+var migrations = NewMigrationChain(StateVersion)
+
+// RegisterMigration registers the migration in the default chain used by Restore.
+func RegisterMigration(fromVersion, toVersion int, migrate MigrationFunc) {
+	migrations.RegisterMigration(fromVersion, toVersion, migrate)
+}
+
+func (chain *MigrationChain) CurrentVersion() int {
+	return chain.currentVersion
+}
+
+func (chain *MigrationChain) RegisterMigration(fromVersion, toVersion int, migrate MigrationFunc) {
+	chain.migrations[fromVersion] = append(chain.migrations[fromVersion], migration{
+		fromVersion: fromVersion,
+		toVersion:   toVersion,
+		migrate:     migrate,
+	})
+}
+
+// Path returns the shortest sequence of migrations from the version to the current one.
+func (chain *MigrationChain) Path(version int) ([]MigrationFunc, error) {
+	if version == chain.currentVersion {
+		return []MigrationFunc{}, nil
+	}
+
+	// Breadth-first search, remembering the migration leading to each version.
+	previous := map[int]migration{}
+	visited := map[int]bool{version: true}
+	queue := []int{version}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range chain.migrations[current] {
+			if visited[next.toVersion] {
+				continue
+			}
+			visited[next.toVersion] = true
+			previous[next.toVersion] = next
+
+			if next.toVersion == chain.currentVersion {
+				var path []MigrationFunc
+				for step := next; ; step = previous[step.fromVersion] {
+					path = append([]MigrationFunc{step.migrate}, path...)
+					if step.fromVersion == version {
+						return path, nil
+					}
+				}
+			}
+			queue = append(queue, next.toVersion)
+		}
+	}
+
+	return nil, ErrIncompatibleVersion{Version: version, CurrentVersion: chain.currentVersion}
+}
+
+// Migrate upgrades the state to the current version. The version is read from "schemaVersion", or from
+// "version" for the checkpoints predating it; states without either predate the versioning and are
+// considered to be of the version 1.
+func (chain *MigrationChain) Migrate(state map[string]interface{}) (map[string]interface{}, error) {
+	version := 1
+	for _, key := range []string{"schemaVersion", "version"} {
+		value, ok := state[key]
+		if !ok {
+			continue
+		}
+		number, ok := toNumber(value)
+		if !ok {
+			return nil, fmt.Errorf("invalid state version: %v", value)
+		}
+		version = int(number)
+		break
+	}
+
+	path, err := chain.Path(version)
+	if err != nil {
+		return nil, err
+	}
+	for _, migrate := range path {
+		state = migrate(state)
+	}
+	delete(state, "version")
+	state["schemaVersion"] = chain.currentVersion
+	return state, nil
+}
+
+// Restore migrates the checkpoint data to the current version and deserializes the game.
+func (chain *MigrationChain) Restore(data []byte) (*Game, error) {
+	state := make(map[string]interface{})
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+
+	state, err := chain.Migrate(state)
+	if err != nil {
+		return nil, err
+	}
+	return deserialize(state)
+}
+
+// Checkpoint serializes the game state, which carries its schema version.
+func (game *Game) Checkpoint() ([]byte, error) {
+	return json.Marshal(game.Serialize())
+}
+
+// Restore restores the game from a checkpoint, applying the registered migrations.
+func Restore(data []byte) (*Game, error) {
+	return migrations.Restore(data)
+}
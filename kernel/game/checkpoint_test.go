@@ -0,0 +1,114 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+// legacyCheckpoint returns a checkpoint of the given version, storing the seed under the "randomSeed" key
+// and the version under the "version" key preceding "schemaVersion".
+func legacyCheckpoint(t *testing.T, version int) []byte {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0)
+
+	state := game.Serialize()
+	state["randomSeed"] = state["seed"]
+	delete(state, "seed")
+	delete(state, "schemaVersion")
+	state["version"] = version
+
+	data, err := json.Marshal(state)
+	assert.NoError(t, err)
+	return data
+}
+
+func renameSeed(state map[string]interface{}) map[string]interface{} {
+	state["seed"] = state["randomSeed"]
+	delete(state, "randomSeed")
+	return state
+}
+
+func TestGame_Checkpoint(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0)
+
+	data, err := game.Checkpoint()
+	assert.NoError(t, err)
+
+	state := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal(data, &state))
+	assert.Equal(t, float64(StateVersion), state["schemaVersion"])
+
+	restored, err := Restore(data)
+	assert.NoError(t, err)
+	assert.Equal(t, game.seed, restored.seed)
+	_, err = restored.manager.GetSpaceship("test")
+	assert.NoError(t, err)
+}
+
+func TestMigrationChain_Restore_SingleStep(t *testing.T) {
+	chain := NewMigrationChain(2)
+	chain.RegisterMigration(1, 2, renameSeed)
+
+	game, err := chain.Restore(legacyCheckpoint(t, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1234567890), game.seed)
+}
+
+func TestMigrationChain_Restore_Chained(t *testing.T) {
+	var steps []string
+	chain := NewMigrationChain(3)
+	chain.RegisterMigration(2, 3, func(state map[string]interface{}) map[string]interface{} {
+		steps = append(steps, "v2->v3")
+		return renameSeed(state)
+	})
+	chain.RegisterMigration(1, 2, func(state map[string]interface{}) map[string]interface{} {
+		steps = append(steps, "v1->v2")
+		return state
+	})
+
+	game, err := chain.Restore(legacyCheckpoint(t, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1->v2", "v2->v3"}, steps)
+	assert.Equal(t, int64(1234567890), game.seed)
+}
+
+func TestMigrationChain_Path(t *testing.T) {
+	noop := func(state map[string]interface{}) map[string]interface{} { return state }
+	chain := NewMigrationChain(3)
+	chain.RegisterMigration(1, 2, noop)
+	chain.RegisterMigration(2, 3, noop)
+	chain.RegisterMigration(1, 3, noop)
+
+	// Shortest path
+	path, err := chain.Path(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(path))
+
+	path, err = chain.Path(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(path))
+
+	// Already current
+	path, err = chain.Path(3)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(path))
+}
+
+func TestMigrationChain_Restore_NoPath(t *testing.T) {
+	chain := NewMigrationChain(3)
+	chain.RegisterMigration(1, 2, renameSeed)
+
+	_, err := chain.Restore(legacyCheckpoint(t, 1))
+	var incompatible ErrIncompatibleVersion
+	assert.True(t, errors.As(err, &incompatible))
+	assert.Equal(t, ErrIncompatibleVersion{Version: 1, CurrentVersion: 3}, incompatible)
+
+	// Newer than current
+	_, err = chain.Restore(legacyCheckpoint(t, 4))
+	assert.True(t, errors.As(err, &incompatible))
+}
@@ -0,0 +1,77 @@
+package game
+
+import "fmt"
+
+const (
+	CommandSetEngineThrust = "setEngineThrust"
+	CommandFireLaser       = "fireLaser"
+	CommandFireRocket      = "fireRocket"
+	CommandFireWeapon      = "fireWeapon"
+	CommandSetDeflector    = "setDeflector"
+)
+
+// Command is a serializable spaceship action, e.g. received from a remote client or stored in a replay.
+// The action names and the arguments match the ones of the JS API.
+type Command struct {
+	Ship   string    `json:"ship"`
+	Action string    `json:"action"`
+	Args   []float64 `json:"args,omitempty"`
+}
+
+var commandArgs = map[string]int{
+	CommandSetEngineThrust: 3,
+	CommandFireLaser:       0,
+	CommandFireRocket:      0,
+	CommandFireWeapon:      1, // Slot of the weapon, see Spaceship.EquipWeapon
+	CommandSetDeflector:    1, // Non-zero raises the deflector, zero lowers it
+}
+
+// Validate checks the action is known and has the expected number of the arguments.
+func (command Command) Validate() error {
+	args, ok := commandArgs[command.Action]
+	if !ok {
+		return fmt.Errorf("invalid action: %s", command.Action)
+	}
+	if len(command.Args) != args {
+		return fmt.Errorf("%s() expects %d arguments, got %d", command.Action, args, len(command.Args))
+	}
+	return nil
+}
+
+// NamedAction converts the command to the action applied to the spaceship. The errors of the
+// spaceship, e.g. firing while reloading, are ignored like the ones of the JS API.
+func (command Command) NamedAction() (NamedAction, error) {
+	if err := command.Validate(); err != nil {
+		return NamedAction{}, err
+	}
+
+	args := append([]float64{}, command.Args...)
+	var action func(spaceShip *Spaceship, gameManager *GameManager)
+	switch command.Action {
+	case CommandSetEngineThrust:
+		action = func(spaceShip *Spaceship, gameManager *GameManager) {
+			_ = spaceShip.SetEngineThrust(args[0], args[1], args[2])
+		}
+	case CommandFireLaser:
+		action = func(spaceShip *Spaceship, gameManager *GameManager) {
+			_ = spaceShip.FireLaser(gameManager)
+		}
+	case CommandFireRocket:
+		action = func(spaceShip *Spaceship, gameManager *GameManager) {
+			_ = spaceShip.FireRocket(gameManager)
+		}
+	case CommandFireWeapon:
+		action = func(spaceShip *Spaceship, gameManager *GameManager) {
+			_ = spaceShip.FireWeapon(int(args[0]), gameManager)
+		}
+	case CommandSetDeflector:
+		action = func(spaceShip *Spaceship, gameManager *GameManager) {
+			if args[0] != 0 {
+				_ = spaceShip.RaiseDeflector(gameManager)
+			} else {
+				spaceShip.LowerDeflector(gameManager)
+			}
+		}
+	}
+	return NamedAction{Name: command.Ship, Action: action}, nil
+}
@@ -0,0 +1,88 @@
+package game
+
+import "fmt"
+
+type queuedCommand struct {
+	command    Command
+	delayTicks int // Ticks left until applied
+}
+
+// WithCommandDelay delays the enqueued commands of the spaceship by the number of the ticks, e.g. to
+// simulate the network or the reaction latency. The negative delay is treated as 0.
+func WithCommandDelay(ticks int) SpaceshipOption {
+	return func(ship *Spaceship) {
+		ship.SetCommandDelay(ticks)
+	}
+}
+
+// CommandDelay returns the number of the ticks the enqueued commands wait before being applied.
+func (ship *Spaceship) CommandDelay() int {
+	return ship.commandDelayTicks
+}
+
+// SetCommandDelay changes the delay of the commands enqueued from now on, the queued ones keep theirs.
+func (ship *Spaceship) SetCommandDelay(ticks int) {
+	ship.commandDelayTicks = max(ticks, 0)
+}
+
+// EnqueueCommand validates the command and queues it to be applied by the game's Update, before the
+// game objects update, once the command delay passes. Without a delay it is applied by the next
+// Update. The commands are applied in the enqueueing order, the ones naming another spaceship are
+// rejected.
+func (ship *Spaceship) EnqueueCommand(command Command) error {
+	if command.Ship != "" && command.Ship != ship.name {
+		return fmt.Errorf("command for %s enqueued to %s", command.Ship, ship.name)
+	}
+	if err := command.Validate(); err != nil {
+		return err
+	}
+	command.Args = append([]float64{}, command.Args...)
+	ship.commands = append(ship.commands, queuedCommand{command: command, delayTicks: ship.commandDelayTicks})
+	return nil
+}
+
+// PendingCommands returns the number of the queued commands not applied yet.
+func (ship *Spaceship) PendingCommands() int {
+	return len(ship.commands)
+}
+
+// applyCommands applies the commands due this tick and counts the others down.
+func (ship *Spaceship) applyCommands(gameManager *GameManager) {
+	if len(ship.commands) == 0 {
+		return
+	}
+	queue := ship.commands
+	ship.commands = nil
+	for _, queued := range queue {
+		if queued.delayTicks > 0 {
+			queued.delayTicks--
+			ship.commands = append(ship.commands, queued)
+			continue
+		}
+		// Validated on enqueueing
+		action, _ := queued.command.NamedAction()
+		action.Action(ship, gameManager)
+	}
+}
+
+// EnqueueCommand queues the command to the spaceship it names, see Spaceship.EnqueueCommand.
+func (game *Game) EnqueueCommand(command Command) error {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	spaceShip, err := game.manager.GetSpaceship(command.Ship)
+	if err != nil {
+		return err
+	}
+	return spaceShip.EnqueueCommand(command)
+}
+
+// applyCommands applies the due commands of the enabled spaceships in the game objects order, all of
+// them before any spaceship moves.
+func (game *Game) applyCommands() {
+	for _, spaceShip := range game.manager.Spaceships() {
+		if spaceShip.enabled {
+			spaceShip.applyCommands(&game.manager)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpaceship_EnqueueCommand(t *testing.T) {
+	ship := NewSpaceship(1, "alpha", physics.Vector2{X: 0, Y: 0}, 0)
+
+	assert.NoError(t, ship.EnqueueCommand(Command{Action: CommandSetEngineThrust, Args: []float64{100, 0, 0}}))
+	assert.NoError(t, ship.EnqueueCommand(Command{Ship: "alpha", Action: CommandFireLaser}))
+	assert.EqualError(t, ship.EnqueueCommand(Command{Ship: "beta", Action: CommandFireLaser}), "command for beta enqueued to alpha")
+	assert.EqualError(t, ship.EnqueueCommand(Command{Action: "selfDestruct"}), "invalid action: selfDestruct")
+	assert.Equal(t, 2, ship.PendingCommands())
+	// Not applied until the game updates
+	assert.Equal(t, 0.0, ship.engine.mainThrust)
+
+	ship.Reset()
+	assert.Equal(t, 0, ship.PendingCommands())
+}
+
+func TestSpaceship_SetCommandDelay(t *testing.T) {
+	ship := NewSpaceship(1, "alpha", physics.Vector2{X: 0, Y: 0}, 0, WithCommandDelay(2))
+	assert.Equal(t, 2, ship.CommandDelay())
+	ship.SetCommandDelay(-1)
+	assert.Equal(t, 0, ship.CommandDelay())
+}
+
+func TestGame_EnqueueCommand(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	alpha, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 100}})
+	beta, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "beta", Position: physics.Vector2{X: 900, Y: 900}, CommandDelayTicks: 2})
+	game.Start()
+
+	assert.Equal(t, ErrSpaceshipNotFound{Name: "gamma"}, game.EnqueueCommand(Command{Ship: "gamma", Action: CommandFireLaser}))
+	assert.NoError(t, game.EnqueueCommand(Command{Ship: "alpha", Action: CommandSetEngineThrust, Args: []float64{100, 0, 0}}))
+	assert.NoError(t, game.EnqueueCommand(Command{Ship: "beta", Action: CommandSetEngineThrust, Args: []float64{100, 0, 0}}))
+
+	game.Update(16)
+	assert.Equal(t, 100.0, alpha.engine.mainThrust)
+	assert.Equal(t, 0.0, beta.engine.mainThrust)
+
+	// Delayed by 2 ticks
+	game.Update(16)
+	assert.Equal(t, 0.0, beta.engine.mainThrust)
+	game.Update(16)
+	assert.Equal(t, 100.0, beta.engine.mainThrust)
+	assert.Equal(t, 0, beta.PendingCommands())
+
+	t.Run("Order", func(t *testing.T) {
+		assert.NoError(t, game.EnqueueCommand(Command{Ship: "alpha", Action: CommandSetEngineThrust, Args: []float64{50, 0, 0}}))
+		assert.NoError(t, game.EnqueueCommand(Command{Ship: "alpha", Action: CommandSetEngineThrust, Args: []float64{20, 0, 0}}))
+		game.Update(16)
+		assert.Equal(t, 20.0, alpha.engine.mainThrust)
+	})
+
+	t.Run("Delay change", func(t *testing.T) {
+		assert.NoError(t, game.EnqueueCommand(Command{Ship: "beta", Action: CommandSetEngineThrust, Args: []float64{50, 0, 0}}))
+		beta.SetCommandDelay(0)
+		assert.NoError(t, game.EnqueueCommand(Command{Ship: "beta", Action: CommandSetEngineThrust, Args: []float64{20, 0, 0}}))
+		game.Update(16)
+		assert.Equal(t, 20.0, beta.engine.mainThrust)
+		game.Update(16)
+		game.Update(16)
+		// The earlier command keeps its delay
+		assert.Equal(t, 50.0, beta.engine.mainThrust)
+	})
+}
+
+func TestGame_EnqueueCommand_Bot(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	bot := &recordingBot{commands: []Command{{Action: CommandSetEngineThrust, Args: []float64{100, 0, 0}}}}
+	self, _ := game.AddSpaceshipWithBot(SpaceshipConfig{Name: "self", Position: physics.Vector2{X: 100, Y: 100}, CommandDelayTicks: 1}, NewBotStrategy(bot))
+	game.AddSpaceship("other", physics.Vector2{X: 900, Y: 900}, 0)
+	game.Start()
+
+	// The bot's commands are delayed as well
+	game.Update(16)
+	assert.Equal(t, 0.0, self.engine.mainThrust)
+	game.Update(16)
+	assert.Equal(t, 100.0, self.engine.mainThrust)
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand_Validate(t *testing.T) {
+	assert.NoError(t, Command{Ship: "alpha", Action: CommandSetEngineThrust, Args: []float64{100, 0, 0}}.Validate())
+	assert.NoError(t, Command{Ship: "alpha", Action: CommandFireLaser}.Validate())
+	assert.EqualError(t, Command{Ship: "alpha", Action: "selfDestruct"}.Validate(), "invalid action: selfDestruct")
+	assert.EqualError(t, Command{Ship: "alpha", Action: CommandSetEngineThrust, Args: []float64{100}}.Validate(),
+		"setEngineThrust() expects 3 arguments, got 1")
+}
+
+func TestCommand_NamedAction(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+
+	var actions []NamedAction
+	for _, command := range []Command{
+		{Ship: "alpha", Action: CommandSetEngineThrust, Args: []float64{100, 20, 30}},
+		{Ship: "alpha", Action: CommandFireLaser},
+		{Ship: "alpha", Action: CommandFireRocket},
+	} {
+		action, err := command.NamedAction()
+		assert.NoError(t, err)
+		assert.Equal(t, "alpha", action.Name)
+		actions = append(actions, action)
+	}
+	assert.NoError(t, game.BatchSpaceshipActions(actions))
+
+	alpha, _ := game.manager.GetSpaceship("alpha")
+	assert.Equal(t, 100.0, alpha.engine.mainThrust)
+	assert.Equal(t, 20.0, alpha.engine.leftThrust)
+	assert.Equal(t, 30.0, alpha.engine.rightThrust)
+	assert.Equal(t, 2, alpha.ProjectileCount(&game.manager))
+
+	_, err := Command{Ship: "alpha", Action: "selfDestruct"}.NamedAction()
+	assert.Error(t, err)
+
+	alpha.EquipWeapon(MineWeapon{})
+	alpha.energy = MaxEnergy
+	action, err := Command{Ship: "alpha", Action: CommandFireWeapon, Args: []float64{0}}.NamedAction()
+	assert.NoError(t, err)
+	assert.NoError(t, game.BatchSpaceshipActions([]NamedAction{action}))
+	assert.Equal(t, 3, alpha.ProjectileCount(&game.manager))
+
+	action, err = Command{Ship: "alpha", Action: CommandSetDeflector, Args: []float64{1}}.NamedAction()
+	assert.NoError(t, err)
+	assert.NoError(t, game.BatchSpaceshipActions([]NamedAction{action}))
+	assert.True(t, alpha.Deflector().Raised())
+}
+
+func TestCommand_JSON(t *testing.T) {
+	var command Command
+	assert.NoError(t, json.Unmarshal([]byte(`{"ship":"alpha","action":"setEngineThrust","args":[100,0,0]}`), &command))
+	assert.Equal(t, Command{Ship: "alpha", Action: CommandSetEngineThrust, Args: []float64{100, 0, 0}}, command)
+
+	data, err := json.Marshal(Command{Ship: "alpha", Action: CommandFireLaser})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ship":"alpha","action":"fireLaser"}`, string(data))
+}
@@ -1,5 +1,7 @@
 package game
 
+import "math"
+
 const (
 	// Asteroid configuration
 	MinAsteroids          = 2
@@ -7,6 +9,13 @@ const (
 	MinAsteroidSize       = 10
 	MaxAsteroidSize       = 30
 	MinAsteroidSeparation = 10 // Minimum distance between asteroids
+	// The largest asteroid takes 3 lasers or a rocket
+	AsteroidHealthPerRadius = 2
+	// The destroyed asteroid splits into 2-3 fragments of the reduced radius, the ones that
+	// would be smaller than the minimum are not spawned.
+	AsteroidFragmentRadiusFactor = 0.6
+	MinAsteroidFragmentSize      = 8
+	AsteroidFragmentSpeedSec     = 40 // Added to the inherited velocity, away from the center
 
 	// Ship configuration
 	ShipSize  = 30
@@ -22,15 +31,28 @@ const (
 	// without any thrust
 	DragCoefficient            = 0.2385 * MaxVelocitySec
 	SideThrustPowerCoefficient = 0.80 // Relative to the main thrust
+	// Full turn in one second
+	MaxTurnRateMs = 2 * math.Pi / 1000
 
 	EnergyConsumptionMainThrustSec = MaxEnergy / 8
 	EnergyConsumptionSideThrustSec = MaxEnergy / 12
 	EnergyRechargeRateSec          = MaxEnergy / 8
+	EnergyConsumptionShieldPoint   = 0.5 // Per recharged shield point
 	ShipExplosionRadius            = 30
 	ShipExplosionDurationSec       = 1
 	ScorePerKill                   = 100
 	ScorePerDamageCoefficient      = 0.5
 
+	// Inertial kinematics configuration, see WithKinematics
+	// Reaches the max velocity in under 5 seconds with max main thrust, alongside the drag
+	KinematicsAccelerationSec = 0.4 * MaxVelocitySec
+	// Below 5% of the velocity in 10 seconds without any thrust
+	KinematicsDragSec = 0.3
+	// Reaches the max turn rate in about half a second with a single side thruster
+	KinematicsAngularAccelerationSec = 6 * math.Pi
+	KinematicsMaxAngularVelocitySec  = MaxTurnRateMs * 1000
+	KinematicsAngularDragSec         = 2
+
 	// Laser configuration
 	LaserReloadSec         = 0.25
 	EnergyConsumptionLaser = 6
@@ -54,4 +76,92 @@ const (
 	RocketDetonateRadius       = 20
 	RocketExplosionRadius      = 30
 	RocketExplosionDurationSec = 1
+
+	// Mine configuration
+	MineReloadSec            = 2
+	EnergyConsumptionMine    = 15
+	MineLifespanSec          = 30
+	MineDamage               = 40
+	MineRadius               = 8
+	MineExplosionRadius      = 25
+	MineExplosionDurationSec = 1
+	// Distance behind the ship the mine is dropped at, clear of its collider
+	MineDropDistance = ShipSize
+
+	// Spread shot configuration
+	SpreadReloadSec         = 0.75
+	EnergyConsumptionSpread = 15
+	SpreadProjectiles       = 3
+	SpreadAngleRad          = 0.3 // Between the outermost projectiles
+
+	// Rocket splash configuration, the damage falls off linearly to zero at the radius
+	RocketSplashRadius = 60
+	RocketSplashDamage = 30
+
+	// Drone configuration
+	MaxDrones           = 3
+	DroneSize           = 10
+	DroneMaxVelocitySec = MaxVelocitySec
+
+	// Projectile configuration
+	// Cap for the accelerating projectiles, edge to edge in 2 seconds for 1920 width
+	MaxProjectileSpeedSec = 1920 / 2
+	// Damage of a chain lightning jump relative to the previous one
+	ChainLightningDamageFactor = 0.5
+
+	// Deflector configuration
+	DeflectorAbsorption = 0.6
+	// Outpaces the recharge, a raised deflector lasts about 24 seconds on the full energy
+	EnergyConsumptionDeflectorSec = MaxEnergy / 6
+
+	// Bounded arena configuration
+	WallRestitution = 0.5 // Fraction of the speed into the wall kept after the bounce
+	// Hull damage per the speed into the wall, the full speed impact takes about a fifth of the health
+	WallDamageCoefficient = 0.1
+
+	// Pickup configuration
+	PickupRadius           = 12
+	PickupLifespanSec      = 15
+	MaxPickups             = 3 // Out at once, the spawns over the limit are skipped
+	PickupHealAmount       = 30
+	PickupEnergyAmount     = MaxEnergy / 2
+	PickupShieldAmount     = 50
+	PickupDamageMultiplier = 1.5
+	PickupBoostDurationMs  = 10000
+
+	// Game configuration
+	StatusChanSize = 4
+	// Higher priority objects are updated first within a tick
+	SpaceshipUpdatePriority  = 10
+	ProjectileUpdatePriority = 5
+	// The fewest objects or collision pairs split across the workers, see WithWorkers
+	ParallelMinBatch = 64
+
+	// Time scale configuration
+	MinTimeScale = 0.01
+	MaxTimeScale = 10
+	// Slack of the fixed step accumulator against the float rounding
+	FixedStepToleranceMs = 1e-6
+
+	// Lockstep configuration
+	// Ticks ahead of the last advanced one a player can submit the input for
+	LockstepMaxInputLead = 64
+
+	// Logger configuration
+	LogCapacity     = 1000
+	ChatLogCapacity = 100
+
+	// Collision prediction configuration
+	CollisionLookAheadMs = 10000
+	// Cell size of the collision broad-phase grid, the largest asteroid fits a cell
+	BroadPhaseCellSize = 2 * MaxAsteroidSize
+
+	// Serialization configuration
+	// Despawns kept for SerializeDelta, an older since tick gets the full game objects
+	DeltaHistoryTicks = 600
+	// Bump when the serialized game state changes and register a migration from the previous version.
+	StateVersion = 1
 )
+
+// Hull zones covered by the default deflector, the rear is left exposed
+var DeflectorZones = []HullZone{HullZoneFront, HullZoneLeft, HullZoneRight}
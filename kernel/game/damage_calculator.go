@@ -0,0 +1,45 @@
+package game
+
+import "github.com/davidhorak/space-wars/kernel/physics"
+
+// DamageCalculator computes the collision damage the defender takes from the attacker.
+// The relative velocity is the attacker's velocity relative to the defender's one.
+type DamageCalculator interface {
+	Calculate(attacker, defender GameObject, relativeVelocity physics.Vector2) float64
+}
+
+// FlatDamageCalculator depletes the whole health bar of the hit spaceship, regardless of the speed.
+type FlatDamageCalculator struct{}
+
+func (calculator FlatDamageCalculator) Calculate(attacker, defender GameObject, relativeVelocity physics.Vector2) float64 {
+	if ship, ok := defender.(*Spaceship); ok {
+		return ship.maxHealth - ship.minHealth
+	}
+	return 0
+}
+
+// VelocityDamageCalculator deals DamagePerSpeed for every pixel per second of the relative velocity.
+type VelocityDamageCalculator struct {
+	DamagePerSpeed float64
+}
+
+func (calculator VelocityDamageCalculator) Calculate(attacker, defender GameObject, relativeVelocity physics.Vector2) float64 {
+	return relativeVelocity.Magnitude() * calculator.DamagePerSpeed
+}
+
+type velocityHolder interface {
+	Velocity() physics.Vector2
+}
+
+// relativeVelocity returns the attacker's velocity relative to the defender's one,
+// the objects without a velocity, e.g. the explosions, are static.
+func relativeVelocity(attacker, defender GameObject) physics.Vector2 {
+	var attackerVelocity, defenderVelocity physics.Vector2
+	if holder, ok := attacker.(velocityHolder); ok {
+		attackerVelocity = holder.Velocity()
+	}
+	if holder, ok := defender.(velocityHolder); ok {
+		defenderVelocity = holder.Velocity()
+	}
+	return attackerVelocity.Subtract(defenderVelocity)
+}
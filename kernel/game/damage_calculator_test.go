@@ -0,0 +1,88 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatDamageCalculator_Calculate(t *testing.T) {
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+	asteroid := NewAsteroid(1, physics.Vector2{X: 120, Y: 100}, 10)
+	calculator := FlatDamageCalculator{}
+
+	assert.Equal(t, 100.0, calculator.Calculate(asteroid, ship, physics.Vector2{X: 0, Y: 0}))
+	assert.Equal(t, 100.0, calculator.Calculate(asteroid, ship, physics.Vector2{X: 500, Y: 0}))
+	assert.Equal(t, 0.0, calculator.Calculate(ship, asteroid, physics.Vector2{X: 500, Y: 0}))
+}
+
+func TestVelocityDamageCalculator_Calculate(t *testing.T) {
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+	asteroid := NewAsteroid(1, physics.Vector2{X: 120, Y: 100}, 10)
+	calculator := VelocityDamageCalculator{DamagePerSpeed: 0.5}
+
+	assert.Equal(t, 0.0, calculator.Calculate(asteroid, ship, physics.Vector2{X: 0, Y: 0}))
+	assert.Equal(t, 25.0, calculator.Calculate(asteroid, ship, physics.Vector2{X: 30, Y: 40}))
+}
+
+func TestGameManager_DamageCalculator(t *testing.T) {
+	t.Run("Defaults to the flat damage", func(t *testing.T) {
+		gameManager := NewGameManager()
+		assert.Equal(t, FlatDamageCalculator{}, gameManager.DamageCalculator())
+
+		gameManager.SetDamageCalculator(VelocityDamageCalculator{DamagePerSpeed: 1})
+		gameManager.SetDamageCalculator(nil)
+		assert.Equal(t, FlatDamageCalculator{}, gameManager.DamageCalculator())
+
+		ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+		ship.OnCollision(NewAsteroid(1, physics.Vector2{X: 120, Y: 100}, 10), &gameManager, 0)
+		assert.False(t, ship.Enabled())
+	})
+
+	t.Run("Velocity damage scales with the relative velocity", func(t *testing.T) {
+		gameManager := NewGameManager()
+		gameManager.SetDamageCalculator(VelocityDamageCalculator{DamagePerSpeed: 0.5})
+
+		ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+		ship.velocity = physics.Vector2{X: 60, Y: 0}
+		ship.OnCollision(NewAsteroid(1, physics.Vector2{X: 120, Y: 100}, 10), &gameManager, 0)
+
+		assert.Equal(t, [4]float64{70, 100, 100, 100}, ship.HullZones())
+		assert.True(t, ship.Enabled())
+	})
+
+	t.Run("Spaceships collide by the relative velocity", func(t *testing.T) {
+		gameManager := NewGameManager()
+		gameManager.SetDamageCalculator(VelocityDamageCalculator{DamagePerSpeed: 0.5})
+
+		ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+		other := NewSpaceship(1, "other", physics.Vector2{X: 120, Y: 100}, 0)
+		ship.velocity = physics.Vector2{X: 50, Y: 0}
+		other.velocity = physics.Vector2{X: 30, Y: 0}
+		ship.OnCollision(other, &gameManager, 0)
+
+		assert.Equal(t, [4]float64{90, 100, 100, 100}, ship.HullZones())
+	})
+
+	t.Run("Asteroids take the collision damage of the calculator", func(t *testing.T) {
+		gameManager := NewGameManager()
+		ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+		ship.velocity = physics.Vector2{X: 60, Y: 0}
+		asteroid := NewAsteroid(1, physics.Vector2{X: 120, Y: 100}, 10)
+		health := asteroid.Health()
+
+		// The flat damage leaves the asteroid intact
+		asteroid.OnCollision(ship, &gameManager, 0)
+		assert.Equal(t, health, asteroid.Health())
+
+		gameManager.SetDamageCalculator(VelocityDamageCalculator{DamagePerSpeed: 0.05})
+		asteroid.OnCollision(ship, &gameManager, 0)
+		assert.Equal(t, health-3, asteroid.Health())
+
+		other := NewAsteroid(2, physics.Vector2{X: 130, Y: 100}, 10)
+		other.velocity = physics.Vector2{X: -40, Y: 0}
+		asteroid.OnCollision(other, &gameManager, 0)
+		assert.Equal(t, health-5, asteroid.Health())
+	})
+}
@@ -0,0 +1,152 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Deflector is the raisable energy shield of the spaceship. While raised it absorbs a portion of
+// the damage hitting the covered hull zones and drains the energy, it lowers itself once the energy
+// runs out. Unlike the Shield it has no capacity, the energy is the limit.
+type Deflector struct {
+	raised     bool
+	absorption float64 // 0-1, portion of the damage absorbed
+	zones      [4]bool // Covered per HullZone
+}
+
+func (deflector *Deflector) Raised() bool {
+	return deflector.raised
+}
+
+func (deflector *Deflector) Absorption() float64 {
+	return deflector.absorption
+}
+
+// Covers reports whether the deflector protects the hull zone.
+func (deflector *Deflector) Covers(zone HullZone) bool {
+	return deflector.zones[zone]
+}
+
+// deflect returns the damage left after the deflector absorbed its portion.
+func (deflector *Deflector) deflect(zone HullZone, damage float64) float64 {
+	if !deflector.raised || !deflector.zones[zone] {
+		return damage
+	}
+	return damage * (1 - deflector.absorption)
+}
+
+// deflectSpread returns the damage spread over the whole hull left after the deflector, only the covered
+// part of the hull is protected.
+func (deflector *Deflector) deflectSpread(damage float64) float64 {
+	if !deflector.raised {
+		return damage
+	}
+	covered := 0
+	for _, zone := range deflector.zones {
+		if zone {
+			covered++
+		}
+	}
+	return damage * (1 - deflector.absorption*float64(covered)/float64(len(deflector.zones)))
+}
+
+func (deflector *Deflector) Serialize() map[string]interface{} {
+	zones := []interface{}{}
+	for zone, covered := range deflector.zones {
+		if covered {
+			zones = append(zones, float64(zone))
+		}
+	}
+	return map[string]interface{}{
+		"raised":     deflector.raised,
+		"absorption": deflector.absorption,
+		"zones":      zones,
+	}
+}
+
+func deserializeDeflector(data map[string]interface{}) (Deflector, error) {
+	var deflector Deflector
+	var err error
+	if deflector.raised, err = boolean(data, "raised"); err != nil {
+		return Deflector{}, err
+	}
+	if deflector.absorption, err = number(data, "absorption"); err != nil {
+		return Deflector{}, err
+	}
+	zones, err := list(data, "zones")
+	if err != nil {
+		return Deflector{}, err
+	}
+	for _, rawZone := range zones {
+		zone, ok := toNumber(rawZone)
+		if !ok || zone < 0 || int(zone) >= len(deflector.zones) {
+			return Deflector{}, ErrInvalidState{Field: "zones"}
+		}
+		deflector.zones[int(zone)] = true
+	}
+	return deflector, nil
+}
+
+// WithDeflector replaces the default deflector, covering the hull zones with the absorption (0-1).
+func WithDeflector(absorption float64, zones ...HullZone) SpaceshipOption {
+	return func(ship *Spaceship) {
+		ship.deflector = Deflector{absorption: math.Max(0, math.Min(absorption, 1))}
+		for _, zone := range zones {
+			ship.deflector.zones[zone] = true
+		}
+	}
+}
+
+func defaultDeflector() Deflector {
+	deflector := Deflector{absorption: DeflectorAbsorption}
+	for _, zone := range DeflectorZones {
+		deflector.zones[zone] = true
+	}
+	return deflector
+}
+
+func (ship *Spaceship) Deflector() *Deflector {
+	return &ship.deflector
+}
+
+// RaiseDeflector raises the deflector, it drains EnergyConsumptionDeflectorSec until lowered.
+func (ship *Spaceship) RaiseDeflector(gameManager *GameManager) error {
+	if ship.deflector.raised {
+		return nil
+	}
+	if ship.energy <= 0 {
+		return errors.New("not enough energy")
+	}
+	ship.setDeflector(true, gameManager)
+	return nil
+}
+
+// LowerDeflector lowers the deflector, stopping its energy drain.
+func (ship *Spaceship) LowerDeflector(gameManager *GameManager) {
+	if ship.deflector.raised {
+		ship.setDeflector(false, gameManager)
+	}
+}
+
+func (ship *Spaceship) setDeflector(raised bool, gameManager *GameManager) {
+	ship.deflector.raised = raised
+	state := "lowered"
+	if raised {
+		state = "raised"
+	}
+	gameManager.Logger().LogEvent(LogLevelInfo, fmt.Sprintf("\"%s\" %s the deflector", ship.name, state), ship.id, map[string]interface{}{
+		"who":    ship.name,
+		"raised": raised,
+	})
+}
+
+func (ship *Spaceship) deflectorManagement(deltaTimeSec float64, gameManager *GameManager) {
+	if !ship.deflector.raised {
+		return
+	}
+	ship.energy = math.Max(ship.energy-deltaTimeSec*EnergyConsumptionDeflectorSec, 0)
+	if ship.energy <= 0 {
+		ship.setDeflector(false, gameManager)
+	}
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpaceship_RaiseDeflector(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+
+	assert.False(t, ship.Deflector().Raised())
+	assert.NoError(t, ship.RaiseDeflector(&gameManager))
+	assert.True(t, ship.Deflector().Raised())
+	// Raising the raised deflector is a no-op
+	assert.NoError(t, ship.RaiseDeflector(&gameManager))
+	ship.LowerDeflector(&gameManager)
+	assert.False(t, ship.Deflector().Raised())
+	ship.LowerDeflector(&gameManager)
+
+	logs := gameManager.Logger().Logs()
+	assert.Len(t, logs, 2)
+	assert.Equal(t, "\"ship\" raised the deflector", logs[0].Message())
+	assert.Equal(t, true, logs[0].Metadata()["raised"])
+	assert.Equal(t, "\"ship\" lowered the deflector", logs[1].Message())
+
+	ship.energy = 0
+	assert.EqualError(t, ship.RaiseDeflector(&gameManager), "not enough energy")
+
+	ship.energy = MaxEnergy
+	_ = ship.RaiseDeflector(&gameManager)
+	ship.Reset()
+	assert.False(t, ship.Deflector().Raised())
+}
+
+func TestDeflector_Absorb(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	_ = ship.RaiseDeflector(&gameManager)
+
+	// The covered zones absorb the portion, the rear is exposed
+	ship.TakeZoneDamage(HullZoneFront, 50, &gameManager, nil)
+	ship.TakeZoneDamage(HullZoneRear, 10, &gameManager, nil)
+	assert.Equal(t, [4]float64{80, 90, 100, 100}, ship.HullZones())
+
+	// The hull-wide damage is absorbed by the covered part of the hull
+	ship.TakeDamage(40, &gameManager, nil)
+	assert.InDelta(t, 80-40*(1-DeflectorAbsorption*3.0/4.0), ship.HullZones()[HullZoneFront], 1e-9)
+
+	ship.LowerDeflector(&gameManager)
+	ship.Reset()
+	ship.TakeZoneDamage(HullZoneFront, 50, &gameManager, nil)
+	assert.Equal(t, 50.0, ship.HullZones()[HullZoneFront])
+
+	custom := NewSpaceship(2, "custom", physics.Vector2{X: 0, Y: 0}, 0, WithDeflector(2, HullZoneRear))
+	assert.Equal(t, 1.0, custom.Deflector().Absorption())
+	assert.True(t, custom.Deflector().Covers(HullZoneRear))
+	assert.False(t, custom.Deflector().Covers(HullZoneFront))
+	_ = custom.RaiseDeflector(&gameManager)
+	custom.TakeZoneDamage(HullZoneRear, 50, &gameManager, nil)
+	assert.Equal(t, 100.0, custom.HullZones()[HullZoneRear])
+}
+
+func TestDeflector_Drain(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	_ = ship.RaiseDeflector(&gameManager)
+
+	ship.Update(1000, &gameManager)
+	assert.InDelta(t, MaxEnergy-EnergyConsumptionDeflectorSec, ship.Energy(), 1e-9)
+
+	// Lowers itself once the energy runs out
+	ship.energy = 1
+	ship.Update(1000, &gameManager)
+	assert.False(t, ship.Deflector().Raised())
+	assert.Equal(t, 0.0, ship.Energy())
+}
+
+func TestDeflector_Serialize(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	_ = ship.RaiseDeflector(&gameManager)
+
+	assert.Equal(t, map[string]interface{}{
+		"raised":     true,
+		"absorption": DeflectorAbsorption,
+		"zones":      []interface{}{0.0, 2.0, 3.0},
+	}, ship.Serialize()["deflector"])
+
+	deserialized, err := DeserializeSpaceship(ship.Serialize())
+	assert.NoError(t, err)
+	assert.Equal(t, ship.deflector, deserialized.deflector)
+
+	_, err = deserializeDeflector(map[string]interface{}{"raised": true, "absorption": 0.5, "zones": []interface{}{7.0}})
+	assert.Equal(t, ErrInvalidState{Field: "zones"}, err)
+}
@@ -0,0 +1,240 @@
+package game
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"sync"
+)
+
+// deltaTracker records the tick at which every game object last changed, spawned or despawned,
+// for SerializeDelta. It is started by the first SerializeDelta call, so the games without any
+// delta viewer skip the tracking, and it is updated at the end of every step.
+type deltaTracker struct {
+	mutex     sync.Mutex // The spectators read the deltas concurrently under the game's read lock
+	started   bool
+	startTick uint64
+	hashes    map[int64]uint64 // Of the serialized game objects at the last tracking
+	changed   map[int64]uint64 // Tick of the last change by the game object id
+	removed   []removal        // Within the last DeltaHistoryTicks
+}
+
+type removal struct {
+	id   int64
+	tick uint64
+}
+
+// start takes the current game objects as the baseline, their changes are tracked from the next step on.
+func (tracker *deltaTracker) start(manager *GameManager) {
+	tracker.started = true
+	tracker.startTick = manager.tick
+	tracker.hashes = make(map[int64]uint64, len(manager.gameObjects))
+	tracker.changed = make(map[int64]uint64, len(manager.gameObjects))
+	tracker.removed = nil
+	for _, gameObject := range manager.gameObjects {
+		tracker.hashes[gameObject.ID()] = hashGameObject(gameObject)
+		tracker.changed[gameObject.ID()] = manager.tick
+	}
+}
+
+// stop drops the tracked changes, e.g. on the reset rewinding the ticks. The next SerializeDelta starts over.
+func (tracker *deltaTracker) stop() {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	tracker.started = false
+}
+
+// track compares the game objects against the last tracking, stamping the differences with the current tick.
+func (tracker *deltaTracker) track(manager *GameManager) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	if !tracker.started {
+		return
+	}
+
+	tick := manager.tick
+	seen := make(map[int64]bool, len(manager.gameObjects))
+	for _, gameObject := range manager.gameObjects {
+		id := gameObject.ID()
+		seen[id] = true
+		hash := hashGameObject(gameObject)
+		if previous, ok := tracker.hashes[id]; !ok || previous != hash {
+			tracker.hashes[id] = hash
+			tracker.changed[id] = tick
+		}
+	}
+	for id := range tracker.hashes {
+		if !seen[id] {
+			delete(tracker.hashes, id)
+			delete(tracker.changed, id)
+			tracker.removed = append(tracker.removed, removal{id: id, tick: tick})
+		}
+	}
+
+	kept := tracker.removed[:0]
+	for _, removed := range tracker.removed {
+		if removed.tick+DeltaHistoryTicks > tick {
+			kept = append(kept, removed)
+		}
+	}
+	tracker.removed = kept
+}
+
+// complete returns whether the changes after the since tick are all known, the removals are kept
+// for the last DeltaHistoryTicks only.
+func (tracker *deltaTracker) complete(since, tick uint64) bool {
+	if since < tracker.startTick {
+		return false
+	}
+	return tick < DeltaHistoryTicks || since+DeltaHistoryTicks >= tick
+}
+
+func hashGameObject(gameObject GameObject) uint64 {
+	data, err := json.Marshal(gameObject.Serialize())
+	if err != nil {
+		return 0
+	}
+	hash := fnv.New64a()
+	hash.Write(data)
+	return hash.Sum64()
+}
+
+// SerializeDelta returns the game objects changed after the since tick, the spawned ones included,
+// and the ids of the despawned ones under "removed", see ApplyDelta. The changes made between two
+// ticks are reported with the next tick. The logs are omitted.
+//
+// When no tick has passed since, only the current tick is returned. The full game objects are returned,
+// flagged by "full", by the first call, for a since tick older than the DeltaHistoryTicks and after
+// the game was reset.
+func (game *Game) SerializeDelta(since uint64) map[string]interface{} {
+	tick := game.manager.CurrentTick()
+	if tick == since {
+		return map[string]interface{}{
+			"tick": tick,
+		}
+	}
+
+	tracker := &game.deltas
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	full := false
+	if !tracker.started {
+		tracker.start(&game.manager)
+		full = true
+	} else {
+		full = since > tick || !tracker.complete(since, tick)
+	}
+
+	gameObjects := make([]interface{}, 0)
+	removed := make([]interface{}, 0)
+	for _, gameObject := range game.manager.GameObjects() {
+		if full || tracker.changed[gameObject.ID()] > since {
+			gameObjects = append(gameObjects, gameObject.Serialize())
+		}
+	}
+	if !full {
+		for _, despawned := range tracker.removed {
+			if despawned.tick > since {
+				removed = append(removed, despawned.id)
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"tick":        tick,
+		"since":       since,
+		"full":        full,
+		"status":      string(game.Status()),
+		"elapsedMs":   game.manager.elapsedMs,
+		"gameObjects": gameObjects,
+		"removed":     removed,
+	}
+}
+
+// ApplyDelta updates the state, a Serialize output, by the SerializeDelta output, either as is or
+// decoded from JSON. The changed game objects replace the ones of the same id, the new ones are appended.
+func ApplyDelta(state map[string]interface{}, delta map[string]interface{}) error {
+	tick, err := number(delta, "tick")
+	if err != nil {
+		return err
+	}
+	state["tick"] = tick
+	if _, ok := delta["gameObjects"]; !ok {
+		return nil
+	}
+
+	gameObjects, err := list(delta, "gameObjects")
+	if err != nil {
+		return err
+	}
+	status, err := text(delta, "status")
+	if err != nil {
+		return err
+	}
+	elapsedMs, err := number(delta, "elapsedMs")
+	if err != nil {
+		return err
+	}
+	full, err := boolean(delta, "full")
+	if err != nil {
+		return err
+	}
+	state["status"], state["elapsedMs"] = status, elapsedMs
+	if full {
+		state["gameObjects"] = append([]interface{}{}, gameObjects...)
+		return nil
+	}
+
+	current, err := list(state, "gameObjects")
+	if err != nil {
+		return err
+	}
+	removedIDs, err := list(delta, "removed")
+	if err != nil {
+		return err
+	}
+	removed := make(map[float64]bool, len(removedIDs))
+	for _, rawID := range removedIDs {
+		id, ok := toNumber(rawID)
+		if !ok {
+			return ErrInvalidState{Field: "removed"}
+		}
+		removed[id] = true
+	}
+
+	indexes := make(map[float64]int, len(current))
+	updated := make([]interface{}, 0, len(current)+len(gameObjects))
+	for _, rawGameObject := range current {
+		id, err := gameObjectID(rawGameObject)
+		if err != nil {
+			return err
+		}
+		if removed[id] {
+			continue
+		}
+		indexes[id] = len(updated)
+		updated = append(updated, rawGameObject)
+	}
+	for _, rawGameObject := range gameObjects {
+		id, err := gameObjectID(rawGameObject)
+		if err != nil {
+			return err
+		}
+		if index, ok := indexes[id]; ok {
+			updated[index] = rawGameObject
+		} else {
+			indexes[id] = len(updated)
+			updated = append(updated, rawGameObject)
+		}
+	}
+	state["gameObjects"] = updated
+	return nil
+}
+
+func gameObjectID(rawGameObject interface{}) (float64, error) {
+	serialized, ok := rawGameObject.(map[string]interface{})
+	if !ok {
+		return 0, ErrInvalidState{Field: "gameObjects"}
+	}
+	return number(serialized, "id")
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDeltaGame() *Game {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game.SeedAsteroids()
+	_, _ = game.AddSpaceshipWithBot(SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 500}}, NewBotStrategy(ChaserBot{}))
+	_, _ = game.AddSpaceshipWithBot(SpaceshipConfig{Name: "beta", Position: physics.Vector2{X: 900, Y: 500}, Rotation: 3.14}, NewBotStrategy(ChaserBot{}))
+	return game
+}
+
+func TestGame_SerializeDelta(t *testing.T) {
+	game := newDeltaGame()
+	game.Start()
+	game.Update(16)
+
+	first := game.SerializeDelta(0)
+	assert.Equal(t, true, first["full"])
+	assert.Len(t, first["gameObjects"], len(game.manager.GameObjects()))
+
+	game.Update(16)
+	delta := game.SerializeDelta(1)
+	assert.Equal(t, false, delta["full"])
+	assert.Equal(t, uint64(2), delta["tick"])
+	// Only the moving spaceships changed, the asteroids stay in place
+	changed := delta["gameObjects"].([]interface{})
+	assert.Len(t, changed, 2)
+	for _, gameObject := range changed {
+		assert.Equal(t, "spaceship", gameObject.(map[string]interface{})["type"])
+	}
+	assert.Empty(t, delta["removed"])
+	assert.Equal(t, map[string]interface{}{"tick": uint64(2)}, game.SerializeDelta(2))
+
+	t.Run("Spawns and despawns", func(t *testing.T) {
+		alpha, _ := game.manager.GetSpaceship("alpha")
+		_ = alpha.FireLaser(&game.manager)
+		laser := game.manager.gameObjects[len(game.manager.gameObjects)-1].(*Projectile)
+		game.Update(16)
+		assert.Contains(t, game.SerializeDelta(2)["gameObjects"], laser.Serialize())
+
+		laser.Destroy(&game.manager, false)
+		game.Update(16)
+		assert.Equal(t, []interface{}{laser.ID()}, game.SerializeDelta(3)["removed"])
+		assert.Equal(t, []interface{}{laser.ID()}, game.SerializeDelta(2)["removed"])
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		game.Reset()
+		game.Start()
+		game.Update(16)
+		assert.Equal(t, true, game.SerializeDelta(4)["full"])
+		game.Update(16)
+		assert.Equal(t, false, game.SerializeDelta(1)["full"])
+	})
+
+	t.Run("Beyond the history", func(t *testing.T) {
+		for i := 0; i < DeltaHistoryTicks+1; i++ {
+			game.Update(1)
+		}
+		assert.Equal(t, true, game.SerializeDelta(1)["full"])
+		assert.Equal(t, false, game.SerializeDelta(3)["full"])
+	})
+}
+
+func TestApplyDelta(t *testing.T) {
+	game := newDeltaGame()
+	game.Start()
+	state := game.Serialize()
+	tick := game.manager.CurrentTick()
+
+	// The client following every other tick ends up with the full state
+	for i := 0; i < 300 && game.Status() == Running; i++ {
+		game.Update(16)
+		if i%2 == 0 {
+			continue
+		}
+		assert.NoError(t, ApplyDelta(state, game.SerializeDelta(tick)))
+		tick = game.manager.CurrentTick()
+		assert.Equal(t, game.Serialize()["gameObjects"], state["gameObjects"])
+	}
+	assert.Equal(t, float64(tick), state["tick"])
+
+	t.Run("JSON", func(t *testing.T) {
+		var decodedState, decodedDelta map[string]interface{}
+		data, _ := json.Marshal(game.Serialize())
+		_ = json.Unmarshal(data, &decodedState)
+		since := game.manager.CurrentTick()
+		alpha, _ := game.manager.GetSpaceship("alpha")
+		_ = alpha.FireLaser(&game.manager)
+		game.Update(16)
+		data, _ = json.Marshal(game.SerializeDelta(since))
+		_ = json.Unmarshal(data, &decodedDelta)
+
+		assert.NoError(t, ApplyDelta(decodedState, decodedDelta))
+		var expected map[string]interface{}
+		data, _ = json.Marshal(game.Serialize())
+		_ = json.Unmarshal(data, &expected)
+		assert.Equal(t, expected["gameObjects"], decodedState["gameObjects"])
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, ErrInvalidState{Field: "tick"}, ApplyDelta(state, map[string]interface{}{}))
+		delta := map[string]interface{}{"tick": 1, "status": "running", "elapsedMs": 1, "full": false,
+			"gameObjects": []interface{}{}, "removed": []interface{}{"alpha"}}
+		assert.Equal(t, ErrInvalidState{Field: "removed"}, ApplyDelta(state, delta))
+	})
+}
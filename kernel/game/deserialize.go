@@ -0,0 +1,108 @@
+package game
+
+import (
+	"encoding/json"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// The accessors below read the fields of a serialized state, either as produced by Serialize or
+// decoded from JSON, so the numbers may be of any numeric type. A missing or mistyped field is
+// reported as ErrInvalidState, the optional ones fall back to the given value when missing.
+
+func toNumber(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case float32:
+		return float64(number), true
+	case int:
+		return float64(number), true
+	case int32:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	case uint64:
+		return float64(number), true
+	case json.Number:
+		parsed, err := number.Float64()
+		return parsed, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func number(data map[string]interface{}, key string) (float64, error) {
+	value, ok := toNumber(data[key])
+	if !ok {
+		return 0, ErrInvalidState{Field: key}
+	}
+	return value, nil
+}
+
+func optionalNumber(data map[string]interface{}, key string, fallback float64) (float64, error) {
+	if _, ok := data[key]; !ok {
+		return fallback, nil
+	}
+	return number(data, key)
+}
+
+func text(data map[string]interface{}, key string) (string, error) {
+	value, ok := data[key].(string)
+	if !ok {
+		return "", ErrInvalidState{Field: key}
+	}
+	return value, nil
+}
+
+func boolean(data map[string]interface{}, key string) (bool, error) {
+	value, ok := data[key].(bool)
+	if !ok {
+		return false, ErrInvalidState{Field: key}
+	}
+	return value, nil
+}
+
+func object(data map[string]interface{}, key string) (map[string]interface{}, error) {
+	value, ok := data[key].(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidState{Field: key}
+	}
+	return value, nil
+}
+
+func list(data map[string]interface{}, key string) ([]interface{}, error) {
+	value, ok := data[key].([]interface{})
+	if !ok {
+		return nil, ErrInvalidState{Field: key}
+	}
+	return value, nil
+}
+
+func vector(data map[string]interface{}, key string) (physics.Vector2, error) {
+	value, err := object(data, key)
+	if err != nil {
+		return physics.Vector2{}, err
+	}
+	x, okX := toNumber(value["x"])
+	y, okY := toNumber(value["y"])
+	if !okX || !okY {
+		return physics.Vector2{}, ErrInvalidState{Field: key}
+	}
+	return physics.Vector2{X: x, Y: y}, nil
+}
+
+// gameObjectHeader reads the fields common to all the serialized game objects.
+func gameObjectHeader(data map[string]interface{}) (id int64, enabled bool, position physics.Vector2, err error) {
+	idNumber, err := number(data, "id")
+	if err != nil {
+		return 0, false, physics.Vector2{}, err
+	}
+	if enabled, err = boolean(data, "enabled"); err != nil {
+		return 0, false, physics.Vector2{}, err
+	}
+	if position, err = vector(data, "position"); err != nil {
+		return 0, false, physics.Vector2{}, err
+	}
+	return int64(idNumber), enabled, position, nil
+}
@@ -0,0 +1,55 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumber(t *testing.T) {
+	data := map[string]interface{}{
+		"float":  1.5,
+		"int":    2,
+		"int32":  int32(3),
+		"int64":  int64(4),
+		"uint64": uint64(5),
+		"json":   json.Number("6.5"),
+		"text":   "7",
+	}
+
+	for key, expected := range map[string]float64{"float": 1.5, "int": 2, "int32": 3, "int64": 4, "uint64": 5, "json": 6.5} {
+		value, err := number(data, key)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, key)
+	}
+
+	_, err := number(data, "text")
+	assert.Equal(t, ErrInvalidState{Field: "text"}, err)
+	_, err = number(data, "missing")
+	assert.Equal(t, ErrInvalidState{Field: "missing"}, err)
+
+	value, err := optionalNumber(data, "missing", 8)
+	assert.NoError(t, err)
+	assert.Equal(t, 8.0, value)
+	_, err = optionalNumber(data, "text", 8)
+	assert.Equal(t, ErrInvalidState{Field: "text"}, err)
+}
+
+func TestVector(t *testing.T) {
+	data := map[string]interface{}{
+		"position": map[string]interface{}{"x": 1, "y": 2.5},
+		"partial":  map[string]interface{}{"x": 1},
+		"scalar":   1.0,
+	}
+
+	position, err := vector(data, "position")
+	assert.NoError(t, err)
+	assert.Equal(t, physics.Vector2{X: 1, Y: 2.5}, position)
+
+	_, err = vector(data, "partial")
+	assert.Equal(t, ErrInvalidState{Field: "partial"}, err)
+	_, err = vector(data, "scalar")
+	assert.Equal(t, ErrInvalidState{Field: "scalar"}, err)
+}
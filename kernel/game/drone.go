@@ -0,0 +1,179 @@
+package game
+
+import (
+	"errors"
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
+
+// DroneStrategy controls a drone, it is invoked once per Update while the drone is enabled.
+type DroneStrategy interface {
+	Update(drone *Drone, gameManager *GameManager, deltaTimeMs float64)
+}
+
+// DroneStrategyFunc adapts a plain function to the DroneStrategy.
+type DroneStrategyFunc func(drone *Drone, gameManager *GameManager, deltaTimeMs float64)
+
+func (fn DroneStrategyFunc) Update(drone *Drone, gameManager *GameManager, deltaTimeMs float64) {
+	fn(drone, gameManager, deltaTimeMs)
+}
+
+type DroneConfig struct {
+	Offset   physics.Vector2 // Launch position relative to the owner, orientation to rad 0
+	Strategy DroneStrategy
+}
+
+// Drone is a small autonomous craft launched by a spaceship. It shares the owner's team,
+// so the projectiles of the team pass through it, and it is removed once the owner is destroyed.
+type Drone struct {
+	id       int64
+	ownerID  int64
+	team     string
+	enabled  bool
+	position physics.Vector2
+	velocity physics.Vector2
+	rotation float64
+	collider collider.CircleCollider
+	strategy DroneStrategy
+}
+
+func NewDrone(id int64, owner *Spaceship, position physics.Vector2, rotation float64, strategy DroneStrategy) *Drone {
+	return &Drone{
+		id:       id,
+		ownerID:  owner.id,
+		team:     owner.team,
+		enabled:  true,
+		position: position,
+		rotation: rotation,
+		collider: *collider.NewCircleCollider(position, DroneSize/2),
+		strategy: strategy,
+	}
+}
+
+// LaunchDrone launches the drone next to the ship, up to MaxDrones at once.
+func (ship *Spaceship) LaunchDrone(config DroneConfig, gameManager *GameManager) (*Drone, error) {
+	if !ship.enabled {
+		return nil, errors.New("space ship is destroyed")
+	}
+
+	drones := gameManager.Count(func(gameObject GameObject) bool {
+		drone, ok := gameObject.(*Drone)
+		return ok && drone.enabled && drone.ownerID == ship.id
+	})
+	if drones >= MaxDrones {
+		return nil, errors.New("too many drones")
+	}
+
+	drone := NewDrone(gameManager.NewID(), ship, ship.position.Add(config.Offset.Rotate(ship.rotation)), ship.rotation, config.Strategy)
+	if _, err := gameManager.AddGameObject(drone); err != nil {
+		return nil, err
+	}
+	return drone, nil
+}
+
+func (drone *Drone) ID() int64 {
+	return drone.id
+}
+
+func (drone *Drone) OwnerID() int64 {
+	return drone.ownerID
+}
+
+func (drone *Drone) Team() string {
+	return drone.team
+}
+
+func (drone *Drone) Enabled() bool {
+	return drone.enabled
+}
+
+func (drone *Drone) SetEnabled(enabled bool) {
+	drone.enabled = enabled
+}
+
+func (drone *Drone) Position() physics.Vector2 {
+	return drone.position
+}
+
+func (drone *Drone) SetPosition(position physics.Vector2) {
+	drone.position = position
+	drone.collider.SetPosition(position)
+}
+
+func (drone *Drone) Velocity() physics.Vector2 {
+	return drone.velocity
+}
+
+// SetVelocity sets the velocity per second, clamped to the DroneMaxVelocitySec.
+func (drone *Drone) SetVelocity(velocity physics.Vector2) {
+	drone.velocity = velocity.Clamp(DroneMaxVelocitySec)
+	if drone.velocity.Magnitude() > 0 {
+		drone.rotation = math.Atan2(drone.velocity.Y, drone.velocity.X)
+	}
+}
+
+func (drone *Drone) Update(deltaTimeMs float64, gameManager *GameManager) {
+	owner := gameManager.GetGameObjectByID(drone.ownerID)
+	if owner == nil || !owner.Enabled() {
+		drone.Destroy(gameManager)
+		return
+	}
+
+	if drone.strategy != nil {
+		drone.strategy.Update(drone, gameManager, deltaTimeMs)
+	}
+
+	drone.position = drone.position.Add(drone.velocity.Multiply(deltaTimeMs / 1000))
+	drone.collider.SetPosition(drone.position)
+}
+
+func (drone *Drone) Collider() collider.Collider {
+	return &drone.collider
+}
+
+// Friendly reports whether the spaceship is the owner or a team mate of the drone.
+func (drone *Drone) Friendly(spaceship *Spaceship) bool {
+	return spaceship.id == drone.ownerID || (drone.team != "" && spaceship.team == drone.team)
+}
+
+func (drone *Drone) OnCollision(other GameObject, gameManager *GameManager, order int) {
+	switch other := other.(type) {
+	case *Asteroid:
+		drone.Destroy(gameManager)
+	case *Spaceship:
+		if !drone.Friendly(other) {
+			drone.Destroy(gameManager)
+		}
+	case *Projectile:
+		if !drone.Friendly(other.owner) {
+			drone.Destroy(gameManager)
+		}
+	}
+}
+
+func (drone *Drone) Destroy(gameManager *GameManager) {
+	gameManager.DisableGameObject(drone)
+	gameManager.RemoveGameObject(drone)
+}
+
+func (drone *Drone) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "drone",
+		"id":      drone.id,
+		"enabled": drone.enabled,
+		"owner":   drone.ownerID,
+		"team":    drone.team,
+		"position": map[string]interface{}{
+			"x": drone.position.X,
+			"y": drone.position.Y,
+		},
+		"rotation": drone.rotation,
+		"velocity": map[string]interface{}{
+			"x": drone.velocity.X,
+			"y": drone.velocity.Y,
+		},
+		"collider": drone.collider.Serialize(),
+	}
+}
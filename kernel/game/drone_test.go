@@ -0,0 +1,102 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpaceship_LaunchDrone(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 100, Y: 100}, math.Pi/2, WithTeam("red"))
+	gameManager.AddSpaceship(ship)
+
+	drone, err := ship.LaunchDrone(DroneConfig{Offset: physics.Vector2{X: -20, Y: 0}}, &gameManager)
+	assert.NoError(t, err)
+	assert.Contains(t, gameManager.GameObjects(), drone)
+	assert.Equal(t, ship.ID(), drone.OwnerID())
+	assert.Equal(t, "red", drone.Team())
+	assert.InDelta(t, 100, drone.Position().X, 1e-9)
+	assert.InDelta(t, 80, drone.Position().Y, 1e-9)
+
+	// Limited number of drones
+	for i := 1; i < MaxDrones; i++ {
+		_, err = ship.LaunchDrone(DroneConfig{}, &gameManager)
+		assert.NoError(t, err)
+	}
+	_, err = ship.LaunchDrone(DroneConfig{}, &gameManager)
+	assert.Error(t, err)
+}
+
+func TestDrone_Update_Strategy(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+	gameManager.AddSpaceship(ship)
+
+	calls := 0
+	drone, _ := ship.LaunchDrone(DroneConfig{
+		Strategy: DroneStrategyFunc(func(drone *Drone, gameManager *GameManager, deltaTimeMs float64) {
+			calls++
+			drone.SetVelocity(physics.Vector2{X: 0, Y: 50})
+		}),
+	}, &gameManager)
+
+	drone.Update(1000, &gameManager)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, physics.Vector2{X: 100, Y: 150}, drone.Position())
+	assert.Equal(t, math.Pi/2, drone.rotation)
+}
+
+func TestDrone_RemovedWithOwner(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	owner, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "owner", Position: physics.Vector2{X: 100, Y: 100}})
+	game.AddSpaceship("other", physics.Vector2{X: 800, Y: 800}, 0)
+	drone, _ := owner.LaunchDrone(DroneConfig{Offset: physics.Vector2{X: 50, Y: 0}}, &game.manager)
+
+	game.Update(16)
+	assert.True(t, drone.Enabled())
+
+	owner.TakeDamage(MaxHealth, &game.manager, nil)
+	game.Update(16)
+	assert.False(t, drone.Enabled())
+	assert.Nil(t, game.manager.GetGameObjectByID(drone.ID()))
+}
+
+func TestDrone_OnCollision(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 100, Y: 100}, 0, WithTeam("red"))
+	mate := NewSpaceship(2, "mate", physics.Vector2{X: 200, Y: 100}, 0, WithTeam("red"))
+	enemy := NewSpaceship(3, "enemy", physics.Vector2{X: 300, Y: 100}, 0, WithTeam("blue"))
+
+	// Friendly fire passes through
+	drone := NewDrone(4, owner, physics.Vector2{X: 0, Y: 0}, 0, nil)
+	gameManager.AddGameObject(drone)
+	laser := NewLaserProjectile(5, physics.Vector2{X: 0, Y: 0}, 0, mate)
+	gameManager.AddGameObject(laser)
+	drone.OnCollision(laser, &gameManager, 0)
+	laser.OnCollision(drone, &gameManager, 1)
+	assert.True(t, drone.Enabled())
+	assert.True(t, laser.Enabled())
+
+	// Enemy fire destroys the drone
+	laser = NewLaserProjectile(6, physics.Vector2{X: 0, Y: 0}, 0, enemy)
+	gameManager.AddGameObject(laser)
+	drone.OnCollision(laser, &gameManager, 0)
+	laser.OnCollision(drone, &gameManager, 1)
+	assert.False(t, drone.Enabled())
+	assert.False(t, laser.Enabled())
+}
+
+func TestDrone_Serialize(t *testing.T) {
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 100, Y: 100}, 0, WithTeam("red"))
+	drone := NewDrone(2, owner, physics.Vector2{X: 10, Y: 20}, 0, nil)
+	serialized := drone.Serialize()
+
+	assert.Equal(t, "drone", serialized["type"])
+	assert.Equal(t, int64(2), serialized["id"])
+	assert.Equal(t, int64(1), serialized["owner"])
+	assert.Equal(t, "red", serialized["team"])
+	assert.Equal(t, map[string]interface{}{"x": 10.0, "y": 20.0}, serialized["position"])
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrGameNotEnded = errors.New("game has not ended")
+
+type ErrDuplicateSpaceshipName struct {
+	Name string
+}
+
+func (err ErrDuplicateSpaceshipName) Error() string {
+	return fmt.Sprintf("space ship already exists: %s", err.Name)
+}
+
+type ErrSpaceshipNotFound struct {
+	Name string
+}
+
+func (err ErrSpaceshipNotFound) Error() string {
+	return fmt.Sprintf("space ship not found: %s", err.Name)
+}
+
+type ErrIncompatibleVersion struct {
+	Version        int
+	CurrentVersion int
+}
+
+func (err ErrIncompatibleVersion) Error() string {
+	return fmt.Sprintf("no migration path from state version %d to %d", err.Version, err.CurrentVersion)
+}
+
+type ErrSpaceshipDisabled struct {
+	Name string
+}
+
+func (err ErrSpaceshipDisabled) Error() string {
+	return fmt.Sprintf("space ship is disabled: %s", err.Name)
+}
+
+type ErrObjectLimitReached struct {
+	ObjectType string
+	Limit      int
+}
+
+func (err ErrObjectLimitReached) Error() string {
+	return fmt.Sprintf("%s limit reached: %d", err.ObjectType, err.Limit)
+}
+
+type ErrInvalidState struct {
+	Field string
+}
+
+func (err ErrInvalidState) Error() string {
+	return fmt.Sprintf("invalid state field: %s", err.Field)
+}
+
+type ErrInvalidGameConfig struct {
+	Field string
+}
+
+func (err ErrInvalidGameConfig) Error() string {
+	return fmt.Sprintf("invalid game config field: %s", err.Field)
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"context"
+	"sync"
+)
+
+// ListenUntilEnded subscribes the callback to the game events, see GameManager.Events, until the game ends
+// or the context is cancelled, whichever comes first. The GameStateChanged to Ended is the last event
+// passed. The callback runs on the goroutine updating the game and must not call the Game methods taking
+// the game lock, e.g. Update.
+func (game *Game) ListenUntilEnded(ctx context.Context, callback func(event GameEvent)) {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	if game.status == Ended || ctx.Err() != nil {
+		return
+	}
+
+	var once sync.Once
+	var unsubscribe func()
+	done := make(chan struct{})
+	stop := func() {
+		once.Do(func() {
+			unsubscribe()
+			close(done)
+		})
+	}
+	unsubscribe = game.manager.Events().Subscribe(func(event GameEvent) {
+		// The context could have been cancelled before the subscriber was removed
+		if ctx.Err() != nil {
+			return
+		}
+		callback(event)
+		if changed, ok := event.(GameStateChanged); ok && changed.Status == Ended {
+			stop()
+		}
+	})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			game.mutex.Lock()
+			stop()
+			game.mutex.Unlock()
+		case <-done:
+		}
+	}()
+}
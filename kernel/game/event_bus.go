@@ -0,0 +1,125 @@
+package game
+
+import "time"
+
+// GameEvent is a typed event published on the GameManager's EventBus, the subscribers switch on the
+// concrete type: ShipDestroyed, ProjectileFired, CollisionOccurred, AsteroidDestroyed, PickupCollected
+// or GameStateChanged.
+type GameEvent interface {
+	EventTick() uint64
+}
+
+// ShipDestroyed is published once the spaceship's hull is depleted. Killer is nil when no spaceship
+// dealt the final blow, e.g. an asteroid collision.
+type ShipDestroyed struct {
+	Tick   uint64
+	Ship   *Spaceship
+	Killer *Spaceship
+}
+
+// ProjectileFired is published once a projectile is added to the game.
+type ProjectileFired struct {
+	Tick       uint64
+	Projectile *Projectile
+	Owner      *Spaceship
+}
+
+// CollisionOccurred is published after both colliding objects handled the collision. The overlaps
+// of the triggers are not collisions. A destroyed projectile is recycled once the tick ends, it must
+// not be kept by the subscribers.
+type CollisionOccurred struct {
+	Tick uint64
+	A    GameObject
+	B    GameObject
+}
+
+// AsteroidDestroyed is published once the asteroid's health is depleted, with the fragments it split into,
+// none when it was of the smallest size. Destroyer is nil when no spaceship dealt the final blow.
+type AsteroidDestroyed struct {
+	Tick      uint64
+	Asteroid  *Asteroid
+	Destroyer *Spaceship
+	Fragments []*Asteroid
+}
+
+// PickupCollected is published once the pickup is applied to the spaceship, the pickup is already removed.
+type PickupCollected struct {
+	Tick   uint64
+	Pickup *Pickup
+	Ship   *Spaceship
+}
+
+// GameStateChanged is published on the game status change, Reason is set for the Ended status, see Game.EndReason.
+type GameStateChanged struct {
+	Tick   uint64
+	Status Status
+	Reason string
+}
+
+// SuddenDeathStarted is published once the sudden death phase starts, see WithSuddenDeath.
+type SuddenDeathStarted struct {
+	Tick uint64
+}
+
+func (event ShipDestroyed) EventTick() uint64      { return event.Tick }
+func (event ProjectileFired) EventTick() uint64    { return event.Tick }
+func (event CollisionOccurred) EventTick() uint64  { return event.Tick }
+func (event AsteroidDestroyed) EventTick() uint64  { return event.Tick }
+func (event PickupCollected) EventTick() uint64    { return event.Tick }
+func (event GameStateChanged) EventTick() uint64   { return event.Tick }
+func (event SuddenDeathStarted) EventTick() uint64 { return event.Tick }
+
+type eventSubscriber struct {
+	id       int
+	callback func(event GameEvent)
+}
+
+// EventBus passes the game events to the subscribers synchronously, on the goroutine updating the game,
+// in the subscription order. The subscribers must not call the Game methods taking the game lock.
+type EventBus struct {
+	nextID      int
+	subscribers []eventSubscriber
+}
+
+// Subscribe registers the callback for all the events, returns the function unsubscribing it.
+func (bus *EventBus) Subscribe(callback func(event GameEvent)) func() {
+	bus.nextID++
+	id := bus.nextID
+	bus.subscribers = append(bus.subscribers, eventSubscriber{id: id, callback: callback})
+	return func() {
+		for i, subscriber := range bus.subscribers {
+			if subscriber.id == id {
+				bus.subscribers = append(bus.subscribers[:i:i], bus.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (bus *EventBus) Publish(event GameEvent) {
+	for _, subscriber := range bus.subscribers {
+		subscriber.callback(event)
+	}
+}
+
+// Events returns the event bus, the subscriptions are kept across the resets.
+func (manager *GameManager) Events() *EventBus {
+	return &manager.events
+}
+
+// publish logs the event and passes it to the subscribers.
+func (manager *GameManager) publish(event GameEvent) {
+	switch event := event.(type) {
+	case ShipDestroyed:
+		if event.Killer != nil {
+			manager.logger.Kill(time.Now(), event.Ship.id, event.Ship.name, event.Killer.name)
+		}
+	case GameStateChanged:
+		if event.Status == Ended {
+			manager.logger.GameEnded(time.Now(), event.Reason)
+		} else {
+			manager.logger.GameState(time.Now(), event.Status)
+		}
+	}
+	manager.events.Publish(event)
+}
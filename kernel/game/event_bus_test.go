@@ -0,0 +1,72 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventBus_Subscribe(t *testing.T) {
+	var bus EventBus
+	var first, second []GameEvent
+	unsubscribe := bus.Subscribe(func(event GameEvent) { first = append(first, event) })
+	bus.Subscribe(func(event GameEvent) { second = append(second, event) })
+
+	bus.Publish(GameStateChanged{Tick: 1, Status: Running})
+	unsubscribe()
+	bus.Publish(GameStateChanged{Tick: 2, Status: Paused})
+
+	assert.Equal(t, []GameEvent{GameStateChanged{Tick: 1, Status: Running}}, first)
+	assert.Len(t, second, 2)
+	assert.Equal(t, uint64(2), second[1].EventTick())
+}
+
+func TestGameManager_Events(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithTimeLimitMs(1000))
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 110, Y: 100}, 0)
+	game.AddSpaceship("gamma", physics.Vector2{X: 500, Y: 500}, 0)
+	alpha, _ := game.manager.GetSpaceship("alpha")
+	beta, _ := game.manager.GetSpaceship("beta")
+	gamma, _ := game.manager.GetSpaceship("gamma")
+
+	var events []GameEvent
+	game.manager.Events().Subscribe(func(event GameEvent) { events = append(events, event) })
+
+	game.Start()
+	assert.Equal(t, []GameEvent{GameStateChanged{Status: Running}}, events)
+
+	events = nil
+	_ = gamma.FireLaser(&game.manager)
+	game.Update(16)
+	assert.IsType(t, ProjectileFired{}, events[0])
+	assert.Equal(t, gamma, events[0].(ProjectileFired).Owner)
+
+	// Alpha and beta overlap
+	var collision *CollisionOccurred
+	for _, event := range events {
+		if event, ok := event.(CollisionOccurred); ok {
+			collision = &event
+		}
+	}
+	assert.NotNil(t, collision)
+	assert.Equal(t, uint64(1), collision.Tick)
+	assert.ElementsMatch(t, []GameObject{alpha, beta}, []GameObject{collision.A, collision.B})
+
+	events = nil
+	beta.TakeDamage(1000, &game.manager, gamma)
+	assert.Equal(t, []GameEvent{ShipDestroyed{Tick: 1, Ship: beta, Killer: gamma}}, events)
+	logs := game.manager.Logger().Logs()
+	assert.Equal(t, "\"beta\" was killed by \"gamma\"", logs[len(logs)-1].Message())
+
+	events = nil
+	game.Reset()
+	game.Start()
+	game.Update(1000)
+	ended := events[len(events)-1].(GameStateChanged)
+	assert.Equal(t, Ended, ended.Status)
+	assert.Equal(t, "timeLimitReached", ended.Reason)
+	logs = game.manager.Logger().Logs()
+	assert.Equal(t, "Game state changed to: ended (timeLimitReached)", logs[len(logs)-1].Message())
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func subscriberCount(game *Game) int {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	return len(game.manager.events.subscribers)
+}
+
+func TestGame_ListenUntilEnded(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithTimeLimitMs(32))
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 500, Y: 500}, 0)
+
+	var events []GameEvent
+	game.ListenUntilEnded(context.Background(), func(event GameEvent) {
+		events = append(events, event)
+	})
+	assert.Equal(t, 1, subscriberCount(game))
+
+	// Every status change is passed once
+	game.Start()
+	assert.Equal(t, []GameEvent{GameStateChanged{Status: Running}}, events)
+
+	game.Update(16)
+	game.Update(16)
+	assert.Equal(t, Ended, game.Status())
+	assert.Equal(t, GameStateChanged{Tick: 2, Status: Ended, Reason: game.EndReason()}, events[len(events)-1])
+	assert.Equal(t, 0, subscriberCount(game))
+
+	// Unsubscribed after the end
+	count := len(events)
+	game.Reset()
+	game.Start()
+	assert.Len(t, events, count)
+
+	// The ended game is not listened to
+	game.Update(16)
+	game.Update(16)
+	game.ListenUntilEnded(context.Background(), func(event GameEvent) {})
+	assert.Equal(t, 0, subscriberCount(game))
+}
+
+func TestGame_ListenUntilEnded_Cancelled(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var events []GameEvent
+	game.ListenUntilEnded(ctx, func(event GameEvent) {
+		events = append(events, event)
+	})
+	game.Start()
+	assert.Len(t, events, 1)
+
+	cancel()
+	game.Pause()
+	assert.Len(t, events, 1)
+	assert.Eventually(t, func() bool {
+		return subscriberCount(game) == 0
+	}, time.Second, time.Millisecond)
+
+	// An already cancelled context is not listened to
+	game.ListenUntilEnded(ctx, func(event GameEvent) {})
+	assert.Equal(t, 0, subscriberCount(game))
+}
@@ -49,8 +49,9 @@ func (explosion *Explosion) Update(deltaTimeMs float64, gameManager *GameManager
 	explosion.lifespanSec -= deltaTimeMs / 1000
 	if explosion.lifespanSec <= 0 {
 		explosion.lifespanSec = 0
-		explosion.enabled = false
+		gameManager.DisableGameObject(explosion)
 		gameManager.RemoveGameObject(explosion)
+		gameManager.recycle(explosion)
 	}
 }
 
@@ -74,3 +75,28 @@ func (explosion *Explosion) Serialize() map[string]interface{} {
 		"lifespanSec": explosion.lifespanSec,
 	}
 }
+
+// DeserializeExplosion restores the explosion from its Serialize output.
+func DeserializeExplosion(data map[string]interface{}) (*Explosion, error) {
+	id, enabled, position, err := gameObjectHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	radius, err := number(data, "radius")
+	if err != nil {
+		return nil, err
+	}
+	durationSec, err := number(data, "durationSec")
+	if err != nil {
+		return nil, err
+	}
+	lifespanSec, err := number(data, "lifespanSec")
+	if err != nil {
+		return nil, err
+	}
+
+	explosion := NewExplosion(id, position, radius, durationSec)
+	explosion.enabled = enabled
+	explosion.lifespanSec = lifespanSec
+	return explosion, nil
+}
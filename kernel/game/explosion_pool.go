@@ -0,0 +1,43 @@
+package game
+
+import (
+	"sync"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// ExplosionPool recycles the short-lived explosions to reduce the GC pressure.
+type ExplosionPool struct {
+	pool sync.Pool
+}
+
+func NewExplosionPool() *ExplosionPool {
+	return &ExplosionPool{
+		pool: sync.Pool{
+			New: func() interface{} {
+				return &Explosion{}
+			},
+		},
+	}
+}
+
+// Get returns an explosion initialized the same way as NewExplosion.
+func (pool *ExplosionPool) Get(id int64, position physics.Vector2, radius float64, lifespanSec float64) *Explosion {
+	explosion := pool.pool.Get().(*Explosion)
+	*explosion = Explosion{
+		id:          id,
+		enabled:     true,
+		position:    position,
+		radius:      radius,
+		durationSec: lifespanSec,
+		lifespanSec: lifespanSec,
+	}
+	return explosion
+}
+
+// Put resets the explosion and returns it to the pool.
+// The explosion must not be referenced by the game anymore.
+func (pool *ExplosionPool) Put(explosion *Explosion) {
+	*explosion = Explosion{}
+	pool.pool.Put(explosion)
+}
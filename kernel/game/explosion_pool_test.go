@@ -0,0 +1,41 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplosionPool_Get(t *testing.T) {
+	pool := NewExplosionPool()
+	explosion := pool.Get(7, physics.Vector2{X: 15, Y: 30}, 20, 1.5)
+
+	assert.Equal(t, NewExplosion(7, physics.Vector2{X: 15, Y: 30}, 20, 1.5), explosion)
+}
+
+func TestExplosionPool_Put(t *testing.T) {
+	pool := NewExplosionPool()
+	explosion := NewExplosion(7, physics.Vector2{X: 15, Y: 30}, 20, 1.5)
+
+	pool.Put(explosion)
+	assert.Equal(t, Explosion{}, *explosion)
+
+	// A recycled explosion does not carry any stale state
+	recycled := pool.Get(8, physics.Vector2{X: 1, Y: 2}, 5, 0.5)
+	assert.Equal(t, NewExplosion(8, physics.Vector2{X: 1, Y: 2}, 5, 0.5), recycled)
+}
+
+func TestExplosion_Update_ReturnsToPool(t *testing.T) {
+	gameManager := NewGameManager()
+	explosion := gameManager.ExplosionPool().Get(gameManager.NewID(), physics.Vector2{X: 15, Y: 30}, 20, 1)
+	gameManager.AddGameObject(explosion)
+
+	explosion.Update(1000, &gameManager)
+	assert.Equal(t, 0, gameManager.GameObjectSize())
+	// Intact until the tick ends
+	assert.Equal(t, physics.Vector2{X: 15, Y: 30}, explosion.Position())
+
+	gameManager.releaseRecycled()
+	assert.Equal(t, Explosion{}, *explosion)
+}
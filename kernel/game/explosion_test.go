@@ -74,3 +74,17 @@ func TestExplosionSerialize(t *testing.T) {
 		"lifespanSec": 2.0,
 	}, explosion.Serialize())
 }
+
+func TestDeserializeExplosion(t *testing.T) {
+	explosion := NewExplosion(1, physics.Vector2{X: 10, Y: 20}, 5, 2)
+	explosion.lifespanSec = 0.5
+
+	deserialized, err := DeserializeExplosion(explosion.Serialize())
+	assert.NoError(t, err)
+	assert.Equal(t, explosion, deserialized)
+
+	serialized := explosion.Serialize()
+	serialized["lifespanSec"] = "0.5"
+	_, err = DeserializeExplosion(serialized)
+	assert.Equal(t, ErrInvalidState{Field: "lifespanSec"}, err)
+}
@@ -1,11 +1,13 @@
 package game
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"sync"
 	"time"
 
 	"encoding/json"
-	"math/rand"
 
 	"github.com/davidhorak/space-wars/kernel/physics"
 )
@@ -25,106 +27,439 @@ const (
 	DamageTypeUnknown DamageType = "unknown"
 	DamageTypeLaser   DamageType = "laser"
 	DamageTypeRocket  DamageType = "rocket"
+	DamageTypeMine    DamageType = "mine"
 )
 
+type queuedSpaceshipAction struct {
+	name   string
+	action func(spaceShip *Spaceship, gameManager *GameManager)
+}
+
+type NamedAction struct {
+	Name   string
+	Action func(spaceShip *Spaceship, gameManager *GameManager)
+}
+
 type Game struct {
+	mutex sync.RWMutex // Guards Update against the actions, the settings and the spectators
+
 	seed             int64
 	status           Status
 	size             physics.Size
+	config           GameConfig
 	manager          GameManager
 	gracefulEndTimer float64
+	timeScale        float64
+	timeLimitMs      float64    // 0 for no limit
+	statusMutex      sync.Mutex // Guards the status channel against Close
+	statusChan       chan Status
+	closed           bool
+	actionQueue      []queuedSpaceshipAction
+	bots             []bot
+	asteroidLayout   []AsteroidSpec // nil for the seeded random layout
+	fixedStepMs      float64        // 0 for the variable step
+	accumulatorMs    float64        // Game time not simulated yet in the fixed step mode
+	endReason        string
+	serializer       Serializer // nil for the JSONSerializer
+	deltas           deltaTracker
+	visibility       func(viewer *Spaceship, gameObject GameObject) bool // nil when all are visible
+}
+
+type GameOption func(game *Game)
+
+// WithTimeLimitMs ends the game once the elapsed time reaches the limit.
+func WithTimeLimitMs(timeLimitMs float64) GameOption {
+	return func(game *Game) {
+		game.timeLimitMs = timeLimitMs
+	}
+}
+
+// WithMaxGameObjects caps the number of all the game objects.
+func WithMaxGameObjects(n int) GameOption {
+	return func(game *Game) {
+		game.manager.limits.gameObjects = n
+	}
+}
+
+// WithMaxAsteroids caps the number of the asteroids.
+func WithMaxAsteroids(n int) GameOption {
+	return func(game *Game) {
+		game.manager.limits.asteroids = n
+	}
+}
+
+// WithMaxProjectiles caps the number of the projectiles in flight.
+func WithMaxProjectiles(n int) GameOption {
+	return func(game *Game) {
+		game.manager.limits.projectiles = n
+	}
+}
+
+// AsteroidSpec describes an asteroid placed by WithAsteroidLayout.
+type AsteroidSpec struct {
+	Position physics.Vector2
+	Radius   float64
+}
+
+// WithAsteroidLayout makes SeedAsteroids place exactly the given asteroids instead of the seeded random ones.
+func WithAsteroidLayout(asteroids []AsteroidSpec) GameOption {
+	return func(game *Game) {
+		game.asteroidLayout = append([]AsteroidSpec{}, asteroids...)
+	}
+}
+
+// NewGame creates the game with the DefaultGameConfig rules, see NewGameWithConfig.
+func NewGame(size physics.Size, seed int64, options ...GameOption) *Game {
+	game := newGame(size, seed)
+	for _, option := range options {
+		option(game)
+	}
+	return game
 }
 
-func NewGame(size physics.Size, seed int64) *Game {
-	return &Game{
-		status:  Initialized,
-		size:    size,
-		seed:    seed,
-		manager: NewGameManager(),
+func newGame(size physics.Size, seed int64) *Game {
+	manager := NewGameManager()
+	manager.size = size
+	manager.seedRand(seed)
+	game := &Game{
+		status:     Initialized,
+		size:       size,
+		seed:       seed,
+		config:     DefaultGameConfig(),
+		manager:    manager,
+		timeScale:  1,
+		statusChan: make(chan Status, StatusChanSize),
 	}
+	game.manager.logger.SetLocator(game.manager.locate)
+	return game
 }
 
 func (game *Game) Status() Status {
 	return game.status
 }
 
+// StatusChan returns the channel receiving the status on every change and on Reset, until the game is closed.
+// The channel is buffered, when the consumer falls behind the oldest status is dropped
+// so the game loop is never blocked.
+func (game *Game) StatusChan() <-chan Status {
+	return game.statusChan
+}
+
+// Close closes the status channel.
+func (game *Game) Close() {
+	game.statusMutex.Lock()
+	defer game.statusMutex.Unlock()
+
+	if game.closed {
+		return
+	}
+	game.closed = true
+	close(game.statusChan)
+}
+
+func (game *Game) setStatus(status Status) {
+	if game.status == status {
+		return
+	}
+	game.status = status
+	game.emitStatus(status)
+	changed := GameStateChanged{Tick: game.manager.tick, Status: status}
+	if status == Ended {
+		changed.Reason = game.endReason
+	}
+	game.manager.publish(changed)
+}
+
+func (game *Game) emitStatus(status Status) {
+	game.statusMutex.Lock()
+	defer game.statusMutex.Unlock()
+	if game.closed {
+		return
+	}
+	for {
+		select {
+		case game.statusChan <- status:
+			return
+		default:
+			// Drop the oldest status to make room
+			select {
+			case <-game.statusChan:
+			default:
+			}
+		}
+	}
+}
+
 func (game *Game) Start() {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
 	if game.status == Running {
 		return
 	}
 
-	game.status = Running
-	game.manager.Logger().GameState(time.Now(), Running)
+	if game.status == Initialized {
+		game.manager.spawnInitialObjects()
+	}
+	game.setStatus(Running)
 }
 
 func (game *Game) Pause() {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
 	if game.status != Running {
 		return
 	}
 
-	game.status = Paused
-	game.manager.Logger().GameState(time.Now(), Paused)
+	game.setStatus(Paused)
+}
+
+func (game *Game) TimeScale() float64 {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+	return game.timeScale
+}
+
+// SetTimeScale sets the multiplier applied to the delta time of every Update,
+// e.g. 2 runs the simulation at double speed, 0.5 at half speed.
+func (game *Game) SetTimeScale(scale float64) {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	game.timeScale = math.Max(MinTimeScale, math.Min(scale, MaxTimeScale))
 }
 
 func (game *Game) Reset() {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
 	game.manager.Reset()
-	game.manager.Logger().Clear()
+	game.manager.seedRand(game.seed)
+	game.deltas.stop()
+	game.accumulatorMs = 0
+	// The status is kept, it is emitted again to notify about the reset.
+	game.emitStatus(game.status)
+}
+
+// FixedStepMs returns the fixed simulation step, 0 when every Update is simulated as a single step.
+func (game *Game) FixedStepMs() float64 {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+	return game.fixedStepMs
 }
 
+// SetFixedStep switches the game to the fixed step mode: the time passed to Update is accumulated
+// and simulated in steps of exactly stepMs, carrying the remainder over to the next Update, so the
+// simulation is the same regardless of how the caller splits the time. 0 restores the variable step.
+func (game *Game) SetFixedStep(stepMs float64) error {
+	if stepMs < 0 {
+		return errors.New("fixed step must not be negative")
+	}
+
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	game.fixedStepMs = stepMs
+	game.accumulatorMs = 0
+	return nil
+}
+
+// AccumulatorMs returns the game time awaiting the next fixed step, e.g. to interpolate the rendering.
+func (game *Game) AccumulatorMs() float64 {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+	return game.accumulatorMs
+}
+
+// Update advances the game by the delta time, scaled by the time scale. In the fixed step mode it runs
+// as many fixed steps as the accumulated time allows, stopping early once the game ends.
 func (game *Game) Update(deltaTimeMs float64) {
-	for _, gameObject := range game.manager.GameObjects() {
-		if !gameObject.Enabled() {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	deltaTimeMs *= game.timeScale
+	if game.fixedStepMs == 0 {
+		game.step(deltaTimeMs)
+		return
+	}
+
+	game.accumulatorMs += deltaTimeMs
+	// The tolerance keeps the float sums of the step, e.g. 3 * 16.67, from falling a hair short
+	for game.accumulatorMs >= game.fixedStepMs-FixedStepToleranceMs {
+		game.accumulatorMs = math.Max(0, game.accumulatorMs-game.fixedStepMs)
+		ended := game.status == Ended
+		game.step(game.fixedStepMs)
+		if !ended && game.status == Ended {
+			game.accumulatorMs = 0
+			return
+		}
+	}
+}
+
+// step simulates a single step of the scaled delta time.
+func (game *Game) step(deltaTimeMs float64) {
+	game.manager.Tick(deltaTimeMs)
+	game.manager.spawnPickups()
+	game.manager.suddenDeathStep(deltaTimeMs)
+	game.applyQueuedActions()
+	game.updateBots(deltaTimeMs)
+	game.applyCommands()
+
+	game.updateObjects(deltaTimeMs)
+
+	// Broad-phase, only the colliders with intersecting bounds get the exact check. The pairs come in
+	// the order of the game objects, each object is checked to be enabled when its pairs start.
+	game.manager.buildBroadPhase()
+	objects := game.manager.BroadPhaseObjects()
+	pairs := game.manager.BroadPhase().Pairs()
+	contacts := game.manager.narrowPhase(objects, pairs)
+	current, enabledA := -1, false
+	for i, pair := range pairs {
+		a, b := objects[pair[0]], objects[pair[1]]
+		if pair[0] != current {
+			current, enabledA = pair[0], a.Enabled()
+		}
+		if !enabledA || !b.Enabled() {
 			continue
 		}
-		gameObject.Update(deltaTimeMs, &game.manager)
-
-		// Wrap around the screen
-		position := gameObject.Position()
-		if position.X < 0 {
-			gameObject.SetPosition(physics.Vector2{X: game.size.Width - position.X, Y: position.Y})
-		} else if position.X > game.size.Width {
-			gameObject.SetPosition(physics.Vector2{X: position.X - game.size.Width, Y: position.Y})
+		if contacts != nil && !contacts[i] || contacts == nil && !a.Collider().CollidesWith(b.Collider()) {
+			continue
 		}
-		if position.Y < 0 {
-			gameObject.SetPosition(physics.Vector2{X: position.X, Y: game.size.Height - position.Y})
-		} else if position.Y > game.size.Height {
-			gameObject.SetPosition(physics.Vector2{X: position.X, Y: position.Y - game.size.Height})
+
+		// Triggers only report the overlaps, without any physics response
+		triggerA, isTriggerA := a.(trigger)
+		triggerB, isTriggerB := b.(trigger)
+		isTriggerA = isTriggerA && triggerA.IsTrigger()
+		isTriggerB = isTriggerB && triggerB.IsTrigger()
+		switch {
+		case isTriggerA && isTriggerB:
+		case isTriggerA:
+			triggerA.overlap(b)
+		case isTriggerB:
+			triggerB.overlap(a)
+		default:
+			a.OnCollision(b, &game.manager, 0)
+			b.OnCollision(a, &game.manager, 1)
+			game.manager.publish(CollisionOccurred{Tick: game.manager.tick, A: a, B: b})
 		}
 	}
 
-	size := game.manager.GameObjectSize()
-	for i := 0; i < size-1; i++ {
-		a := game.manager.GetGameObjectByIndex(i)
-		colliderA := a.Collider()
-		if !a.Enabled() || colliderA == nil {
-			continue
-		}
-		for j := i + 1; j < size; j++ {
-			b := game.manager.GetGameObjectByIndex(j)
-			colliderB := b.Collider()
-			if !b.Enabled() || colliderB == nil {
-				continue
-			}
+	game.manager.sweepProjectiles()
+	if game.config.Arena == ArenaBounded {
+		// After the sweep, the path of the step up to the wall still hits
+		game.despawnEscapedProjectiles()
+	}
 
-			if colliderA.CollidesWith(colliderB) {
-				a.OnCollision(b, &game.manager, 0)
-				b.OnCollision(a, &game.manager, 1)
-			}
+	for _, gameObject := range game.manager.GameObjects() {
+		if trigger, ok := gameObject.(trigger); ok && gameObject.Enabled() && trigger.IsTrigger() {
+			trigger.resolveOverlaps(&game.manager)
 		}
 	}
 
 	if game.manager.HasEnded(deltaTimeMs) {
-		game.status = Ended
-		game.manager.Logger().GameState(time.Now(), Ended)
+		game.endReason = game.manager.EndReason()
+		game.setStatus(Ended)
+	} else if game.timeLimitReached() {
+		game.endReason = "timeLimitReached"
+		game.setStatus(Ended)
+	}
+	game.deltas.track(&game.manager)
+	game.manager.releaseRecycled()
+}
+
+// EndReason returns why the game ended, e.g. the met end conditions or "timeLimitReached".
+// Empty until the game ends and for the default LastShipStanding end.
+func (game *Game) EndReason() string {
+	return game.endReason
+}
+
+func (game *Game) TimeLimitMs() float64 {
+	return game.timeLimitMs
+}
+
+func (game *Game) timeLimitReached() bool {
+	return game.timeLimitMs > 0 && game.status != Ended && game.manager.ElapsedMs() >= game.timeLimitMs
+}
+
+// Winner returns the name of the spaceship with the highest score, see GameManager.SpaceshipsByScore.
+func (game *Game) Winner() (string, error) {
+	return game.manager.Scoreboard().Winner()
+}
+
+// Scoreboard returns the current standings, ranked as in Winner.
+func (game *Game) Scoreboard() Scoreboard {
+	return game.manager.Scoreboard()
+}
+
+// GameSummary is the end-of-game report, Duration is the simulated time in milliseconds and
+// Rounds is the number of the ticks played.
+type GameSummary struct {
+	Winner                  string
+	Duration                float64
+	Rounds                  int
+	FinalScores             map[string]int64
+	TotalAsteroidsDestroyed int
+	TotalProjectilesFired   int
+	MVPSpaceship            string
+}
+
+// Summary returns the report of an ended game, ErrGameNotEnded otherwise.
+// The MVP is the spaceship with the most kills, the ties are broken as in Winner.
+func (game *Game) Summary() (GameSummary, error) {
+	if game.Status() != Ended {
+		return GameSummary{}, ErrGameNotEnded
 	}
+
+	summary := GameSummary{
+		Duration:                game.manager.ElapsedMs(),
+		Rounds:                  int(game.manager.CurrentTick()),
+		FinalScores:             make(map[string]int64),
+		TotalAsteroidsDestroyed: game.manager.AsteroidsDestroyed(),
+		TotalProjectilesFired:   game.manager.ProjectilesFired(),
+	}
+	ranked := game.manager.SpaceshipsByScore()
+	for i, spaceship := range ranked {
+		summary.FinalScores[spaceship.Name()] = int64(spaceship.Score())
+		if i == 0 {
+			summary.Winner = spaceship.Name()
+			summary.MVPSpaceship = spaceship.Name()
+		} else if spaceship.KillCount() > game.manager.spaceShips[summary.MVPSpaceship].KillCount() {
+			summary.MVPSpaceship = spaceship.Name()
+		}
+	}
+	return summary, nil
 }
 
+// SeedAsteroids places the asteroids of the layout, see WithAsteroidLayout, or the seeded random ones.
 func (game *Game) SeedAsteroids() {
-	asteroids := SeedAsteroids(rand.New(rand.NewSource(game.seed)), game.size.Width, game.size.Height, 1000)
-	game.manager.AddGameObjects(asteroids)
+	if game.asteroidLayout != nil {
+		factory := game.manager.ObjectFactory()
+		for _, spec := range game.asteroidLayout {
+			game.manager.AddGameObject(factory.NewAsteroid(game.manager.NewID(), spec.Position, spec.Radius))
+		}
+		return
+	}
+
+	asteroids := seedAsteroids(game.manager.Rand(), game.manager.ObjectFactory(), game.manager.ids, game.config.MinAsteroids, game.config.MaxAsteroids,
+		game.size.Width, game.size.Height, 1000)
+	// The asteroids over the limits are dropped
+	_ = game.manager.AddGameObjects(asteroids)
 }
 
 func (game *Game) SpaceshipAction(name string, action func(spaceShip *Spaceship, gameManager *GameManager)) error {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	return game.spaceshipAction(name, action)
+}
+
+// HasSpaceship reports whether the spaceship is in the game, including a destroyed one.
+func (game *Game) HasSpaceship(name string) bool {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+	_, err := game.manager.GetSpaceship(name)
+	return err == nil
+}
+
+func (game *Game) spaceshipAction(name string, action func(spaceShip *Spaceship, gameManager *GameManager)) error {
 	spaceShip, err := game.manager.GetSpaceship(name)
 	if err != nil {
 		return err
@@ -133,184 +468,229 @@ func (game *Game) SpaceshipAction(name string, action func(spaceShip *Spaceship,
 	return nil
 }
 
-func (game *Game) AddSpaceship(name string, position physics.Vector2, rotation float64) error {
-	spaceShip := NewSpaceship(NewUUID(), name, position, rotation)
-	return game.manager.AddSpaceship(spaceShip)
+// BatchSpaceshipActions applies all the actions in the slice order without any Update in between.
+// When any of the spaceships is not found, none of the actions is applied and the error lists all
+// the missing spaceships.
+func (game *Game) BatchSpaceshipActions(actions []NamedAction) error {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	spaceShips := make([]*Spaceship, len(actions))
+	var errs []error
+	for i, namedAction := range actions {
+		spaceShip, err := game.manager.GetSpaceship(namedAction.Name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		spaceShips[i] = spaceShip
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for i, namedAction := range actions {
+		namedAction.Action(spaceShips[i], &game.manager)
+	}
+	return nil
 }
 
-func (game *Game) RemoveSpaceship(name string) error {
-	return game.manager.RemoveSpaceship(name)
+// SpaceshipActionAll applies the action to every registered spaceship, including the disabled ones,
+// in the game objects order. The errors returned by the action are collected and joined.
+func (game *Game) SpaceshipActionAll(action func(spaceShip *Spaceship, gameManager *GameManager) error) error {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	var errs []error
+	for _, spaceShip := range game.manager.Spaceships() {
+		if err := action(spaceShip, &game.manager); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", spaceShip.name, err))
+		}
+	}
+	return errors.Join(errs...)
 }
 
-func (game *Game) Serialize() map[string]interface{} {
-	gameObjects := make([]interface{}, 0)
-	for _, gameObject := range game.manager.GameObjects() {
-		gameObjects = append(gameObjects, gameObject.Serialize())
+// QueueSpaceshipAction enqueues the action to be applied at the start of the next Update,
+// so all the inputs for a tick are collected before any of them is applied.
+func (game *Game) QueueSpaceshipAction(name string, action func(spaceShip *Spaceship, gameManager *GameManager)) error {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	if _, err := game.manager.GetSpaceship(name); err != nil {
+		return err
 	}
+	game.actionQueue = append(game.actionQueue, queuedSpaceshipAction{name: name, action: action})
+	return nil
+}
 
-	logs := make([]interface{}, 0)
-	for _, log := range game.manager.Logger().Logs() {
-		logs = append(logs, log.Serialize())
+func (game *Game) applyQueuedActions() {
+	queue := game.actionQueue
+	game.actionQueue = nil
+	for _, queued := range queue {
+		// The spaceship could have been removed since the action was queued.
+		_ = game.spaceshipAction(queued.name, queued.action)
 	}
+}
+
+// AddSpaceship returns ErrDuplicateSpaceshipName when the name is taken, even by a destroyed spaceship.
+func (game *Game) AddSpaceship(name string, position physics.Vector2, rotation float64) error {
+	_, err := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: name, Position: position, Rotation: rotation})
+	return err
+}
 
-	return map[string]interface{}{
-		"status": string(game.Status()),
-		"seed":   game.seed,
-		"size": map[string]interface{}{
-			"width":  game.size.Width,
-			"height": game.size.Height,
-		},
-		"gameObjects": gameObjects,
-		"logs":        logs,
+func (game *Game) AddSpaceshipWithConfig(config SpaceshipConfig) (*Spaceship, error) {
+	options := []SpaceshipOption{WithTeam(config.Team), WithCommandDelay(config.CommandDelayTicks)}
+	if config.Kinematics != nil {
+		options = append(options, WithKinematics(*config.Kinematics))
+	}
+	spaceShip := game.manager.ObjectFactory().NewSpaceship(game.manager.NewID(), config.Name, config.Position, config.Rotation, options...)
+	if err := game.manager.AddSpaceship(spaceShip); err != nil {
+		return nil, err
 	}
+	return spaceShip, nil
 }
 
-func Deserialize(jsonData string) (*Game, error) {
-	data := make(map[string]interface{})
-	err := json.Unmarshal([]byte(jsonData), &data)
+// AddSpaceshipWithBot adds the spaceship and registers the bot strategy controlling it.
+func (game *Game) AddSpaceshipWithBot(config SpaceshipConfig, strategy BotStrategy) (*Spaceship, error) {
+	spaceShip, err := game.AddSpaceshipWithConfig(config)
 	if err != nil {
 		return nil, err
 	}
+	if err := game.AddBot(config.Name, strategy); err != nil {
+		return nil, err
+	}
+	return spaceShip, nil
+}
 
-	size := data["size"].(map[string]interface{})
-
-	game := NewGame(
-		physics.Size{
-			Width:  size["width"].(float64),
-			Height: size["height"].(float64),
-		},
-		int64(data["seed"].(float64)),
-	)
-
-	uuid := int64(0)
-	destroyedShips := 0
-
-	// Game objects
-	for _, gameObject := range data["gameObjects"].([]interface{}) {
-		gameObjectMap := gameObject.(map[string]interface{})
-		id := int64(gameObjectMap["id"].(float64))
-		gameObjectType := gameObjectMap["type"].(string)
-		enabled := gameObjectMap["enabled"].(bool)
-		position := physics.Vector2{
-			X: gameObjectMap["position"].(map[string]interface{})["x"].(float64),
-			Y: gameObjectMap["position"].(map[string]interface{})["y"].(float64),
-		}
+// AddBot registers the bot strategy for the spaceship, replacing the previous one.
+// Bots are updated in the registration order.
+func (game *Game) AddBot(name string, strategy BotStrategy) error {
+	if _, err := game.manager.GetSpaceship(name); err != nil {
+		return err
+	}
 
-		if id > uuid {
-			uuid = id
+	for i := range game.bots {
+		if game.bots[i].name == name {
+			game.bots[i].strategy = strategy
+			return nil
 		}
+	}
+	game.bots = append(game.bots, bot{name: name, strategy: strategy})
+	return nil
+}
 
-		switch gameObjectType {
-		case "asteroid":
-			asteroid := NewAsteroid(
-				id,
-				position,
-				gameObjectMap["radius"].(float64),
-			)
-			asteroid.enabled = enabled
-			game.manager.AddGameObject(asteroid)
-		case "laser":
-			fallthrough
-		case "rocket":
-			owner := game.manager.GetGameObjectByID(int64(gameObjectMap["owner"].(float64)))
-			if owner == nil {
-				fmt.Println("Owner not found")
-				continue
-			}
-			rotation := gameObjectMap["rotation"].(float64)
-
-			var projectile Projectile
-			if gameObjectType == "laser" {
-				projectile = *NewLaserProjectile(
-					id,
-					position,
-					rotation,
-					owner.(*Spaceship),
-				)
-			} else {
-				projectile = *NewRocketProjectile(
-					id,
-					position,
-					rotation,
-					owner.(*Spaceship),
-				)
-			}
-
-			projectile.enabled = enabled
-			projectile.velocity = physics.Vector2{
-				X: gameObjectMap["velocity"].(map[string]interface{})["x"].(float64),
-				Y: gameObjectMap["velocity"].(map[string]interface{})["y"].(float64),
-			}
-			projectile.lifespanSec = gameObjectMap["lifespanSec"].(float64)
-			projectile.damage = gameObjectMap["damage"].(float64)
-			game.manager.AddGameObject(&projectile)
-		case "spaceship":
-			spaceship := NewSpaceship(
-				id,
-				gameObjectMap["name"].(string),
-				position,
-				gameObjectMap["rotation"].(float64),
-			)
-			spaceship.enabled = enabled
-			spaceship.velocity = physics.Vector2{
-				X: gameObjectMap["velocity"].(map[string]interface{})["x"].(float64),
-				Y: gameObjectMap["velocity"].(map[string]interface{})["y"].(float64),
-			}
-			spaceship.health = gameObjectMap["health"].(float64)
-			spaceship.energy = gameObjectMap["energy"].(float64)
-			spaceship.engine.mainThrust = gameObjectMap["engine"].(map[string]interface{})["mainThrust"].(float64)
-			spaceship.engine.leftThrust = gameObjectMap["engine"].(map[string]interface{})["leftThrust"].(float64)
-			spaceship.engine.rightThrust = gameObjectMap["engine"].(map[string]interface{})["rightThrust"].(float64)
-			spaceship.rockets = int32(gameObjectMap["rockets"].(float64))
-			spaceship.kills = int32(gameObjectMap["kills"].(float64))
-			spaceship.score = gameObjectMap["score"].(float64)
-			spaceship.laserReloadTimerSec = gameObjectMap["laserReloadTimerSec"].(float64)
-			spaceship.rocketReloadTimerSec = gameObjectMap["rocketReloadTimerSec"].(float64)
-			if gameObjectMap["destroyed"].(bool) {
-				destroyedShips++
-			}
-			game.manager.AddSpaceship(spaceship)
-		case "explosion":
-			explosion := NewExplosion(
-				id,
-				position,
-				gameObjectMap["radius"].(float64),
-				gameObjectMap["durationSec"].(float64),
-			)
-			explosion.enabled = enabled
-			explosion.lifespanSec = gameObjectMap["lifespanSec"].(float64)
-			game.manager.AddGameObject(explosion)
-		default:
-			fmt.Println("Unknown game object type", gameObjectType)
+func (game *Game) RemoveBot(name string) {
+	for i := range game.bots {
+		if game.bots[i].name == name {
+			game.bots = append(game.bots[:i], game.bots[i+1:]...)
+			return
 		}
 	}
+}
 
-	// Logs
-	logger := game.manager.Logger()
-	for _, log := range data["logs"].([]interface{}) {
-		logMap := log.(map[string]interface{})
-		time, err := time.Parse("2006-01-02 15:04:05", logMap["time"].(string))
-		if err != nil {
-			fmt.Println(err)
+func (game *Game) updateBots(deltaTimeMs float64) {
+	for _, bot := range game.bots {
+		spaceShip, err := game.manager.GetSpaceship(bot.name)
+		if err != nil || !spaceShip.Enabled() {
 			continue
 		}
+		bot.strategy.Update(spaceShip, &game.manager, deltaTimeMs)
+	}
+}
 
-		id := int64(logMap["id"].(float64))
-		if id > uuid {
-			uuid = id
-		}
+// RemoveSpaceship removes the spaceship and its bot, ErrSpaceshipNotFound when there is no such spaceship.
+func (game *Game) RemoveSpaceship(name string) error {
+	if err := game.manager.RemoveSpaceship(name); err != nil {
+		return err
+	}
+	game.RemoveBot(name)
+	return nil
+}
+
+func (game *Game) Serialize() map[string]interface{} {
+	serialized := game.manager.Serialize()
+	serialized["status"] = string(game.Status())
+	serialized["seed"] = game.seed
+	serialized["size"] = map[string]interface{}{
+		"width":  game.size.Width,
+		"height": game.size.Height,
+	}
+	serialized["timestamp"] = time.Now().UnixNano() // Wall clock time of the snapshot
+	serialized["timeScale"] = game.timeScale
+	serialized["timeLimitMs"] = game.timeLimitMs
+	serialized["config"] = game.Config().Serialize()
+	serialized["schemaVersion"] = StateVersion
+	return serialized
+}
+
+// Deserialize restores the game from the JSON of its Serialize output, see DeserializeMap.
+func Deserialize(jsonData string) (*Game, error) {
+	data := make(map[string]interface{})
+	err := json.Unmarshal([]byte(jsonData), &data)
+	if err != nil {
+		return nil, err
+	}
+	return DeserializeMap(data)
+}
 
-		logger.AddMessage(Message{
-			id:      id,
-			logType: LogType(logMap["logType"].(string)),
-			time:    time,
-			message: logMap["message"].(string),
-			meta:    logMap["meta"].(map[string]interface{}),
-		})
+// DeserializeMap restores the game from its Serialize output, either as is or decoded from JSON.
+// The state is upgraded to the current schema version by the registered migrations first.
+func DeserializeMap(data map[string]interface{}) (*Game, error) {
+	data, err := migrations.Migrate(data)
+	if err != nil {
+		return nil, err
+	}
+	return deserialize(data)
+}
+
+// deserialize restores the game from a state of the current schema version, created with the options.
+func deserialize(data map[string]interface{}, options ...GameOption) (*Game, error) {
+	size, err := object(data, "size")
+	if err != nil {
+		return nil, err
+	}
+	width, err := number(size, "width")
+	if err != nil {
+		return nil, err
+	}
+	height, err := number(size, "height")
+	if err != nil {
+		return nil, err
+	}
+	seed, err := number(data, "seed")
+	if err != nil {
+		return nil, err
+	}
+	status, err := text(data, "status")
+	if err != nil {
+		return nil, err
 	}
 
-	SetUUID(uuid)
-	game.manager.destroyedShips = destroyedShips
-	game.status = Status(data["status"].(string))
+	config := DefaultGameConfig()
+	if _, ok := data["config"]; ok {
+		serializedConfig, err := object(data, "config")
+		if err != nil {
+			return nil, err
+		}
+		if config, err = deserializeGameConfig(serializedConfig); err != nil {
+			return nil, err
+		}
+	}
+	game, err := NewGameWithConfig(physics.Size{Width: width, Height: height}, int64(seed), config, options...)
+	if err != nil {
+		return nil, err
+	}
+	if err := game.manager.Deserialize(data); err != nil {
+		return nil, err
+	}
+	if game.timeLimitMs, err = optionalNumber(data, "timeLimitMs", game.timeLimitMs); err != nil {
+		return nil, err
+	}
+	timeScale, err := optionalNumber(data, "timeScale", game.timeScale)
+	if err != nil {
+		return nil, err
+	}
+	game.SetTimeScale(timeScale)
+	game.status = Status(status)
 	return game, nil
 }
@@ -0,0 +1,177 @@
+package game
+
+import (
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// ArenaMode decides what happens at the edges of the arena.
+type ArenaMode string
+
+const (
+	ArenaWrap    ArenaMode = "wrap"    // The objects leaving the arena reappear on the opposite edge
+	ArenaBounded ArenaMode = "bounded" // The edges are solid walls, see Game.confine
+)
+
+func (mode ArenaMode) valid() bool {
+	return mode == ArenaWrap || mode == ArenaBounded
+}
+
+// GameConfig holds the tunable rules of a game, see NewGameWithConfig. The rules of the spawned
+// objects are applied by the game's object factory, a factory set by SetObjectFactory replaces them.
+type GameConfig struct {
+	MinAsteroids          int // Inclusive range of the seeded asteroids count
+	MaxAsteroids          int
+	ShipMaxHealth         float64 // Per hull zone
+	LaserDamage           float64
+	RocketDamage          float64
+	EnergyRechargeRateSec float64
+	Arena                 ArenaMode
+	TimeLimitMs           float64 // 0 for no limit
+}
+
+// DefaultGameConfig returns the rules of the configuration constants.
+func DefaultGameConfig() GameConfig {
+	return GameConfig{
+		// The seeding has always placed at least one asteroid more than MinAsteroids
+		MinAsteroids:          MinAsteroids + 1,
+		MaxAsteroids:          MaxAsteroids,
+		ShipMaxHealth:         MaxHealth,
+		LaserDamage:           LaserDamage,
+		RocketDamage:          RocketDamage,
+		EnergyRechargeRateSec: EnergyRechargeRateSec,
+		Arena:                 ArenaWrap,
+	}
+}
+
+// Validate returns ErrInvalidGameConfig for the first invalid field.
+func (config GameConfig) Validate() error {
+	switch {
+	case config.MinAsteroids < 0:
+		return ErrInvalidGameConfig{Field: "MinAsteroids"}
+	case config.MaxAsteroids < config.MinAsteroids:
+		return ErrInvalidGameConfig{Field: "MaxAsteroids"}
+	case !(config.ShipMaxHealth > 0) || math.IsInf(config.ShipMaxHealth, 1):
+		return ErrInvalidGameConfig{Field: "ShipMaxHealth"}
+	case !(config.LaserDamage >= 0):
+		return ErrInvalidGameConfig{Field: "LaserDamage"}
+	case !(config.RocketDamage >= 0):
+		return ErrInvalidGameConfig{Field: "RocketDamage"}
+	case !(config.EnergyRechargeRateSec >= 0):
+		return ErrInvalidGameConfig{Field: "EnergyRechargeRateSec"}
+	case !config.Arena.valid():
+		return ErrInvalidGameConfig{Field: "Arena"}
+	case !(config.TimeLimitMs >= 0):
+		return ErrInvalidGameConfig{Field: "TimeLimitMs"}
+	}
+	return nil
+}
+
+// NewGameWithConfig creates the game with the rules of the config, the options are applied after the config.
+func NewGameWithConfig(size physics.Size, seed int64, config GameConfig, options ...GameOption) (*Game, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	game := newGame(size, seed)
+	game.config = config
+	game.timeLimitMs = config.TimeLimitMs
+	game.manager.objectFactory = configuredObjectFactory{config: config}
+	for _, option := range options {
+		option(game)
+	}
+	return game, nil
+}
+
+// Config returns the rules of the game, TimeLimitMs as changed by WithTimeLimitMs.
+func (game *Game) Config() GameConfig {
+	config := game.config
+	config.TimeLimitMs = game.timeLimitMs
+	return config
+}
+
+func (config GameConfig) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"minAsteroids":          config.MinAsteroids,
+		"maxAsteroids":          config.MaxAsteroids,
+		"shipMaxHealth":         config.ShipMaxHealth,
+		"laserDamage":           config.LaserDamage,
+		"rocketDamage":          config.RocketDamage,
+		"energyRechargeRateSec": config.EnergyRechargeRateSec,
+		"arena":                 string(config.Arena),
+		"timeLimitMs":           config.TimeLimitMs,
+	}
+}
+
+func deserializeGameConfig(data map[string]interface{}) (GameConfig, error) {
+	var config GameConfig
+	minAsteroids, err := number(data, "minAsteroids")
+	if err != nil {
+		return GameConfig{}, err
+	}
+	maxAsteroids, err := number(data, "maxAsteroids")
+	if err != nil {
+		return GameConfig{}, err
+	}
+	config.MinAsteroids, config.MaxAsteroids = int(minAsteroids), int(maxAsteroids)
+	if config.ShipMaxHealth, err = number(data, "shipMaxHealth"); err != nil {
+		return GameConfig{}, err
+	}
+	if config.LaserDamage, err = number(data, "laserDamage"); err != nil {
+		return GameConfig{}, err
+	}
+	if config.RocketDamage, err = number(data, "rocketDamage"); err != nil {
+		return GameConfig{}, err
+	}
+	if config.EnergyRechargeRateSec, err = number(data, "energyRechargeRateSec"); err != nil {
+		return GameConfig{}, err
+	}
+	arena, err := text(data, "arena")
+	if err != nil {
+		return GameConfig{}, err
+	}
+	config.Arena = ArenaMode(arena)
+	if config.TimeLimitMs, err = number(data, "timeLimitMs"); err != nil {
+		return GameConfig{}, err
+	}
+	return config, config.Validate()
+}
+
+// configuredObjectFactory creates the game objects with the package constructors and the rules of the config.
+type configuredObjectFactory struct {
+	config GameConfig
+}
+
+func (factory configuredObjectFactory) NewAsteroid(id int64, position physics.Vector2, radius float64) *Asteroid {
+	return NewAsteroid(id, position, radius)
+}
+
+func (factory configuredObjectFactory) NewSpaceship(id int64, name string, position physics.Vector2, rotation float64, options ...SpaceshipOption) *Spaceship {
+	options = append([]SpaceshipOption{
+		WithMaxHealth(factory.config.ShipMaxHealth),
+		WithEnergyRechargeRate(factory.config.EnergyRechargeRateSec),
+	}, options...)
+	return NewSpaceship(id, name, position, rotation, options...)
+}
+
+func (factory configuredObjectFactory) NewLaserProjectile(id int64, position physics.Vector2, rotation float64, owner *Spaceship) *Projectile {
+	projectile := &Projectile{}
+	factory.initLaserProjectile(projectile, id, position, rotation, owner)
+	return projectile
+}
+
+func (factory configuredObjectFactory) NewRocketProjectile(id int64, position physics.Vector2, rotation float64, owner *Spaceship) *Projectile {
+	projectile := &Projectile{}
+	factory.initRocketProjectile(projectile, id, position, rotation, owner)
+	return projectile
+}
+
+func (factory configuredObjectFactory) initLaserProjectile(projectile *Projectile, id int64, position physics.Vector2, rotation float64, owner *Spaceship) {
+	initLaserProjectile(projectile, id, position, rotation, owner)
+	projectile.damage = factory.config.LaserDamage
+}
+
+func (factory configuredObjectFactory) initRocketProjectile(projectile *Projectile, id int64, position physics.Vector2, rotation float64, owner *Spaceship) {
+	initRocketProjectile(projectile, id, position, rotation, owner)
+	projectile.damage = factory.config.RocketDamage
+}
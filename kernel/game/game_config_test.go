@@ -0,0 +1,109 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameConfig_Validate(t *testing.T) {
+	assert.NoError(t, DefaultGameConfig().Validate())
+
+	tests := []struct {
+		field  string
+		modify func(config *GameConfig)
+	}{
+		{"MinAsteroids", func(config *GameConfig) { config.MinAsteroids = -1 }},
+		{"MaxAsteroids", func(config *GameConfig) { config.MaxAsteroids = config.MinAsteroids - 1 }},
+		{"ShipMaxHealth", func(config *GameConfig) { config.ShipMaxHealth = 0 }},
+		{"LaserDamage", func(config *GameConfig) { config.LaserDamage = math.NaN() }},
+		{"RocketDamage", func(config *GameConfig) { config.RocketDamage = -1 }},
+		{"EnergyRechargeRateSec", func(config *GameConfig) { config.EnergyRechargeRateSec = -1 }},
+		{"Arena", func(config *GameConfig) { config.Arena = "sphere" }},
+		{"TimeLimitMs", func(config *GameConfig) { config.TimeLimitMs = -1 }},
+	}
+	for _, test := range tests {
+		t.Run(test.field, func(t *testing.T) {
+			config := DefaultGameConfig()
+			test.modify(&config)
+			assert.Equal(t, ErrInvalidGameConfig{Field: test.field}, config.Validate())
+
+			_, err := NewGameWithConfig(physics.Size{Width: 1000, Height: 1000}, 1, config)
+			assert.Equal(t, ErrInvalidGameConfig{Field: test.field}, err)
+		})
+	}
+}
+
+func TestNewGameWithConfig(t *testing.T) {
+	config := DefaultGameConfig()
+	config.MinAsteroids, config.MaxAsteroids = 4, 4
+	config.ShipMaxHealth = 250
+	config.LaserDamage = 5
+	config.RocketDamage = 75
+	config.EnergyRechargeRateSec = 0
+	config.TimeLimitMs = 1000
+
+	game, err := NewGameWithConfig(physics.Size{Width: 1000, Height: 1000}, 1234567890, config)
+	assert.NoError(t, err)
+	assert.Equal(t, config, game.Config())
+
+	game.SeedAsteroids()
+	assert.Len(t, game.manager.GameObjects(), 4)
+
+	ship, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "ship", Position: physics.Vector2{X: 100, Y: 100}})
+	assert.Equal(t, 250.0, ship.MaxHealth())
+	assert.Equal(t, 250.0, ship.Health())
+
+	_ = ship.FireLaser(&game.manager)
+	_ = ship.FireRocket(&game.manager)
+	projectiles := game.manager.Query(func(gameObject GameObject) bool {
+		_, ok := gameObject.(*Projectile)
+		return ok
+	})
+	assert.Equal(t, 5.0, projectiles[0].(*Projectile).Damage())
+	assert.Equal(t, 75.0, projectiles[1].(*Projectile).Damage())
+
+	game.AddSpaceship("other", physics.Vector2{X: 900, Y: 900}, 0)
+	energy := ship.Energy()
+	game.Start()
+	game.Update(1000)
+	assert.Equal(t, energy, ship.Energy())
+	assert.Equal(t, "timeLimitReached", game.EndReason())
+
+	t.Run("Options after the config", func(t *testing.T) {
+		game, _ := NewGameWithConfig(physics.Size{Width: 1000, Height: 1000}, 1, config, WithTimeLimitMs(500))
+		assert.Equal(t, 500.0, game.Config().TimeLimitMs)
+	})
+}
+
+func TestGameConfig_Serialize(t *testing.T) {
+	config := DefaultGameConfig()
+	config.ShipMaxHealth = 150
+	config.LaserDamage = 10
+	game, _ := NewGameWithConfig(physics.Size{Width: 1000, Height: 1000}, 1234567890, config)
+	game.AddSpaceship("ship", physics.Vector2{X: 100, Y: 100}, 0)
+
+	serialized := game.Serialize()
+	assert.Equal(t, config.Serialize(), serialized["config"])
+
+	deserialized, err := DeserializeMap(serialized)
+	assert.NoError(t, err)
+	assert.Equal(t, config, deserialized.Config())
+	ship, _ := deserialized.manager.GetSpaceship("ship")
+	assert.Equal(t, 150.0, ship.MaxHealth())
+	_ = ship.FireLaser(&deserialized.manager)
+	gameObjects := deserialized.manager.GameObjects()
+	assert.Equal(t, 10.0, gameObjects[len(gameObjects)-1].(*Projectile).Damage())
+
+	// The states serialized before the config use the defaults
+	delete(serialized, "config")
+	deserialized, err = DeserializeMap(serialized)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultGameConfig(), deserialized.Config())
+
+	serialized["config"] = map[string]interface{}{"minAsteroids": 1}
+	_, err = DeserializeMap(serialized)
+	assert.Equal(t, ErrInvalidState{Field: "maxAsteroids"}, err)
+}
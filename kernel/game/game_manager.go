@@ -1,36 +1,524 @@
 package game
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
 
 type GameManager struct {
+	size               physics.Size
 	gameObjects        []GameObject
+	updateOrder        []GameObject // gameObjects by the update priority, nil when to be sorted
 	spaceShips         map[string]*Spaceship
 	destroyedShips     int
+	projectilesFired   int
+	asteroidsDestroyed int
 	gracefulEndTimerMs float64
+	elapsedMs          float64
+	tick               uint64
+	outbox             []Message
 	logger             Logger
+	chatLog            []ChatMessage
+	chatHead           int
+	projectilePool     *ProjectilePool
+	explosionPool      *ExplosionPool
+	recycled           []GameObject // Destroyed by the collisions, returned to the pools at the end of the tick
+	workers            int          // Of the parallel physics update, 1 or less for none
+	rand               *rand.Rand
+	randSource         *countingSource // Of rand, counts the draws for the snapshots
+	damageCalculator   DamageCalculator
+	objectFactory      ObjectFactory
+	limits             objectLimits
+	counts             objectCounts // Of the limited types, so the limits are checked without scanning the objects
+	initialCounts      map[string]int
+	endConditions      []endCondition
+	endLogic           EndLogic
+	endReason          string
+	hooks              lifecycleHooks
+	broadPhase         *collider.SpatialGrid
+	broadPhaseObjects  []GameObject // Indexed by the broad-phase ids, nil when to be built
+	broadPhaseTick     uint64
+	records            map[string]*shipRecord // Scoreboard records by the spaceship name
+	events             EventBus
+	pickups            pickupSpawner
+	ids                IDGenerator
+	suddenDeath        suddenDeathPhase
+}
+
+// objectLimits caps the number of the game objects, zero means unlimited.
+type objectLimits struct {
+	gameObjects int
+	asteroids   int
+	projectiles int
+}
+
+// objectCounts counts the game objects of the types capped by the objectLimits.
+type objectCounts struct {
+	asteroids   int
+	projectiles int
+}
+
+type EndLogic int
+
+const (
+	EndLogicOr EndLogic = iota
+	EndLogicAnd
+)
+
+type endCondition struct {
+	name      string
+	condition func(*GameManager) bool
+}
+
+type lifecycleHook struct {
+	id       int
+	callback func(GameObject)
+}
+
+type lifecycleHooks struct {
+	nextID   int
+	added    []lifecycleHook
+	removed  []lifecycleHook
+	disabled []lifecycleHook
+}
+
+func (hooks *lifecycleHooks) subscribe(list *[]lifecycleHook, callback func(GameObject)) func() {
+	hooks.nextID++
+	id := hooks.nextID
+	*list = append(*list, lifecycleHook{id: id, callback: callback})
+	return func() {
+		for i, hook := range *list {
+			if hook.id == id {
+				*list = append((*list)[:i:i], (*list)[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func dispatch(list []lifecycleHook, gameObject GameObject) {
+	for _, hook := range list {
+		hook.callback(gameObject)
+	}
 }
 
 func NewGameManager() GameManager {
-	return GameManager{
+	ids := NewSequentialIDs()
+	logger := NewLogger(LogCapacity)
+	logger.SetIDGenerator(ids)
+	manager := GameManager{
 		gameObjects:    []GameObject{},
 		spaceShips:     map[string]*Spaceship{},
-		logger:         NewLogger(),
+		logger:         logger,
+		ids:            ids,
+		projectilePool: NewProjectilePool(),
+		explosionPool:  NewExplosionPool(),
+		broadPhase:     collider.NewSpatialGrid(BroadPhaseCellSize),
+		records:        map[string]*shipRecord{},
 		destroyedShips: 0,
 	}
+	manager.seedRand(0)
+	return manager
 }
 
+// ElapsedMs returns the game time advanced by the ticks since the start or the last reset.
+func (manager *GameManager) ElapsedMs() float64 {
+	return manager.elapsedMs
+}
+
+// Tick advances the game time and delivers the messages sent during the previous tick.
+func (manager *GameManager) Tick(deltaTimeMs float64) {
+	manager.tick++
+	manager.elapsedMs += deltaTimeMs
+	manager.logger.SetTick(manager.tick)
+	manager.logger.SetTickMs(manager.elapsedMs)
+	manager.deliverMessages()
+}
+
+// CurrentTick returns the number of the ticks since the start.
+func (manager *GameManager) CurrentTick() uint64 {
+	return manager.tick
+}
+
+// ProjectilesFired returns the number of the projectiles added to the game since the start.
+func (manager *GameManager) ProjectilesFired() int {
+	return manager.projectilesFired
+}
+
+// AsteroidsDestroyed returns the number of the asteroids removed from the game since the start.
+func (manager *GameManager) AsteroidsDestroyed() int {
+	return manager.asteroidsDestroyed
+}
+
+// GameObjects returns all the game objects, the disabled ones included, see GetAllGameObjects.
 func (manager *GameManager) GameObjects() []GameObject {
 	return manager.gameObjects
 }
 
+// GetAllGameObjects returns all the game objects, the disabled ones included. The destroyed spaceships
+// stay in the game disabled, so the serialization and the reset rely on this list being complete:
+// do not filter it, use EnabledGameObjects instead.
+func (manager *GameManager) GetAllGameObjects() []GameObject {
+	return manager.gameObjects
+}
+
+// EnabledGameObjects returns a new slice of the enabled game objects.
+func (manager *GameManager) EnabledGameObjects() []GameObject {
+	return manager.Query(func(gameObject GameObject) bool {
+		return gameObject.Enabled()
+	})
+}
+
+// IterateGameObjects passes the game objects to the callback, in order, until it returns false.
+// The iteration runs over the live game objects, without copying them: the objects added during
+// the iteration are visited as well, and removing the visited object does not skip the next one.
+// It is not safe for concurrent use from multiple goroutines.
+func (manager *GameManager) IterateGameObjects(callback func(gameObject GameObject) bool) {
+	for i := 0; i < len(manager.gameObjects); {
+		gameObject := manager.gameObjects[i]
+		if !callback(gameObject) {
+			return
+		}
+		// Step over the object unless the callback removed it
+		if i < len(manager.gameObjects) && manager.gameObjects[i].ID() == gameObject.ID() {
+			i++
+		}
+	}
+}
+
+// Query returns the game objects matching the predicate.
+// It returns nil, without allocating, when nothing matches.
+func (manager *GameManager) Query(predicate func(GameObject) bool) []GameObject {
+	var result []GameObject
+	for _, gameObject := range manager.gameObjects {
+		if predicate(gameObject) {
+			result = append(result, gameObject)
+		}
+	}
+	return result
+}
+
+// ObjectsInRadius returns the enabled game objects positioned within the radius, the boundary included.
+func (manager *GameManager) ObjectsInRadius(center physics.Vector2, radius float64) []GameObject {
+	area := physics.Circle{Center: center, Radius: radius}
+	return manager.Query(func(gameObject GameObject) bool {
+		return gameObject.Enabled() && area.Contains(gameObject.Position())
+	})
+}
+
+// ObjectsInRect returns the enabled game objects positioned within the rectangle, the boundary included.
+func (manager *GameManager) ObjectsInRect(rect physics.Rect) []GameObject {
+	return manager.Query(func(gameObject GameObject) bool {
+		return gameObject.Enabled() && rect.Contains(gameObject.Position())
+	})
+}
+
+// buildBroadPhase inserts the bounds of the enabled colliding game objects into the broad-phase grid.
+func (manager *GameManager) buildBroadPhase() {
+	manager.broadPhase.Clear()
+	manager.broadPhaseObjects = manager.broadPhaseObjects[:0]
+	for _, gameObject := range manager.gameObjects {
+		if !gameObject.Enabled() || gameObject.Collider() == nil {
+			continue
+		}
+		manager.broadPhase.Insert(len(manager.broadPhaseObjects), gameObject.Collider().Bounds())
+		manager.broadPhaseObjects = append(manager.broadPhaseObjects, gameObject)
+	}
+	manager.broadPhaseTick = manager.tick
+}
+
+// BroadPhase returns the collision broad-phase grid as of the last collision check, its ids index
+// BroadPhaseObjects.
+func (manager *GameManager) BroadPhase() *collider.SpatialGrid {
+	return manager.broadPhase
+}
+
+// BroadPhaseObjects returns the game objects inserted into the broad-phase grid, by their ids.
+func (manager *GameManager) BroadPhaseObjects() []GameObject {
+	return manager.broadPhaseObjects
+}
+
+// Neighbors returns the enabled colliding game objects whose bounds intersect the given ones, e.g. for
+// the sensors. The grid is built once per tick, the objects added or moved since are not reflected
+// until the next tick.
+func (manager *GameManager) Neighbors(bounds physics.AABB) []GameObject {
+	if manager.broadPhaseObjects == nil || manager.broadPhaseTick != manager.tick {
+		manager.buildBroadPhase()
+	}
+	var result []GameObject
+	for _, id := range manager.broadPhase.Query(bounds) {
+		if gameObject := manager.broadPhaseObjects[id]; gameObject.Enabled() {
+			result = append(result, gameObject)
+		}
+	}
+	return result
+}
+
+// Count returns the number of game objects matching the predicate.
+func (manager *GameManager) Count(predicate func(GameObject) bool) int {
+	count := 0
+	for _, gameObject := range manager.gameObjects {
+		if predicate(gameObject) {
+			count++
+		}
+	}
+	return count
+}
+
+// Spaceships returns the spaceships in the game objects order.
+func (manager *GameManager) Spaceships() []*Spaceship {
+	var spaceships []*Spaceship
+	for _, gameObject := range manager.gameObjects {
+		if spaceship, ok := gameObject.(*Spaceship); ok {
+			spaceships = append(spaceships, spaceship)
+		}
+	}
+	return spaceships
+}
+
+// SpaceshipsByScore returns a new slice of the spaceships ranked by the score, descending.
+// The ties are broken by the kill count, descending, and then by the name.
+func (manager *GameManager) SpaceshipsByScore() []*Spaceship {
+	spaceships := make([]*Spaceship, 0, len(manager.spaceShips))
+	for _, spaceship := range manager.spaceShips {
+		spaceships = append(spaceships, spaceship)
+	}
+	sort.Slice(spaceships, func(i, j int) bool {
+		a, b := spaceships[i], spaceships[j]
+		if a.Score() != b.Score() {
+			return a.Score() > b.Score()
+		}
+		if a.KillCount() != b.KillCount() {
+			return a.KillCount() > b.KillCount()
+		}
+		return a.Name() < b.Name()
+	})
+	return spaceships
+}
+
+// GetSpaceshipTeamScores returns the total score of each team, the spaceships without a team
+// are aggregated under the empty name.
+func (manager *GameManager) GetSpaceshipTeamScores() map[string]int64 {
+	totals := map[string]float64{}
+	for _, spaceship := range manager.spaceShips {
+		totals[spaceship.team] += spaceship.score
+	}
+
+	scores := make(map[string]int64, len(totals))
+	for team, total := range totals {
+		scores[team] = int64(total)
+	}
+	return scores
+}
+
+// Asteroids returns the asteroids in the game objects order.
+func (manager *GameManager) Asteroids() []*Asteroid {
+	var asteroids []*Asteroid
+	for _, gameObject := range manager.gameObjects {
+		if asteroid, ok := gameObject.(*Asteroid); ok {
+			asteroids = append(asteroids, asteroid)
+		}
+	}
+	return asteroids
+}
+
+// Size returns the size of the arena the game objects live in.
+func (manager *GameManager) Size() physics.Size {
+	return manager.size
+}
+
+// MirrorObject mirrors the game object position across the arena center axis.
+// The "horizontal" axis flips the Y coordinate, the "vertical" axis flips the X coordinate.
+func (manager *GameManager) MirrorObject(id int64, axis string) error {
+	gameObject := manager.GetGameObjectByID(id)
+	if gameObject == nil {
+		return fmt.Errorf("game object not found: %d", id)
+	}
+
+	position := gameObject.Position()
+	switch axis {
+	case "horizontal":
+		position.Y = manager.size.Height - position.Y
+	case "vertical":
+		position.X = manager.size.Width - position.X
+	default:
+		return fmt.Errorf("invalid mirror axis: %s", axis)
+	}
+
+	gameObject.SetPosition(position)
+	if collider := gameObject.Collider(); collider != nil {
+		collider.SetPosition(position)
+	}
+	return nil
+}
+
+// TimeUntilNextCollision predicts when the two game objects collide, assuming constant velocities.
+// The colliders are approximated by their bounding circles.
+// Returns (ms, true) for a collision within CollisionLookAheadMs, (0, true) when already colliding.
+func (manager *GameManager) TimeUntilNextCollision(id1, id2 int64) (float64, bool) {
+	a := manager.GetGameObjectByID(id1)
+	b := manager.GetGameObjectByID(id2)
+	if a == nil || b == nil || a.Collider() == nil || b.Collider() == nil {
+		return 0, false
+	}
+
+	radius := boundingRadius(a.Collider()) + boundingRadius(b.Collider())
+	position := b.Position()
+	relativePosition := position.Subtract(a.Position())
+	velocity := velocityOf(b)
+	relativeVelocity := velocity.Subtract(velocityOf(a))
+
+	// |relativePosition + relativeVelocity * t| = radius
+	c := relativePosition.Dot(relativePosition) - radius*radius
+	if c <= 0 {
+		return 0, true
+	}
+	a2 := relativeVelocity.Dot(relativeVelocity)
+	if a2 == 0 {
+		return 0, false
+	}
+	b2 := 2 * relativePosition.Dot(relativeVelocity)
+	discriminant := b2*b2 - 4*a2*c
+	if discriminant < 0 {
+		return 0, false
+	}
+
+	timeSec := (-b2 - math.Sqrt(discriminant)) / (2 * a2)
+	if timeSec < 0 || timeSec*1000 > CollisionLookAheadMs {
+		return 0, false
+	}
+	return timeSec * 1000, true
+}
+
+func velocityOf(gameObject GameObject) physics.Vector2 {
+	if moving, ok := gameObject.(interface{ Velocity() physics.Vector2 }); ok {
+		return moving.Velocity()
+	}
+	return physics.Vector2{}
+}
+
+func boundingRadius(shape collider.Collider) float64 {
+	switch shape := shape.(type) {
+	case *collider.CircleCollider:
+		return shape.Radius()
+	case *collider.SquareCollider:
+		size := shape.Size()
+		return math.Sqrt(size.Width*size.Width+size.Height*size.Height) / 2
+	case *collider.PolygonCollider:
+		radius := 0.0
+		for _, vertex := range shape.Polygon().Vertices {
+			radius = math.Max(radius, vertex.Magnitude())
+		}
+		return radius
+	case *collider.CapsuleCollider:
+		return shape.Length()/2 + shape.Radius()
+	default:
+		return 0
+	}
+}
+
+// HasEnded reports whether the game ended, by the registered end conditions if any,
+// otherwise when at most one spaceship is left.
 func (manager *GameManager) HasEnded(deltaTimeMs float64) bool {
 	if manager.gracefulEndTimerMs > 0 {
 		manager.gracefulEndTimerMs -= deltaTimeMs
 		return false
 	}
+	if len(manager.endConditions) == 0 {
+		manager.endReason = ""
+		return LastShipStanding(manager)
+	}
+
+	var met []string
+	for _, endCondition := range manager.endConditions {
+		if endCondition.condition(manager) {
+			met = append(met, endCondition.name)
+		}
+	}
+
+	ended := len(met) > 0
+	if manager.endLogic == EndLogicAnd {
+		ended = len(met) == len(manager.endConditions)
+	}
+	manager.endReason = ""
+	if ended {
+		manager.endReason = strings.Join(met, ", ")
+	}
+	return ended
+}
+
+// LastShipStanding is the default end condition, met when at most one spaceship is left.
+func LastShipStanding(manager *GameManager) bool {
 	return manager.destroyedShips >= len(manager.spaceShips)-1
 }
 
+// TimeLimit returns the end condition met once the elapsed game time reaches the limit.
+func TimeLimit(limitMs float64) func(*GameManager) bool {
+	return func(manager *GameManager) bool {
+		return manager.elapsedMs >= limitMs
+	}
+}
+
+// ScoreTarget returns the end condition met once any spaceship scores the target.
+func ScoreTarget(score float64) func(*GameManager) bool {
+	return func(manager *GameManager) bool {
+		for _, spaceShip := range manager.spaceShips {
+			if spaceShip.score >= score {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// RegisterEndCondition registers the named end condition, replacing the one of the same name.
+// The registered conditions replace the default LastShipStanding and are combined by the end logic.
+func (manager *GameManager) RegisterEndCondition(name string, condition func(*GameManager) bool) {
+	for i := range manager.endConditions {
+		if manager.endConditions[i].name == name {
+			manager.endConditions[i].condition = condition
+			return
+		}
+	}
+	manager.endConditions = append(manager.endConditions, endCondition{name: name, condition: condition})
+}
+
+func (manager *GameManager) RemoveEndCondition(name string) {
+	for i := range manager.endConditions {
+		if manager.endConditions[i].name == name {
+			manager.endConditions = append(manager.endConditions[:i], manager.endConditions[i+1:]...)
+			return
+		}
+	}
+}
+
+// EndConditions returns the names of the registered end conditions in the registration order,
+// empty for the default LastShipStanding.
+func (manager *GameManager) EndConditions() []string {
+	names := make([]string, len(manager.endConditions))
+	for i, endCondition := range manager.endConditions {
+		names[i] = endCondition.name
+	}
+	return names
+}
+
+// SetEndLogic sets how the registered end conditions are combined, EndLogicOr by default.
+func (manager *GameManager) SetEndLogic(logic EndLogic) {
+	manager.endLogic = logic
+}
+
+// EndReason returns the names of the end conditions met by the last HasEnded.
+func (manager *GameManager) EndReason() string {
+	return manager.endReason
+}
+
 func (manager *GameManager) GetGameObjectByID(id int64) GameObject {
 	for _, gameObject := range manager.gameObjects {
 		if gameObject.ID() == id {
@@ -40,6 +528,15 @@ func (manager *GameManager) GetGameObjectByID(id int64) GameObject {
 	return nil
 }
 
+// locate returns the position of the game object by its id, the Locator of the game's logger.
+func (manager *GameManager) locate(id int64) (physics.Vector2, bool) {
+	gameObject := manager.GetGameObjectByID(id)
+	if gameObject == nil {
+		return physics.Vector2{}, false
+	}
+	return gameObject.Position(), true
+}
+
 func (manager *GameManager) GetGameObjectByIndex(index int) GameObject {
 	return manager.gameObjects[index]
 }
@@ -48,41 +545,155 @@ func (manager *GameManager) GameObjectSize() int {
 	return len(manager.gameObjects)
 }
 
-func (manager *GameManager) AddGameObject(gameObject GameObject) {
+// OnObjectAdded registers the callback invoked when a game object is added.
+// Returns the function unsubscribing the callback.
+func (manager *GameManager) OnObjectAdded(callback func(GameObject)) func() {
+	return manager.hooks.subscribe(&manager.hooks.added, callback)
+}
+
+// OnObjectRemoved registers the callback invoked when a game object is removed.
+// Returns the function unsubscribing the callback.
+func (manager *GameManager) OnObjectRemoved(callback func(GameObject)) func() {
+	return manager.hooks.subscribe(&manager.hooks.removed, callback)
+}
+
+// OnObjectDisabled registers the callback invoked when a game object is disabled via DisableGameObject.
+// Returns the function unsubscribing the callback.
+func (manager *GameManager) OnObjectDisabled(callback func(GameObject)) func() {
+	return manager.hooks.subscribe(&manager.hooks.disabled, callback)
+}
+
+// UpdateOrder returns the game objects in the update order, by the update priority descending.
+// The equal priority objects keep the insertion order. The order is sorted once the game objects
+// change, not every tick.
+func (manager *GameManager) UpdateOrder() []GameObject {
+	if manager.updateOrder == nil {
+		manager.updateOrder = make([]GameObject, len(manager.gameObjects))
+		copy(manager.updateOrder, manager.gameObjects)
+		sort.SliceStable(manager.updateOrder, func(i, j int) bool {
+			return updatePriority(manager.updateOrder[i]) > updatePriority(manager.updateOrder[j])
+		})
+	}
+	return manager.updateOrder
+}
+
+// AddGameObject adds the game object unless it would exceed the object limits, see WithMaxGameObjects.
+// Returns whether the object was accepted, the rejection is logged as a warning.
+func (manager *GameManager) AddGameObject(gameObject GameObject) (bool, error) {
+	if err := manager.checkLimits(gameObject); err != nil {
+		var limitErr ErrObjectLimitReached
+		errors.As(err, &limitErr)
+		manager.logger.LogEvent(LogLevelWarning, err.Error(), gameObject.ID(), map[string]interface{}{
+			"objectType": limitErr.ObjectType,
+			"limit":      limitErr.Limit,
+		})
+		return false, err
+	}
+
 	manager.gameObjects = append(manager.gameObjects, gameObject)
+	manager.updateOrder = nil
+	manager.ids.Observe(gameObject.ID())
+	manager.countAdded(gameObject)
+	dispatch(manager.hooks.added, gameObject)
+	return true, nil
 }
 
-func (manager *GameManager) AddGameObjects(gameObjects []GameObject) {
-	manager.gameObjects = append(manager.gameObjects, gameObjects...)
+// AddGameObjects adds the game objects in order, skipping those exceeding the object limits.
+// Returns the error of the first skipped one, nil when all were added.
+func (manager *GameManager) AddGameObjects(gameObjects []GameObject) error {
+	var rejected error
+	for _, gameObject := range gameObjects {
+		if _, err := manager.AddGameObject(gameObject); err != nil && rejected == nil {
+			rejected = err
+		}
+	}
+	return rejected
+}
+
+func (manager *GameManager) countAdded(gameObject GameObject) {
+	switch gameObject := gameObject.(type) {
+	case *Asteroid:
+		manager.counts.asteroids++
+	case *Projectile:
+		manager.counts.projectiles++
+		manager.projectilesFired++
+		manager.publish(ProjectileFired{Tick: manager.tick, Projectile: gameObject, Owner: gameObject.owner})
+	}
+}
+
+func (manager *GameManager) countRemoved(gameObject GameObject) {
+	switch gameObject.(type) {
+	case *Asteroid:
+		manager.counts.asteroids--
+	case *Projectile:
+		manager.counts.projectiles--
+	}
+}
+
+// checkLimits returns ErrObjectLimitReached when the game object would exceed the object limits.
+func (manager *GameManager) checkLimits(gameObject GameObject) error {
+	if limit := manager.limits.gameObjects; limit > 0 && len(manager.gameObjects) >= limit {
+		return ErrObjectLimitReached{ObjectType: "gameObject", Limit: limit}
+	}
+	switch gameObject.(type) {
+	case *Asteroid:
+		if limit := manager.limits.asteroids; limit > 0 && manager.counts.asteroids >= limit {
+			return ErrObjectLimitReached{ObjectType: "asteroid", Limit: limit}
+		}
+	case *Projectile:
+		if limit := manager.limits.projectiles; limit > 0 && manager.counts.projectiles >= limit {
+			return ErrObjectLimitReached{ObjectType: "projectile", Limit: limit}
+		}
+	}
+	return nil
 }
 
 func (manager *GameManager) RemoveGameObject(gameObject GameObject) {
 	for i, obj := range manager.gameObjects {
 		if obj.ID() == gameObject.ID() {
-			manager.gameObjects = append(manager.gameObjects[:i], manager.gameObjects[i+1:]...)
+			manager.RemoveGameObjectByIndex(i)
 			break
 		}
 	}
 }
 
 func (manager *GameManager) RemoveGameObjectByIndex(index int) {
+	gameObject := manager.gameObjects[index]
 	manager.gameObjects = append(manager.gameObjects[:index], manager.gameObjects[index+1:]...)
+	manager.updateOrder = nil
+	manager.countRemoved(gameObject)
+	if _, ok := gameObject.(*Asteroid); ok {
+		manager.asteroidsDestroyed++
+	}
+	dispatch(manager.hooks.removed, gameObject)
+}
+
+// DisableGameObject disables the game object and notifies the OnObjectDisabled callbacks.
+func (manager *GameManager) DisableGameObject(gameObject GameObject) {
+	if !gameObject.Enabled() {
+		return
+	}
+	gameObject.SetEnabled(false)
+	dispatch(manager.hooks.disabled, gameObject)
 }
 
 func (manager *GameManager) AddSpaceship(spaceShip *Spaceship) error {
 	if _, ok := manager.spaceShips[spaceShip.name]; ok {
-		return fmt.Errorf("space ship already exists: %s", spaceShip.name)
+		return ErrDuplicateSpaceshipName{Name: spaceShip.name}
 	}
 
+	if _, err := manager.AddGameObject(spaceShip); err != nil {
+		return err
+	}
 	manager.spaceShips[spaceShip.name] = spaceShip
-	manager.gameObjects = append(manager.gameObjects, spaceShip)
+	manager.records[spaceShip.name] = &shipRecord{joinedMs: manager.elapsedMs}
 	return nil
 }
 
 func (manager *GameManager) GetSpaceship(name string) (*Spaceship, error) {
 	spaceShip, ok := manager.spaceShips[name]
 	if !ok {
-		return nil, fmt.Errorf("space ship not found: %s", name)
+		return nil, ErrSpaceshipNotFound{Name: name}
 	}
 	return spaceShip, nil
 }
@@ -95,6 +706,7 @@ func (manager *GameManager) RemoveSpaceship(name string) error {
 
 	manager.RemoveGameObject(spaceShip)
 	delete(manager.spaceShips, name)
+	delete(manager.records, name)
 	return nil
 }
 
@@ -107,6 +719,7 @@ func (manager *GameManager) OnShipDestroyed() {
 
 func (manager *GameManager) Reset() {
 	gameObjects := make([]GameObject, 0)
+	removed := make([]GameObject, 0)
 	for _, gameObject := range manager.GameObjects() {
 		switch gameObject.(type) {
 		case *Spaceship:
@@ -114,13 +727,316 @@ func (manager *GameManager) Reset() {
 			gameObjects = append(gameObjects, gameObject)
 		case *Asteroid:
 			gameObjects = append(gameObjects, gameObject)
+		default:
+			removed = append(removed, gameObject)
 		}
 	}
 	manager.gameObjects = gameObjects
+	manager.updateOrder = nil
+	for _, gameObject := range removed {
+		manager.countRemoved(gameObject)
+		dispatch(manager.hooks.removed, gameObject)
+	}
+	manager.resetState()
+}
+
+// Clear removes all the game objects, including the spaceships and the asteroids, and resets the logs
+// and the stats. The settings, e.g. the damage calculator, the object factory, the object limits,
+// the end conditions, the hooks and the event subscribers, are kept.
+func (manager *GameManager) Clear() {
+	removed := manager.gameObjects
+	manager.gameObjects = make([]GameObject, 0)
+	manager.spaceShips = make(map[string]*Spaceship)
+	manager.updateOrder = nil
+	for _, gameObject := range removed {
+		manager.countRemoved(gameObject)
+		dispatch(manager.hooks.removed, gameObject)
+	}
+	manager.resetState()
+}
+
+// resetState resets the game time, the stats, the scoreboard, the pending messages and the logs.
+func (manager *GameManager) resetState() {
 	manager.destroyedShips = 0
+	manager.projectilesFired = 0
+	manager.asteroidsDestroyed = 0
 	manager.gracefulEndTimerMs = 0
+	manager.elapsedMs = 0
+	manager.tick = 0
+	manager.endReason = ""
+	manager.outbox = nil
+	manager.logger.Clear()
+	manager.logger.SetTick(0)
+	manager.logger.SetTickMs(0)
+	manager.chatLog = nil
+	manager.chatHead = 0
+	manager.broadPhaseObjects = nil
+	manager.pickups.nextMs = 0
+	manager.suddenDeath.started = false
+	manager.resetRecords()
+}
+
+// Serialize returns the game objects, the logs and the game time, without the Game settings.
+func (manager *GameManager) Serialize() map[string]interface{} {
+	gameObjects := make([]interface{}, 0)
+	for _, gameObject := range manager.gameObjects {
+		gameObjects = append(gameObjects, gameObject.Serialize())
+	}
+
+	logs := make([]interface{}, 0)
+	for _, log := range manager.logger.Logs() {
+		logs = append(logs, log.Serialize())
+	}
+
+	serialized := map[string]interface{}{
+		"elapsedMs":   manager.elapsedMs,
+		"tick":        manager.tick,
+		"lastId":      manager.ids.LastID(),
+		"gameObjects": gameObjects,
+		"logs":        logs,
+	}
+	if manager.suddenDeath.started {
+		zone := manager.SafeZone()
+		serialized["safeZone"] = map[string]interface{}{
+			"min": map[string]interface{}{"x": zone.Min.X, "y": zone.Min.Y},
+			"max": map[string]interface{}{"x": zone.Max.X, "y": zone.Max.Y},
+		}
+	}
+	return serialized
+}
+
+// Deserialize replaces the game objects, the logs and the game time with the ones of the Serialize output,
+// keeping the settings. Skipped are the unknown game objects, the projectiles whose owner is gone, the
+// trigger zones and drones, whose callbacks and strategies are recreated by the game mode, and the logs
+// that cannot be parsed.
+func (manager *GameManager) Deserialize(data map[string]interface{}) error {
+	gameObjects, err := list(data, "gameObjects")
+	if err != nil {
+		return err
+	}
+	logs, err := list(data, "logs")
+	if err != nil {
+		return err
+	}
+	manager.Clear()
+
+	uuid := int64(0)
+	for _, rawGameObject := range gameObjects {
+		serialized, ok := rawGameObject.(map[string]interface{})
+		if !ok {
+			return ErrInvalidState{Field: "gameObjects"}
+		}
+		id, err := number(serialized, "id")
+		if err != nil {
+			return err
+		}
+		uuid = max(uuid, int64(id))
+
+		gameObject, err := manager.deserializeGameObject(serialized)
+		if err != nil {
+			return err
+		}
+		if gameObject == nil {
+			continue
+		}
+		if ship, ok := gameObject.(*Spaceship); ok {
+			if destroyed, _ := serialized["destroyed"].(bool); destroyed {
+				manager.destroyedShips++
+			}
+			err = manager.AddSpaceship(ship)
+		} else {
+			_, err = manager.AddGameObject(gameObject)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, rawLog := range logs {
+		serialized, ok := rawLog.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		entry, err := DeserializeLogEntry(serialized)
+		if err != nil {
+			continue
+		}
+		uuid = max(uuid, entry.id)
+		manager.logger.AddMessage(entry)
+	}
+
+	if manager.elapsedMs, err = optionalNumber(data, "elapsedMs", 0); err != nil {
+		return err
+	}
+	manager.logger.SetTickMs(manager.elapsedMs)
+	manager.suddenDeath.started = manager.suddenDeath.enabled && manager.elapsedMs >= manager.suddenDeath.rules.StartMs
+	tick, err := optionalNumber(data, "tick", 0)
+	if err != nil {
+		return err
+	}
+	manager.tick = uint64(tick)
+	manager.logger.SetTick(manager.tick)
+
+	// The ids allocated to the objects gone since are not reused either, when known
+	lastID, err := optionalNumber(data, "lastId", 0)
+	if err != nil {
+		return err
+	}
+	manager.ids.SetLastID(max(uuid, int64(lastID)))
+	return nil
+}
+
+// deserializeGameObject restores the game object by its type, nil when it is to be skipped.
+func (manager *GameManager) deserializeGameObject(serialized map[string]interface{}) (GameObject, error) {
+	gameObjectType, err := text(serialized, "type")
+	if err != nil {
+		return nil, err
+	}
+
+	switch gameObjectType {
+	case "asteroid":
+		return DeserializeAsteroid(serialized)
+	case "spaceship":
+		return DeserializeSpaceship(serialized)
+	case "laser", "rocket", "mine":
+		ownerID, err := number(serialized, "owner")
+		if err != nil {
+			return nil, err
+		}
+		owner, ok := manager.GetGameObjectByID(int64(ownerID)).(*Spaceship)
+		if !ok {
+			return nil, nil
+		}
+		return DeserializeProjectile(serialized, owner)
+	case "explosion":
+		return DeserializeExplosion(serialized)
+	case "pickup":
+		return DeserializePickup(serialized)
+	default:
+		return nil, nil
+	}
 }
 
 func (manager *GameManager) Logger() Logger {
 	return manager.logger
 }
+
+// DamageCalculator returns the collision damage formula, FlatDamageCalculator unless set otherwise.
+func (manager *GameManager) DamageCalculator() DamageCalculator {
+	if manager.damageCalculator == nil {
+		return FlatDamageCalculator{}
+	}
+	return manager.damageCalculator
+}
+
+// SetDamageCalculator replaces the collision damage formula, nil restores the default one.
+func (manager *GameManager) SetDamageCalculator(calculator DamageCalculator) {
+	manager.damageCalculator = calculator
+}
+
+// ObjectFactory returns the factory of the spawned game objects, DefaultObjectFactory unless set otherwise.
+func (manager *GameManager) ObjectFactory() ObjectFactory {
+	if manager.objectFactory == nil {
+		return DefaultObjectFactory{}
+	}
+	return manager.objectFactory
+}
+
+// SetObjectFactory replaces the factory of the spawned game objects, nil restores the default one.
+func (manager *GameManager) SetObjectFactory(factory ObjectFactory) {
+	manager.objectFactory = factory
+}
+
+// SetInitialObjectCount sets the number of the objects of the type spawned when the game starts,
+// only "asteroid" is supported.
+func (manager *GameManager) SetInitialObjectCount(typeName string, count int) error {
+	if typeName != "asteroid" {
+		return fmt.Errorf("unsupported initial object type: %s", typeName)
+	}
+	if count < 0 {
+		return fmt.Errorf("invalid initial object count: %d", count)
+	}
+	if manager.initialCounts == nil {
+		manager.initialCounts = make(map[string]int)
+	}
+	manager.initialCounts[typeName] = count
+	return nil
+}
+
+// spawnInitialObjects adds the objects set by SetInitialObjectCount.
+func (manager *GameManager) spawnInitialObjects() {
+	if count, ok := manager.initialCounts["asteroid"]; ok {
+		// The asteroids over the limits are dropped
+		_ = manager.AddGameObjects(placeAsteroids(manager.rand, manager.ObjectFactory(), manager.ids, count, manager.size.Width, manager.size.Height, 1000))
+	}
+}
+
+// destroyAsteroid removes the asteroid and spawns its 2-3 fragments, of the reduced radius, evenly spread
+// around its center and drifting apart on top of its velocity. The fragments over the asteroid limit are dropped.
+func (manager *GameManager) destroyAsteroid(asteroid *Asteroid, destroyer *Spaceship) {
+	manager.DisableGameObject(asteroid)
+	manager.RemoveGameObject(asteroid)
+	if destroyer != nil {
+		manager.RecordAsteroidDestroyed(destroyer)
+	}
+
+	var fragments []*Asteroid
+	if radius := asteroid.radius * AsteroidFragmentRadiusFactor; radius >= MinAsteroidFragmentSize {
+		count := 2 + manager.rand.Intn(2)
+		offset := manager.rand.Float64() * 2 * math.Pi
+		for i := 0; i < count; i++ {
+			direction := physics.FromAngle(offset + 2*math.Pi*float64(i)/float64(count))
+			fragment := manager.ObjectFactory().NewAsteroid(manager.NewID(), asteroid.position.Add(direction.Multiply(radius)), radius)
+			fragment.velocity = asteroid.velocity.Add(direction.Multiply(AsteroidFragmentSpeedSec))
+			if _, err := manager.AddGameObject(fragment); err == nil {
+				fragments = append(fragments, fragment)
+			}
+		}
+	}
+	manager.publish(AsteroidDestroyed{Tick: manager.tick, Asteroid: asteroid, Destroyer: destroyer, Fragments: fragments})
+}
+
+// seedRand replaces the random numbers generator with a new one of the seed.
+func (manager *GameManager) seedRand(seed int64) {
+	manager.randSource = newCountingSource(seed)
+	manager.rand = rand.New(manager.randSource)
+}
+
+// Rand returns the random numbers generator seeded by the game. The game objects draw their
+// random values from it, instead of creating their own, to keep the game reproducible.
+func (manager *GameManager) Rand() *rand.Rand {
+	return manager.rand
+}
+
+func (manager *GameManager) ProjectilePool() *ProjectilePool {
+	return manager.projectilePool
+}
+
+func (manager *GameManager) ExplosionPool() *ExplosionPool {
+	return manager.explosionPool
+}
+
+// recycle returns the removed game object to its pool once the tick ends, the other side of
+// the collision and the event subscribers still read it.
+func (manager *GameManager) recycle(gameObject GameObject) {
+	for _, recycled := range manager.recycled {
+		if recycled == gameObject {
+			return
+		}
+	}
+	manager.recycled = append(manager.recycled, gameObject)
+}
+
+// releaseRecycled returns the game objects recycled during the tick to their pools.
+func (manager *GameManager) releaseRecycled() {
+	for _, gameObject := range manager.recycled {
+		switch gameObject := gameObject.(type) {
+		case *Projectile:
+			manager.projectilePool.Put(gameObject)
+		case *Explosion:
+			manager.explosionPool.Put(gameObject)
+		}
+	}
+	clear(manager.recycled)
+	manager.recycled = manager.recycled[:0]
+}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"math"
 	"testing"
 
 	"github.com/davidhorak/space-wars/kernel/physics"
@@ -28,6 +29,416 @@ func TestGameManager_GameObjects(t *testing.T) {
 	assert.ElementsMatch(t, []GameObject{asteroid, spaceship}, manager.GameObjects())
 }
 
+func TestGameManager_IterateGameObjects(t *testing.T) {
+	manager := NewGameManager()
+	manager.AddGameObjects([]GameObject{
+		NewAsteroid(1, physics.Vector2{X: 0, Y: 0}, 5),
+		NewAsteroid(2, physics.Vector2{X: 0, Y: 0}, 5),
+		NewAsteroid(3, physics.Vector2{X: 0, Y: 0}, 5),
+	})
+
+	// All visited
+	var visited []int64
+	manager.IterateGameObjects(func(gameObject GameObject) bool {
+		visited = append(visited, gameObject.ID())
+		return true
+	})
+	assert.Equal(t, []int64{1, 2, 3}, visited)
+
+	// Early exit
+	visited = nil
+	manager.IterateGameObjects(func(gameObject GameObject) bool {
+		visited = append(visited, gameObject.ID())
+		return gameObject.ID() != 2
+	})
+	assert.Equal(t, []int64{1, 2}, visited)
+
+	// Removing the visited object does not skip the next one, added objects are visited
+	visited = nil
+	manager.IterateGameObjects(func(gameObject GameObject) bool {
+		visited = append(visited, gameObject.ID())
+		switch gameObject.ID() {
+		case 1:
+			manager.RemoveGameObject(gameObject)
+		case 3:
+			manager.AddGameObject(NewAsteroid(4, physics.Vector2{X: 0, Y: 0}, 5))
+		}
+		return true
+	})
+	assert.Equal(t, []int64{1, 2, 3, 4}, visited)
+	assert.Equal(t, 3, manager.GameObjectSize())
+}
+
+func TestGameManager_Query(t *testing.T) {
+	manager := NewGameManager()
+	asteroid1 := NewAsteroid(1, physics.Vector2{X: 10, Y: 10}, 5)
+	asteroid2 := NewAsteroid(2, physics.Vector2{X: 500, Y: 500}, 5)
+	spaceship := NewSpaceship(3, "TestShip", physics.Vector2{X: 20, Y: 20}, 0)
+	manager.AddGameObjects([]GameObject{asteroid1, asteroid2})
+	manager.AddSpaceship(spaceship)
+
+	nearOrigin := func(gameObject GameObject) bool {
+		position := gameObject.Position()
+		return position.Magnitude() < 100
+	}
+	assert.Equal(t, []GameObject{asteroid1, spaceship}, manager.Query(nearOrigin))
+
+	none := func(gameObject GameObject) bool { return false }
+	assert.Nil(t, manager.Query(none))
+
+	// The result is not the internal slice
+	result := manager.Query(func(gameObject GameObject) bool { return true })
+	result[0] = nil
+	assert.Equal(t, asteroid1, manager.GetGameObjectByIndex(0))
+}
+
+func TestGameManager_ObjectsInRadius(t *testing.T) {
+	manager := NewGameManager()
+	inside := NewAsteroid(1, physics.Vector2{X: 101, Y: 101}, 5)
+	boundary := NewAsteroid(2, physics.Vector2{X: 103, Y: 104}, 5)
+	outside := NewAsteroid(3, physics.Vector2{X: 104, Y: 104}, 5)
+	disabled := NewAsteroid(4, physics.Vector2{X: 100, Y: 100}, 5)
+	disabled.SetEnabled(false)
+	manager.AddGameObjects([]GameObject{inside, boundary, outside, disabled})
+
+	assert.Equal(t, []GameObject{inside, boundary}, manager.ObjectsInRadius(physics.Vector2{X: 100, Y: 100}, 5))
+	assert.Empty(t, manager.ObjectsInRadius(physics.Vector2{X: 500, Y: 500}, 5))
+}
+
+func TestGameManager_ObjectsInRect(t *testing.T) {
+	manager := NewGameManager()
+	inside := NewAsteroid(1, physics.Vector2{X: 15, Y: 25}, 5)
+	corner := NewAsteroid(2, physics.Vector2{X: 40, Y: 60}, 5)
+	outside := NewAsteroid(3, physics.Vector2{X: 41, Y: 25}, 5)
+	disabled := NewAsteroid(4, physics.Vector2{X: 15, Y: 25}, 5)
+	disabled.SetEnabled(false)
+	manager.AddGameObjects([]GameObject{inside, corner, outside, disabled})
+
+	assert.Equal(t, []GameObject{inside, corner}, manager.ObjectsInRect(physics.Rect{X: 10, Y: 20, Width: 30, Height: 40}))
+	assert.Empty(t, manager.ObjectsInRect(physics.Rect{X: 100, Y: 100, Width: 1, Height: 1}))
+}
+
+func TestGameManager_Neighbors(t *testing.T) {
+	manager := NewGameManager()
+	near := NewAsteroid(1, physics.Vector2{X: 100, Y: 100}, 10)
+	touching := NewAsteroid(2, physics.Vector2{X: 125, Y: 100}, 5)
+	far := NewAsteroid(3, physics.Vector2{X: 400, Y: 400}, 10)
+	disabled := NewAsteroid(4, physics.Vector2{X: 100, Y: 100}, 10)
+	disabled.SetEnabled(false)
+	manager.AddGameObjects([]GameObject{near, touching, far, disabled})
+
+	area := physics.AABB{Min: physics.Vector2{X: 90, Y: 90}, Max: physics.Vector2{X: 120, Y: 110}}
+	assert.Equal(t, []GameObject{near, touching}, manager.Neighbors(area))
+	assert.Equal(t, 3, manager.BroadPhase().Len())
+
+	// The grid is kept for the rest of the tick
+	far.SetPosition(physics.Vector2{X: 100, Y: 100})
+	far.Collider().SetPosition(physics.Vector2{X: 100, Y: 100})
+	assert.Equal(t, []GameObject{near, touching}, manager.Neighbors(area))
+	manager.Tick(16)
+	assert.Equal(t, []GameObject{near, touching, far}, manager.Neighbors(area))
+
+	// Disabled since the grid was built
+	near.SetEnabled(false)
+	assert.Equal(t, []GameObject{touching, far}, manager.Neighbors(area))
+}
+
+func TestGameManager_Count(t *testing.T) {
+	manager := NewGameManager()
+	manager.AddGameObjects([]GameObject{
+		NewAsteroid(1, physics.Vector2{X: 10, Y: 10}, 5),
+		NewAsteroid(2, physics.Vector2{X: 500, Y: 500}, 5),
+	})
+	manager.AddSpaceship(NewSpaceship(3, "TestShip", physics.Vector2{X: 20, Y: 20}, 0))
+
+	predicates := []func(GameObject) bool{
+		func(gameObject GameObject) bool { return true },
+		func(gameObject GameObject) bool { return false },
+		func(gameObject GameObject) bool { return gameObject.Position().X < 100 },
+	}
+	for _, predicate := range predicates {
+		assert.Equal(t, len(manager.Query(predicate)), manager.Count(predicate))
+	}
+	assert.Equal(t, 2, manager.Count(func(gameObject GameObject) bool { return gameObject.Position().X < 100 }))
+}
+
+func TestGameManager_Spaceships(t *testing.T) {
+	manager := NewGameManager()
+	assert.Nil(t, manager.Spaceships())
+
+	ship1 := NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 0)
+	ship2 := NewSpaceship(2, "Ship2", physics.Vector2{X: 0, Y: 0}, 0)
+	manager.AddGameObject(NewAsteroid(3, physics.Vector2{X: 0, Y: 0}, 5))
+	manager.AddSpaceship(ship1)
+	manager.AddGameObject(NewExplosion(4, physics.Vector2{X: 0, Y: 0}, 5, 1))
+	manager.AddSpaceship(ship2)
+
+	assert.Equal(t, []*Spaceship{ship1, ship2}, manager.Spaceships())
+}
+
+func TestGameManager_SpaceshipsByScore(t *testing.T) {
+	manager := NewGameManager()
+	charlie := NewSpaceship(1, "charlie", physics.Vector2{X: 0, Y: 0}, 0)
+	bravo := NewSpaceship(2, "bravo", physics.Vector2{X: 0, Y: 0}, 0)
+	alpha := NewSpaceship(3, "alpha", physics.Vector2{X: 0, Y: 0}, 0)
+	delta := NewSpaceship(4, "delta", physics.Vector2{X: 0, Y: 0}, 0)
+	for _, spaceship := range []*Spaceship{charlie, bravo, alpha, delta} {
+		manager.AddSpaceship(spaceship)
+	}
+	delta.AddScore(500)
+	charlie.AddScore(100)
+	bravo.AddScore(100)
+	alpha.AddScore(100)
+	bravo.kills = 2
+
+	// Score, then kills, then name
+	assert.Equal(t, []*Spaceship{delta, bravo, alpha, charlie}, manager.SpaceshipsByScore())
+
+	// Copy
+	ranked := manager.SpaceshipsByScore()
+	ranked[0], ranked[3] = ranked[3], ranked[0]
+	assert.Equal(t, []*Spaceship{delta, bravo, alpha, charlie}, manager.SpaceshipsByScore())
+	assert.Equal(t, []*Spaceship{charlie, bravo, alpha, delta}, manager.Spaceships())
+}
+
+func TestGameManager_GetSpaceshipTeamScores(t *testing.T) {
+	manager := NewGameManager()
+	red1 := NewSpaceship(1, "red1", physics.Vector2{X: 0, Y: 0}, 0, WithTeam("red"))
+	red2 := NewSpaceship(2, "red2", physics.Vector2{X: 0, Y: 0}, 0, WithTeam("red"))
+	blue := NewSpaceship(3, "blue", physics.Vector2{X: 0, Y: 0}, 0, WithTeam("blue"))
+	loner := NewSpaceship(4, "loner", physics.Vector2{X: 0, Y: 0}, 0)
+	for _, spaceship := range []*Spaceship{red1, red2, blue, loner} {
+		manager.AddSpaceship(spaceship)
+	}
+	red1.AddScore(100)
+	red2.AddScore(50)
+	blue.AddScore(120)
+	loner.AddScore(10)
+
+	assert.Equal(t, map[string]int64{"red": 150, "blue": 120, "": 10}, manager.GetSpaceshipTeamScores())
+
+	// Reflects the score changes
+	blue.AddScore(40)
+	assert.Equal(t, int64(160), manager.GetSpaceshipTeamScores()["blue"])
+}
+
+func TestGameManager_GetSpaceshipTeamScores_NoTeams(t *testing.T) {
+	manager := NewGameManager()
+	alpha := NewSpaceship(1, "alpha", physics.Vector2{X: 0, Y: 0}, 0)
+	beta := NewSpaceship(2, "beta", physics.Vector2{X: 0, Y: 0}, 0)
+	manager.AddSpaceship(alpha)
+	manager.AddSpaceship(beta)
+	alpha.AddScore(30)
+	beta.AddScore(12)
+
+	assert.Equal(t, map[string]int64{"": 42}, manager.GetSpaceshipTeamScores())
+}
+
+func TestGameManager_Asteroids(t *testing.T) {
+	manager := NewGameManager()
+	assert.Nil(t, manager.Asteroids())
+
+	asteroid := NewAsteroid(1, physics.Vector2{X: 0, Y: 0}, 5)
+	manager.AddSpaceship(NewSpaceship(2, "Ship", physics.Vector2{X: 0, Y: 0}, 0))
+	manager.AddGameObject(asteroid)
+
+	assert.Equal(t, []*Asteroid{asteroid}, manager.Asteroids())
+}
+
+func TestGameManager_MirrorObject(t *testing.T) {
+	tests := []struct {
+		position physics.Vector2
+		axis     string
+		expected physics.Vector2
+	}{
+		{physics.Vector2{X: 100, Y: 50}, "horizontal", physics.Vector2{X: 100, Y: 550}},
+		{physics.Vector2{X: 700, Y: 50}, "horizontal", physics.Vector2{X: 700, Y: 550}},
+		{physics.Vector2{X: 100, Y: 500}, "horizontal", physics.Vector2{X: 100, Y: 100}},
+		{physics.Vector2{X: 700, Y: 500}, "horizontal", physics.Vector2{X: 700, Y: 100}},
+		{physics.Vector2{X: 100, Y: 50}, "vertical", physics.Vector2{X: 700, Y: 50}},
+		{physics.Vector2{X: 700, Y: 50}, "vertical", physics.Vector2{X: 100, Y: 50}},
+		{physics.Vector2{X: 100, Y: 500}, "vertical", physics.Vector2{X: 700, Y: 500}},
+		{physics.Vector2{X: 700, Y: 500}, "vertical", physics.Vector2{X: 100, Y: 500}},
+	}
+
+	for _, test := range tests {
+		manager := NewGameManager()
+		manager.size = physics.Size{Width: 800, Height: 600}
+		asteroid := NewAsteroid(1, test.position, 5)
+		manager.AddGameObject(asteroid)
+
+		err := manager.MirrorObject(1, test.axis)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, asteroid.Position())
+		assert.Equal(t, test.expected, asteroid.Collider().Position())
+		assert.True(t, asteroid.Position().X >= 0 && asteroid.Position().X <= 800)
+		assert.True(t, asteroid.Position().Y >= 0 && asteroid.Position().Y <= 600)
+	}
+}
+
+func TestGameManager_MirrorObject_Errors(t *testing.T) {
+	manager := NewGameManager()
+	manager.size = physics.Size{Width: 800, Height: 600}
+	manager.AddGameObject(NewAsteroid(1, physics.Vector2{X: 100, Y: 100}, 5))
+
+	err := manager.MirrorObject(1, "diagonal")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid mirror axis")
+
+	err = manager.MirrorObject(2, "vertical")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "game object not found")
+}
+
+func TestGameManager_TimeUntilNextCollision(t *testing.T) {
+	t.Run("Head-on", func(t *testing.T) {
+		manager := NewGameManager()
+		ship1 := NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 0)
+		ship2 := NewSpaceship(2, "Ship2", physics.Vector2{X: 130, Y: 0}, 0)
+		ship1.velocity = physics.Vector2{X: 50, Y: 0}
+		ship2.velocity = physics.Vector2{X: -50, Y: 0}
+		manager.AddSpaceship(ship1)
+		manager.AddSpaceship(ship2)
+
+		// 100 units gap closing at 100 units per second
+		ms, ok := manager.TimeUntilNextCollision(1, 2)
+		assert.True(t, ok)
+		assert.InDelta(t, 1000, ms, 1e-9)
+	})
+
+	t.Run("Crossing paths", func(t *testing.T) {
+		manager := NewGameManager()
+		ship := NewSpaceship(1, "Ship", physics.Vector2{X: 37.5, Y: 100}, 0)
+		ship.velocity = physics.Vector2{X: 100, Y: 0}
+		laser := NewLaserProjectile(2, physics.Vector2{X: 100, Y: 300}, -math.Pi/2, ship)
+		manager.AddSpaceship(ship)
+		manager.AddGameObject(laser)
+
+		// Both reach (100, 100) in 625ms, the bounding circles touch slightly before
+		ms, ok := manager.TimeUntilNextCollision(1, 2)
+		assert.True(t, ok)
+		assert.Greater(t, ms, float64(500))
+		assert.Less(t, ms, float64(625))
+	})
+
+	t.Run("Diverging paths", func(t *testing.T) {
+		manager := NewGameManager()
+		ship1 := NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 0)
+		ship2 := NewSpaceship(2, "Ship2", physics.Vector2{X: 100, Y: 0}, 0)
+		ship1.velocity = physics.Vector2{X: -50, Y: 0}
+		ship2.velocity = physics.Vector2{X: 50, Y: 10}
+		manager.AddSpaceship(ship1)
+		manager.AddSpaceship(ship2)
+
+		ms, ok := manager.TimeUntilNextCollision(1, 2)
+		assert.False(t, ok)
+		assert.Equal(t, float64(0), ms)
+	})
+
+	t.Run("Beyond the look-ahead window", func(t *testing.T) {
+		manager := NewGameManager()
+		ship := NewSpaceship(1, "Ship", physics.Vector2{X: 0, Y: 0}, 0)
+		ship.velocity = physics.Vector2{X: 1, Y: 0}
+		manager.AddSpaceship(ship)
+		manager.AddGameObject(NewAsteroid(2, physics.Vector2{X: 1000, Y: 0}, 10))
+
+		_, ok := manager.TimeUntilNextCollision(1, 2)
+		assert.False(t, ok)
+	})
+
+	t.Run("Already colliding", func(t *testing.T) {
+		manager := NewGameManager()
+		manager.AddSpaceship(NewSpaceship(1, "Ship", physics.Vector2{X: 0, Y: 0}, 0))
+		manager.AddGameObject(NewAsteroid(2, physics.Vector2{X: 10, Y: 0}, 10))
+
+		ms, ok := manager.TimeUntilNextCollision(1, 2)
+		assert.True(t, ok)
+		assert.Equal(t, float64(0), ms)
+	})
+
+	t.Run("Unknown or non-colliding objects", func(t *testing.T) {
+		manager := NewGameManager()
+		manager.AddSpaceship(NewSpaceship(1, "Ship", physics.Vector2{X: 0, Y: 0}, 0))
+		manager.AddGameObject(NewExplosion(2, physics.Vector2{X: 0, Y: 0}, 10, 1))
+
+		_, ok := manager.TimeUntilNextCollision(1, 2)
+		assert.False(t, ok)
+		_, ok = manager.TimeUntilNextCollision(1, 3)
+		assert.False(t, ok)
+	})
+}
+
+func TestGameManager_OnObjectAdded(t *testing.T) {
+	manager := NewGameManager()
+	asteroid := NewAsteroid(1, physics.Vector2{X: 0, Y: 0}, 5)
+	ship := NewSpaceship(2, "Ship", physics.Vector2{X: 0, Y: 0}, 0)
+
+	first := []GameObject{}
+	second := []GameObject{}
+	unsubscribe := manager.OnObjectAdded(func(gameObject GameObject) { first = append(first, gameObject) })
+	manager.OnObjectAdded(func(gameObject GameObject) { second = append(second, gameObject) })
+
+	manager.AddGameObject(asteroid)
+	manager.AddSpaceship(ship)
+	assert.Equal(t, []GameObject{asteroid, ship}, first)
+	assert.Equal(t, []GameObject{asteroid, ship}, second)
+
+	unsubscribe()
+	other := NewAsteroid(3, physics.Vector2{X: 0, Y: 0}, 5)
+	manager.AddGameObjects([]GameObject{other})
+	assert.Equal(t, []GameObject{asteroid, ship}, first)
+	assert.Equal(t, []GameObject{asteroid, ship, other}, second)
+}
+
+func TestGameManager_OnObjectRemoved(t *testing.T) {
+	manager := NewGameManager()
+	asteroid1 := NewAsteroid(1, physics.Vector2{X: 0, Y: 0}, 5)
+	asteroid2 := NewAsteroid(2, physics.Vector2{X: 0, Y: 0}, 5)
+	ship := NewSpaceship(3, "Ship", physics.Vector2{X: 0, Y: 0}, 0)
+	manager.AddGameObjects([]GameObject{asteroid1, asteroid2})
+	manager.AddSpaceship(ship)
+
+	removed := []GameObject{}
+	unsubscribe := manager.OnObjectRemoved(func(gameObject GameObject) { removed = append(removed, gameObject) })
+
+	manager.RemoveGameObject(asteroid1)
+	manager.RemoveSpaceship("Ship")
+	assert.Equal(t, []GameObject{asteroid1, ship}, removed)
+
+	unsubscribe()
+	manager.RemoveGameObjectByIndex(0)
+	assert.Equal(t, []GameObject{asteroid1, ship}, removed)
+}
+
+func TestGameManager_OnObjectDisabled(t *testing.T) {
+	manager := NewGameManager()
+	ship1 := NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 0)
+	ship2 := NewSpaceship(2, "Ship2", physics.Vector2{X: 0, Y: 0}, 0)
+	manager.AddSpaceship(ship1)
+	manager.AddSpaceship(ship2)
+
+	disabled := []GameObject{}
+	manager.OnObjectDisabled(func(gameObject GameObject) { disabled = append(disabled, gameObject) })
+
+	ship1.TakeDamage(MaxHealth, &manager, nil)
+	assert.False(t, ship1.Enabled())
+	assert.Equal(t, []GameObject{ship1}, disabled)
+
+	// Already disabled
+	manager.DisableGameObject(ship1)
+	assert.Equal(t, []GameObject{ship1}, disabled)
+}
+
+func TestGameManager_Hooks_NotCalledOnConstruction(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1)
+	calls := 0
+	game.manager.OnObjectAdded(func(gameObject GameObject) { calls++ })
+	assert.Equal(t, 0, calls)
+
+	game.SeedAsteroids()
+	assert.Equal(t, game.manager.GameObjectSize(), calls)
+}
+
 func TestGameManager_HasEnded(t *testing.T) {
 	manager := NewGameManager()
 	ship1 := NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 100)
@@ -46,6 +457,55 @@ func TestGameManager_HasEnded(t *testing.T) {
 	assert.True(t, manager.HasEnded(0))
 }
 
+func TestGameManager_RegisterEndCondition_Or(t *testing.T) {
+	manager := NewGameManager()
+	noShips, timeLimitReached := false, false
+	manager.RegisterEndCondition("noShips", func(*GameManager) bool { return noShips })
+	manager.RegisterEndCondition("timeLimitReached", func(*GameManager) bool { return timeLimitReached })
+
+	// Replaces the default condition, which is met without spaceships
+	assert.False(t, manager.HasEnded(0))
+	assert.Equal(t, "", manager.EndReason())
+
+	timeLimitReached = true
+	assert.True(t, manager.HasEnded(0))
+	assert.Equal(t, "timeLimitReached", manager.EndReason())
+
+	noShips = true
+	assert.True(t, manager.HasEnded(0))
+	assert.Equal(t, "noShips, timeLimitReached", manager.EndReason())
+}
+
+func TestGameManager_RegisterEndCondition_And(t *testing.T) {
+	manager := NewGameManager()
+	manager.SetEndLogic(EndLogicAnd)
+	noShips, timeLimitReached := true, false
+	manager.RegisterEndCondition("noShips", func(*GameManager) bool { return noShips })
+	manager.RegisterEndCondition("timeLimitReached", func(*GameManager) bool { return timeLimitReached })
+
+	assert.False(t, manager.HasEnded(0))
+	assert.Equal(t, "", manager.EndReason())
+
+	timeLimitReached = true
+	assert.True(t, manager.HasEnded(0))
+	assert.Equal(t, "noShips, timeLimitReached", manager.EndReason())
+}
+
+func TestGameManager_RemoveEndCondition(t *testing.T) {
+	manager := NewGameManager()
+	manager.RegisterEndCondition("never", func(*GameManager) bool { return false })
+	manager.RegisterEndCondition("always", func(*GameManager) bool { return true })
+	assert.True(t, manager.HasEnded(0))
+
+	manager.RemoveEndCondition("always")
+	assert.False(t, manager.HasEnded(0))
+
+	// Back to the default condition
+	manager.RemoveEndCondition("never")
+	assert.True(t, manager.HasEnded(0))
+	assert.Equal(t, "", manager.EndReason())
+}
+
 func TestGameManager_GetGameObjectByID(t *testing.T) {
 	manager := NewGameManager()
 	asteroid := &Asteroid{id: 1}
@@ -74,6 +534,54 @@ func TestGameManager_GameObjectSize(t *testing.T) {
 	assert.Equal(t, 2, manager.GameObjectSize())
 }
 
+func TestGameManager_UpdateOrder(t *testing.T) {
+	manager := NewGameManager()
+	asteroid1 := NewAsteroid(1, physics.Vector2{X: 0, Y: 0}, 5)
+	ship1 := NewSpaceship(2, "ship1", physics.Vector2{X: 0, Y: 0}, 0)
+	asteroid2 := NewAsteroid(3, physics.Vector2{X: 0, Y: 0}, 5)
+	projectile := NewProjectile(physics.Vector2{X: 0, Y: 0}, physics.Vector2{X: 0, Y: 0}, 0, 1, 1, ship1)
+	ship2 := NewSpaceship(4, "ship2", physics.Vector2{X: 0, Y: 0}, 0)
+	manager.AddGameObjects([]GameObject{asteroid1, ship1, asteroid2, projectile})
+	manager.AddSpaceship(ship2)
+
+	// By priority, stable for the equal ones
+	assert.Equal(t, []GameObject{ship1, ship2, projectile, asteroid1, asteroid2}, manager.UpdateOrder())
+
+	// Sorted once until changed
+	order := manager.UpdateOrder()
+	assert.Same(t, &order[0], &manager.UpdateOrder()[0])
+
+	manager.RemoveGameObject(ship1)
+	assert.Equal(t, []GameObject{ship2, projectile, asteroid1, asteroid2}, manager.UpdateOrder())
+}
+
+func TestGameManager_GetAllGameObjects(t *testing.T) {
+	manager := NewGameManager()
+	ship := NewSpaceship(1, "Ship", physics.Vector2{X: 0, Y: 0}, 0)
+	asteroid := NewAsteroid(2, physics.Vector2{X: 100, Y: 100}, 10)
+	_ = manager.AddSpaceship(ship)
+	manager.AddGameObject(asteroid)
+
+	manager.DisableGameObject(ship)
+	assert.Equal(t, []GameObject{ship, asteroid}, manager.GetAllGameObjects())
+	assert.Equal(t, manager.GameObjects(), manager.GetAllGameObjects())
+	assert.Equal(t, []GameObject{asteroid}, manager.EnabledGameObjects())
+
+	manager.DisableGameObject(asteroid)
+	assert.Len(t, manager.GetAllGameObjects(), 2)
+	assert.Empty(t, manager.EnabledGameObjects())
+}
+
+func TestGameManager_SetInitialObjectCount(t *testing.T) {
+	manager := NewGameManager()
+
+	assert.Error(t, manager.SetInitialObjectCount("spaceship", 2))
+	assert.Error(t, manager.SetInitialObjectCount("asteroid", -1))
+	assert.NoError(t, manager.SetInitialObjectCount("asteroid", 5))
+	assert.Empty(t, manager.GameObjects())
+	assert.Equal(t, map[string]int{"asteroid": 5}, manager.initialCounts)
+}
+
 func TestGameManager_AddGameObject(t *testing.T) {
 	manager := NewGameManager()
 	asteroid := NewAsteroid(1, physics.Vector2{X: 0, Y: 0}, 5)
@@ -87,10 +595,89 @@ func TestGameManager_AddGameObjects(t *testing.T) {
 	asteroid1 := NewAsteroid(1, physics.Vector2{X: 0, Y: 0}, 5)
 	asteroid2 := NewAsteroid(2, physics.Vector2{X: 0, Y: 0}, 5)
 
-	manager.AddGameObjects([]GameObject{asteroid1, asteroid2})
+	assert.NoError(t, manager.AddGameObjects([]GameObject{asteroid1, asteroid2}))
 	assert.Equal(t, 2, manager.GameObjectSize())
 }
 
+func TestGameManager_AddGameObject_Limits(t *testing.T) {
+	t.Run("Game objects cap", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithMaxGameObjects(2))
+		manager := &game.manager
+		asteroid1 := NewAsteroid(1, physics.Vector2{X: 0, Y: 0}, 5)
+		asteroid2 := NewAsteroid(2, physics.Vector2{X: 0, Y: 0}, 5)
+		asteroid3 := NewAsteroid(3, physics.Vector2{X: 0, Y: 0}, 5)
+
+		accepted, err := manager.AddGameObject(asteroid1)
+		assert.True(t, accepted)
+		assert.NoError(t, err)
+		manager.AddGameObject(asteroid2)
+
+		accepted, err = manager.AddGameObject(asteroid3)
+		assert.False(t, accepted)
+		assert.ErrorAs(t, err, &ErrObjectLimitReached{})
+		assert.Equal(t, ErrObjectLimitReached{ObjectType: "gameObject", Limit: 2}, err)
+		assert.Equal(t, 2, manager.GameObjectSize())
+
+		logs := manager.Logger().Logs()
+		assert.Equal(t, LogLevelWarning, logs[len(logs)-1].Level())
+
+		// Removing an object frees the capacity
+		manager.RemoveGameObject(asteroid1)
+		accepted, err = manager.AddGameObject(asteroid3)
+		assert.True(t, accepted)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, manager.GameObjectSize())
+	})
+
+	t.Run("Asteroids cap", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithMaxAsteroids(1))
+		manager := &game.manager
+
+		err := manager.AddGameObjects([]GameObject{
+			NewAsteroid(1, physics.Vector2{X: 0, Y: 0}, 5),
+			NewAsteroid(2, physics.Vector2{X: 0, Y: 0}, 5),
+			NewAsteroid(3, physics.Vector2{X: 0, Y: 0}, 5),
+		})
+		assert.Equal(t, ErrObjectLimitReached{ObjectType: "asteroid", Limit: 1}, err)
+		assert.Equal(t, 1, manager.GameObjectSize())
+
+		// The removed and the cleared ones are not counted
+		manager.RemoveGameObject(manager.GetGameObjectByIndex(0))
+		assert.NoError(t, manager.AddGameObjects([]GameObject{NewAsteroid(4, physics.Vector2{X: 0, Y: 0}, 5)}))
+		manager.Clear()
+		assert.NoError(t, manager.AddGameObjects([]GameObject{NewAsteroid(5, physics.Vector2{X: 0, Y: 0}, 5)}))
+		assert.Equal(t, 1, manager.GameObjectSize())
+
+		// Other object types are not capped
+		assert.NoError(t, game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0))
+		assert.Equal(t, 2, manager.GameObjectSize())
+	})
+
+	t.Run("Projectiles cap", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithMaxProjectiles(1))
+		ship, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 100}})
+
+		assert.NoError(t, ship.FireLaser(&game.manager))
+		energy := ship.energy
+		err := ship.FireRocket(&game.manager)
+		assert.Equal(t, ErrObjectLimitReached{ObjectType: "projectile", Limit: 1}, err)
+		// Nothing is consumed by the rejected shot
+		assert.Equal(t, energy, ship.energy)
+		assert.Equal(t, int32(MaxRockets), ship.rockets)
+		assert.Equal(t, 2, game.manager.GameObjectSize())
+	})
+
+	t.Run("Rejected spaceship is not registered", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithMaxGameObjects(1))
+		assert.NoError(t, game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0))
+
+		err := game.AddSpaceship("beta", physics.Vector2{X: 500, Y: 500}, 0)
+		assert.ErrorAs(t, err, &ErrObjectLimitReached{})
+		_, err = game.manager.GetSpaceship("beta")
+		assert.Error(t, err)
+	})
+}
+
 func TestGameManager_RemoveGameObject(t *testing.T) {
 	manager := NewGameManager()
 	asteroid := &Asteroid{id: 1}
@@ -141,7 +728,7 @@ func TestGameManager_GetSpaceship(t *testing.T) {
 	assert.Equal(t, ship, ship)
 
 	ship, err = manager.GetSpaceship("NonExistentShip")
-	assert.Error(t, err)
+	assert.ErrorAs(t, err, &ErrSpaceshipNotFound{})
 	assert.Contains(t, err.Error(), "space ship not found")
 	assert.Nil(t, ship)
 }
@@ -180,6 +767,57 @@ func TestGameManager_OnShipDestroyed(t *testing.T) {
 	assert.Equal(t, float64(ShipExplosionDurationSec*1000+100), manager.gracefulEndTimerMs)
 }
 
+func TestGameManager_Serialize(t *testing.T) {
+	manager := NewGameManager()
+	manager.AddSpaceship(NewSpaceship(1, "Ship", physics.Vector2{X: 0, Y: 0}, 0))
+	manager.AddGameObject(NewAsteroid(2, physics.Vector2{X: 100, Y: 100}, 10))
+	manager.Logger().LogEvent(LogLevelInfo, "hello", 0, nil)
+	manager.Tick(16)
+
+	serialized := manager.Serialize()
+	assert.Len(t, serialized, 5)
+	assert.Equal(t, 16.0, serialized["elapsedMs"])
+	// The log entry took the id after the game objects
+	assert.Equal(t, int64(3), serialized["lastId"])
+	assert.Equal(t, uint64(1), serialized["tick"])
+	assert.Len(t, serialized["gameObjects"], 2)
+	assert.Equal(t, "spaceship", serialized["gameObjects"].([]interface{})[0].(map[string]interface{})["type"])
+	assert.Len(t, serialized["logs"], 1)
+}
+
+func TestGameManager_Deserialize(t *testing.T) {
+	source := NewGameManager()
+	ship := NewSpaceship(NewUUID(), "ship", physics.Vector2{X: 100, Y: 100}, 0)
+	destroyed := NewSpaceship(NewUUID(), "destroyed", physics.Vector2{X: 500, Y: 500}, 0)
+	source.AddSpaceship(ship)
+	source.AddSpaceship(destroyed)
+	source.AddGameObject(NewAsteroid(NewUUID(), physics.Vector2{X: 300, Y: 300}, 10))
+	ship.FireLaser(&source)
+	destroyed.TakeDamage(MaxHealth, &source, nil)
+	source.Tick(16)
+	serialized := source.Serialize()
+
+	// The projectiles of the removed owners and the unknown objects are skipped
+	orphan := NewLaserProjectile(NewUUID(), physics.Vector2{X: 0, Y: 0}, 0, NewSpaceship(NewUUID(), "gone", physics.Vector2{X: 0, Y: 0}, 0))
+	serialized["gameObjects"] = append(serialized["gameObjects"].([]interface{}), orphan.Serialize(), map[string]interface{}{
+		"type": "unknown",
+		"id":   0,
+	})
+
+	manager := NewGameManager()
+	manager.AddGameObject(NewAsteroid(NewUUID(), physics.Vector2{X: 0, Y: 0}, 10))
+	assert.NoError(t, manager.Deserialize(serialized))
+
+	assert.Equal(t, source.Serialize(), manager.Serialize())
+	assert.Equal(t, 1, manager.destroyedShips)
+	_, err := manager.GetSpaceship("ship")
+	assert.NoError(t, err)
+	// Past all the ids, the skipped objects included
+	assert.Equal(t, max(orphan.ID(), source.IDGenerator().LastID()), manager.IDGenerator().LastID())
+
+	assert.Equal(t, ErrInvalidState{Field: "gameObjects"}, manager.Deserialize(map[string]interface{}{"logs": []interface{}{}}))
+}
+
 func TestGameManager_Reset(t *testing.T) {
 	manager := NewGameManager()
 	ship1 := NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 100)
@@ -192,9 +830,79 @@ func TestGameManager_Reset(t *testing.T) {
 	manager.AddGameObject(asteroid)
 	manager.AddGameObject(explosion)
 
+	manager.Logger().LogEvent(LogLevelInfo, "hello", 0, nil)
+	manager.Tick(16)
+
 	manager.Reset()
 	assert.Equal(t, 0, manager.destroyedShips)
 	assert.Equal(t, float64(0), manager.gracefulEndTimerMs)
 	assert.Len(t, manager.spaceShips, 2)
 	assert.Len(t, manager.gameObjects, 3)
+	assert.Empty(t, manager.Logger().Logs())
+	assert.Equal(t, uint64(0), manager.CurrentTick())
+	assert.Equal(t, 0.0, manager.ElapsedMs())
+}
+
+func TestGameManager_Clear(t *testing.T) {
+	manager := NewGameManager()
+	calculator := VelocityDamageCalculator{DamagePerSpeed: 1}
+	factory := &MockObjectFactory{}
+	manager.SetDamageCalculator(calculator)
+	manager.SetObjectFactory(factory)
+	manager.limits.asteroids = 1
+	manager.RegisterEndCondition("never", func(*GameManager) bool { return false })
+	var removed []GameObject
+	manager.OnObjectRemoved(func(gameObject GameObject) {
+		removed = append(removed, gameObject)
+	})
+
+	ship := NewSpaceship(1, "Ship", physics.Vector2{X: 0, Y: 0}, 100)
+	asteroid := NewAsteroid(2, physics.Vector2{X: 0, Y: 0}, 5)
+	_ = manager.AddSpaceship(ship)
+	manager.AddGameObject(asteroid)
+	manager.Logger().LogEvent(LogLevelInfo, "hello", 0, nil)
+	manager.Tick(16)
+
+	manager.Clear()
+	assert.Empty(t, manager.GameObjects())
+	assert.Empty(t, manager.Spaceships())
+	_, err := manager.GetSpaceship("Ship")
+	assert.ErrorAs(t, err, &ErrSpaceshipNotFound{})
+	assert.Equal(t, []GameObject{ship, asteroid}, removed)
+	assert.Empty(t, manager.Logger().Logs())
+	assert.Equal(t, uint64(0), manager.CurrentTick())
+	assert.Equal(t, 0.0, manager.ElapsedMs())
+	assert.Equal(t, 0, manager.ProjectilesFired())
+
+	// The settings are kept
+	assert.Equal(t, calculator, manager.DamageCalculator())
+	assert.Equal(t, factory, manager.ObjectFactory())
+	assert.Len(t, manager.endConditions, 1)
+	manager.AddGameObject(NewAsteroid(3, physics.Vector2{X: 0, Y: 0}, 5))
+	accepted, _ := manager.AddGameObject(NewAsteroid(4, physics.Vector2{X: 0, Y: 0}, 5))
+	assert.False(t, accepted)
+
+	// The names are free again
+	assert.NoError(t, manager.AddSpaceship(NewSpaceship(5, "Ship", physics.Vector2{X: 0, Y: 0}, 100)))
+}
+
+func TestTimeLimit(t *testing.T) {
+	manager := NewGameManager()
+	manager.RegisterEndCondition("timeLimit", TimeLimit(1000))
+	manager.Tick(999)
+	assert.False(t, manager.HasEnded(0))
+	manager.Tick(1)
+	assert.True(t, manager.HasEnded(0))
+	assert.Equal(t, "timeLimit", manager.EndReason())
+}
+
+func TestScoreTarget(t *testing.T) {
+	manager := NewGameManager()
+	ship := NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 0)
+	_ = manager.AddSpaceship(ship)
+	manager.RegisterEndCondition("scoreTarget", ScoreTarget(100))
+	assert.False(t, manager.HasEnded(0))
+	ship.AddScore(100)
+	assert.True(t, manager.HasEnded(0))
+	assert.Equal(t, []string{"scoreTarget"}, manager.EndConditions())
 }
@@ -16,3 +16,16 @@ type GameObject interface {
 	OnCollision(other GameObject, gameManager *GameManager, order int)
 	Serialize() map[string]interface{}
 }
+
+// UpdatePrioritizer is optionally implemented by the game objects to be updated
+// before the ones of a lower priority within a tick, the default priority is 0.
+type UpdatePrioritizer interface {
+	UpdatePriority() int
+}
+
+func updatePriority(gameObject GameObject) int {
+	if prioritizer, ok := gameObject.(UpdatePrioritizer); ok {
+		return prioritizer.UpdatePriority()
+	}
+	return 0
+}
@@ -2,7 +2,9 @@ package game
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/davidhorak/space-wars/kernel/physics"
 	"github.com/davidhorak/space-wars/kernel/physics/collider"
@@ -47,12 +49,69 @@ func (object *MockGameObject) Serialize() map[string]interface{} {
 	return nil
 }
 
+type countingCollider struct {
+	*collider.CircleCollider
+	calls *int
+}
+
+func (counting *countingCollider) CollidesWith(other collider.Collider) bool {
+	*counting.calls++
+	if other, ok := other.(*countingCollider); ok {
+		return counting.CircleCollider.CollidesWith(other.CircleCollider)
+	}
+	return counting.CircleCollider.CollidesWith(other)
+}
+
+type countingGameObject struct {
+	MockGameObject
+	collider *countingCollider
+}
+
+func (object *countingGameObject) Update(deltaTimeMs float64, gameManager *GameManager) {}
+
+func (object *countingGameObject) Collider() collider.Collider {
+	return object.collider
+}
+
+func newCountingGameObjects(count int, calls *int) []GameObject {
+	gameObjects := make([]GameObject, count)
+	for i := range gameObjects {
+		// Grid of 10 units apart circles with 2 units radius, no collisions
+		position := physics.Vector2{X: float64(i%25) * 10, Y: float64(i/25) * 10}
+		gameObjects[i] = &countingGameObject{
+			MockGameObject: MockGameObject{position: position},
+			collider:       &countingCollider{CircleCollider: collider.NewCircleCollider(position, 2), calls: calls},
+		}
+	}
+	return gameObjects
+}
+
+type priorityGameObject struct {
+	MockGameObject
+	id       int64
+	priority int
+	update   func()
+}
+
+func (object *priorityGameObject) ID() int64 {
+	return object.id
+}
+
+func (object *priorityGameObject) UpdatePriority() int {
+	return object.priority
+}
+
+func (object *priorityGameObject) Update(deltaTimeMs float64, gameManager *GameManager) {
+	object.update()
+}
+
 func TestNewGame(t *testing.T) {
 	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
 
 	assert.Equal(t, int64(1234567890), game.seed)
 	assert.Equal(t, Initialized, game.status)
 	assert.Equal(t, physics.Size{Width: 1024, Height: 768}, game.size)
+	assert.Equal(t, physics.Size{Width: 1024, Height: 768}, game.manager.Size())
 }
 
 func TestGame_Status(t *testing.T) {
@@ -103,6 +162,23 @@ func TestGame_Reset(t *testing.T) {
 	game.Reset()
 	assert.Equal(t, Running, game.Status())
 	assert.Equal(t, 0, len(game.manager.Logger().Logs()))
+	assert.False(t, game.manager.Logger().Full())
+}
+
+func TestGame_Reset_KeepsSettings(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, WithTimeLimitMs(1000))
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.SetTimeScale(2)
+	game.manager.SetDamageCalculator(VelocityDamageCalculator{DamagePerSpeed: 1})
+	game.Start()
+	game.Update(16)
+
+	game.Reset()
+	assert.Equal(t, 2.0, game.TimeScale())
+	assert.Equal(t, 1000.0, game.TimeLimitMs())
+	assert.Equal(t, VelocityDamageCalculator{DamagePerSpeed: 1}, game.manager.DamageCalculator())
+	assert.Equal(t, uint64(0), game.manager.CurrentTick())
+	assert.Len(t, game.manager.Spaceships(), 1)
 }
 
 func TestGame_Update(t *testing.T) {
@@ -148,7 +224,7 @@ func TestGame_Update(t *testing.T) {
 		game.Update(100)
 
 		assert.False(t, spaceship.Enabled())
-		assert.Equal(t, float64(0), spaceship.health)
+		assert.Equal(t, float64(0), spaceship.Health())
 		assert.True(t, asteroid.Enabled())
 	})
 
@@ -162,7 +238,7 @@ func TestGame_Update(t *testing.T) {
 		game.Update(100)
 
 		assert.True(t, spaceship.Enabled())
-		assert.Equal(t, float64(100), spaceship.health)
+		assert.Equal(t, float64(100), spaceship.Health())
 		assert.False(t, asteroid.Enabled())
 	})
 
@@ -176,10 +252,20 @@ func TestGame_Update(t *testing.T) {
 
 		game.Update(100)
 
-		assert.Equal(t, float64(100), spaceship.health)
+		assert.Equal(t, float64(100), spaceship.Health())
 		assert.True(t, asteroid.Enabled())
 	})
 
+	t.Run("Skips the exact check for distant colliders", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+		calls := 0
+		game.manager.AddGameObjects(newCountingGameObjects(500, &calls))
+
+		game.Update(10)
+
+		assert.Equal(t, 0, calls)
+	})
+
 	t.Run("Game ends when manager ends", func(t *testing.T) {
 		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
 		game.Start()
@@ -191,11 +277,469 @@ func TestGame_Update(t *testing.T) {
 	})
 }
 
+func TestGame_Update_TickMs(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game.Update(100)
+	game.Update(50)
+	game.manager.Logger().LogEvent(LogLevelInfo, "test", 0, nil)
+
+	assert.Equal(t, 150.0, game.manager.ElapsedMs())
+	logs := game.manager.Logger().Logs()
+	assert.Equal(t, 150.0, logs[len(logs)-1].tickMs)
+
+	game.Reset()
+	assert.Equal(t, 0.0, game.manager.ElapsedMs())
+}
+
+func TestGame_StatusChan(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	statuses := game.StatusChan()
+
+	game.Start()
+	assert.Equal(t, Running, <-statuses)
+	game.Start()
+	game.Pause()
+	assert.Equal(t, Paused, <-statuses)
+	game.Reset()
+	assert.Equal(t, Paused, <-statuses)
+	game.Start()
+	assert.Equal(t, Running, <-statuses)
+
+	// Ends as a single spaceship is left
+	game.Update(16)
+	game.Update(16)
+	assert.Equal(t, Ended, <-statuses)
+	assert.Empty(t, statuses)
+
+	game.Close()
+	_, open := <-statuses
+	assert.False(t, open)
+
+	// No emit after Close
+	game.Reset()
+	game.Close()
+}
+
+func TestGame_StatusChan_SlowConsumer(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			game.Start()
+			game.Pause()
+		}
+		game.Start()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the game is blocked by the status channel")
+	}
+
+	// The oldest statuses are dropped
+	statuses := game.StatusChan()
+	assert.Equal(t, StatusChanSize, len(statuses))
+	assert.Equal(t, Paused, <-statuses)
+	assert.Equal(t, Running, <-statuses)
+	assert.Equal(t, Paused, <-statuses)
+	assert.Equal(t, Running, <-statuses)
+}
+
+func TestGame_SetTimeScale(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	assert.Equal(t, 1.0, game.TimeScale())
+
+	game.SetTimeScale(0.5)
+	assert.Equal(t, 0.5, game.TimeScale())
+
+	// Clamped to (0, 10]
+	game.SetTimeScale(20)
+	assert.Equal(t, float64(MaxTimeScale), game.TimeScale())
+	game.SetTimeScale(0)
+	assert.Equal(t, MinTimeScale, game.TimeScale())
+	game.SetTimeScale(-1)
+	assert.Equal(t, MinTimeScale, game.TimeScale())
+}
+
+func TestGame_SettingsAndActions_Concurrent(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.Start()
+
+	// Run with -race, the server goroutines change the settings and the actions while the game updates
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 500; i++ {
+			game.Update(1)
+		}
+	}()
+	for i := 0; i < 500; i++ {
+		game.SetTimeScale(float64(i%4 + 1))
+		_ = game.TimeScale()
+		assert.NoError(t, game.QueueSpaceshipAction("alpha", func(spaceShip *Spaceship, gameManager *GameManager) {
+			spaceShip.SetEngineThrust(50, 0, 0)
+		}))
+		assert.NoError(t, game.SpaceshipAction("alpha", func(spaceShip *Spaceship, gameManager *GameManager) {
+			spaceShip.score++
+		}))
+	}
+	<-done
+
+	alpha, _ := game.manager.GetSpaceship("alpha")
+	assert.Equal(t, 500.0, alpha.score)
+}
+
+func TestGame_SetFixedStep(t *testing.T) {
+	newGame := func() *Game {
+		game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+		game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+		game.AddSpaceship("beta", physics.Vector2{X: 500, Y: 500}, 0)
+		game.SpaceshipAction("alpha", func(spaceShip *Spaceship, gameManager *GameManager) {
+			spaceShip.SetEngineThrust(100, 30, 0)
+		})
+		game.Start()
+		assert.NoError(t, game.SetFixedStep(10))
+		return game
+	}
+
+	t.Run("Same simulation regardless of the caller cadence", func(t *testing.T) {
+		once, split := newGame(), newGame()
+		once.Update(100)
+		for _, deltaTimeMs := range []float64{7, 13, 30, 0, 50} {
+			split.Update(deltaTimeMs)
+		}
+
+		assert.Equal(t, uint64(10), once.manager.CurrentTick())
+		assert.Equal(t, uint64(10), split.manager.CurrentTick())
+		assert.Equal(t, once.StateHash(), split.StateHash())
+	})
+
+	t.Run("Carries the remainder over", func(t *testing.T) {
+		game := newGame()
+		game.Update(25)
+		assert.Equal(t, uint64(2), game.manager.CurrentTick())
+		assert.InDelta(t, 5.0, game.AccumulatorMs(), 1e-9)
+		game.Update(-25)
+		assert.Equal(t, uint64(2), game.manager.CurrentTick())
+		game.Update(30)
+		assert.Equal(t, uint64(3), game.manager.CurrentTick())
+
+		game.Reset()
+		assert.Equal(t, 0.0, game.AccumulatorMs())
+	})
+
+	t.Run("Tolerates the float rounding", func(t *testing.T) {
+		game := newGame()
+		assert.NoError(t, game.SetFixedStep(16.67))
+		for i := 0; i < 3; i++ {
+			game.Update(16.67)
+		}
+		assert.Equal(t, uint64(3), game.manager.CurrentTick())
+		assert.InDelta(t, 50.01, game.manager.ElapsedMs(), 1e-9)
+	})
+
+	t.Run("Applies the time scale before accumulating", func(t *testing.T) {
+		game := newGame()
+		game.SetTimeScale(2)
+		game.Update(50)
+		assert.Equal(t, uint64(10), game.manager.CurrentTick())
+	})
+
+	t.Run("Stops once the game ends", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, WithTimeLimitMs(50))
+		game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+		game.AddSpaceship("beta", physics.Vector2{X: 500, Y: 500}, 0)
+		game.Start()
+		game.SetFixedStep(10)
+		game.Update(200)
+
+		assert.Equal(t, Ended, game.Status())
+		assert.Equal(t, uint64(5), game.manager.CurrentTick())
+		assert.Equal(t, 0.0, game.AccumulatorMs())
+		assert.Equal(t, "timeLimitReached", game.EndReason())
+	})
+
+	t.Run("Zero restores the variable step", func(t *testing.T) {
+		game := newGame()
+		assert.Error(t, game.SetFixedStep(-1))
+		assert.Equal(t, 10.0, game.FixedStepMs())
+		assert.NoError(t, game.SetFixedStep(0))
+		game.Update(25)
+		assert.Equal(t, uint64(1), game.manager.CurrentTick())
+		assert.Equal(t, 25.0, game.manager.ElapsedMs())
+	})
+}
+
+func TestGame_Update_TimeScale(t *testing.T) {
+	newGame := func(timeScale float64) (*Game, *Projectile) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+		game.SetTimeScale(timeScale)
+		owner := NewSpaceship(1, "owner", physics.Vector2{X: 900, Y: 900}, 0)
+		projectile := NewProjectile(physics.Vector2{X: 100, Y: 100}, physics.Vector2{X: 30, Y: 20}, 0, 100, 10, owner)
+		game.manager.AddGameObject(projectile)
+		return game, projectile
+	}
+
+	fastGame, fastProjectile := newGame(2)
+	for i := 0; i < 10; i++ {
+		fastGame.Update(16)
+	}
+	realTimeGame, realTimeProjectile := newGame(1)
+	for i := 0; i < 20; i++ {
+		realTimeGame.Update(16)
+	}
+
+	assert.InDelta(t, realTimeProjectile.position.X, fastProjectile.position.X, 1e-9)
+	assert.InDelta(t, realTimeProjectile.position.Y, fastProjectile.position.Y, 1e-9)
+	assert.Equal(t, realTimeGame.manager.ElapsedMs(), fastGame.manager.ElapsedMs())
+}
+
+func TestGame_WithTimeLimitMs(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithTimeLimitMs(1000))
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 500, Y: 500}, 0)
+	beta, _ := game.manager.GetSpaceship("beta")
+	beta.AddScore(10)
+	game.Start()
+	assert.Equal(t, 1000.0, game.TimeLimitMs())
+
+	for i := 0; i < 62; i++ {
+		game.Update(16)
+	}
+	assert.Equal(t, Running, game.Status())
+
+	// Ends within one tick of the limit
+	game.Update(16)
+	assert.Equal(t, Ended, game.Status())
+	assert.Equal(t, 1008.0, game.manager.ElapsedMs())
+
+	winner, err := game.Winner()
+	assert.NoError(t, err)
+	assert.Equal(t, "beta", winner)
+
+	serialized := game.Serialize()
+	assert.Equal(t, 1000.0, serialized["timeLimitMs"])
+	assert.Equal(t, 1008.0, serialized["elapsedMs"])
+}
+
+func TestGame_Update_EndConditionLogged(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 500, Y: 500}, 0)
+	game.manager.RegisterEndCondition("scoreReached", func(manager *GameManager) bool {
+		return manager.SpaceshipsByScore()[0].Score() >= 100
+	})
+	game.Start()
+
+	game.Update(16)
+	assert.Equal(t, Running, game.Status())
+
+	alpha, _ := game.manager.GetSpaceship("alpha")
+	alpha.AddScore(100)
+	game.Update(16)
+	assert.Equal(t, Ended, game.Status())
+
+	logs := game.manager.Logger().Logs()
+	assert.Equal(t, "Game state changed to: ended (scoreReached)", logs[len(logs)-1].message)
+}
+
+func TestGame_WithoutTimeLimit(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 500, Y: 500}, 0)
+	game.Start()
+
+	for i := 0; i < 1000; i++ {
+		game.Update(100)
+	}
+	assert.Equal(t, Running, game.Status())
+	assert.Equal(t, 0.0, game.Serialize()["timeLimitMs"])
+}
+
+func TestGame_Winner_NoSpaceships(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+
+	_, err := game.Winner()
+	assert.Error(t, err)
+}
+
+func TestGame_Summary(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithTimeLimitMs(160))
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 500, Y: 500}, 0)
+	game.AddSpaceship("gamma", physics.Vector2{X: 900, Y: 900}, 0)
+	asteroid := NewAsteroid(NewUUID(), physics.Vector2{X: 300, Y: 700}, 10)
+	game.manager.AddGameObject(asteroid)
+	alpha, _ := game.manager.GetSpaceship("alpha")
+	beta, _ := game.manager.GetSpaceship("beta")
+	gamma, _ := game.manager.GetSpaceship("gamma")
+	game.Start()
+
+	// beta wins on the score, gamma has the most kills
+	beta.AddScore(500)
+	gamma.HasKilled(alpha)
+	gamma.HasKilled(beta)
+	assert.NoError(t, alpha.FireLaser(&game.manager))
+	game.manager.RemoveGameObject(asteroid)
+
+	for game.Status() != Ended {
+		game.Update(16)
+	}
+
+	summary, err := game.Summary()
+	assert.NoError(t, err)
+	assert.Equal(t, "beta", summary.Winner)
+	assert.Equal(t, "gamma", summary.MVPSpaceship)
+	assert.Equal(t, 160.0, summary.Duration)
+	assert.Equal(t, 10, summary.Rounds)
+	assert.Equal(t, map[string]int64{
+		"alpha": 0,
+		"beta":  500,
+		"gamma": int64(2 * ScorePerKill),
+	}, summary.FinalScores)
+	assert.Equal(t, 1, summary.TotalAsteroidsDestroyed)
+	assert.Equal(t, 1, summary.TotalProjectilesFired)
+}
+
+func TestGame_Summary_NotEnded(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 500, Y: 500}, 0)
+	game.Start()
+	game.Update(16)
+
+	_, err := game.Summary()
+	assert.ErrorIs(t, err, ErrGameNotEnded)
+}
+
+func TestGame_Rand_Deterministic(t *testing.T) {
+	type draws struct {
+		asteroids []physics.Vector2
+		placement physics.Vector2
+		waveMs    float64
+	}
+	play := func(game *Game) draws {
+		game.SeedAsteroids()
+		var result draws
+		for _, asteroid := range game.manager.Asteroids() {
+			result.asteroids = append(result.asteroids, asteroid.Position())
+		}
+		random := game.manager.Rand()
+		result.placement = physics.Vector2{X: random.Float64() * game.size.Width, Y: random.Float64() * game.size.Height}
+		result.waveMs = 1000 + random.Float64()*5000
+		return result
+	}
+
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	first := play(game)
+	second := play(NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890))
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, play(NewGame(physics.Size{Width: 1024, Height: 768}, 42)))
+
+	// Reset replays the same stream
+	game.Reset()
+	random := game.manager.Rand()
+	rand := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890).manager.Rand()
+	assert.Equal(t, rand.Int63(), random.Int63())
+}
+
+func TestGame_Update_Priority(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	var order []int64
+	var observed physics.Vector2
+	leader := &priorityGameObject{id: 1, priority: 10}
+	follower := &priorityGameObject{id: 2, priority: 0}
+	leader.update = func() {
+		order = append(order, leader.id)
+		leader.position = leader.position.Add(physics.Vector2{X: 10, Y: 0})
+	}
+	follower.update = func() {
+		order = append(order, follower.id)
+		observed = leader.position
+	}
+
+	// Inserted after the follower, updated before it
+	game.manager.AddGameObject(follower)
+	game.manager.AddGameObject(leader)
+	game.Update(16)
+
+	assert.Equal(t, []int64{1, 2}, order)
+	assert.Equal(t, physics.Vector2{X: 10, Y: 0}, observed)
+}
+
 func TestGame_SeedAsteroids(t *testing.T) {
 	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
 	game.SeedAsteroids()
 
 	assert.GreaterOrEqual(t, len(game.manager.GameObjects()), MinAsteroids)
+
+	// The same seed places the same asteroids
+	other := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	other.SeedAsteroids()
+	assert.Equal(t, len(game.manager.GameObjects()), len(other.manager.GameObjects()))
+	for i, gameObject := range game.manager.GameObjects() {
+		assert.Equal(t, gameObject.Position(), other.manager.GameObjects()[i].Position())
+	}
+}
+
+func TestGame_Start_InitialObjectCount(t *testing.T) {
+	newGame := func() *Game {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+		assert.NoError(t, game.manager.SetInitialObjectCount("asteroid", 20))
+		return game
+	}
+	asteroids := func(game *Game) []GameObject {
+		return game.manager.Query(func(gameObject GameObject) bool {
+			_, ok := gameObject.(*Asteroid)
+			return ok
+		})
+	}
+
+	game := newGame()
+	assert.Empty(t, asteroids(game))
+
+	game.Start()
+	assert.Len(t, asteroids(game), 20)
+
+	// Resuming does not spawn again
+	game.Pause()
+	game.Start()
+	assert.Len(t, asteroids(game), 20)
+
+	// The same seed spawns the same asteroids
+	other := newGame()
+	other.Start()
+	for i, asteroid := range asteroids(game) {
+		assert.Equal(t, asteroid.Position(), asteroids(other)[i].Position())
+		assert.Equal(t, asteroid.(*Asteroid).radius, asteroids(other)[i].(*Asteroid).radius)
+	}
+}
+
+func TestGame_WithAsteroidLayout(t *testing.T) {
+	layout := []AsteroidSpec{
+		{Position: physics.Vector2{X: 150, Y: 100}, Radius: 50},
+		{Position: physics.Vector2{X: 600, Y: 700}, Radius: 20},
+	}
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithAsteroidLayout(layout))
+	layout[0].Radius = 10 // The option keeps its own copy
+	game.SeedAsteroids()
+
+	gameObjects := game.manager.GameObjects()
+	assert.Len(t, gameObjects, 2)
+	assert.Equal(t, physics.Vector2{X: 150, Y: 100}, gameObjects[0].Position())
+	assert.Equal(t, 50.0, gameObjects[0].(*Asteroid).radius)
+	assert.Equal(t, physics.Vector2{X: 600, Y: 700}, gameObjects[1].Position())
+	assert.Equal(t, 20.0, gameObjects[1].(*Asteroid).radius)
+
+	// An empty layout places no asteroids
+	game = NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithAsteroidLayout([]AsteroidSpec{}))
+	game.SeedAsteroids()
+	assert.Empty(t, game.manager.GameObjects())
 }
 
 func TestGame_SpaceshipAction(t *testing.T) {
@@ -211,10 +755,100 @@ func TestGame_SpaceshipAction(t *testing.T) {
 	assert.Equal(t, physics.Vector2{X: 200, Y: 200}, spaceship.position)
 
 	// Spaceship not found
+	called := false
 	err = game.SpaceshipAction("test1", func(spaceShip *Spaceship, gameManager *GameManager) {
-		spaceShip.position = physics.Vector2{X: 200, Y: 200}
+		called = true
+	})
+	assert.Equal(t, ErrSpaceshipNotFound{Name: "test1"}, err)
+	assert.EqualError(t, err, "space ship not found: test1")
+	assert.False(t, called)
+}
+
+func TestGame_BatchSpaceshipActions(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 300, Y: 100}, 0)
+	alpha, _ := game.manager.GetSpaceship("alpha")
+	beta, _ := game.manager.GetSpaceship("beta")
+
+	// Every Update observes either none or all of the batched actions
+	partial := false
+	game.AddBot("alpha", BotStrategyFunc(func(spaceShip *Spaceship, gameManager *GameManager, deltaTimeMs float64) {
+		if alpha.score != beta.score {
+			partial = true
+		}
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			game.Update(1)
+		}
+	}()
+	for i := 1; i <= 1000; i++ {
+		score := float64(i)
+		err := game.BatchSpaceshipActions([]NamedAction{
+			{Name: "alpha", Action: func(spaceShip *Spaceship, gameManager *GameManager) { spaceShip.score = score }},
+			{Name: "beta", Action: func(spaceShip *Spaceship, gameManager *GameManager) { spaceShip.score = score }},
+		})
+		assert.NoError(t, err)
+	}
+	<-done
+	assert.False(t, partial)
+	assert.Equal(t, 1000.0, alpha.score)
+	assert.Equal(t, 1000.0, beta.score)
+
+	// Unknown spaceships
+	applied := false
+	err := game.BatchSpaceshipActions([]NamedAction{
+		{Name: "alpha", Action: func(spaceShip *Spaceship, gameManager *GameManager) { applied = true }},
+		{Name: "gamma", Action: func(spaceShip *Spaceship, gameManager *GameManager) {}},
+		{Name: "delta", Action: func(spaceShip *Spaceship, gameManager *GameManager) {}},
 	})
 	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "gamma")
+	assert.Contains(t, err.Error(), "delta")
+	assert.False(t, applied)
+}
+
+func TestGame_HasSpaceship(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+
+	assert.True(t, game.HasSpaceship("alpha"))
+	assert.False(t, game.HasSpaceship("beta"))
+
+	game.SpaceshipAction("alpha", func(spaceShip *Spaceship, gameManager *GameManager) {
+		spaceShip.TakeDamage(MaxHealth, gameManager, nil)
+	})
+	assert.True(t, game.HasSpaceship("alpha"))
+}
+
+func TestGame_SpaceshipActionAll(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 300, Y: 100}, 0)
+	beta, _ := game.manager.GetSpaceship("beta")
+	beta.SetEnabled(false)
+
+	var names []string
+	err := game.SpaceshipActionAll(func(spaceShip *Spaceship, gameManager *GameManager) error {
+		names = append(names, spaceShip.name)
+		spaceShip.energy = 10
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alpha", "beta"}, names)
+	assert.Equal(t, 10.0, beta.energy)
+
+	// Errors are collected
+	err = game.SpaceshipActionAll(func(spaceShip *Spaceship, gameManager *GameManager) error {
+		return fmt.Errorf("out of fuel")
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "alpha: out of fuel")
+	assert.Contains(t, err.Error(), "beta: out of fuel")
 }
 
 func TestGame_AddSpaceship(t *testing.T) {
@@ -225,16 +859,32 @@ func TestGame_AddSpaceship(t *testing.T) {
 	spaceship := gameObjects[len(gameObjects)-1].(*Spaceship)
 	assert.IsType(t, &Spaceship{}, spaceship)
 	assert.Equal(t, "test", spaceship.name)
+
+	// Duplicate name
+	err := game.AddSpaceship("test", physics.Vector2{X: 500, Y: 500}, 0)
+	assert.Equal(t, ErrDuplicateSpaceshipName{Name: "test"}, err)
+	assert.Len(t, game.manager.GameObjects(), len(gameObjects))
+
+	// Destroyed spaceships keep their names
+	spaceship.TakeDamage(100, &game.manager, nil)
+	assert.False(t, spaceship.Enabled())
+	count := game.manager.GameObjectSize()
+	err = game.AddSpaceship("test", physics.Vector2{X: 500, Y: 500}, 0)
+	assert.ErrorAs(t, err, &ErrDuplicateSpaceshipName{})
+	assert.Equal(t, count, game.manager.GameObjectSize())
 }
 
 func TestGame_RemoveSpaceship(t *testing.T) {
 	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
 	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0)
 
-	game.RemoveSpaceship("test")
+	assert.NoError(t, game.RemoveSpaceship("test"))
 
 	gameObjects := game.manager.GameObjects()
 	assert.Equal(t, 0, len(gameObjects))
+
+	err := game.RemoveSpaceship("test")
+	assert.Equal(t, ErrSpaceshipNotFound{Name: "test"}, err)
 }
 
 func TestGame_Serialize(t *testing.T) {
@@ -250,6 +900,34 @@ func TestGame_Serialize(t *testing.T) {
 	assert.Equal(t, 768.0, serialized["size"].(map[string]interface{})["height"])
 	assert.GreaterOrEqual(t, len(serialized["gameObjects"].([]interface{})), MinAsteroids)
 	assert.Equal(t, 1, len(serialized["logs"].([]interface{})))
+	assert.Equal(t, 1.0, serialized["timeScale"])
+}
+
+func TestGame_Serialize_ManagerSuperset(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game.SeedAsteroids()
+	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0)
+	game.Start()
+	game.Update(16)
+
+	serialized := game.Serialize()
+	for key, value := range game.manager.Serialize() {
+		assert.Contains(t, serialized, key)
+		assert.Equal(t, value, serialized[key], key)
+	}
+}
+
+func TestGame_Serialize_Timestamp(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+
+	before := time.Now().UnixNano()
+	first := game.Serialize()["timestamp"].(int64)
+	second := game.Serialize()["timestamp"].(int64)
+	after := time.Now().UnixNano()
+
+	assert.GreaterOrEqual(t, first, before)
+	assert.LessOrEqual(t, first, after)
+	assert.GreaterOrEqual(t, second, first)
 }
 
 func TestDeserialize_InvalidJSON(t *testing.T) {
@@ -266,8 +944,9 @@ func TestDeserialize(t *testing.T) {
 	game.SpaceshipAction("test", func(spaceShip *Spaceship, gameManager *GameManager) {
 		spaceShip.FireLaser(gameManager)
 		spaceShip.FireRocket(gameManager)
+		spaceShip.TakeZoneDamage(HullZoneRear, 30, gameManager, nil)
 	})
-	game.manager.Logger().AddMessage(Message{
+	game.manager.Logger().AddMessage(LogEntry{
 		id:      NewUUID(),
 		message: "test",
 		meta:    map[string]interface{}{},
@@ -314,5 +993,180 @@ func TestDeserialize(t *testing.T) {
 	assert.Equal(t, game.status, deserialized.status)
 	assert.Equal(t, len(game.manager.GameObjects()), len(deserialized.manager.GameObjects()))
 	assert.Equal(t, len(game.manager.Logger().Logs()), len(deserialized.manager.Logger().Logs()))
+	assert.Equal(t, game.manager.Logger().Logs()[0].level, deserialized.manager.Logger().Logs()[0].level)
+	deserializedShip, err := deserialized.manager.GetSpaceship("test")
+	assert.NoError(t, err)
+	assert.Equal(t, [4]float64{100, 70, 100, 100}, deserializedShip.HullZones())
 	assert.Equal(t, GetUUID(), uuid)
 }
+
+func TestDeserializeMap(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, WithTimeLimitMs(60000))
+	game.SeedAsteroids()
+	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("other", physics.Vector2{X: 500, Y: 500}, 0)
+	game.SetTimeScale(2)
+	game.Start()
+	game.SpaceshipAction("test", func(spaceShip *Spaceship, gameManager *GameManager) {
+		spaceShip.SetEngineThrust(100, 0, 0)
+		spaceShip.FireLaser(gameManager)
+	})
+	game.Update(16)
+
+	serialized := game.Serialize()
+	assert.Equal(t, StateVersion, serialized["schemaVersion"])
+
+	// The Serialize output is accepted as is, without a JSON round trip
+	deserialized, err := DeserializeMap(game.Serialize())
+	assert.NoError(t, err)
+	restored := deserialized.Serialize()
+	for _, key := range []string{"gameObjects", "logs", "tick", "elapsedMs", "status", "seed", "size", "timeScale", "timeLimitMs", "schemaVersion"} {
+		assert.Equal(t, serialized[key], restored[key], key)
+	}
+
+	t.Run("Invalid state", func(t *testing.T) {
+		serialized := game.Serialize()
+		delete(serialized, "size")
+		_, err := DeserializeMap(serialized)
+		assert.Equal(t, ErrInvalidState{Field: "size"}, err)
+
+		serialized = game.Serialize()
+		gameObjects := serialized["gameObjects"].([]interface{})
+		delete(gameObjects[len(gameObjects)-1].(map[string]interface{}), "position")
+		_, err = DeserializeMap(serialized)
+		assert.Equal(t, ErrInvalidState{Field: "position"}, err)
+	})
+
+	t.Run("Incompatible schema version", func(t *testing.T) {
+		serialized := game.Serialize()
+		serialized["schemaVersion"] = StateVersion + 1
+		_, err := DeserializeMap(serialized)
+		assert.Equal(t, ErrIncompatibleVersion{Version: StateVersion + 1, CurrentVersion: StateVersion}, err)
+	})
+}
+
+func TestGame_QueueSpaceshipAction(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0)
+
+	err := game.QueueSpaceshipAction("test", func(spaceShip *Spaceship, gameManager *GameManager) {
+		spaceShip.startPosition = physics.Vector2{X: 200, Y: 200}
+	})
+	assert.NoError(t, err)
+
+	spaceship, err := game.manager.GetSpaceship("test")
+	assert.NoError(t, err)
+	assert.Equal(t, physics.Vector2{X: 100, Y: 100}, spaceship.startPosition)
+	assert.Len(t, game.actionQueue, 1)
+
+	game.Update(10)
+	assert.Equal(t, physics.Vector2{X: 200, Y: 200}, spaceship.startPosition)
+	assert.Empty(t, game.actionQueue)
+
+	// Spaceship not found
+	err = game.QueueSpaceshipAction("test1", func(spaceShip *Spaceship, gameManager *GameManager) {})
+	assert.Error(t, err)
+	assert.Empty(t, game.actionQueue)
+}
+
+func TestGame_QueueSpaceshipAction_AppliedBeforeUpdate(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0)
+
+	game.QueueSpaceshipAction("test", func(spaceShip *Spaceship, gameManager *GameManager) {
+		spaceShip.SetEngineThrust(100, 0, 0)
+	})
+
+	spaceship, _ := game.manager.GetSpaceship("test")
+	game.Update(100)
+
+	// The thrust was applied before the ship was moved within the same tick.
+	assert.Greater(t, spaceship.position.X, float64(100))
+}
+
+func TestGame_AddSpaceshipWithConfig(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+
+	spaceship, err := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "test", Position: physics.Vector2{X: 100, Y: 100}, Rotation: 1})
+	assert.NoError(t, err)
+	assert.NotNil(t, spaceship)
+	assert.Equal(t, "test", spaceship.name)
+	assert.Equal(t, physics.Vector2{X: 100, Y: 100}, spaceship.position)
+	assert.Equal(t, float64(1), spaceship.rotation)
+
+	spaceship, err = game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "test"})
+	assert.ErrorIs(t, err, ErrDuplicateSpaceshipName{Name: "test"})
+	assert.Nil(t, spaceship)
+}
+
+func TestGame_AddSpaceshipWithBot(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	updates := 0
+	strategy := BotStrategyFunc(func(spaceship *Spaceship, gameManager *GameManager, deltaTimeMs float64) {
+		updates++
+		spaceship.SetEngineThrust(100, 0, 0)
+	})
+
+	spaceship, err := game.AddSpaceshipWithBot(SpaceshipConfig{Name: "bot", Position: physics.Vector2{X: 100, Y: 100}}, strategy)
+	assert.NoError(t, err)
+	assert.NotNil(t, spaceship)
+	assert.Equal(t, 0, updates)
+
+	game.Update(100)
+	assert.Equal(t, 1, updates)
+	assert.Greater(t, spaceship.position.X, float64(100))
+
+	// Duplicate name
+	_, err = game.AddSpaceshipWithBot(SpaceshipConfig{Name: "bot"}, strategy)
+	assert.ErrorIs(t, err, ErrDuplicateSpaceshipName{Name: "bot"})
+	assert.Len(t, game.bots, 1)
+
+	// Removing the spaceship removes the bot
+	err = game.RemoveSpaceship("bot")
+	assert.NoError(t, err)
+	assert.Empty(t, game.bots)
+	game.Update(100)
+	assert.Equal(t, 1, updates)
+}
+
+func TestGame_AddBot(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0)
+	calls := []string{}
+
+	err := game.AddBot("test", BotStrategyFunc(func(spaceship *Spaceship, gameManager *GameManager, deltaTimeMs float64) {
+		calls = append(calls, "first")
+	}))
+	assert.NoError(t, err)
+
+	// Replaces the previous strategy
+	err = game.AddBot("test", BotStrategyFunc(func(spaceship *Spaceship, gameManager *GameManager, deltaTimeMs float64) {
+		calls = append(calls, "second")
+	}))
+	assert.NoError(t, err)
+	assert.Len(t, game.bots, 1)
+
+	game.Update(10)
+	assert.Equal(t, []string{"second"}, calls)
+
+	// Disabled spaceships are skipped
+	spaceship, _ := game.manager.GetSpaceship("test")
+	spaceship.SetEnabled(false)
+	game.Update(10)
+	assert.Equal(t, []string{"second"}, calls)
+
+	err = game.AddBot("unknown", BotStrategyFunc(func(spaceship *Spaceship, gameManager *GameManager, deltaTimeMs float64) {}))
+	assert.Error(t, err)
+}
+
+func BenchmarkGame_Update_Collisions(b *testing.B) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	calls := 0
+	game.manager.AddGameObjects(newCountingGameObjects(500, &calls))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		game.Update(10)
+	}
+	b.ReportMetric(float64(calls)/float64(b.N), "narrowphase/op")
+}
@@ -0,0 +1,90 @@
+package game
+
+// IDGenerator allocates the ids of the game objects and the log entries spawned by the game.
+type IDGenerator interface {
+	// NextID allocates the next id, greater than all the allocated and observed ones.
+	NextID() int64
+	// LastID returns the greatest allocated or observed id.
+	LastID() int64
+	// Observe makes the following ids greater than the id, e.g. of the objects added with their own ids.
+	Observe(id int64)
+	// SetLastID restarts the allocation after the id, e.g. restoring a serialized game.
+	SetLastID(id int64)
+}
+
+// SequentialIDs allocates the ids of a single game counting up from 1, so the games of the same
+// seed and inputs get the same ids, regardless of the other games run in the process.
+type SequentialIDs struct {
+	last int64
+}
+
+func NewSequentialIDs() *SequentialIDs {
+	return &SequentialIDs{}
+}
+
+func (ids *SequentialIDs) NextID() int64 {
+	ids.last++
+	return ids.last
+}
+
+func (ids *SequentialIDs) LastID() int64 {
+	return ids.last
+}
+
+func (ids *SequentialIDs) Observe(id int64) {
+	ids.last = max(ids.last, id)
+}
+
+func (ids *SequentialIDs) SetLastID(id int64) {
+	ids.last = id
+}
+
+// GlobalIDs allocates the ids from the process wide NewUUID counter shared by all the games,
+// unique across the games but dependent on the order they run in.
+type GlobalIDs struct{}
+
+func (ids GlobalIDs) NextID() int64 {
+	return NewUUID()
+}
+
+func (ids GlobalIDs) LastID() int64 {
+	return GetUUID()
+}
+
+func (ids GlobalIDs) Observe(id int64) {
+	SetUUID(max(GetUUID(), id))
+}
+
+func (ids GlobalIDs) SetLastID(id int64) {
+	SetUUID(id)
+}
+
+// WithIDGenerator replaces the game's SequentialIDs, e.g. by the GlobalIDs.
+func WithIDGenerator(ids IDGenerator) GameOption {
+	return func(game *Game) {
+		game.manager.SetIDGenerator(ids)
+	}
+}
+
+// NewID allocates the id of a game object spawned into the game.
+func (manager *GameManager) NewID() int64 {
+	return manager.ids.NextID()
+}
+
+// IDGenerator returns the allocator of the game object ids, SequentialIDs unless set otherwise.
+func (manager *GameManager) IDGenerator() IDGenerator {
+	return manager.ids
+}
+
+// SetIDGenerator replaces the allocator of the game object and the log entry ids, nil restores
+// a new SequentialIDs. The ids of the current game objects are observed.
+func (manager *GameManager) SetIDGenerator(ids IDGenerator) {
+	if ids == nil {
+		ids = NewSequentialIDs()
+	}
+	manager.ids = ids
+	manager.logger.SetIDGenerator(ids)
+	for _, gameObject := range manager.gameObjects {
+		ids.Observe(gameObject.ID())
+	}
+}
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequentialIDs(t *testing.T) {
+	ids := NewSequentialIDs()
+	assert.Equal(t, int64(1), ids.NextID())
+	assert.Equal(t, int64(2), ids.NextID())
+	assert.Equal(t, int64(2), ids.LastID())
+
+	ids.Observe(10)
+	ids.Observe(5)
+	assert.Equal(t, int64(11), ids.NextID())
+
+	ids.SetLastID(3)
+	assert.Equal(t, int64(4), ids.NextID())
+}
+
+func TestGlobalIDs(t *testing.T) {
+	ResetUUID()
+	ids := GlobalIDs{}
+	assert.Equal(t, int64(1), ids.NextID())
+	assert.Equal(t, int64(2), NewUUID())
+	assert.Equal(t, int64(2), ids.LastID())
+
+	ids.Observe(1)
+	assert.Equal(t, int64(2), GetUUID())
+	ids.SetLastID(1)
+	assert.Equal(t, int64(2), ids.NextID())
+}
+
+func TestGameManager_NewID(t *testing.T) {
+	manager := NewGameManager()
+	_, _ = manager.AddGameObject(NewAsteroid(7, physics.Vector2{X: 0, Y: 0}, 10))
+	// Past the ids of the added objects
+	assert.Equal(t, int64(8), manager.NewID())
+	manager.Logger().LogEvent(LogLevelInfo, "test", 0, nil)
+	assert.Equal(t, int64(9), manager.Logger().Logs()[0].ID())
+
+	t.Run("SetIDGenerator", func(t *testing.T) {
+		ResetUUID()
+		manager.SetIDGenerator(GlobalIDs{})
+		assert.Equal(t, int64(7), GetUUID())
+		assert.Equal(t, int64(8), manager.NewID())
+		manager.Logger().LogEvent(LogLevelInfo, "test", 0, nil)
+		assert.Equal(t, int64(9), GetUUID())
+
+		manager.SetIDGenerator(nil)
+		assert.IsType(t, &SequentialIDs{}, manager.IDGenerator())
+		assert.Equal(t, int64(8), manager.NewID())
+	})
+}
+
+func TestGame_DeterministicIDs(t *testing.T) {
+	play := func() string {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithPickups(500, 1000))
+		game.SeedAsteroids()
+		_, _ = game.AddSpaceshipWithBot(SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 500}}, NewBotStrategy(ChaserBot{}))
+		_, _ = game.AddSpaceshipWithBot(SpaceshipConfig{Name: "beta", Position: physics.Vector2{X: 900, Y: 500}, Rotation: 3.14}, NewBotStrategy(ChaserBot{}))
+		game.Start()
+		for i := 0; i < 300; i++ {
+			game.Update(16)
+		}
+		serialized := game.Serialize()
+		// The wall clock times differ
+		delete(serialized, "timestamp")
+		for _, log := range serialized["logs"].([]interface{}) {
+			delete(log.(map[string]interface{}), "time")
+		}
+		data, _ := json.Marshal(serialized)
+		return string(data)
+	}
+
+	first := play()
+	// Unaffected by the ids allocated in between
+	NewUUID()
+	NewGame(physics.Size{Width: 1000, Height: 1000}, 1).AddSpaceship("other", physics.Vector2{X: 0, Y: 0}, 0)
+	assert.Equal(t, first, play())
+
+	t.Run("Restored", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+		game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+		game.AddSpaceship("beta", physics.Vector2{X: 900, Y: 900}, 0)
+		game.Start()
+		data, _ := json.Marshal(game.Serialize())
+		restored, err := Deserialize(string(data))
+		assert.NoError(t, err)
+		assert.Equal(t, game.manager.NewID(), restored.manager.NewID())
+	})
+}
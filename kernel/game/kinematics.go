@@ -0,0 +1,106 @@
+package game
+
+import (
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// Kinematics is the inertial flight model of the spaceship, see WithKinematics. The main thruster
+// accelerates the spaceship along its heading and the side thrusters apply the torque turning it,
+// the left one counterclockwise. The drag slows both the movement and the turning down. Unlike the
+// default model the heading does not follow the velocity, the spaceship drifts on until its thrust
+// brings it around.
+type Kinematics struct {
+	AccelerationSec        float64 `json:"accelerationSec"`        // At the max main thrust
+	DragSec                float64 `json:"dragSec"`                // Share of the velocity lost per second
+	MaxVelocitySec         float64 `json:"maxVelocitySec"`         // Scaled by the active speed boost
+	AngularAccelerationSec float64 `json:"angularAccelerationSec"` // Radians, at the max side thrust
+	AngularDragSec         float64 `json:"angularDragSec"`         // Share of the angular velocity lost per second
+	MaxAngularVelocitySec  float64 `json:"maxAngularVelocitySec"`  // Radians
+}
+
+func DefaultKinematics() Kinematics {
+	return Kinematics{
+		AccelerationSec:        KinematicsAccelerationSec,
+		DragSec:                KinematicsDragSec,
+		MaxVelocitySec:         MaxVelocitySec,
+		AngularAccelerationSec: KinematicsAngularAccelerationSec,
+		AngularDragSec:         KinematicsAngularDragSec,
+		MaxAngularVelocitySec:  KinematicsMaxAngularVelocitySec,
+	}
+}
+
+func (kinematics Kinematics) serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"accelerationSec":        kinematics.AccelerationSec,
+		"dragSec":                kinematics.DragSec,
+		"maxVelocitySec":         kinematics.MaxVelocitySec,
+		"angularAccelerationSec": kinematics.AngularAccelerationSec,
+		"angularDragSec":         kinematics.AngularDragSec,
+		"maxAngularVelocitySec":  kinematics.MaxAngularVelocitySec,
+	}
+}
+
+func deserializeKinematics(data map[string]interface{}) (Kinematics, error) {
+	var kinematics Kinematics
+	var err error
+	for field, value := range map[string]*float64{
+		"accelerationSec":        &kinematics.AccelerationSec,
+		"dragSec":                &kinematics.DragSec,
+		"maxVelocitySec":         &kinematics.MaxVelocitySec,
+		"angularAccelerationSec": &kinematics.AngularAccelerationSec,
+		"angularDragSec":         &kinematics.AngularDragSec,
+		"maxAngularVelocitySec":  &kinematics.MaxAngularVelocitySec,
+	} {
+		if *value, err = number(data, field); err != nil {
+			return Kinematics{}, err
+		}
+	}
+	return kinematics, nil
+}
+
+// WithKinematics replaces the default flight model of the spaceship by the inertial one.
+func WithKinematics(kinematics Kinematics) SpaceshipOption {
+	return func(ship *Spaceship) {
+		ship.kinematics = &kinematics
+	}
+}
+
+// Kinematics returns the inertial flight model, false for the default one.
+func (ship *Spaceship) Kinematics() (Kinematics, bool) {
+	if ship.kinematics == nil {
+		return Kinematics{}, false
+	}
+	return *ship.kinematics, true
+}
+
+// AngularVelocity returns the turning speed in radians per second, positive counterclockwise. It is
+// always 0 with the default flight model.
+func (ship *Spaceship) AngularVelocity() float64 {
+	return ship.angularVelocity
+}
+
+// integrate advances the inertial flight model by the semi-implicit Euler step, the velocities are
+// updated before the position and the rotation.
+func (ship *Spaceship) integrate(deltaTimeSec float64) {
+	kinematics := ship.kinematics
+
+	heading := physics.FromAngle(ship.rotation)
+	acceleration := heading.Multiply(ship.engine.mainThrust / MaxThrust)
+	acceleration = acceleration.Add(ship.engine.rawThrust.Multiply(1.0 / MaxThrust))
+	acceleration = acceleration.Multiply(kinematics.AccelerationSec * deltaTimeSec)
+	velocity := ship.velocity.Add(acceleration)
+	velocity = velocity.Multiply(math.Max(0, 1-kinematics.DragSec*deltaTimeSec))
+	ship.velocity = velocity.Clamp(ship.MaxSpeed())
+
+	torque := (ship.engine.leftThrust - ship.engine.rightThrust) / MaxThrust
+	ship.angularVelocity += torque * kinematics.AngularAccelerationSec * deltaTimeSec
+	ship.angularVelocity *= math.Max(0, 1-kinematics.AngularDragSec*deltaTimeSec)
+	ship.angularVelocity = math.Max(-kinematics.MaxAngularVelocitySec, math.Min(ship.angularVelocity, kinematics.MaxAngularVelocitySec))
+
+	ship.position = ship.position.Add(ship.velocity.Multiply(deltaTimeSec))
+	ship.rotation += ship.angularVelocity * deltaTimeSec
+	ship.collider.SetPosition(ship.position)
+	ship.collider.SetRotation(ship.rotation)
+}
@@ -0,0 +1,135 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpaceship_Kinematics(t *testing.T) {
+	gameManager := NewGameManager()
+
+	t.Run("Default model", func(t *testing.T) {
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+		_, ok := ship.Kinematics()
+		assert.False(t, ok)
+		ship.SetEngineThrust(0, 100, 0)
+		ship.Update(100, &gameManager)
+		assert.Equal(t, 0.0, ship.AngularVelocity())
+	})
+
+	t.Run("Thrust", func(t *testing.T) {
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0, WithKinematics(DefaultKinematics()))
+		ship.SetEngineThrust(100, 0, 0)
+		ship.Update(1000, &gameManager)
+		assert.InDelta(t, KinematicsAccelerationSec*(1-KinematicsDragSec), ship.Velocity().X, 1e-9)
+		assert.Equal(t, 0.0, ship.Velocity().Y)
+		assert.Equal(t, ship.Velocity().X, ship.Position().X)
+
+		// Capped
+		for i := 0; i < 600; i++ {
+			ship.Update(16, &gameManager)
+		}
+		assert.InDelta(t, MaxVelocitySec, ship.Speed(), 1e-9)
+		_ = ship.BoostSpeed(0.5, 1000)
+		ship.Update(16, &gameManager)
+		assert.InDelta(t, MaxVelocitySec/2, ship.Speed(), 1e-9)
+	})
+
+	t.Run("Torque", func(t *testing.T) {
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0, WithKinematics(DefaultKinematics()))
+		ship.SetEngineThrust(0, 100, 0)
+		ship.Update(100, &gameManager)
+		angularVelocity := KinematicsAngularAccelerationSec * 0.1 * (1 - KinematicsAngularDragSec*0.1)
+		assert.InDelta(t, angularVelocity, ship.AngularVelocity(), 1e-9)
+		assert.InDelta(t, angularVelocity*0.1, ship.rotation, 1e-9)
+
+		// Capped
+		for i := 0; i < 100; i++ {
+			ship.Update(16, &gameManager)
+		}
+		assert.InDelta(t, KinematicsMaxAngularVelocitySec, ship.AngularVelocity(), 1e-9)
+
+		// The right thruster turns clockwise, the drag slows the turning down without any
+		ship.SetEngineThrust(0, 0, 100)
+		ship.Update(16, &gameManager)
+		assert.Less(t, ship.AngularVelocity(), KinematicsMaxAngularVelocitySec)
+		ship.SetEngineThrust(0, 0, 0)
+		for i := 0; i < 300; i++ {
+			ship.Update(16, &gameManager)
+		}
+		assert.InDelta(t, 0, ship.AngularVelocity(), 0.01)
+	})
+
+	t.Run("Drift", func(t *testing.T) {
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0, WithKinematics(DefaultKinematics()))
+		ship.velocity = physics.Vector2{X: 100, Y: 0}
+		ship.rotation = math.Pi / 2
+		ship.energy = 0
+		ship.SetEngineThrust(100, 0, 0)
+		ship.Update(16, &gameManager)
+
+		// The heading does not follow the velocity, the ship drifts on without energy
+		assert.Equal(t, math.Pi/2, ship.rotation)
+		assert.InDelta(t, 100*(1-KinematicsDragSec*0.016), ship.Velocity().X, 1e-9)
+		assert.Greater(t, ship.Position().X, 0.0)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0, WithKinematics(DefaultKinematics()))
+		ship.SetEngineThrust(0, 100, 0)
+		ship.Update(100, &gameManager)
+		ship.Reset()
+		assert.Equal(t, 0.0, ship.AngularVelocity())
+	})
+}
+
+func TestSpaceship_Kinematics_Serialize(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0, WithKinematics(Kinematics{
+		AccelerationSec: 10, DragSec: 0.1, MaxVelocitySec: 100, AngularAccelerationSec: 5, AngularDragSec: 1, MaxAngularVelocitySec: 3,
+	}))
+	ship.SetEngineThrust(100, 100, 0)
+	ship.Update(100, &gameManager)
+
+	serialized := ship.Serialize()
+	assert.Equal(t, map[string]interface{}{
+		"accelerationSec":        10.0,
+		"dragSec":                0.1,
+		"maxVelocitySec":         100.0,
+		"angularAccelerationSec": 5.0,
+		"angularDragSec":         1.0,
+		"maxAngularVelocitySec":  3.0,
+	}, serialized["kinematics"])
+	assert.Equal(t, ship.AngularVelocity(), serialized["angularVelocity"])
+
+	deserialized, err := DeserializeSpaceship(serialized)
+	assert.NoError(t, err)
+	kinematics, ok := deserialized.Kinematics()
+	assert.True(t, ok)
+	assert.Equal(t, 100.0, kinematics.MaxVelocitySec)
+	assert.Equal(t, ship.AngularVelocity(), deserialized.AngularVelocity())
+
+	_, ok = NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0).Serialize()["kinematics"]
+	assert.False(t, ok)
+}
+
+func TestGame_Kinematics_Bot(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	kinematics := DefaultKinematics()
+	chaser, _ := game.AddSpaceshipWithBot(SpaceshipConfig{
+		Name: "chaser", Position: physics.Vector2{X: 200, Y: 500}, Rotation: math.Pi, Kinematics: &kinematics,
+	}, NewBotStrategy(OrbiterBot{Radius: 300, Thrust: 0}))
+	game.AddSpaceship("target", physics.Vector2{X: 800, Y: 500}, 0)
+	game.Start()
+
+	// Turns physically towards the orbit tangent and settles there, without oscillating
+	heading := physics.Vector2{X: 0, Y: -1}
+	for i := 0; i < 120; i++ {
+		game.Update(16)
+	}
+	assert.InDelta(t, 0, headingErrorTo(newSpaceshipState(chaser), heading), 0.1)
+	assert.InDelta(t, 0, chaser.AngularVelocity(), 0.2)
+}
@@ -4,13 +4,20 @@ import (
 	"math"
 
 	"github.com/davidhorak/space-wars/kernel/physics"
-	"github.com/davidhorak/space-wars/kernel/physics/collider"
 )
 
 func NewLaserProjectile(id int64, position physics.Vector2, rotation float64, owner *Spaceship) *Projectile {
+	projectile := &Projectile{}
+	initLaserProjectile(projectile, id, position, rotation, owner)
+	return projectile
+}
+
+// initLaserProjectile initializes the projectile as NewLaserProjectile, reusing its square collider if any.
+func initLaserProjectile(projectile *Projectile, id int64, position physics.Vector2, rotation float64, owner *Spaceship) {
 	direction := physics.Vector2{X: math.Cos(rotation), Y: math.Sin(rotation)}
+	square := recycledSquareCollider(projectile.collider, position, rotation, physics.Size{Width: LaserWidth, Height: LaserLength})
 
-	return &Projectile{
+	*projectile = Projectile{
 		id:                   id,
 		damageType:           DamageTypeLaser,
 		enabled:              true,
@@ -22,10 +29,6 @@ func NewLaserProjectile(id int64, position physics.Vector2, rotation float64, ow
 		owner:                owner,
 		explosionRadius:      float64(LaserExplosionRadius),
 		explosionDurationSec: float64(LaserExplosionDurationSec),
-		collider: collider.NewSquareCollider(
-			position,
-			rotation,
-			physics.Size{Width: LaserWidth, Height: LaserLength},
-		),
+		collider:             square,
 	}
 }
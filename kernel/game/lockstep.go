@@ -0,0 +1,141 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+)
+
+// LockstepSession advances the game in fixed ticks, once the inputs of all the players
+// for the tick were submitted, so the peers applying the same inputs stay in sync.
+type LockstepSession struct {
+	game      *Game
+	players   []string
+	tickMs    float64
+	tick      uint64 // Last advanced tick
+	inputs    map[uint64]map[string]NamedAction
+	stateHash uint64
+}
+
+// NewLockstepSession creates the session expecting an input from each player every tick.
+// A player without any input for the tick submits a NamedAction without the Action.
+func NewLockstepSession(game *Game, players []string, tickMs float64) *LockstepSession {
+	sorted := append([]string{}, players...)
+	sort.Strings(sorted)
+	return &LockstepSession{
+		game:    game,
+		players: sorted,
+		tickMs:  tickMs,
+		inputs:  map[uint64]map[string]NamedAction{},
+	}
+}
+
+func (session *LockstepSession) Game() *Game {
+	return session.game
+}
+
+// Tick returns the last advanced tick, 0 before the first AdvanceTick.
+func (session *LockstepSession) Tick() uint64 {
+	return session.tick
+}
+
+// SubmitInput submits the player's input for the tick, at most LockstepMaxInputLead ticks ahead.
+// The action drives the player's own spaceship only, an action named after another one is rejected.
+func (session *LockstepSession) SubmitInput(tick uint64, name string, action NamedAction) error {
+	if tick <= session.tick {
+		return fmt.Errorf("tick %d was already advanced", tick)
+	}
+	if tick > session.tick+LockstepMaxInputLead {
+		return fmt.Errorf("tick %d is more than %d ticks ahead of %d", tick, LockstepMaxInputLead, session.tick)
+	}
+	if !session.hasPlayer(name) {
+		return fmt.Errorf("unknown player: %s", name)
+	}
+	if action.Name != "" && action.Name != name {
+		return fmt.Errorf("input of %s names the spaceship %s", name, action.Name)
+	}
+	action.Name = name
+
+	inputs, ok := session.inputs[tick]
+	if !ok {
+		inputs = map[string]NamedAction{}
+		session.inputs[tick] = inputs
+	}
+	if _, ok := inputs[name]; ok {
+		return fmt.Errorf("input of %s for tick %d was already submitted", name, tick)
+	}
+	inputs[name] = action
+	return nil
+}
+
+// AdvanceTick applies the inputs of the tick, in the players' name order, and updates the game.
+// The ticks must be advanced in order, each one with the inputs of all the players.
+func (session *LockstepSession) AdvanceTick(tick uint64) error {
+	if tick != session.tick+1 {
+		return fmt.Errorf("tick %d is out of order, expected %d", tick, session.tick+1)
+	}
+
+	inputs := session.inputs[tick]
+	var actions []NamedAction
+	for _, player := range session.players {
+		action, ok := inputs[player]
+		if !ok {
+			return fmt.Errorf("missing input of %s for tick %d", player, tick)
+		}
+		if action.Action != nil {
+			actions = append(actions, action)
+		}
+	}
+
+	if err := session.game.BatchSpaceshipActions(actions); err != nil {
+		return err
+	}
+	session.game.Update(session.tickMs)
+
+	delete(session.inputs, tick)
+	session.tick = tick
+	session.stateHash = hashState(session.game)
+	return nil
+}
+
+// StateHash returns the checksum of the game state after the last advanced tick,
+// to be compared across the peers.
+func (session *LockstepSession) StateHash() uint64 {
+	return session.stateHash
+}
+
+func (session *LockstepSession) hasPlayer(name string) bool {
+	index := sort.SearchStrings(session.players, name)
+	return index < len(session.players) && session.players[index] == name
+}
+
+// StateHash returns the checksum of the game state, equal for the games in the same state,
+// see hashState.
+func (game *Game) StateHash() uint64 {
+	return hashState(game)
+}
+
+// hashState returns the FNV hash of the serialized game state. The timestamp and the logs, holding
+// the wall clock time, differ between the peers and are left out. The ids are hashed when allocated
+// by the game's SequentialIDs, the GlobalIDs drawn from the process wide sequence are left out too.
+func hashState(game *Game) uint64 {
+	state := game.Serialize()
+	delete(state, "timestamp")
+	delete(state, "logs")
+	if _, sequential := game.manager.IDGenerator().(*SequentialIDs); !sequential {
+		for _, gameObject := range state["gameObjects"].([]interface{}) {
+			gameObjectMap := gameObject.(map[string]interface{})
+			delete(gameObjectMap, "id")
+			delete(gameObjectMap, "owner")
+		}
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return 0
+	}
+	hash := fnv.New64a()
+	hash.Write(data)
+	return hash.Sum64()
+}
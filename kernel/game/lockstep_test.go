@@ -0,0 +1,138 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLockstepSession() *LockstepSession {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 900, Y: 600}, 0)
+	game.Start()
+	return NewLockstepSession(game, []string{"beta", "alpha"}, 16)
+}
+
+func thrust(name string, main float64) NamedAction {
+	return NamedAction{Name: name, Action: func(spaceShip *Spaceship, gameManager *GameManager) {
+		spaceShip.SetEngineThrust(main, 0, 0)
+	}}
+}
+
+func fire(name string) NamedAction {
+	return NamedAction{Name: name, Action: func(spaceShip *Spaceship, gameManager *GameManager) {
+		spaceShip.FireLaser(gameManager)
+	}}
+}
+
+func TestLockstepSession_IdenticalInputs(t *testing.T) {
+	first := newLockstepSession()
+	second := newLockstepSession()
+
+	for tick := uint64(1); tick <= 100; tick++ {
+		for _, session := range []*LockstepSession{first, second} {
+			assert.NoError(t, session.SubmitInput(tick, "alpha", thrust("alpha", float64(tick%100))))
+			if tick%10 == 0 {
+				assert.NoError(t, session.SubmitInput(tick, "beta", fire("beta")))
+			} else {
+				assert.NoError(t, session.SubmitInput(tick, "beta", NamedAction{Name: "beta"}))
+			}
+			assert.NoError(t, session.AdvanceTick(tick))
+		}
+		assert.Equal(t, first.StateHash(), second.StateHash())
+	}
+	assert.Equal(t, uint64(100), first.Tick())
+	assert.NotZero(t, first.StateHash())
+
+	// Diverging inputs
+	first.SubmitInput(101, "alpha", thrust("alpha", 100))
+	first.SubmitInput(101, "beta", NamedAction{Name: "beta"})
+	second.SubmitInput(101, "alpha", thrust("alpha", 0))
+	second.SubmitInput(101, "beta", NamedAction{Name: "beta"})
+	assert.NoError(t, first.AdvanceTick(101))
+	assert.NoError(t, second.AdvanceTick(101))
+	assert.NotEqual(t, first.StateHash(), second.StateHash())
+}
+
+func TestLockstepSession_StateHash_IDs(t *testing.T) {
+	advance := func(session *LockstepSession) {
+		tick := session.Tick() + 1
+		assert.NoError(t, session.SubmitInput(tick, "alpha", fire("alpha")))
+		assert.NoError(t, session.SubmitInput(tick, "beta", NamedAction{Name: "beta"}))
+		assert.NoError(t, session.AdvanceTick(tick))
+	}
+
+	// The sequential ids of the peers diverge, e.g. one of them spawned an extra object meanwhile removed
+	first := newLockstepSession()
+	second := newLockstepSession()
+	second.Game().manager.NewID()
+	advance(first)
+	advance(second)
+	assert.NotEqual(t, first.StateHash(), second.StateHash())
+
+	// The global ids depend on the other games in the process, they are left out
+	first.Game().manager.SetIDGenerator(GlobalIDs{})
+	second.Game().manager.SetIDGenerator(GlobalIDs{})
+	assert.Equal(t, first.Game().StateHash(), second.Game().StateHash())
+}
+
+func TestLockstepSession_AdvanceTick_OutOfOrder(t *testing.T) {
+	session := newLockstepSession()
+	session.SubmitInput(2, "alpha", NamedAction{Name: "alpha"})
+	session.SubmitInput(2, "beta", NamedAction{Name: "beta"})
+
+	assert.Error(t, session.AdvanceTick(2))
+	assert.Error(t, session.AdvanceTick(0))
+	assert.Equal(t, uint64(0), session.Tick())
+}
+
+func TestLockstepSession_AdvanceTick_MissingInput(t *testing.T) {
+	session := newLockstepSession()
+	session.SubmitInput(1, "alpha", NamedAction{Name: "alpha"})
+
+	assert.Error(t, session.AdvanceTick(1))
+	assert.Equal(t, uint64(0), session.Tick())
+
+	session.SubmitInput(1, "beta", NamedAction{Name: "beta"})
+	assert.NoError(t, session.AdvanceTick(1))
+	assert.Equal(t, 16.0, session.Game().manager.ElapsedMs())
+}
+
+func TestLockstepSession_SubmitInput(t *testing.T) {
+	session := newLockstepSession()
+
+	assert.NoError(t, session.SubmitInput(1, "alpha", NamedAction{Name: "alpha"}))
+	// Duplicate
+	assert.Error(t, session.SubmitInput(1, "alpha", NamedAction{Name: "alpha"}))
+	// Unknown player
+	assert.Error(t, session.SubmitInput(1, "gamma", NamedAction{Name: "gamma"}))
+
+	session.SubmitInput(1, "beta", NamedAction{Name: "beta"})
+	session.AdvanceTick(1)
+	// Already advanced
+	assert.Error(t, session.SubmitInput(1, "alpha", NamedAction{Name: "alpha"}))
+	// Ahead of time
+	assert.NoError(t, session.SubmitInput(5, "alpha", NamedAction{Name: "alpha"}))
+	// Too far ahead
+	assert.NoError(t, session.SubmitInput(1+LockstepMaxInputLead, "alpha", NamedAction{Name: "alpha"}))
+	assert.Error(t, session.SubmitInput(2+LockstepMaxInputLead, "alpha", NamedAction{Name: "alpha"}))
+	// Without the name, the input is of the player
+	assert.NoError(t, session.SubmitInput(2, "alpha", NamedAction{}))
+}
+
+func TestLockstepSession_SubmitInput_ForeignSpaceship(t *testing.T) {
+	session := newLockstepSession()
+	alpha, _ := session.Game().manager.GetSpaceship("alpha")
+	beta, _ := session.Game().manager.GetSpaceship("beta")
+
+	// Alpha can not drive beta's spaceship
+	assert.Error(t, session.SubmitInput(1, "alpha", thrust("beta", 100)))
+	assert.NoError(t, session.SubmitInput(1, "alpha", thrust("alpha", 50)))
+	assert.NoError(t, session.SubmitInput(1, "beta", thrust("beta", 20)))
+	assert.NoError(t, session.AdvanceTick(1))
+
+	assert.Equal(t, 50.0, alpha.engine.mainThrust)
+	assert.Equal(t, 20.0, beta.engine.mainThrust)
+}
@@ -1,8 +1,14 @@
 package game
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"regexp"
+	"strings"
 	"time"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
 )
 
 type LogType string
@@ -12,64 +18,442 @@ const (
 	LogTypeKill      LogType = "kill"
 	LogTypeCollision LogType = "collision"
 	LogTypeGameState LogType = "game_state"
+	LogTypeEvent     LogType = "event"
+	LogTypeChat      LogType = "chat"
+)
+
+type LogLevel string
+
+const (
+	LogLevelDebug   LogLevel = "debug"
+	LogLevelInfo    LogLevel = "info"
+	LogLevelWarning LogLevel = "warning"
+	LogLevelError   LogLevel = "error"
 )
 
-type Message struct {
+// severity orders the levels from the debug up, the unknown levels rank as info.
+func (level LogLevel) severity() int {
+	switch level {
+	case LogLevelDebug:
+		return 0
+	case LogLevelWarning:
+		return 2
+	case LogLevelError:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// AtLeast reports whether the level is as severe as the other one or more.
+func (level LogLevel) AtLeast(other LogLevel) bool {
+	return level.severity() >= other.severity()
+}
+
+type LogEntry struct {
 	id      int64
 	logType LogType
+	level   LogLevel
 	time    time.Time
-	message string
-	meta    map[string]interface{}
+	// Game time at the entry creation
+	tick   uint64
+	tickMs float64
+	// Zero when the entry is not related to a game object
+	objectID int64
+	// Of the game object at the entry creation, nil when unknown
+	position *physics.Vector2
+	message  string
+	meta     map[string]interface{}
+}
+
+func (entry *LogEntry) ID() int64 {
+	return entry.id
+}
+
+func (entry *LogEntry) Type() LogType {
+	return entry.logType
+}
+
+func (entry *LogEntry) Time() time.Time {
+	return entry.time
 }
 
-func (message *Message) Serialize() map[string]interface{} {
-	return map[string]interface{}{
-		"id":      message.id,
-		"logType": string(message.logType),
-		"time":    message.time.Format("2006-01-02 15:04:05"),
-		"message": message.message,
-		"meta":    message.meta,
+func (entry *LogEntry) Tick() uint64 {
+	return entry.tick
+}
+
+func (entry *LogEntry) TickMs() float64 {
+	return entry.tickMs
+}
+
+// Position returns the position of the related game object at the entry creation, false when unknown.
+func (entry *LogEntry) Position() (physics.Vector2, bool) {
+	if entry.position == nil {
+		return physics.Vector2{}, false
+	}
+	return *entry.position, true
+}
+
+func (entry *LogEntry) ObjectID() int64 {
+	return entry.objectID
+}
+
+func (entry *LogEntry) Level() LogLevel {
+	return entry.level
+}
+
+func (entry *LogEntry) Message() string {
+	return entry.message
+}
+
+func (entry *LogEntry) Metadata() map[string]interface{} {
+	return entry.meta
+}
+
+func (entry *LogEntry) Serialize() map[string]interface{} {
+	serialized := map[string]interface{}{
+		"id":       entry.id,
+		"logType":  string(entry.logType),
+		"level":    string(entry.level),
+		"time":     entry.time.Format("2006-01-02 15:04:05"),
+		"tick":     entry.tick,
+		"tickMs":   entry.tickMs,
+		"objectId": entry.objectID,
+		"message":  entry.message,
+		"meta":     entry.meta,
+	}
+	if entry.position != nil {
+		serialized["position"] = map[string]interface{}{
+			"x": entry.position.X,
+			"y": entry.position.Y,
+		}
 	}
+	return serialized
 }
 
+// MarshalJSON encodes the entry as its Serialize output.
+func (entry LogEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(entry.Serialize())
+}
+
+// EncodeLogsJSON writes the entries as JSON Lines, one Serialize output per line.
+func EncodeLogsJSON(writer io.Writer, entries []LogEntry) error {
+	encoder := json.NewEncoder(writer)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeserializeLogEntry restores the log entry from its Serialize output.
+func DeserializeLogEntry(data map[string]interface{}) (LogEntry, error) {
+	rawTime, err := text(data, "time")
+	if err != nil {
+		return LogEntry{}, err
+	}
+	entryTime, err := time.Parse("2006-01-02 15:04:05", rawTime)
+	if err != nil {
+		return LogEntry{}, ErrInvalidState{Field: "time"}
+	}
+	id, err := number(data, "id")
+	if err != nil {
+		return LogEntry{}, err
+	}
+	logType, err := text(data, "logType")
+	if err != nil {
+		return LogEntry{}, err
+	}
+	message, err := text(data, "message")
+	if err != nil {
+		return LogEntry{}, err
+	}
+	meta, _ := data["meta"].(map[string]interface{}) // Absent for the entries without metadata
+	tickMs, err := optionalNumber(data, "tickMs", 0)
+	if err != nil {
+		return LogEntry{}, err
+	}
+	objectID, err := optionalNumber(data, "objectId", 0)
+	if err != nil {
+		return LogEntry{}, err
+	}
+	tick, err := optionalNumber(data, "tick", 0)
+	if err != nil {
+		return LogEntry{}, err
+	}
+
+	entry := LogEntry{
+		id:       int64(id),
+		logType:  LogType(logType),
+		level:    LogLevelInfo,
+		time:     entryTime,
+		tick:     uint64(tick),
+		tickMs:   tickMs,
+		objectID: int64(objectID),
+		message:  message,
+		meta:     meta,
+	}
+	if level, ok := data["level"].(string); ok {
+		entry.level = LogLevel(level)
+	}
+	if _, ok := data["position"]; ok {
+		position, err := vector(data, "position")
+		if err != nil {
+			return LogEntry{}, err
+		}
+		entry.position = &position
+	}
+	return entry, nil
+}
+
+// LogFilter selects the log entries, the zero value matches all of them.
+type LogFilter struct {
+	MinLevel  LogLevel  // Empty for all the levels
+	Types     []LogType // Empty for all the types
+	ObjectIDs []int64   // Empty for all the sources, 0 selects the entries not related to a game object
+	SinceTick uint64    // Entries created at the tick or later
+}
+
+func (filter LogFilter) matches(entry *LogEntry) bool {
+	if filter.MinLevel != "" && !entry.level.AtLeast(filter.MinLevel) {
+		return false
+	}
+	if entry.tick < filter.SinceTick {
+		return false
+	}
+	if len(filter.Types) > 0 {
+		found := false
+		for _, logType := range filter.Types {
+			found = found || entry.logType == logType
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(filter.ObjectIDs) > 0 {
+		found := false
+		for _, objectID := range filter.ObjectIDs {
+			found = found || entry.objectID == objectID
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Locator returns the position of the game object by its id, false when it is not in the game.
+type Locator func(objectID int64) (physics.Vector2, bool)
+
 type Logger interface {
-	Logs() []Message
+	Logs() []LogEntry
+	Capacity() int
+	Full() bool
 	Clear()
-	AddMessage(message Message)
-	Damage(time time.Time, damage float64, who string, by string, damageType DamageType)
-	Kill(time time.Time, who string, by string)
-	Collision(time time.Time, who string, with string)
+	SetTickMs(tickMs float64)
+	SetTick(tick uint64)
+	SetMinLevel(level LogLevel)
+	MinLevel() LogLevel
+	SetLocator(locator Locator)
+	SetIDGenerator(ids IDGenerator)
+	Filter(filter LogFilter) []LogEntry
+	Search(query string) []LogEntry
+	SearchRegex(pattern string) ([]LogEntry, error)
+	AddMessage(message LogEntry)
+	LogEvent(level LogLevel, message string, objectID int64, meta map[string]interface{})
+	Damage(time time.Time, objectID int64, damage float64, who string, by string, damageType DamageType)
+	Kill(time time.Time, objectID int64, who string, by string)
+	Collision(time time.Time, objectID int64, who string, with string, health float64)
 	GameState(time time.Time, state Status)
+	GameEnded(time time.Time, reason string)
+	Chat(time time.Time, from string, message string)
 }
 
-func NewLogger() Logger {
+// NewLogger creates a logger keeping at most capacity messages,
+// the oldest message is overwritten once the capacity is reached.
+// Non-positive capacity falls back to the LogCapacity.
+func NewLogger(capacity int) Logger {
+	if capacity <= 0 {
+		capacity = LogCapacity
+	}
 	return &logger{
-		messages: []Message{},
+		capacity: capacity,
+		messages: []LogEntry{},
 	}
 }
 
 type logger struct {
-	messages []Message
+	capacity int
+	// Index of the oldest message once the buffer is full
+	head     int
+	tick     uint64
+	tickMs   float64
+	minLevel LogLevel // Empty to keep all the levels
+	locator  Locator
+	ids      IDGenerator // nil for the global NewUUID
+	messages []LogEntry
 }
 
-func (logger *logger) Logs() []Message {
-	return logger.messages
+// Logs returns the messages in the insertion order.
+func (logger *logger) Logs() []LogEntry {
+	if logger.head == 0 {
+		return logger.messages
+	}
+
+	messages := make([]LogEntry, 0, len(logger.messages))
+	messages = append(messages, logger.messages[logger.head:]...)
+	return append(messages, logger.messages[:logger.head]...)
+}
+
+func (logger *logger) Capacity() int {
+	return logger.capacity
+}
+
+func (logger *logger) Full() bool {
+	return len(logger.messages) >= logger.capacity
 }
 
 func (logger *logger) Clear() {
-	logger.messages = []Message{}
+	logger.head = 0
+	logger.messages = []LogEntry{}
 }
 
-func (logger *logger) AddMessage(message Message) {
-	logger.messages = append(logger.messages, message)
+// Filter returns the entries matching the filter, in the insertion order.
+func (logger *logger) Filter(filter LogFilter) []LogEntry {
+	result := []LogEntry{}
+	for _, entry := range logger.Logs() {
+		if filter.matches(&entry) {
+			result = append(result, entry)
+		}
+	}
+	return result
 }
 
-func (logger *logger) Damage(time time.Time, damage float64, who string, whom string, damageType DamageType) {
-	logger.messages = append(logger.messages, Message{
-		id:      NewUUID(),
-		logType: LogTypeDamage,
-		time:    time,
-		message: fmt.Sprintf("\"%s\" did %.2f damage to \"%s\" with %s", who, damage, whom, damageType),
+// Search returns the entries whose message contains the query, case-insensitive.
+func (logger *logger) Search(query string) []LogEntry {
+	query = strings.ToLower(query)
+	result := []LogEntry{}
+	for _, entry := range logger.Logs() {
+		if strings.Contains(strings.ToLower(entry.message), query) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// SearchRegex returns the entries whose message matches the pattern.
+func (logger *logger) SearchRegex(pattern string) ([]LogEntry, error) {
+	expression, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []LogEntry{}
+	for _, entry := range logger.Logs() {
+		if expression.MatchString(entry.message) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+// SetTickMs sets the game time stamped onto the following entries.
+func (logger *logger) SetTickMs(tickMs float64) {
+	logger.tickMs = tickMs
+}
+
+// SetTick sets the tick stamped onto the following entries.
+func (logger *logger) SetTick(tick uint64) {
+	logger.tick = tick
+}
+
+// SetMinLevel drops the following entries less severe than the level, e.g. the debug ones
+// of the long matches. Empty keeps all the levels.
+func (logger *logger) SetMinLevel(level LogLevel) {
+	logger.minLevel = level
+}
+
+func (logger *logger) MinLevel() LogLevel {
+	return logger.minLevel
+}
+
+// SetIDGenerator sets the allocator of the ids of the entries created by the logger, nil for NewUUID.
+func (logger *logger) SetIDGenerator(ids IDGenerator) {
+	logger.ids = ids
+}
+
+func (logger *logger) nextID() int64 {
+	if logger.ids == nil {
+		return NewUUID()
+	}
+	return logger.ids.NextID()
+}
+
+func (logger *logger) accepts(level LogLevel) bool {
+	return logger.minLevel == "" || level == "" || level.AtLeast(logger.minLevel)
+}
+
+// SetLocator sets the lookup stamping the position of the related game object onto the following
+// entries, nil for none. The locator is kept on Clear.
+func (logger *logger) SetLocator(locator Locator) {
+	logger.locator = locator
+}
+
+// AddMessage records the entry unless below the min level. The entries created by the logger are
+// stamped with the tick and the position of the related game object, the added ones are kept as is.
+func (logger *logger) AddMessage(message LogEntry) {
+	if !logger.accepts(message.level) {
+		return
+	}
+	if !logger.Full() {
+		logger.messages = append(logger.messages, message)
+		return
+	}
+
+	logger.messages[logger.head] = message
+	logger.head = (logger.head + 1) % logger.capacity
+}
+
+// add stamps the entry created by the logger with the tick and the position of the related game object.
+func (logger *logger) add(entry LogEntry) {
+	if !logger.accepts(entry.level) {
+		return
+	}
+	entry.tick = logger.tick
+	if entry.objectID != 0 && logger.locator != nil {
+		if position, ok := logger.locator(entry.objectID); ok {
+			entry.position = &position
+		}
+	}
+	logger.AddMessage(entry)
+}
+
+func (logger *logger) LogEvent(level LogLevel, message string, objectID int64, meta map[string]interface{}) {
+	if meta == nil {
+		meta = map[string]interface{}{}
+	}
+	logger.add(LogEntry{
+		id:       logger.nextID(),
+		logType:  LogTypeEvent,
+		level:    level,
+		time:     time.Now(),
+		tickMs:   logger.tickMs,
+		objectID: objectID,
+		message:  message,
+		meta:     meta,
+	})
+}
+
+func (logger *logger) Damage(time time.Time, objectID int64, damage float64, who string, whom string, damageType DamageType) {
+	logger.add(LogEntry{
+		id:       logger.nextID(),
+		logType:  LogTypeDamage,
+		level:    LogLevelInfo,
+		time:     time,
+		tickMs:   logger.tickMs,
+		objectID: objectID,
+		message:  fmt.Sprintf("\"%s\" did %.2f damage to \"%s\" with %s", who, damage, whom, damageType),
 		meta: map[string]interface{}{
 			"who":        who,
 			"whom":       whom,
@@ -79,12 +463,15 @@ func (logger *logger) Damage(time time.Time, damage float64, who string, whom st
 	})
 }
 
-func (logger *logger) Kill(time time.Time, who string, whom string) {
-	logger.messages = append(logger.messages, Message{
-		id:      NewUUID(),
-		logType: LogTypeKill,
-		time:    time,
-		message: fmt.Sprintf("\"%s\" was killed by \"%s\"", who, whom),
+func (logger *logger) Kill(time time.Time, objectID int64, who string, whom string) {
+	logger.add(LogEntry{
+		id:       logger.nextID(),
+		logType:  LogTypeKill,
+		level:    LogLevelInfo,
+		time:     time,
+		tickMs:   logger.tickMs,
+		objectID: objectID,
+		message:  fmt.Sprintf("\"%s\" was killed by \"%s\"", who, whom),
 		meta: map[string]interface{}{
 			"who":  who,
 			"whom": whom,
@@ -92,27 +479,69 @@ func (logger *logger) Kill(time time.Time, who string, whom string) {
 	})
 }
 
-func (logger *logger) Collision(time time.Time, who string, with string) {
-	logger.messages = append(logger.messages, Message{
-		id:      NewUUID(),
-		logType: LogTypeCollision,
-		time:    time,
-		message: fmt.Sprintf("\"%s\" collided with \"%s\"", who, with),
+func (logger *logger) Collision(time time.Time, objectID int64, who string, with string, health float64) {
+	logger.add(LogEntry{
+		id:       logger.nextID(),
+		logType:  LogTypeCollision,
+		level:    LogLevelInfo,
+		time:     time,
+		tickMs:   logger.tickMs,
+		objectID: objectID,
+		message:  fmt.Sprintf("\"%s\" collided with \"%s\"", who, with),
 		meta: map[string]interface{}{
-			"who":  who,
-			"with": with,
+			"who":    who,
+			"with":   with,
+			"health": fmt.Sprintf("%.2f", health),
 		},
 	})
 }
 
 func (logger *logger) GameState(time time.Time, state Status) {
-	logger.messages = append(logger.messages, Message{
-		id:      NewUUID(),
+	logger.add(LogEntry{
+		id:      logger.nextID(),
 		logType: LogTypeGameState,
+		level:   LogLevelInfo,
 		time:    time,
+		tickMs:  logger.tickMs,
 		message: fmt.Sprintf("Game state changed to: %s", state),
 		meta: map[string]interface{}{
 			"state": string(state),
 		},
 	})
 }
+
+// GameEnded logs the game state change to ended alongside the reason, e.g. the met end conditions.
+func (logger *logger) GameEnded(time time.Time, reason string) {
+	if reason == "" {
+		logger.GameState(time, Ended)
+		return
+	}
+
+	logger.add(LogEntry{
+		id:      logger.nextID(),
+		logType: LogTypeGameState,
+		level:   LogLevelInfo,
+		time:    time,
+		tickMs:  logger.tickMs,
+		message: fmt.Sprintf("Game state changed to: %s (%s)", Ended, reason),
+		meta: map[string]interface{}{
+			"state":  string(Ended),
+			"reason": reason,
+		},
+	})
+}
+
+func (logger *logger) Chat(time time.Time, from string, message string) {
+	logger.add(LogEntry{
+		id:      logger.nextID(),
+		logType: LogTypeChat,
+		level:   LogLevelInfo,
+		time:    time,
+		tickMs:  logger.tickMs,
+		message: fmt.Sprintf("%s: %s", from, message),
+		meta: map[string]interface{}{
+			"from":    from,
+			"message": message,
+		},
+	})
+}
@@ -1,50 +1,117 @@
 package game
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/davidhorak/space-wars/kernel/physics"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestMessage_Serialize(t *testing.T) {
+func TestLogEntry_Serialize(t *testing.T) {
 	now := time.Now()
-	message := Message{
-		id:      1,
-		logType: LogTypeDamage,
-		time:    now,
-		message: "test",
-		meta:    map[string]interface{}{"test": "test"},
+	message := LogEntry{
+		id:       1,
+		logType:  LogTypeDamage,
+		level:    LogLevelInfo,
+		time:     now,
+		tickMs:   1500,
+		objectID: 3,
+		message:  "test",
+		meta:     map[string]interface{}{"test": "test"},
 	}
 
 	serialized := message.Serialize()
 	assert.Equal(t, int64(1), serialized["id"])
 	assert.Equal(t, "damage", serialized["logType"])
+	assert.Equal(t, "info", serialized["level"])
 	assert.Equal(t, now.Format("2006-01-02 15:04:05"), serialized["time"])
+	assert.Equal(t, 1500.0, serialized["tickMs"])
+	assert.Equal(t, int64(3), serialized["objectId"])
 	assert.Equal(t, "test", serialized["message"])
 	assert.Equal(t, map[string]interface{}{"test": "test"}, serialized["meta"])
 }
 
+func TestDeserializeLogEntry(t *testing.T) {
+	entry := LogEntry{
+		id:       1,
+		logType:  LogTypeDamage,
+		level:    LogLevelWarning,
+		time:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		tick:     90,
+		tickMs:   1500,
+		objectID: 3,
+		position: &physics.Vector2{X: 10, Y: 20},
+		message:  "test",
+		meta:     map[string]interface{}{"test": "test"},
+	}
+
+	deserialized, err := DeserializeLogEntry(entry.Serialize())
+	assert.NoError(t, err)
+	assert.Equal(t, entry, deserialized)
+
+	serialized := entry.Serialize()
+	serialized["time"] = "invalid"
+	_, err = DeserializeLogEntry(serialized)
+	assert.Equal(t, ErrInvalidState{Field: "time"}, err)
+}
+
 func TestNewLogger(t *testing.T) {
-	logger := NewLogger()
-	assert.Equal(t, []Message{}, logger.Logs())
+	logger := NewLogger(LogCapacity)
+	assert.Equal(t, []LogEntry{}, logger.Logs())
+	assert.Equal(t, LogCapacity, logger.Capacity())
+	assert.False(t, logger.Full())
+
+	// Falls back to the default capacity
+	logger = NewLogger(0)
+	assert.Equal(t, LogCapacity, logger.Capacity())
+}
+
+func TestLogger_Capacity(t *testing.T) {
+	logger := NewLogger(3)
+	for i := int64(1); i <= 4; i++ {
+		logger.AddMessage(LogEntry{id: i})
+	}
+
+	logs := logger.Logs()
+	assert.True(t, logger.Full())
+	assert.Equal(t, 3, len(logs))
+	assert.Equal(t, []int64{2, 3, 4}, []int64{logs[0].id, logs[1].id, logs[2].id})
+
+	for i := int64(5); i <= 8; i++ {
+		logger.AddMessage(LogEntry{id: i})
+	}
+
+	logs = logger.Logs()
+	assert.Equal(t, 3, len(logs))
+	assert.Equal(t, []int64{6, 7, 8}, []int64{logs[0].id, logs[1].id, logs[2].id})
+
+	logger.Clear()
+	assert.False(t, logger.Full())
+	assert.Equal(t, []LogEntry{}, logger.Logs())
+
+	logger.AddMessage(LogEntry{id: 9})
+	assert.Equal(t, int64(9), logger.Logs()[0].id)
 }
 
 func TestLogger_Logs(t *testing.T) {
-	logger := NewLogger()
-	assert.Equal(t, []Message{}, logger.Logs())
+	logger := NewLogger(LogCapacity)
+	assert.Equal(t, []LogEntry{}, logger.Logs())
 }
 
 func TestLogger_Clear(t *testing.T) {
-	logger := NewLogger()
+	logger := NewLogger(LogCapacity)
 	logger.Clear()
-	assert.Equal(t, []Message{}, logger.Logs())
+	assert.Equal(t, []LogEntry{}, logger.Logs())
 }
 
 func TestLogger_AddMessage(t *testing.T) {
 	now := time.Now()
-	logger := NewLogger()
-	logger.AddMessage(Message{
+	logger := NewLogger(LogCapacity)
+	logger.AddMessage(LogEntry{
 		id:      1,
 		logType: LogTypeDamage,
 		time:    now,
@@ -53,7 +120,7 @@ func TestLogger_AddMessage(t *testing.T) {
 	})
 
 	assert.Equal(t, 1, len(logger.Logs()))
-	assert.Equal(t, Message{
+	assert.Equal(t, LogEntry{
 		id:      1,
 		logType: LogTypeDamage,
 		time:    now,
@@ -64,8 +131,8 @@ func TestLogger_AddMessage(t *testing.T) {
 
 func TestLogger_Damage(t *testing.T) {
 	now := time.Now()
-	logger := NewLogger()
-	logger.Damage(now, 10, "test", "other", DamageTypeUnknown)
+	logger := NewLogger(LogCapacity)
+	logger.Damage(now, 2, 10, "test", "other", DamageTypeUnknown)
 
 	log := logger.Logs()[0]
 	assert.Equal(t, 1, len(logger.Logs()))
@@ -73,13 +140,15 @@ func TestLogger_Damage(t *testing.T) {
 	assert.Equal(t, LogTypeDamage, log.logType)
 	assert.Equal(t, now, log.time)
 	assert.Equal(t, "\"test\" did 10.00 damage to \"other\" with unknown", log.message)
+	assert.Equal(t, int64(2), log.objectID)
+	assert.Equal(t, LogLevelInfo, log.level)
 	assert.Equal(t, map[string]interface{}{"who": "test", "whom": "other", "damage": "10.00", "damageType": "unknown"}, log.meta)
 }
 
 func TestLogger_Kill(t *testing.T) {
 	now := time.Now()
-	logger := NewLogger()
-	logger.Kill(now, "test", "test")
+	logger := NewLogger(LogCapacity)
+	logger.Kill(now, 2, "test", "test")
 
 	log := logger.Logs()[0]
 	assert.Equal(t, 1, len(logger.Logs()))
@@ -87,13 +156,15 @@ func TestLogger_Kill(t *testing.T) {
 	assert.Equal(t, LogTypeKill, log.logType)
 	assert.Equal(t, now, log.time)
 	assert.Equal(t, "\"test\" was killed by \"test\"", log.message)
+	assert.Equal(t, int64(2), log.objectID)
 	assert.Equal(t, map[string]interface{}{"who": "test", "whom": "test"}, log.meta)
 }
 
 func TestLogger_Collision(t *testing.T) {
 	now := time.Now()
-	logger := NewLogger()
-	logger.Collision(now, "test", "test")
+	logger := NewLogger(LogCapacity)
+	logger.SetTickMs(250)
+	logger.Collision(now, 2, "test", "test", 0)
 
 	log := logger.Logs()[0]
 	assert.Equal(t, 1, len(logger.Logs()))
@@ -101,12 +172,14 @@ func TestLogger_Collision(t *testing.T) {
 	assert.Equal(t, LogTypeCollision, log.logType)
 	assert.Equal(t, now, log.time)
 	assert.Equal(t, "\"test\" collided with \"test\"", log.message)
-	assert.Equal(t, map[string]interface{}{"who": "test", "with": "test"}, log.meta)
+	assert.Equal(t, int64(2), log.objectID)
+	assert.Equal(t, 250.0, log.tickMs)
+	assert.Equal(t, map[string]interface{}{"who": "test", "with": "test", "health": "0.00"}, log.meta)
 }
 
 func TestLogger_GameState(t *testing.T) {
 	now := time.Now()
-	logger := NewLogger()
+	logger := NewLogger(LogCapacity)
 	logger.GameState(now, Running)
 
 	log := logger.Logs()[0]
@@ -115,5 +188,182 @@ func TestLogger_GameState(t *testing.T) {
 	assert.Equal(t, LogTypeGameState, log.logType)
 	assert.Equal(t, now, log.time)
 	assert.Equal(t, "Game state changed to: running", log.message)
+	assert.Equal(t, int64(0), log.objectID)
 	assert.Equal(t, map[string]interface{}{"state": "running"}, log.meta)
 }
+
+func TestLogger_GameEnded(t *testing.T) {
+	now := time.Now()
+	logger := NewLogger(LogCapacity)
+	logger.GameEnded(now, "noShips")
+	logger.GameEnded(now, "")
+
+	logs := logger.Logs()
+	assert.Equal(t, LogTypeGameState, logs[0].logType)
+	assert.Equal(t, "Game state changed to: ended (noShips)", logs[0].message)
+	assert.Equal(t, map[string]interface{}{"state": "ended", "reason": "noShips"}, logs[0].meta)
+	assert.Equal(t, "Game state changed to: ended", logs[1].message)
+	assert.Equal(t, map[string]interface{}{"state": "ended"}, logs[1].meta)
+}
+
+func TestLogger_LogEvent(t *testing.T) {
+	logger := NewLogger(LogCapacity)
+	logger.SetTickMs(100)
+	logger.LogEvent(LogLevelWarning, "low energy", 7, map[string]interface{}{"energy": 5.0})
+	logger.LogEvent(LogLevelDebug, "tick", 0, nil)
+
+	logs := logger.Logs()
+	assert.Equal(t, 2, len(logs))
+
+	assert.Equal(t, LogTypeEvent, logs[0].logType)
+	assert.Equal(t, LogLevelWarning, logs[0].Level())
+	assert.Equal(t, "low energy", logs[0].Message())
+	assert.Equal(t, int64(7), logs[0].ObjectID())
+	assert.Equal(t, 100.0, logs[0].tickMs)
+	assert.Equal(t, map[string]interface{}{"energy": 5.0}, logs[0].Metadata())
+
+	// Not object specific
+	assert.Equal(t, int64(0), logs[1].ObjectID())
+	assert.NotEqual(t, logs[0].ObjectID(), logs[1].ObjectID())
+	assert.Equal(t, map[string]interface{}{}, logs[1].Metadata())
+}
+
+func TestLogger_Search(t *testing.T) {
+	now := time.Now()
+	logger := NewLogger(LogCapacity)
+	logger.Damage(now, 2, 10, "Alpha", "Beta", DamageTypeLaser)
+	logger.Kill(now, 2, "Beta", "Alpha")
+	logger.GameState(now, Ended)
+
+	// Case-insensitive
+	result := logger.Search("ALPHA")
+	assert.Equal(t, 2, len(result))
+	assert.Equal(t, LogTypeDamage, result[0].logType)
+	assert.Equal(t, LogTypeKill, result[1].logType)
+
+	// Partial match
+	result = logger.Search("kill")
+	assert.Equal(t, 1, len(result))
+	assert.Equal(t, LogTypeKill, result[0].logType)
+
+	// No match
+	assert.Equal(t, []LogEntry{}, logger.Search("gamma"))
+}
+
+func TestLogger_SearchRegex(t *testing.T) {
+	now := time.Now()
+	logger := NewLogger(LogCapacity)
+	logger.Damage(now, 2, 10, "Alpha", "Beta", DamageTypeLaser)
+	logger.Damage(now, 2, 60, "Alpha", "Beta", DamageTypeRocket)
+
+	result, err := logger.SearchRegex(`did \d+\.\d+ damage .* with rocket$`)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result))
+	assert.Equal(t, "rocket", result[0].meta["damageType"])
+
+	result, err = logger.SearchRegex("^nothing")
+	assert.NoError(t, err)
+	assert.Equal(t, []LogEntry{}, result)
+
+	_, err = logger.SearchRegex("(unclosed")
+	assert.Error(t, err)
+}
+
+func TestLogger_Stamps(t *testing.T) {
+	logger := NewLogger(LogCapacity)
+	logger.SetTick(42)
+	logger.SetLocator(func(objectID int64) (physics.Vector2, bool) {
+		return physics.Vector2{X: float64(objectID), Y: 1}, objectID == 2
+	})
+	logger.Damage(time.Now(), 2, 10, "Alpha", "Beta", DamageTypeLaser)
+	logger.Kill(time.Now(), 3, "Beta", "Alpha")
+	logger.AddMessage(LogEntry{id: 1, objectID: 2})
+
+	logs := logger.Logs()
+	assert.Equal(t, uint64(42), logs[0].Tick())
+	position, ok := logs[0].Position()
+	assert.True(t, ok)
+	assert.Equal(t, physics.Vector2{X: 2, Y: 1}, position)
+	assert.Equal(t, map[string]interface{}{"x": 2.0, "y": 1.0}, logs[0].Serialize()["position"])
+	// Not in the game
+	_, ok = logs[1].Position()
+	assert.False(t, ok)
+	assert.NotContains(t, logs[1].Serialize(), "position")
+	// Added as is
+	assert.Equal(t, LogEntry{id: 1, objectID: 2}, logs[2])
+
+	t.Run("Game", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+		ship, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 200}})
+		game.AddSpaceship("beta", physics.Vector2{X: 900, Y: 900}, 0)
+		game.Start()
+		game.Update(16)
+		game.manager.Logger().LogEvent(LogLevelInfo, "test", ship.ID(), nil)
+
+		entry := game.manager.Logger().Filter(LogFilter{ObjectIDs: []int64{ship.ID()}})[0]
+		assert.Equal(t, uint64(1), entry.Tick())
+		position, ok := entry.Position()
+		assert.True(t, ok)
+		assert.Equal(t, ship.Position(), position)
+	})
+}
+
+func TestLogger_SetMinLevel(t *testing.T) {
+	logger := NewLogger(LogCapacity)
+	assert.Equal(t, LogLevel(""), logger.MinLevel())
+	logger.SetMinLevel(LogLevelWarning)
+	logger.LogEvent(LogLevelDebug, "debug", 0, nil)
+	logger.LogEvent(LogLevelError, "error", 0, nil)
+	logger.LogEvent(LogLevelWarning, "warning", 0, nil)
+	logger.Kill(time.Now(), 2, "Beta", "Alpha")
+
+	logs := logger.Logs()
+	assert.Equal(t, 2, len(logs))
+	assert.Equal(t, "error", logs[0].Message())
+	assert.Equal(t, "warning", logs[1].Message())
+
+	logger.SetMinLevel("")
+	logger.LogEvent(LogLevelDebug, "debug", 0, nil)
+	assert.Equal(t, 3, len(logger.Logs()))
+}
+
+func TestLogger_Filter(t *testing.T) {
+	logger := NewLogger(LogCapacity)
+	logger.Damage(time.Now(), 2, 10, "Alpha", "Beta", DamageTypeLaser)
+	logger.SetTick(5)
+	logger.LogEvent(LogLevelWarning, "low energy", 3, nil)
+	logger.GameState(time.Now(), Ended)
+
+	assert.Equal(t, logger.Logs(), logger.Filter(LogFilter{}))
+	assert.Equal(t, []LogEntry{logger.Logs()[1]}, logger.Filter(LogFilter{MinLevel: LogLevelWarning}))
+	assert.Equal(t, 2, len(logger.Filter(LogFilter{Types: []LogType{LogTypeDamage, LogTypeGameState}})))
+	assert.Equal(t, 2, len(logger.Filter(LogFilter{ObjectIDs: []int64{2, 3}})))
+	// Not related to a game object
+	assert.Equal(t, LogTypeGameState, logger.Filter(LogFilter{ObjectIDs: []int64{0}})[0].Type())
+	assert.Equal(t, 2, len(logger.Filter(LogFilter{SinceTick: 5})))
+	assert.Equal(t, []LogEntry{}, logger.Filter(LogFilter{SinceTick: 5, Types: []LogType{LogTypeDamage}}))
+}
+
+func TestEncodeLogsJSON(t *testing.T) {
+	logger := NewLogger(LogCapacity)
+	logger.SetTick(3)
+	logger.LogEvent(LogLevelWarning, "low energy", 7, map[string]interface{}{"energy": 5.0})
+	logger.GameState(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Running)
+
+	var buffer bytes.Buffer
+	assert.NoError(t, EncodeLogsJSON(&buffer, logger.Logs()))
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Equal(t, 2, len(lines))
+
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &decoded))
+	assert.Equal(t, "game_state", decoded["logType"])
+	assert.Equal(t, "2024-01-02 03:04:05", decoded["time"])
+	assert.Equal(t, 3.0, decoded["tick"])
+
+	// Round trips
+	entry, err := DeserializeLogEntry(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, "Game state changed to: running", entry.Message())
+	assert.Equal(t, uint64(3), entry.Tick())
+}
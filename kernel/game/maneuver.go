@@ -0,0 +1,81 @@
+package game
+
+import "math"
+
+type ManeuverStep struct {
+	// Rotation change in radians, turned by the max turn rate over as many ticks as needed
+	RotateDelta float64
+	// Main engine thrust, 0-100
+	ThrustAmount float64
+	// Fires the laser
+	Fire bool
+}
+
+// Maneuver is a sequence of steps executed one per tick.
+type Maneuver []ManeuverStep
+
+// PerformManeuver executes the first step immediately and schedules the rest
+// for the following updates, replacing any maneuver in progress. The rotation beyond
+// the turn budget carries over to the next updates, e.g. the whole first step's one
+// of a ship not updated yet.
+func (ship *Spaceship) PerformManeuver(maneuver Maneuver, gameManager *GameManager) {
+	ship.maneuver = maneuver
+	ship.maneuverStep = 0
+	ship.maneuverTurn = 0
+	if !ship.Stunned() {
+		ship.performManeuverStep(gameManager)
+	}
+}
+
+// ManeuverInProgress reports whether any maneuver step is yet to be executed.
+func (ship *Spaceship) ManeuverInProgress() bool {
+	return ship.maneuverStep < len(ship.maneuver)
+}
+
+// Stun pauses the maneuver in progress for the duration.
+func (ship *Spaceship) Stun(durationSec float64) {
+	ship.stunTimerSec = durationSec
+}
+
+func (ship *Spaceship) Stunned() bool {
+	return ship.stunTimerSec > 0
+}
+
+func (ship *Spaceship) performManeuverStep(gameManager *GameManager) {
+	if !ship.ManeuverInProgress() {
+		ship.turnManeuver()
+		return
+	}
+
+	step := ship.maneuver[ship.maneuverStep]
+	ship.maneuverStep++
+	ship.maneuverTurn += step.RotateDelta
+	ship.turnManeuver()
+	ship.SetEngineThrust(step.ThrustAmount, ship.engine.leftThrust, ship.engine.rightThrust)
+	if step.Fire {
+		ship.FireLaser(gameManager)
+	}
+
+	if !ship.ManeuverInProgress() {
+		ship.maneuver = nil
+		ship.maneuverStep = 0
+	}
+}
+
+// turnManeuver turns the ship by the maneuver rotation left, as far as the turn budget allows.
+func (ship *Spaceship) turnManeuver() {
+	turn := math.Max(-ship.turnBudget, math.Min(ship.maneuverTurn, ship.turnBudget))
+	ship.Rotate(turn)
+	ship.maneuverTurn -= turn
+}
+
+func (ship *Spaceship) maneuverManagement(deltaTimeSec float64, gameManager *GameManager) {
+	if ship.Stunned() {
+		ship.stunTimerSec -= deltaTimeSec
+		if ship.stunTimerSec < 0 {
+			ship.stunTimerSec = 0
+		}
+		return
+	}
+	ship.performManeuverStep(gameManager)
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpaceship_PerformManeuver(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+	gameManager.AddSpaceship(ship)
+
+	ship.PerformManeuver(Maneuver{
+		{ThrustAmount: 50},
+		{ThrustAmount: 0, Fire: true},
+	}, &gameManager)
+
+	// First step executes immediately
+	assert.Equal(t, float64(50), ship.engine.mainThrust)
+	assert.Equal(t, 1, gameManager.GameObjectSize())
+	assert.True(t, ship.ManeuverInProgress())
+
+	// Second step executes on the next tick
+	ship.Update(10, &gameManager)
+	assert.Equal(t, float64(0), ship.engine.mainThrust)
+	assert.Equal(t, 2, gameManager.GameObjectSize())
+	assert.IsType(t, &Projectile{}, gameManager.GetGameObjectByIndex(1))
+	assert.False(t, ship.ManeuverInProgress())
+
+	// Nothing left to execute
+	ship.Update(10, &gameManager)
+	assert.Equal(t, 2, gameManager.GameObjectSize())
+}
+
+func TestSpaceship_PerformManeuver_Rotate(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+	ship.SetMaxTurnRate(0.001)
+
+	ship.PerformManeuver(Maneuver{{RotateDelta: 0.05}, {RotateDelta: 0.05}}, &gameManager)
+	// No turn budget before the first update, the first step's turn carries over
+	assert.Equal(t, float64(0), ship.rotation)
+
+	ship.Update(100, &gameManager)
+	assert.InDelta(t, 0.1, ship.rotation, 1e-9)
+	assert.False(t, ship.ManeuverInProgress())
+}
+
+func TestSpaceship_PerformManeuver_RotateOverTurnBudget(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+	ship.SetMaxTurnRate(0.001)
+	ship.Update(10, &gameManager)
+
+	ship.PerformManeuver(Maneuver{{RotateDelta: -0.025}}, &gameManager)
+	assert.InDelta(t, -0.01, ship.rotation, 1e-9)
+
+	// Turned by the following ticks after the maneuver ended
+	ship.Update(10, &gameManager)
+	assert.InDelta(t, -0.02, ship.rotation, 1e-9)
+	ship.Update(10, &gameManager)
+	assert.InDelta(t, -0.025, ship.rotation, 1e-9)
+	ship.Update(10, &gameManager)
+	assert.InDelta(t, -0.025, ship.rotation, 1e-9)
+
+	// A new maneuver drops the rotation left
+	ship.PerformManeuver(Maneuver{{RotateDelta: 0.5}}, &gameManager)
+	ship.PerformManeuver(Maneuver{{}}, &gameManager)
+	ship.Update(10, &gameManager)
+	assert.InDelta(t, -0.015, ship.rotation, 1e-9)
+}
+
+func TestSpaceship_PerformManeuver_Stunned(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+
+	ship.PerformManeuver(Maneuver{
+		{ThrustAmount: 10},
+		{ThrustAmount: 20},
+		{ThrustAmount: 30},
+	}, &gameManager)
+	assert.Equal(t, float64(10), ship.engine.mainThrust)
+
+	ship.Stun(0.1)
+	assert.True(t, ship.Stunned())
+
+	// Paused while stunned
+	ship.Update(50, &gameManager)
+	assert.Equal(t, float64(10), ship.engine.mainThrust)
+	ship.Update(50, &gameManager)
+	assert.Equal(t, float64(10), ship.engine.mainThrust)
+	assert.False(t, ship.Stunned())
+
+	// Resumes with the next step
+	ship.Update(50, &gameManager)
+	assert.Equal(t, float64(20), ship.engine.mainThrust)
+	ship.Update(50, &gameManager)
+	assert.Equal(t, float64(30), ship.engine.mainThrust)
+	assert.False(t, ship.ManeuverInProgress())
+}
+
+func TestSpaceship_PerformManeuver_Reset(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+
+	ship.PerformManeuver(Maneuver{{ThrustAmount: 10}, {ThrustAmount: 20}}, &gameManager)
+	ship.Stun(1)
+	ship.Reset()
+
+	assert.False(t, ship.ManeuverInProgress())
+	assert.False(t, ship.Stunned())
+}
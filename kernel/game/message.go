@@ -0,0 +1,60 @@
+package game
+
+// Message is sent between the spaceships, e.g. to share the enemy positions between the bots.
+type Message struct {
+	From     string
+	To       string // Empty for the broadcast
+	TickSent uint64
+	Payload  interface{}
+}
+
+// SendMessage queues the message, it is delivered at the start of the next tick, before the game
+// objects update. The empty recipient broadcasts the message to all the other enabled spaceships.
+func (manager *GameManager) SendMessage(from, to string, payload interface{}) error {
+	message := Message{From: from, To: to, TickSent: manager.tick, Payload: payload}
+	if to == "" {
+		manager.outbox = append(manager.outbox, message)
+		return nil
+	}
+
+	spaceShip, err := manager.GetSpaceship(to)
+	if err != nil {
+		return err
+	}
+	if !spaceShip.enabled {
+		return ErrSpaceshipDisabled{Name: to}
+	}
+	manager.outbox = append(manager.outbox, message)
+	return nil
+}
+
+func (manager *GameManager) deliverMessages() {
+	outbox := manager.outbox
+	manager.outbox = nil
+	for _, message := range outbox {
+		if message.To != "" {
+			if spaceShip, ok := manager.spaceShips[message.To]; ok && spaceShip.enabled {
+				spaceShip.inbox = append(spaceShip.inbox, message)
+			}
+			continue
+		}
+
+		for _, spaceShip := range manager.Spaceships() {
+			if spaceShip.enabled && spaceShip.name != message.From {
+				spaceShip.inbox = append(spaceShip.inbox, message)
+			}
+		}
+	}
+}
+
+// InboxSize returns the number of the delivered messages waiting to be received.
+func (ship *Spaceship) InboxSize() int {
+	return len(ship.inbox)
+}
+
+// ReceiveMessages returns the delivered messages in the sending order and empties the inbox.
+func (ship *Spaceship) ReceiveMessages() []Message {
+	messages := ship.inbox
+	ship.inbox = nil
+	return messages
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMessagingGame() (*Game, *Spaceship, *Spaceship, *Spaceship) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	alpha, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 100}})
+	beta, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "beta", Position: physics.Vector2{X: 500, Y: 100}})
+	gamma, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "gamma", Position: physics.Vector2{X: 900, Y: 100}})
+	return game, alpha, beta, gamma
+}
+
+func TestGameManager_SendMessage(t *testing.T) {
+	game, _, beta, _ := newMessagingGame()
+	game.Update(16)
+
+	assert.NoError(t, game.manager.SendMessage("alpha", "beta", "first"))
+	assert.NoError(t, game.manager.SendMessage("gamma", "beta", "second"))
+	assert.Equal(t, 0, beta.InboxSize())
+
+	// Delivered before the update of the next tick, in order
+	var received []Message
+	game.AddBot("beta", BotStrategyFunc(func(spaceship *Spaceship, gameManager *GameManager, deltaTimeMs float64) {
+		received = append(received, spaceship.ReceiveMessages()...)
+	}))
+	game.Update(16)
+	assert.Equal(t, []Message{
+		{From: "alpha", To: "beta", TickSent: 1, Payload: "first"},
+		{From: "gamma", To: "beta", TickSent: 1, Payload: "second"},
+	}, received)
+	assert.Equal(t, 0, beta.InboxSize())
+
+	// Unknown recipient
+	assert.Error(t, game.manager.SendMessage("alpha", "delta", nil))
+}
+
+func TestGameManager_SendMessage_Broadcast(t *testing.T) {
+	game, alpha, beta, gamma := newMessagingGame()
+
+	assert.NoError(t, game.manager.SendMessage("alpha", "", physics.Vector2{X: 1, Y: 2}))
+	game.manager.Tick(16)
+
+	assert.Equal(t, 0, alpha.InboxSize())
+	assert.Equal(t, 1, beta.InboxSize())
+	assert.Equal(t, 1, gamma.InboxSize())
+	assert.Equal(t, []Message{{From: "alpha", TickSent: 0, Payload: physics.Vector2{X: 1, Y: 2}}}, gamma.ReceiveMessages())
+}
+
+func TestGameManager_SendMessage_Disabled(t *testing.T) {
+	game, _, beta, gamma := newMessagingGame()
+	beta.SetEnabled(false)
+
+	err := game.manager.SendMessage("alpha", "beta", "hello")
+	var disabled ErrSpaceshipDisabled
+	assert.True(t, errors.As(err, &disabled))
+	assert.Equal(t, "beta", disabled.Name)
+
+	// Skipped by the broadcast
+	assert.NoError(t, game.manager.SendMessage("alpha", "", "hello"))
+	game.manager.Tick(16)
+	assert.Equal(t, 0, beta.InboxSize())
+	assert.Equal(t, 1, gamma.InboxSize())
+}
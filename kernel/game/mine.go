@@ -0,0 +1,29 @@
+package game
+
+import (
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
+
+// NewMineProjectile creates a mine staying at the position until hit or expired.
+func NewMineProjectile(id int64, position physics.Vector2, owner *Spaceship) *Projectile {
+	projectile := &Projectile{}
+	initMineProjectile(projectile, id, position, owner)
+	return projectile
+}
+
+// initMineProjectile initializes the projectile as NewMineProjectile.
+func initMineProjectile(projectile *Projectile, id int64, position physics.Vector2, owner *Spaceship) {
+	*projectile = Projectile{
+		id:                   id,
+		damageType:           DamageTypeMine,
+		enabled:              true,
+		position:             position,
+		lifespanSec:          MineLifespanSec,
+		damage:               MineDamage,
+		owner:                owner,
+		explosionRadius:      float64(MineExplosionRadius),
+		explosionDurationSec: float64(MineExplosionDurationSec),
+		collider:             collider.NewCircleCollider(position, MineRadius),
+	}
+}
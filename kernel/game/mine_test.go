@@ -0,0 +1,43 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMineProjectile(t *testing.T) {
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 0)
+	mine := NewMineProjectile(1, physics.Vector2{X: 5, Y: 30}, owner)
+
+	assert.Equal(t, int64(1), mine.ID())
+	assert.Equal(t, DamageTypeMine, mine.damageType)
+	assert.Equal(t, true, mine.Enabled())
+	assert.Equal(t, physics.Vector2{X: 5, Y: 30}, mine.Position())
+	assert.Equal(t, physics.Vector2{}, mine.velocity)
+	assert.Equal(t, 30.0, mine.lifespanSec)
+	assert.Equal(t, 40.0, mine.damage)
+	assert.Equal(t, owner, mine.owner)
+	assert.Equal(t, 8.0, mine.collider.(*collider.CircleCollider).Radius())
+
+	gameManager := NewGameManager()
+	mine.Update(1000, &gameManager)
+	assert.Equal(t, physics.Vector2{X: 5, Y: 30}, mine.Position())
+	assert.Equal(t, 29.0, mine.lifespanSec)
+}
+
+func TestMine_Serialize(t *testing.T) {
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 0)
+	mine := NewMineProjectile(2, physics.Vector2{X: 5, Y: 30}, owner)
+
+	serialized := mine.Serialize()
+	assert.Equal(t, "mine", serialized["type"])
+
+	restored, err := DeserializeProjectile(serialized, owner)
+	assert.NoError(t, err)
+	assert.Equal(t, DamageTypeMine, restored.damageType)
+	assert.Equal(t, mine.Serialize(), restored.Serialize())
+}
@@ -0,0 +1,91 @@
+package game
+
+import "github.com/davidhorak/space-wars/kernel/physics"
+
+// ObjectFactory creates the game objects spawned during the game, e.g. to inject the test doubles
+// or the pre-configured objects.
+type ObjectFactory interface {
+	NewAsteroid(id int64, position physics.Vector2, radius float64) *Asteroid
+	NewSpaceship(id int64, name string, position physics.Vector2, rotation float64, options ...SpaceshipOption) *Spaceship
+	NewLaserProjectile(id int64, position physics.Vector2, rotation float64, owner *Spaceship) *Projectile
+	NewRocketProjectile(id int64, position physics.Vector2, rotation float64, owner *Spaceship) *Projectile
+}
+
+// DefaultObjectFactory creates the game objects with the package constructors.
+type DefaultObjectFactory struct{}
+
+func (factory DefaultObjectFactory) NewAsteroid(id int64, position physics.Vector2, radius float64) *Asteroid {
+	return NewAsteroid(id, position, radius)
+}
+
+func (factory DefaultObjectFactory) NewSpaceship(id int64, name string, position physics.Vector2, rotation float64, options ...SpaceshipOption) *Spaceship {
+	return NewSpaceship(id, name, position, rotation, options...)
+}
+
+func (factory DefaultObjectFactory) NewLaserProjectile(id int64, position physics.Vector2, rotation float64, owner *Spaceship) *Projectile {
+	return NewLaserProjectile(id, position, rotation, owner)
+}
+
+func (factory DefaultObjectFactory) NewRocketProjectile(id int64, position physics.Vector2, rotation float64, owner *Spaceship) *Projectile {
+	return NewRocketProjectile(id, position, rotation, owner)
+}
+
+func (factory DefaultObjectFactory) initLaserProjectile(projectile *Projectile, id int64, position physics.Vector2, rotation float64, owner *Spaceship) {
+	initLaserProjectile(projectile, id, position, rotation, owner)
+}
+
+func (factory DefaultObjectFactory) initRocketProjectile(projectile *Projectile, id int64, position physics.Vector2, rotation float64, owner *Spaceship) {
+	initRocketProjectile(projectile, id, position, rotation, owner)
+}
+
+// recyclingObjectFactory is implemented by the package factories, their projectiles are recycled from
+// the projectile pool. The projectiles of the other factories are created by them.
+type recyclingObjectFactory interface {
+	initLaserProjectile(projectile *Projectile, id int64, position physics.Vector2, rotation float64, owner *Spaceship)
+	initRocketProjectile(projectile *Projectile, id int64, position physics.Vector2, rotation float64, owner *Spaceship)
+}
+
+// recyclingFactory returns the object factory if it is one of the package factories. The factories
+// embedding them are not, their overridden constructors are called.
+func (manager *GameManager) recyclingFactory() (recyclingObjectFactory, bool) {
+	switch factory := manager.ObjectFactory().(type) {
+	case DefaultObjectFactory:
+		return factory, true
+	case configuredObjectFactory:
+		return factory, true
+	default:
+		return nil, false
+	}
+}
+
+// newLaserProjectile returns the laser of the object factory, with a new id.
+func (manager *GameManager) newLaserProjectile(position physics.Vector2, rotation float64, owner *Spaceship) *Projectile {
+	factory, ok := manager.recyclingFactory()
+	if !ok {
+		return manager.ObjectFactory().NewLaserProjectile(manager.NewID(), position, rotation, owner)
+	}
+	projectile := manager.projectilePool.get()
+	factory.initLaserProjectile(projectile, manager.NewID(), position, rotation, owner)
+	return projectile
+}
+
+// newRocketProjectile returns the rocket of the object factory, with a new id.
+func (manager *GameManager) newRocketProjectile(position physics.Vector2, rotation float64, owner *Spaceship) *Projectile {
+	factory, ok := manager.recyclingFactory()
+	if !ok {
+		return manager.ObjectFactory().NewRocketProjectile(manager.NewID(), position, rotation, owner)
+	}
+	projectile := manager.projectilePool.get()
+	factory.initRocketProjectile(projectile, manager.NewID(), position, rotation, owner)
+	return projectile
+}
+
+func (manager *GameManager) newMineProjectile(position physics.Vector2, owner *Spaceship) *Projectile {
+	projectile := manager.projectilePool.get()
+	initMineProjectile(projectile, manager.NewID(), position, owner)
+	return projectile
+}
+
+func (manager *GameManager) newExplosion(position physics.Vector2, radius float64, lifespanSec float64) *Explosion {
+	return manager.explosionPool.Get(manager.NewID(), position, radius, lifespanSec)
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+type MockObjectFactory struct {
+	DefaultObjectFactory
+	asteroids   int
+	spaceships  int
+	projectiles int
+}
+
+func (factory *MockObjectFactory) NewAsteroid(id int64, position physics.Vector2, radius float64) *Asteroid {
+	factory.asteroids++
+	return NewAsteroid(id, position, MinAsteroidSize)
+}
+
+func (factory *MockObjectFactory) NewSpaceship(id int64, name string, position physics.Vector2, rotation float64, options ...SpaceshipOption) *Spaceship {
+	factory.spaceships++
+	return NewSpaceship(id, name, position, rotation, append(options, WithShield(50, 0, 0))...)
+}
+
+func (factory *MockObjectFactory) NewLaserProjectile(id int64, position physics.Vector2, rotation float64, owner *Spaceship) *Projectile {
+	factory.projectiles++
+	return NewRocketProjectile(id, position, rotation, owner)
+}
+
+func TestGameManager_SetObjectFactory(t *testing.T) {
+	factory := &MockObjectFactory{}
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game.manager.SetObjectFactory(factory)
+
+	game.SeedAsteroids()
+	assert.Greater(t, factory.asteroids, 0)
+	for _, gameObject := range game.manager.GameObjects() {
+		assert.Equal(t, float64(MinAsteroidSize), gameObject.(*Asteroid).radius)
+	}
+
+	ship, err := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 100}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, factory.spaceships)
+	assert.Equal(t, 50.0, ship.Shield().Max())
+
+	assert.NoError(t, ship.FireLaser(&game.manager))
+	assert.Equal(t, 1, factory.projectiles)
+	projectiles := game.manager.Query(func(gameObject GameObject) bool {
+		_, ok := gameObject.(*Projectile)
+		return ok
+	})
+	assert.Len(t, projectiles, 1)
+	assert.Equal(t, float64(RocketDamage), projectiles[0].(*Projectile).Damage())
+}
+
+func TestGameManager_SetObjectFactory_Nil(t *testing.T) {
+	gameManager := NewGameManager()
+	assert.Equal(t, DefaultObjectFactory{}, gameManager.ObjectFactory())
+
+	gameManager.SetObjectFactory(&MockObjectFactory{})
+	gameManager.SetObjectFactory(nil)
+	assert.Equal(t, DefaultObjectFactory{}, gameManager.ObjectFactory())
+
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+	assert.NoError(t, ship.FireLaser(&gameManager))
+	assert.Equal(t, float64(LaserDamage), gameManager.GameObjects()[0].(*Projectile).Damage())
+}
@@ -0,0 +1,108 @@
+package game
+
+import "sync"
+
+// WithWorkers splits the object updates and the collision narrow-phase across the worker goroutines,
+// for the large simulations. The game stays reproducible, the result is the same as of the serial update.
+func WithWorkers(n int) GameOption {
+	return func(game *Game) {
+		game.manager.SetWorkers(n)
+	}
+}
+
+// SetWorkers sets the number of the workers of the parallel physics update, 1 or less updates serially.
+//
+// Only the runs of the objects updating their own state alone, e.g. the asteroids and the unguided
+// projectiles in flight, are split, the other objects are updated in the update order as before.
+// The narrow-phase checks of the collision pairs are done up front, the collisions are handled in
+// the order of the pairs. The runs and the pairs shorter than the ParallelMinBatch are not split.
+func (manager *GameManager) SetWorkers(n int) {
+	manager.workers = n
+}
+
+func (manager *GameManager) Workers() int {
+	return max(manager.workers, 1)
+}
+
+// concurrentUpdater is implemented by the game objects whose Update may run concurrently with
+// the other such objects: it changes the object's own state only and reads no other object.
+type concurrentUpdater interface {
+	updatesConcurrently(deltaTimeMs float64) bool
+}
+
+func updatesConcurrently(gameObject GameObject, deltaTimeMs float64) bool {
+	updater, ok := gameObject.(concurrentUpdater)
+	return ok && updater.updatesConcurrently(deltaTimeMs)
+}
+
+// updateObjects updates the enabled game objects in the update order, the runs of the objects
+// updating concurrently are split across the workers.
+func (game *Game) updateObjects(deltaTimeMs float64) {
+	order := game.manager.UpdateOrder()
+	workers := game.manager.Workers()
+	for i := 0; i < len(order); {
+		end := i
+		if workers > 1 {
+			for end < len(order) && updatesConcurrently(order[end], deltaTimeMs) {
+				end++
+			}
+		}
+		if end-i >= ParallelMinBatch {
+			run := order[i:end]
+			parallelFor(workers, len(run), func(j int) {
+				game.updateObject(run[j], deltaTimeMs)
+			})
+			i = end
+			continue
+		}
+
+		for end = max(end, i+1); i < end; i++ {
+			game.updateObject(order[i], deltaTimeMs)
+		}
+	}
+}
+
+func (game *Game) updateObject(gameObject GameObject, deltaTimeMs float64) {
+	if !gameObject.Enabled() {
+		return
+	}
+	gameObject.Update(deltaTimeMs, &game.manager)
+	if game.config.Arena == ArenaBounded {
+		game.confine(gameObject)
+	} else {
+		game.wrap(gameObject)
+	}
+}
+
+// narrowPhase returns whether the colliders of the broad-phase pairs collide, checked by the workers.
+// Nil when updating serially, the pairs are then checked as the collisions are handled.
+func (manager *GameManager) narrowPhase(objects []GameObject, pairs [][2]int) []bool {
+	workers := manager.Workers()
+	if workers <= 1 || len(pairs) < ParallelMinBatch {
+		return nil
+	}
+
+	contacts := make([]bool, len(pairs))
+	parallelFor(workers, len(pairs), func(i int) {
+		a, b := objects[pairs[i][0]], objects[pairs[i][1]]
+		contacts[i] = a.Collider().CollidesWith(b.Collider())
+	})
+	return contacts
+}
+
+// parallelFor calls the function for every index, the workers take the contiguous chunks of them.
+func parallelFor(workers, n int, function func(i int)) {
+	chunk := (n + workers - 1) / workers
+	var group sync.WaitGroup
+	for start := 0; start < n; start += chunk {
+		end := min(start+chunk, n)
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for i := start; i < end; i++ {
+				function(i)
+			}
+		}()
+	}
+	group.Wait()
+}
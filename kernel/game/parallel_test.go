@@ -0,0 +1,129 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCrowdedGame returns a running game of the drifting asteroids and two spaceships firing at each other.
+func newCrowdedGame(options ...GameOption) *Game {
+	game := NewGame(physics.Size{Width: 2000, Height: 2000}, 1234567890, options...)
+	random := rand.New(rand.NewSource(42))
+	for i := 0; i < 600; i++ {
+		asteroid := NewAsteroid(game.manager.NewID(), physics.Vector2{X: random.Float64() * 2000, Y: random.Float64() * 2000}, MinAsteroidSize)
+		asteroid.SetVelocity(physics.Vector2{X: random.Float64()*200 - 100, Y: random.Float64()*200 - 100})
+		game.manager.AddGameObject(asteroid)
+	}
+	game.AddSpaceship("alpha", physics.Vector2{X: 500, Y: 1000}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 1500, Y: 1000}, 3.14)
+	game.Start()
+	return game
+}
+
+func objectsJSON(t *testing.T, game *Game) string {
+	var objects []interface{}
+	for _, gameObject := range game.manager.GameObjects() {
+		objects = append(objects, gameObject.Serialize())
+	}
+	data, err := json.Marshal(objects)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+func TestWithWorkers(t *testing.T) {
+	serial := newCrowdedGame()
+	parallel := newCrowdedGame(WithWorkers(4))
+	assert.Equal(t, 1, serial.manager.Workers())
+	assert.Equal(t, 4, parallel.manager.Workers())
+
+	for i := 0; i < 200; i++ {
+		for _, game := range []*Game{serial, parallel} {
+			for _, name := range []string{"alpha", "beta"} {
+				if ship, err := game.manager.GetSpaceship(name); err == nil {
+					_ = ship.FireLaser(&game.manager)
+				}
+			}
+			game.Update(16)
+		}
+	}
+
+	// The same result as of the serial update
+	assert.Equal(t, serial.manager.CurrentTick(), parallel.manager.CurrentTick())
+	assert.Equal(t, objectsJSON(t, serial), objectsJSON(t, parallel))
+	assert.Equal(t, serial.Scoreboard(), parallel.Scoreboard())
+}
+
+func TestGame_UpdateObjects(t *testing.T) {
+	game := newCrowdedGame(WithWorkers(3))
+	owner, _ := game.manager.GetSpaceship("alpha")
+	guided := NewLaserProjectile(game.manager.NewID(), physics.Vector2{X: 10, Y: 10}, 0, owner)
+	guided.GuidedBy(func(projectile *Projectile, gameManager *GameManager) physics.Vector2 {
+		return physics.Vector2{X: 0, Y: 100}
+	})
+	expiring := NewLaserProjectile(game.manager.NewID(), physics.Vector2{X: 20, Y: 20}, 0, owner)
+	expiring.lifespanSec = 0.01
+	game.manager.AddGameObject(guided)
+	game.manager.AddGameObject(expiring)
+
+	assert.False(t, updatesConcurrently(guided, 16))
+	assert.False(t, updatesConcurrently(expiring, 16))
+	assert.False(t, updatesConcurrently(owner, 16))
+	asteroid := game.manager.Query(func(gameObject GameObject) bool { _, ok := gameObject.(*Asteroid); return ok })[0]
+	assert.True(t, updatesConcurrently(asteroid, 16))
+
+	position := asteroid.Position()
+	game.updateObjects(1000)
+	assert.Equal(t, position.Add(asteroid.(*Asteroid).Velocity()), asteroid.Position())
+	assert.Equal(t, physics.Vector2{X: 10, Y: 110}, guided.Position())
+	assert.False(t, expiring.Enabled())
+}
+
+func TestGameManager_NarrowPhase(t *testing.T) {
+	gameManager := NewGameManager()
+	objects := []GameObject{
+		NewAsteroid(1, physics.Vector2{X: 0, Y: 0}, 10),
+		NewAsteroid(2, physics.Vector2{X: 5, Y: 0}, 10),
+		NewAsteroid(3, physics.Vector2{X: 50, Y: 0}, 10),
+	}
+	var pairs [][2]int
+	for i := 0; i < ParallelMinBatch; i++ {
+		pairs = append(pairs, [2]int{0, 1 + i%2})
+	}
+
+	// Checked as the collisions are handled
+	assert.Nil(t, gameManager.narrowPhase(objects, pairs))
+
+	gameManager.SetWorkers(4)
+	assert.Nil(t, gameManager.narrowPhase(objects, pairs[:ParallelMinBatch-1]))
+	contacts := gameManager.narrowPhase(objects, pairs)
+	for i, contact := range contacts {
+		assert.Equal(t, i%2 == 0, contact)
+	}
+}
+
+func TestParallelFor(t *testing.T) {
+	for _, workers := range []int{1, 3, 4, 100} {
+		calls := make([]int, 10)
+		parallelFor(workers, len(calls), func(i int) {
+			calls[i]++
+		})
+		assert.Equal(t, []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, calls)
+	}
+}
+
+func BenchmarkGame_Update_Workers(b *testing.B) {
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("%d workers", workers), func(b *testing.B) {
+			game := newCrowdedGame(WithWorkers(workers))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				game.Update(16)
+			}
+		})
+	}
+}
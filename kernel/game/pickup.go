@@ -0,0 +1,221 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
+
+type PickupType string
+
+const (
+	PickupHealth      PickupType = "health"
+	PickupEnergy      PickupType = "energy"
+	PickupWeaponBoost PickupType = "weaponBoost"
+	PickupShield      PickupType = "shield"
+)
+
+// PickupTypes lists the pickup types in the order of the PickupType enum of proto/game_state.proto.
+var PickupTypes = []PickupType{PickupHealth, PickupEnergy, PickupWeaponBoost, PickupShield}
+
+func (pickupType PickupType) valid() bool {
+	for _, known := range PickupTypes {
+		if pickupType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Pickup is a power-up collected by the first enabled spaceship overlapping it, it despawns once
+// collected or after its lifespan. It is a trigger, the projectiles and the asteroids pass through it.
+type Pickup struct {
+	id          int64
+	enabled     bool
+	position    physics.Vector2
+	pickupType  PickupType
+	lifespanSec float64
+	collider    collider.CircleCollider
+	collector   *Spaceship // Of the current tick
+}
+
+func NewPickup(id int64, position physics.Vector2, pickupType PickupType) *Pickup {
+	return &Pickup{
+		id:          id,
+		enabled:     true,
+		position:    position,
+		pickupType:  pickupType,
+		lifespanSec: PickupLifespanSec,
+		collider:    *collider.NewCircleCollider(position, PickupRadius),
+	}
+}
+
+func (pickup *Pickup) ID() int64 {
+	return pickup.id
+}
+
+func (pickup *Pickup) Type() PickupType {
+	return pickup.pickupType
+}
+
+func (pickup *Pickup) Enabled() bool {
+	return pickup.enabled
+}
+
+func (pickup *Pickup) SetEnabled(enabled bool) {
+	pickup.enabled = enabled
+}
+
+func (pickup *Pickup) Position() physics.Vector2 {
+	return pickup.position
+}
+
+func (pickup *Pickup) SetPosition(position physics.Vector2) {
+	pickup.position = position
+	pickup.collider.SetPosition(position)
+}
+
+func (pickup *Pickup) IsTrigger() bool {
+	return true
+}
+
+func (pickup *Pickup) Update(deltaTimeMs float64, gameManager *GameManager) {
+	pickup.lifespanSec -= deltaTimeMs / 1000
+	if pickup.lifespanSec <= 0 {
+		pickup.lifespanSec = 0
+		gameManager.DisableGameObject(pickup)
+		gameManager.RemoveGameObject(pickup)
+	}
+}
+
+func (pickup *Pickup) Collider() collider.Collider {
+	return &pickup.collider
+}
+
+func (pickup *Pickup) OnCollision(other GameObject, gameManager *GameManager, order int) {}
+
+func (pickup *Pickup) overlap(other GameObject) {
+	if ship, ok := other.(*Spaceship); ok && pickup.collector == nil {
+		pickup.collector = ship
+	}
+}
+
+func (pickup *Pickup) resolveOverlaps(gameManager *GameManager) {
+	if pickup.collector == nil {
+		return
+	}
+	ship := pickup.collector
+	pickup.collector = nil
+	pickup.Collect(ship, gameManager)
+}
+
+// Collect applies the pickup to the spaceship, despawns it and publishes PickupCollected.
+func (pickup *Pickup) Collect(ship *Spaceship, gameManager *GameManager) {
+	if !pickup.enabled || !ship.enabled {
+		return
+	}
+
+	switch pickup.pickupType {
+	case PickupHealth:
+		ship.Heal(PickupHealAmount)
+	case PickupEnergy:
+		ship.energy = math.Min(ship.energy+PickupEnergyAmount, MaxEnergy)
+	case PickupWeaponBoost:
+		_ = ship.BoostDamage(PickupDamageMultiplier, PickupBoostDurationMs)
+	case PickupShield:
+		ship.shield.recharge(PickupShieldAmount)
+	}
+	gameManager.DisableGameObject(pickup)
+	gameManager.RemoveGameObject(pickup)
+	gameManager.publish(PickupCollected{Tick: gameManager.tick, Pickup: pickup, Ship: ship})
+}
+
+func (pickup *Pickup) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "pickup",
+		"id":      pickup.id,
+		"enabled": pickup.enabled,
+		"position": map[string]interface{}{
+			"x": pickup.position.X,
+			"y": pickup.position.Y,
+		},
+		"pickupType":  string(pickup.pickupType),
+		"lifespanSec": pickup.lifespanSec,
+		"collider":    pickup.collider.Serialize(),
+	}
+}
+
+// DeserializePickup restores the pickup from its Serialize output.
+func DeserializePickup(data map[string]interface{}) (*Pickup, error) {
+	id, enabled, position, err := gameObjectHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	pickupType, err := text(data, "pickupType")
+	if err != nil {
+		return nil, err
+	}
+	if !PickupType(pickupType).valid() {
+		return nil, ErrInvalidState{Field: "pickupType"}
+	}
+	lifespanSec, err := number(data, "lifespanSec")
+	if err != nil {
+		return nil, err
+	}
+
+	pickup := NewPickup(id, position, PickupType(pickupType))
+	pickup.enabled = enabled
+	pickup.lifespanSec = lifespanSec
+	return pickup, nil
+}
+
+// pickupSpawner schedules the pickups spawned by the GameManager, disabled while maxIntervalMs is 0.
+type pickupSpawner struct {
+	minIntervalMs float64
+	maxIntervalMs float64
+	nextMs        float64 // Elapsed time of the next spawn, 0 when not scheduled yet
+}
+
+// WithPickups makes the game spawn a random pickup every minIntervalMs to maxIntervalMs, drawn from
+// the seeded random numbers, at a random position of the arena. At most MaxPickups are out at once.
+func WithPickups(minIntervalMs, maxIntervalMs float64) GameOption {
+	return func(game *Game) {
+		game.manager.pickups = pickupSpawner{
+			minIntervalMs: math.Max(minIntervalMs, 0),
+			maxIntervalMs: math.Max(maxIntervalMs, minIntervalMs),
+		}
+	}
+}
+
+func (spawner *pickupSpawner) interval(random *rand.Rand) float64 {
+	return spawner.minIntervalMs + random.Float64()*(spawner.maxIntervalMs-spawner.minIntervalMs)
+}
+
+// spawnPickups spawns the pickup once its time comes and schedules the next one. The first pickup
+// is scheduled on the first tick, the skipped spawns of the full arena are not made up for.
+func (manager *GameManager) spawnPickups() {
+	spawner := &manager.pickups
+	if spawner.maxIntervalMs <= 0 {
+		return
+	}
+	if spawner.nextMs == 0 {
+		spawner.nextMs = manager.elapsedMs + spawner.interval(manager.rand)
+		return
+	}
+	if manager.elapsedMs < spawner.nextMs {
+		return
+	}
+	spawner.nextMs = manager.elapsedMs + spawner.interval(manager.rand)
+
+	if manager.Count(func(gameObject GameObject) bool { _, ok := gameObject.(*Pickup); return ok }) >= MaxPickups {
+		return
+	}
+	pickupType := PickupTypes[manager.rand.Intn(len(PickupTypes))]
+	position := physics.Vector2{
+		X: PickupRadius + manager.rand.Float64()*math.Max(manager.size.Width-2*PickupRadius, 0),
+		Y: PickupRadius + manager.rand.Float64()*math.Max(manager.size.Height-2*PickupRadius, 0),
+	}
+	manager.AddGameObject(NewPickup(manager.NewID(), position, pickupType))
+}
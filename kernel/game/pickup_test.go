@@ -0,0 +1,166 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickup_Collect(t *testing.T) {
+	gameManager := NewGameManager()
+	var collected []PickupCollected
+	gameManager.Events().Subscribe(func(event GameEvent) {
+		if event, ok := event.(PickupCollected); ok {
+			collected = append(collected, event)
+		}
+	})
+
+	t.Run("Health", func(t *testing.T) {
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+		ship.TakeDamage(50, &gameManager, nil)
+		pickup := NewPickup(2, physics.Vector2{X: 0, Y: 0}, PickupHealth)
+		_, _ = gameManager.AddGameObject(pickup)
+
+		pickup.Collect(ship, &gameManager)
+		assert.Equal(t, 50.0+PickupHealAmount, ship.Health())
+		assert.False(t, pickup.Enabled())
+		assert.Nil(t, gameManager.GetGameObjectByID(pickup.ID()))
+		assert.Equal(t, PickupCollected{Tick: 0, Pickup: pickup, Ship: ship}, collected[len(collected)-1])
+
+		// Collected once
+		pickup.Collect(ship, &gameManager)
+		assert.Equal(t, 50.0+PickupHealAmount, ship.Health())
+		assert.Len(t, collected, 1)
+	})
+
+	t.Run("Energy", func(t *testing.T) {
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+		ship.energy = 10
+		NewPickup(2, physics.Vector2{X: 0, Y: 0}, PickupEnergy).Collect(ship, &gameManager)
+		assert.Equal(t, 10.0+PickupEnergyAmount, ship.Energy())
+		NewPickup(3, physics.Vector2{X: 0, Y: 0}, PickupEnergy).Collect(ship, &gameManager)
+		assert.Equal(t, float64(MaxEnergy), ship.Energy())
+	})
+
+	t.Run("Shield", func(t *testing.T) {
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0, WithShield(80, 0.01, 1000))
+		ship.TakeDamage(80, &gameManager, nil)
+		NewPickup(2, physics.Vector2{X: 0, Y: 0}, PickupShield).Collect(ship, &gameManager)
+		assert.Equal(t, float64(PickupShieldAmount), ship.Shield().Current())
+		NewPickup(3, physics.Vector2{X: 0, Y: 0}, PickupShield).Collect(ship, &gameManager)
+		assert.Equal(t, 80.0, ship.Shield().Current())
+	})
+
+	t.Run("Weapon boost", func(t *testing.T) {
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+		target := NewSpaceship(2, "target", physics.Vector2{X: 100, Y: 0}, 0)
+		NewPickup(3, physics.Vector2{X: 0, Y: 0}, PickupWeaponBoost).Collect(ship, &gameManager)
+		assert.Equal(t, float64(PickupDamageMultiplier), ship.DamageMultiplier())
+
+		laser := NewLaserProjectile(4, physics.Vector2{X: 100, Y: 0}, 0, ship)
+		laser.OnCollision(target, &gameManager, 0)
+		assert.Equal(t, MaxHealth-LaserDamage*PickupDamageMultiplier, target.HullZones()[HullZoneFront])
+
+		// Expires
+		ship.Update(PickupBoostDurationMs, &gameManager)
+		assert.Equal(t, 1.0, ship.DamageMultiplier())
+	})
+
+	t.Run("Disabled ship", func(t *testing.T) {
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+		ship.SetEnabled(false)
+		pickup := NewPickup(2, physics.Vector2{X: 0, Y: 0}, PickupHealth)
+		pickup.Collect(ship, &gameManager)
+		assert.True(t, pickup.Enabled())
+	})
+}
+
+func TestPickup_Update(t *testing.T) {
+	gameManager := NewGameManager()
+	pickup := NewPickup(1, physics.Vector2{X: 0, Y: 0}, PickupEnergy)
+	_, _ = gameManager.AddGameObject(pickup)
+
+	pickup.Update(PickupLifespanSec*1000-1, &gameManager)
+	assert.True(t, pickup.Enabled())
+	pickup.Update(1, &gameManager)
+	assert.False(t, pickup.Enabled())
+	assert.Equal(t, 0, gameManager.GameObjectSize())
+}
+
+func TestGame_Pickups(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithPickups(1000, 2000))
+	alpha, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 100}})
+	game.AddSpaceship("beta", physics.Vector2{X: 900, Y: 900}, 0)
+	game.Start()
+
+	pickups := func() []GameObject {
+		return game.manager.Query(func(gameObject GameObject) bool { _, ok := gameObject.(*Pickup); return ok })
+	}
+	game.Update(999)
+	assert.Empty(t, pickups())
+	for i := 0; i < 10; i++ {
+		game.Update(1000)
+	}
+	// Capped, the older ones still within the lifespan
+	assert.Len(t, pickups(), MaxPickups)
+	arena := physics.Rect{X: PickupRadius, Y: PickupRadius, Width: 1000 - 2*PickupRadius, Height: 1000 - 2*PickupRadius}
+	for _, gameObject := range pickups() {
+		assert.True(t, arena.Contains(gameObject.Position()))
+	}
+
+	t.Run("Collected on overlap", func(t *testing.T) {
+		pickup := pickups()[0].(*Pickup)
+		alpha.SetPosition(pickup.Position())
+		alpha.collider.SetPosition(pickup.Position())
+		game.Update(16)
+		assert.False(t, pickup.Enabled())
+		assert.Nil(t, game.manager.GetGameObjectByID(pickup.ID()))
+	})
+
+	t.Run("Seeded", func(t *testing.T) {
+		other := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithPickups(1000, 2000))
+		other.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+		other.AddSpaceship("beta", physics.Vector2{X: 900, Y: 900}, 0)
+		other.Start()
+		game.Reset()
+		for i := 0; i < 5; i++ {
+			game.Update(1000)
+			other.Update(1000)
+		}
+		assert.NotEmpty(t, pickups())
+		expected := other.manager.Query(func(gameObject GameObject) bool { _, ok := gameObject.(*Pickup); return ok })
+		assert.Len(t, pickups(), len(expected))
+		for i, gameObject := range pickups() {
+			assert.Equal(t, expected[i].Position(), gameObject.Position())
+			assert.Equal(t, expected[i].(*Pickup).Type(), gameObject.(*Pickup).Type())
+		}
+	})
+}
+
+func TestPickup_Serialize(t *testing.T) {
+	pickup := NewPickup(1, physics.Vector2{X: 10, Y: 20}, PickupWeaponBoost)
+	pickup.lifespanSec = 3
+
+	assert.Equal(t, map[string]interface{}{
+		"type":    "pickup",
+		"id":      int64(1),
+		"enabled": true,
+		"position": map[string]interface{}{
+			"x": 10.0,
+			"y": 20.0,
+		},
+		"pickupType":  "weaponBoost",
+		"lifespanSec": 3.0,
+		"collider":    pickup.collider.Serialize(),
+	}, pickup.Serialize())
+
+	deserialized, err := DeserializePickup(pickup.Serialize())
+	assert.NoError(t, err)
+	assert.Equal(t, pickup, deserialized)
+
+	serialized := pickup.Serialize()
+	serialized["pickupType"] = "invincibility"
+	_, err = DeserializePickup(serialized)
+	assert.Equal(t, ErrInvalidState{Field: "pickupType"}, err)
+}
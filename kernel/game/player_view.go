@@ -0,0 +1,71 @@
+package game
+
+// redactedShipKeys are the serialized fields of the other spaceships hidden from a player: their
+// energy, weapons readiness and radar picture.
+var redactedShipKeys = []string{"energy", "energyRecharge", "rockets", "laserReloadTimerSec", "rocketReloadTimerSec", "radar"}
+
+// WithVisibility hides the game objects the visible callback rejects from the player views of
+// SerializeFor, e.g. a cloaked spaceship. The player's own spaceship is always visible.
+func WithVisibility(visible func(viewer *Spaceship, gameObject GameObject) bool) GameOption {
+	return func(game *Game) {
+		game.visibility = visible
+	}
+}
+
+// SerializeFor returns the game state as the player of the spaceship sees it, unlike the full Serialize
+// of the spectators. The other spaceships' energy, rockets, reload timers and radar are redacted and the
+// objects rejected by WithVisibility are left out. With a radar, the player sees the objects the radar
+// can detect only when in its last sweep, at the reported positions, and its own projectiles.
+func (game *Game) SerializeFor(playerName string) (map[string]interface{}, error) {
+	viewer, err := game.manager.GetSpaceship(playerName)
+	if err != nil {
+		return nil, err
+	}
+
+	var contacts map[int64]Contact
+	if viewer.radar.Equipped() {
+		contacts = make(map[int64]Contact, len(viewer.contacts))
+		for _, contact := range viewer.contacts {
+			contacts[contact.ID] = contact
+		}
+	}
+
+	serialized := game.Serialize()
+	// Serialized in the order of the game objects
+	serializedObjects := serialized["gameObjects"].([]interface{})
+	gameObjects := make([]interface{}, 0, len(serializedObjects))
+	for i, gameObject := range game.manager.gameObjects {
+		serializedObject := serializedObjects[i].(map[string]interface{})
+		if gameObject == GameObject(viewer) {
+			gameObjects = append(gameObjects, serializedObject)
+			continue
+		}
+		if game.visibility != nil && !game.visibility(viewer, gameObject) {
+			continue
+		}
+		if contacts != nil && !ownedBy(gameObject, viewer) {
+			if _, detectable := newContact(gameObject); detectable {
+				contact, detected := contacts[gameObject.ID()]
+				if !detected {
+					continue
+				}
+				serializedObject["position"] = map[string]interface{}{"x": contact.Position.X, "y": contact.Position.Y}
+			}
+		}
+		if _, ok := gameObject.(*Spaceship); ok {
+			for _, key := range redactedShipKeys {
+				delete(serializedObject, key)
+			}
+		}
+		gameObjects = append(gameObjects, serializedObject)
+	}
+	serialized["gameObjects"] = gameObjects
+	serialized["player"] = playerName
+	return serialized, nil
+}
+
+// ownedBy reports whether the game object is a projectile of the spaceship.
+func ownedBy(gameObject GameObject, ship *Spaceship) bool {
+	projectile, ok := gameObject.(*Projectile)
+	return ok && projectile.owner == ship
+}
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+// serializedObjects returns the serialized game objects by their ids.
+func serializedObjects(serialized map[string]interface{}) map[int64]map[string]interface{} {
+	objects := map[int64]map[string]interface{}{}
+	for _, gameObject := range serialized["gameObjects"].([]interface{}) {
+		object := gameObject.(map[string]interface{})
+		objects[object["id"].(int64)] = object
+	}
+	return objects
+}
+
+func TestGame_SerializeFor(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	alpha, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 100}})
+	beta, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "beta", Position: physics.Vector2{X: 300, Y: 100}})
+	gamma, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "gamma", Position: physics.Vector2{X: 900, Y: 900}})
+	game.Start()
+
+	_, err := game.SerializeFor("delta")
+	assert.Equal(t, ErrSpaceshipNotFound{Name: "delta"}, err)
+
+	serialized, err := game.SerializeFor("alpha")
+	assert.NoError(t, err)
+	assert.Equal(t, "alpha", serialized["player"])
+	objects := serializedObjects(serialized)
+	assert.Len(t, objects, 3)
+	assert.Contains(t, objects[alpha.ID()], "energy")
+	assert.Contains(t, objects[alpha.ID()], "laserReloadTimerSec")
+	for _, key := range redactedShipKeys {
+		assert.NotContains(t, objects[beta.ID()], key)
+	}
+	assert.Contains(t, objects[beta.ID()], "health")
+
+	// The full view is untouched
+	full := serializedObjects(game.Serialize())
+	assert.Contains(t, full[beta.ID()], "energy")
+
+	t.Run("Radar", func(t *testing.T) {
+		WithRadar(400, 0, 0)(alpha)
+		_ = game.EnqueueCommand(Command{Ship: "alpha", Action: CommandFireLaser})
+		_ = game.EnqueueCommand(Command{Ship: "gamma", Action: CommandFireLaser})
+		game.Update(16)
+
+		serialized, err := game.SerializeFor("alpha")
+		assert.NoError(t, err)
+		objects := serializedObjects(serialized)
+		assert.Contains(t, objects, beta.ID())
+		assert.NotContains(t, objects, gamma.ID())
+		var projectiles []int64
+		for id, object := range objects {
+			if object["type"] == "laser" {
+				projectiles = append(projectiles, id)
+			}
+		}
+		// Its own, the gamma's one is out of the range
+		assert.Len(t, projectiles, 1)
+
+		// Not affected by the radar of the others
+		serialized, _ = game.SerializeFor("gamma")
+		assert.Contains(t, serializedObjects(serialized), alpha.ID())
+	})
+
+	t.Run("WithVisibility", func(t *testing.T) {
+		cloaked := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithVisibility(func(viewer *Spaceship, gameObject GameObject) bool {
+			ship, ok := gameObject.(*Spaceship)
+			return !ok || ship.Name() != "beta"
+		}))
+		cloaked.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+		cloaked.AddSpaceship("beta", physics.Vector2{X: 300, Y: 100}, math.Pi)
+
+		serialized, _ := cloaked.SerializeFor("alpha")
+		assert.Len(t, serialized["gameObjects"], 1)
+		// Sees itself
+		serialized, _ = NewSpectatorView(cloaked).SerializeFor("beta")
+		assert.Len(t, serialized["gameObjects"], 2)
+	})
+}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"math"
 	"time"
 
 	"github.com/davidhorak/space-wars/kernel/physics"
@@ -20,8 +21,19 @@ type Projectile struct {
 	collider             collider.Collider
 	explosionRadius      float64
 	explosionDurationSec float64
+	accelerationRate     float64 // Speed gained per millisecond
+	guidance             Guidance
+	chainJumps           int
+	chainRadius          float64
+	splashRadius         float64
+	splashDamage         float64
+	sweepStart           physics.Vector2 // Position before the move of the sweep tick
+	sweepTick            uint64          // Tick of the last move, 0 when not moved yet
 }
 
+// Guidance returns the desired velocity of the projectile, e.g. a pursuit or a proportional navigation.
+type Guidance func(projectile *Projectile, gameManager *GameManager) physics.Vector2
+
 func NewProjectile(position physics.Vector2, velocity physics.Vector2, rotation float64, lifespanSec float64, damage float64, owner *Spaceship) *Projectile {
 	return &Projectile{
 		id:          NewUUID(),
@@ -61,8 +73,25 @@ func (projectile *Projectile) Position() physics.Vector2 {
 	return projectile.position
 }
 
+// SetPosition teleports the projectile, e.g. wrapping it around the screen, the path of the current tick is dropped.
 func (projectile *Projectile) SetPosition(position physics.Vector2) {
 	projectile.position = position
+	projectile.sweepStart = position
+}
+
+func (projectile *Projectile) Velocity() physics.Vector2 {
+	return projectile.velocity
+}
+
+// Accelerate makes the projectile gain the rate of speed every millisecond,
+// up to the MaxProjectileSpeedSec.
+func (projectile *Projectile) Accelerate(rate float64) {
+	projectile.accelerationRate = rate
+}
+
+// GuidedBy sets the guidance steering the projectile every tick, nil restores the ballistic flight.
+func (projectile *Projectile) GuidedBy(guidance Guidance) {
+	projectile.guidance = guidance
 }
 
 func (projectile *Projectile) Update(deltaTimeMs float64, gameManager *GameManager) {
@@ -70,25 +99,63 @@ func (projectile *Projectile) Update(deltaTimeMs float64, gameManager *GameManag
 	projectile.lifespanSec -= deltaTimeSec
 	if projectile.lifespanSec <= 0 {
 		projectile.Destroy(gameManager, false)
+		gameManager.recycle(projectile)
 		return
 	}
 
+	projectile.steer(gameManager)
+	projectile.accelerate(deltaTimeMs)
+	projectile.sweepStart = projectile.position
+	projectile.sweepTick = gameManager.CurrentTick()
 	projectile.position = projectile.position.Add(projectile.velocity.Multiply(deltaTimeSec))
 	projectile.collider.SetPosition(projectile.position)
 }
 
+// updatesConcurrently reports the unguided projectiles staying in flight, the guidance reads the other
+// objects and the expired projectiles are removed.
+func (projectile *Projectile) updatesConcurrently(deltaTimeMs float64) bool {
+	return projectile.guidance == nil && projectile.lifespanSec-deltaTimeMs/1000 > 0
+}
+
+func (projectile *Projectile) steer(gameManager *GameManager) {
+	if projectile.guidance == nil {
+		return
+	}
+
+	projectile.velocity = projectile.guidance(projectile, gameManager)
+	if projectile.velocity.X != 0 || projectile.velocity.Y != 0 {
+		projectile.rotation = math.Atan2(projectile.velocity.Y, projectile.velocity.X)
+	}
+}
+
+func (projectile *Projectile) accelerate(deltaTimeMs float64) {
+	if projectile.accelerationRate == 0 {
+		return
+	}
+
+	speed := projectile.velocity.Magnitude()
+	if speed == 0 {
+		return
+	}
+	newSpeed := math.Min(speed+projectile.accelerationRate*deltaTimeMs, MaxProjectileSpeedSec)
+	projectile.velocity = projectile.velocity.Multiply(newSpeed / speed)
+}
+
+func (projectile *Projectile) UpdatePriority() int {
+	return ProjectileUpdatePriority
+}
+
 func (projectile *Projectile) Collider() collider.Collider {
 	return projectile.collider
 }
 
 func (projectile *Projectile) Destroy(gameManager *GameManager, createExplosion bool) {
 	projectile.lifespanSec = 0
-	projectile.enabled = false
+	gameManager.DisableGameObject(projectile)
 	gameManager.RemoveGameObject(projectile)
 
 	if createExplosion {
-		gameManager.AddGameObject(NewExplosion(
-			NewUUID(),
+		gameManager.AddGameObject(gameManager.newExplosion(
 			physics.Vector2{
 				X: projectile.position.X - float64(projectile.explosionRadius),
 				Y: projectile.position.Y - float64(projectile.explosionRadius),
@@ -106,18 +173,107 @@ func (projectile *Projectile) OnCollision(other GameObject, gameManager *GameMan
 			return
 		}
 
-		gameManager.Logger().Damage(time.Now(), projectile.Damage(), projectile.owner.name, spaceship.name, projectile.damageType)
-		spaceship.TakeDamage(projectile.damage, gameManager, projectile.owner)
-		projectile.owner.AddScore(projectile.damage * ScorePerDamageCoefficient)
+		projectile.hit(spaceship, projectile.damage, gameManager)
+		projectile.chain(spaceship, gameManager)
+	}
+
+	// Pass through the friendly drones
+	if drone, ok := other.(*Drone); ok && drone.Friendly(projectile.owner) {
+		return
 	}
 
+	projectile.splash(other, gameManager)
 	projectile.Destroy(gameManager, true)
+	gameManager.recycle(projectile)
+}
+
+func (projectile *Projectile) hit(spaceship *Spaceship, damage float64, gameManager *GameManager) {
+	damage *= projectile.owner.damageMultiplier
+	gameManager.Logger().Damage(time.Now(), spaceship.ID(), damage, projectile.owner.name, spaceship.name, projectile.damageType)
+	spaceship.TakeZoneDamage(spaceship.HitZone(projectile.position), damage, gameManager, projectile.owner)
+	projectile.owner.AddScore(damage * ScorePerDamageCoefficient)
+}
+
+// Splash makes the projectile's impact damage the other spaceships within the radius, the damage falls off
+// linearly from the full one at the impact to zero at the radius. The owner and the spaceship hit directly are spared.
+func (projectile *Projectile) Splash(radius, damage float64) {
+	projectile.splashRadius = radius
+	projectile.splashDamage = damage
+}
+
+func (projectile *Projectile) splash(directHit GameObject, gameManager *GameManager) {
+	if projectile.splashRadius <= 0 || projectile.splashDamage <= 0 {
+		return
+	}
+	for _, spaceship := range gameManager.Spaceships() {
+		if !spaceship.Enabled() || spaceship == projectile.owner || GameObject(spaceship) == directHit {
+			continue
+		}
+		distance := spaceship.position.Distance(projectile.position)
+		if distance >= projectile.splashRadius {
+			continue
+		}
+		projectile.hit(spaceship, projectile.splashDamage*(1-distance/projectile.splashRadius), gameManager)
+	}
+}
+
+// ChainLightning makes the projectile jump from the hit spaceship to the nearest one within
+// the radius, up to the jumps times. Every jump deals ChainLightningDamageFactor of the previous
+// damage, the same spaceship is not hit twice and an asteroid in the way breaks the chain.
+func (projectile *Projectile) ChainLightning(jumps int, radius float64) {
+	projectile.chainJumps = jumps
+	projectile.chainRadius = radius
+}
+
+func (projectile *Projectile) chain(target *Spaceship, gameManager *GameManager) {
+	hit := map[int64]bool{target.id: true}
+	damage := projectile.damage
+	for jump := 0; jump < projectile.chainJumps; jump++ {
+		next := projectile.nextChainTarget(target, hit, gameManager)
+		if next == nil {
+			return
+		}
+
+		damage *= ChainLightningDamageFactor
+		hit[next.id] = true
+		projectile.hit(next, damage, gameManager)
+		target = next
+	}
+}
+
+func (projectile *Projectile) nextChainTarget(from *Spaceship, hit map[int64]bool, gameManager *GameManager) *Spaceship {
+	var nearest *Spaceship
+	nearestDistance := projectile.chainRadius
+	for _, spaceship := range gameManager.Spaceships() {
+		if !spaceship.enabled || hit[spaceship.id] || spaceship.id == projectile.owner.id {
+			continue
+		}
+		if distance := from.position.Distance(spaceship.position); distance <= nearestDistance {
+			nearest = spaceship
+			nearestDistance = distance
+		}
+	}
+	if nearest == nil {
+		return nil
+	}
+
+	path := physics.Edge{Start: from.position, End: nearest.position}
+	for _, asteroid := range gameManager.Asteroids() {
+		closest := path.ClosestPoint(asteroid.position)
+		if asteroid.enabled && closest.Distance(asteroid.position) <= asteroid.radius {
+			return nil
+		}
+	}
+	return nearest
 }
 
 func (projectile *Projectile) Serialize() map[string]interface{} {
 	projectileType := "laser"
-	if projectile.damageType == DamageTypeRocket {
+	switch projectile.damageType {
+	case DamageTypeRocket:
 		projectileType = "rocket"
+	case DamageTypeMine:
+		projectileType = "mine"
 	}
 
 	return map[string]interface{}{
@@ -139,3 +295,49 @@ func (projectile *Projectile) Serialize() map[string]interface{} {
 		"collider":    projectile.collider.Serialize(),
 	}
 }
+
+// DeserializeProjectile restores the laser or rocket from its Serialize output, the owner is looked up
+// by the caller from the serialized "owner" id.
+func DeserializeProjectile(data map[string]interface{}, owner *Spaceship) (*Projectile, error) {
+	id, enabled, position, err := gameObjectHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	projectileType, err := text(data, "type")
+	if err != nil {
+		return nil, err
+	}
+	rotation, err := number(data, "rotation")
+	if err != nil {
+		return nil, err
+	}
+	velocity, err := vector(data, "velocity")
+	if err != nil {
+		return nil, err
+	}
+	lifespanSec, err := number(data, "lifespanSec")
+	if err != nil {
+		return nil, err
+	}
+	damage, err := number(data, "damage")
+	if err != nil {
+		return nil, err
+	}
+
+	var projectile *Projectile
+	switch projectileType {
+	case "laser":
+		projectile = NewLaserProjectile(id, position, rotation, owner)
+	case "rocket":
+		projectile = NewRocketProjectile(id, position, rotation, owner)
+	case "mine":
+		projectile = NewMineProjectile(id, position, owner)
+	default:
+		return nil, ErrInvalidState{Field: "type"}
+	}
+	projectile.enabled = enabled
+	projectile.velocity = velocity
+	projectile.lifespanSec = lifespanSec
+	projectile.damage = damage
+	return projectile, nil
+}
@@ -0,0 +1,76 @@
+package game
+
+import (
+	"sync"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
+
+// ProjectilePool recycles the short-lived projectiles to reduce the GC pressure.
+type ProjectilePool struct {
+	pool sync.Pool
+}
+
+func NewProjectilePool() *ProjectilePool {
+	return &ProjectilePool{
+		pool: sync.Pool{
+			New: func() interface{} {
+				return &Projectile{}
+			},
+		},
+	}
+}
+
+// Get returns a projectile initialized the same way as NewProjectile, with the id.
+func (pool *ProjectilePool) Get(id int64, position physics.Vector2, velocity physics.Vector2, rotation float64, lifespanSec float64, damage float64, owner *Spaceship) *Projectile {
+	projectile := pool.get()
+	square := recycledSquareCollider(projectile.collider, position, 1, physics.Size{Width: 1, Height: 1})
+	*projectile = Projectile{
+		id:          id,
+		enabled:     true,
+		damageType:  DamageTypeUnknown,
+		position:    position,
+		velocity:    velocity,
+		rotation:    rotation,
+		lifespanSec: lifespanSec,
+		damage:      damage,
+		owner:       owner,
+		collider:    square,
+	}
+	return projectile
+}
+
+// get returns a reset projectile, to be initialized by the caller.
+func (pool *ProjectilePool) get() *Projectile {
+	return pool.pool.Get().(*Projectile)
+}
+
+// Put resets the projectile and returns it to the pool.
+// The projectile must not be referenced by the game anymore.
+func (pool *ProjectilePool) Put(projectile *Projectile) {
+	square, _ := projectile.collider.(*collider.SquareCollider)
+	*projectile = Projectile{}
+	if square != nil {
+		square.SetEnabled(false)
+		square.SetPosition(physics.Vector2{})
+		square.SetRotation(0)
+		square.SetSize(physics.Size{})
+		projectile.collider = square
+	}
+	pool.pool.Put(projectile)
+}
+
+// recycledSquareCollider returns the recycled square collider set up as a new one, or a new one
+// when the recycled collider is not a square.
+func recycledSquareCollider(recycled collider.Collider, position physics.Vector2, rotation float64, size physics.Size) *collider.SquareCollider {
+	square, ok := recycled.(*collider.SquareCollider)
+	if !ok {
+		return collider.NewSquareCollider(position, rotation, size)
+	}
+	square.SetEnabled(true)
+	square.SetPosition(position)
+	square.SetRotation(rotation)
+	square.SetSize(size)
+	return square
+}
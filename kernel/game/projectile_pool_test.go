@@ -0,0 +1,134 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectilePool_Get(t *testing.T) {
+	pool := NewProjectilePool()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+	projectile := pool.Get(7, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+
+	assert.GreaterOrEqual(t, projectile.ID(), int64(1))
+	assert.Equal(t, DamageTypeUnknown, projectile.DamageType())
+	assert.True(t, projectile.enabled)
+	assert.Equal(t, physics.Vector2{X: 15, Y: 30}, projectile.Position())
+	assert.Equal(t, physics.Vector2{X: 10, Y: 20}, projectile.velocity)
+	assert.Equal(t, math.Pi, projectile.rotation)
+	assert.Equal(t, 5.0, projectile.lifespanSec)
+	assert.Equal(t, 20.0, projectile.damage)
+	assert.Equal(t, owner, projectile.owner)
+	assert.Equal(t, physics.Vector2{X: 15, Y: 30}, projectile.collider.Position())
+}
+
+func TestProjectilePool_Put(t *testing.T) {
+	pool := NewProjectilePool()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+	projectile := NewLaserProjectile(NewUUID(), physics.Vector2{X: 15, Y: 30}, math.Pi, owner)
+
+	pool.Put(projectile)
+	assert.Equal(t, Projectile{collider: &collider.SquareCollider{}}, *projectile)
+
+	rocket := NewRocketProjectile(NewUUID(), physics.Vector2{X: 15, Y: 30}, math.Pi, owner)
+	pool.Put(rocket)
+	assert.Equal(t, Projectile{}, *rocket)
+
+	// A recycled projectile does not carry any stale state
+	recycled := pool.Get(7, physics.Vector2{X: 1, Y: 2}, physics.Vector2{X: 3, Y: 4}, 0, 1.0, 5.0, owner)
+	assert.Equal(t, DamageTypeUnknown, recycled.damageType)
+	assert.Equal(t, float64(0), recycled.explosionRadius)
+	assert.Equal(t, float64(0), recycled.explosionDurationSec)
+	assert.Equal(t, collider.NewSquareCollider(physics.Vector2{X: 1, Y: 2}, 1, physics.Size{Width: 1, Height: 1}), recycled.collider)
+	assert.Equal(t, physics.Vector2{X: 1, Y: 2}, recycled.Position())
+}
+
+func TestProjectile_Update_ReturnsToPool(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+	projectile := gameManager.ProjectilePool().Get(gameManager.NewID(), physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, 0, 1.0, 20.0, owner)
+	gameManager.AddGameObject(projectile)
+
+	projectile.Update(1000, &gameManager)
+	assert.Equal(t, 0, gameManager.GameObjectSize())
+	// Intact until the tick ends
+	assert.Equal(t, owner, projectile.owner)
+
+	gameManager.releaseRecycled()
+	assert.Equal(t, Projectile{collider: &collider.SquareCollider{}}, *projectile)
+}
+
+// Fires 10 000 projectiles which expire right away
+func BenchmarkNewProjectile(b *testing.B) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 10000; j++ {
+			projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, 0, 1.0, 20.0, owner)
+			gameManager.AddGameObject(projectile)
+			projectile.Destroy(&gameManager, false)
+		}
+	}
+}
+
+// Fires 10 000 pooled projectiles which expire right away
+func BenchmarkProjectilePool(b *testing.B) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 10000; j++ {
+			projectile := gameManager.ProjectilePool().Get(gameManager.NewID(), physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, 0, 1.0, 20.0, owner)
+			gameManager.AddGameObject(projectile)
+			projectile.Update(1000, &gameManager)
+			gameManager.releaseRecycled()
+		}
+	}
+}
+
+func TestProjectile_OnCollision_ReturnsToPoolAfterTick(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 500}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 300, Y: 500}, 0)
+	game.Start()
+	alpha, _ := game.manager.GetSpaceship("alpha")
+
+	assert.NoError(t, alpha.FireLaser(&game.manager))
+	laser := game.manager.gameObjects[2].(*Projectile)
+	var collided Projectile
+	game.manager.Events().Subscribe(func(event GameEvent) {
+		if collision, ok := event.(CollisionOccurred); ok && (collision.A == GameObject(laser) || collision.B == GameObject(laser)) {
+			collided = *laser
+		}
+	})
+	game.Update(16)
+	game.Update(1000)
+
+	// Still intact for the other side of the collision and the subscribers
+	assert.Equal(t, DamageTypeLaser, collided.damageType)
+	assert.Equal(t, alpha, collided.owner)
+	assert.Equal(t, Projectile{collider: &collider.SquareCollider{}}, *laser)
+	assert.Empty(t, game.manager.recycled)
+}
+
+func TestGameManager_NewLaserProjectile(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 0)
+	recycled := NewRocketProjectile(2, physics.Vector2{X: 1, Y: 2}, math.Pi, owner)
+	recycled.Splash(50, 10)
+	gameManager.ProjectilePool().Put(recycled)
+
+	// The recycled projectile, if any, gets a new id and no stale state
+	laser := gameManager.newLaserProjectile(physics.Vector2{X: 15, Y: 30}, 0, owner)
+	expected := NewLaserProjectile(laser.ID(), physics.Vector2{X: 15, Y: 30}, 0, owner)
+	assert.Equal(t, expected, laser)
+	assert.NotEqual(t, int64(2), laser.ID())
+
+	gameManager.SetObjectFactory(configuredObjectFactory{config: GameConfig{LaserDamage: 42}})
+	assert.Equal(t, 42.0, gameManager.newLaserProjectile(physics.Vector2{}, 0, owner).Damage())
+}
@@ -84,6 +84,13 @@ func TestProjectile_SetPosition(t *testing.T) {
 	assert.Equal(t, physics.Vector2{X: 20, Y: 40}, projectile.Position())
 }
 
+func TestProjectile_Velocity(t *testing.T) {
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+
+	assert.Equal(t, physics.Vector2{X: 10, Y: 20}, projectile.Velocity())
+}
+
 func TestProjectile_Update(t *testing.T) {
 	gameManager := NewGameManager()
 	lifespanSec := 5.0
@@ -99,6 +106,80 @@ func TestProjectile_Update(t *testing.T) {
 	assert.False(t, projectile.enabled)
 }
 
+func TestProjectile_Accelerate(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 300, Y: 400}, 0, 100, 20.0, owner)
+	projectile.Accelerate(0.1)
+
+	// Linear speed increase, keeping the direction
+	projectile.Update(100, &gameManager)
+	assert.InDelta(t, 510.0, projectile.velocity.Magnitude(), 1e-9)
+	projectile.Update(100, &gameManager)
+	assert.InDelta(t, 520.0, projectile.velocity.Magnitude(), 1e-9)
+	assert.InDelta(t, 0.6, projectile.velocity.X/projectile.velocity.Magnitude(), 1e-9)
+
+	// Capped
+	projectile.Update(10000, &gameManager)
+	assert.InDelta(t, float64(MaxProjectileSpeedSec), projectile.velocity.Magnitude(), 1e-9)
+
+	// Not accelerating
+	projectile = NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 300, Y: 400}, 0, 100, 20.0, owner)
+	projectile.Accelerate(0)
+	projectile.Update(100, &gameManager)
+	assert.Equal(t, physics.Vector2{X: 300, Y: 400}, projectile.velocity)
+}
+
+func TestProjectile_GuidedBy(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+
+	// Constant velocity guidance keeps the course
+	projectile := NewProjectile(physics.Vector2{X: 0, Y: 0}, physics.Vector2{X: 0, Y: 0}, 0, 100, 20.0, owner)
+	projectile.GuidedBy(func(projectile *Projectile, gameManager *GameManager) physics.Vector2 {
+		return physics.Vector2{X: 0, Y: 100}
+	})
+	for i := 0; i < 10; i++ {
+		projectile.Update(100, &gameManager)
+	}
+	assert.InDelta(t, 0.0, projectile.position.X, 1e-9)
+	assert.InDelta(t, 100.0, projectile.position.Y, 1e-9)
+	assert.InDelta(t, math.Pi/2, projectile.rotation, 1e-9)
+
+	// Ballistic flight once the guidance is removed
+	projectile.GuidedBy(nil)
+	projectile.velocity = physics.Vector2{X: 50, Y: 0}
+	projectile.Update(1000, &gameManager)
+	assert.Equal(t, physics.Vector2{X: 50, Y: 0}, projectile.velocity)
+	assert.InDelta(t, 50.0, projectile.position.X, 1e-9)
+}
+
+func TestProjectile_GuidedBy_Pursuit(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+	target := NewSpaceship(2, "target", physics.Vector2{X: 500, Y: 0}, 0)
+	target.velocity = physics.Vector2{X: 0, Y: 50}
+
+	projectile := NewProjectile(physics.Vector2{X: 0, Y: 0}, physics.Vector2{X: 200, Y: 0}, 0, 100, 20.0, owner)
+	projectile.GuidedBy(func(projectile *Projectile, gameManager *GameManager) physics.Vector2 {
+		direction := target.position.Subtract(projectile.position)
+		direction = direction.Normalize()
+		return direction.Multiply(200)
+	})
+
+	// Converges until within a single step of the target
+	distance := projectile.position.Distance(target.position)
+	for i := 0; i < 50 && distance >= 20; i++ {
+		target.position = target.position.Add(target.velocity.Multiply(0.1))
+		projectile.Update(100, &gameManager)
+
+		next := projectile.position.Distance(target.position)
+		assert.Less(t, next, distance)
+		distance = next
+	}
+	assert.Less(t, distance, 20.0)
+}
+
 func TestProjectile_Collider(t *testing.T) {
 	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
 	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
@@ -155,10 +236,91 @@ func TestProjectile_OnCollision(t *testing.T) {
 
 	assert.Equal(t, "\"owner\" did 20.00 damage to \"other\" with unknown", gameManager.Logger().Logs()[0].message)
 	assert.Equal(t, 3, len(gameManager.GameObjects()))
-	assert.Equal(t, 80.0, other.health)
+	assert.Equal(t, [4]float64{80, 100, 100, 100}, other.HullZones())
 	assert.Equal(t, 10.0, owner.score)
 }
 
+func TestProjectile_OnCollision_HitZone(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 0, Y: 0}, 0)
+	target := NewSpaceship(2, "target", physics.Vector2{X: 100, Y: 100}, 0)
+	gameManager.AddSpaceship(owner)
+	gameManager.AddSpaceship(target)
+
+	// The target faces to the right, the projectile hits it from behind
+	projectile := NewProjectile(physics.Vector2{X: 90, Y: 100}, physics.Vector2{X: 10, Y: 0}, 0, 5, 30, owner)
+	gameManager.AddGameObject(projectile)
+	projectile.OnCollision(target, &gameManager, 0)
+
+	assert.Equal(t, float64(MaxHealth), target.HullZones()[HullZoneFront])
+	assert.Equal(t, float64(MaxHealth), target.HullZones()[HullZoneLeft])
+	assert.Equal(t, float64(MaxHealth), target.HullZones()[HullZoneRight])
+	assert.Equal(t, MaxHealth-30.0, target.HullZones()[HullZoneRear])
+}
+
+func TestProjectile_ChainLightning(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 0, Y: 0}, 0)
+	first := NewSpaceship(2, "first", physics.Vector2{X: 100, Y: 100}, 0)
+	second := NewSpaceship(3, "second", physics.Vector2{X: 150, Y: 100}, 0)
+	third := NewSpaceship(4, "third", physics.Vector2{X: 200, Y: 100}, 0)
+	fourth := NewSpaceship(5, "fourth", physics.Vector2{X: 250, Y: 100}, 0)
+	far := NewSpaceship(6, "far", physics.Vector2{X: 900, Y: 900}, 0)
+	for _, spaceship := range []*Spaceship{owner, first, second, third, fourth, far} {
+		gameManager.AddSpaceship(spaceship)
+	}
+
+	projectile := NewProjectile(physics.Vector2{X: 100, Y: 100}, physics.Vector2{X: 0, Y: 0}, 0, 10, 40, owner)
+	projectile.ChainLightning(2, 60)
+	gameManager.AddGameObject(projectile)
+	projectile.OnCollision(first, &gameManager, 0)
+
+	// Two jumps with decreasing damage, to the zones facing the impact
+	assert.Equal(t, 60.0, first.HullZones()[HullZoneFront])
+	assert.Equal(t, 80.0, second.HullZones()[HullZoneRear])
+	assert.Equal(t, 90.0, third.HullZones()[HullZoneRear])
+	assert.Equal(t, float64(MaxHealth), fourth.Health())
+	assert.Equal(t, float64(MaxHealth), far.Health())
+	assert.Equal(t, float64(MaxHealth), owner.Health())
+}
+
+func TestProjectile_ChainLightning_NoDoubleHit(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 0, Y: 0}, 0)
+	first := NewSpaceship(2, "first", physics.Vector2{X: 100, Y: 100}, 0)
+	second := NewSpaceship(3, "second", physics.Vector2{X: 150, Y: 100}, 0)
+	for _, spaceship := range []*Spaceship{owner, first, second} {
+		gameManager.AddSpaceship(spaceship)
+	}
+
+	projectile := NewProjectile(physics.Vector2{X: 100, Y: 100}, physics.Vector2{X: 0, Y: 0}, 0, 10, 40, owner)
+	projectile.ChainLightning(5, 60)
+	gameManager.AddGameObject(projectile)
+	projectile.OnCollision(first, &gameManager, 0)
+
+	assert.Equal(t, 60.0, first.HullZones()[HullZoneFront])
+	assert.Equal(t, 80.0, second.HullZones()[HullZoneRear])
+}
+
+func TestProjectile_ChainLightning_AsteroidBreaksChain(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 0, Y: 0}, 0)
+	first := NewSpaceship(2, "first", physics.Vector2{X: 100, Y: 100}, 0)
+	second := NewSpaceship(3, "second", physics.Vector2{X: 150, Y: 100}, 0)
+	for _, spaceship := range []*Spaceship{owner, first, second} {
+		gameManager.AddSpaceship(spaceship)
+	}
+	gameManager.AddGameObject(NewAsteroid(7, physics.Vector2{X: 125, Y: 105}, 10))
+
+	projectile := NewProjectile(physics.Vector2{X: 100, Y: 100}, physics.Vector2{X: 0, Y: 0}, 0, 10, 40, owner)
+	projectile.ChainLightning(2, 60)
+	gameManager.AddGameObject(projectile)
+	projectile.OnCollision(first, &gameManager, 0)
+
+	assert.Equal(t, 60.0, first.HullZones()[HullZoneFront])
+	assert.Equal(t, float64(MaxHealth), second.Health())
+}
+
 func TestProjectile_Serialize(t *testing.T) {
 	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
 	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
@@ -182,3 +344,26 @@ func TestProjectile_Serialize(t *testing.T) {
 		"collider":    projectile.collider.Serialize(),
 	}, projectile.Serialize())
 }
+
+func TestDeserializeProjectile(t *testing.T) {
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+
+	for _, projectile := range []*Projectile{
+		NewLaserProjectile(2, physics.Vector2{X: 15, Y: 30}, math.Pi, owner),
+		NewRocketProjectile(3, physics.Vector2{X: 15, Y: 30}, math.Pi, owner),
+	} {
+		projectile.velocity = physics.Vector2{X: 10, Y: 20}
+		projectile.lifespanSec = 1.5
+
+		deserialized, err := DeserializeProjectile(projectile.Serialize(), owner)
+		assert.NoError(t, err)
+		assert.Equal(t, projectile.Serialize(), deserialized.Serialize())
+		assert.Equal(t, projectile.damageType, deserialized.damageType)
+		assert.Same(t, owner, deserialized.owner)
+	}
+
+	serialized := NewLaserProjectile(2, physics.Vector2{X: 15, Y: 30}, math.Pi, owner).Serialize()
+	serialized["type"] = "plasma"
+	_, err := DeserializeProjectile(serialized, owner)
+	assert.Equal(t, ErrInvalidState{Field: "type"}, err)
+}
@@ -0,0 +1,831 @@
+// Binary game state streamed to the clients, see game.ProtoSerializer.
+// The Go types in game_state.pb.go are generated by protoc-gen-go, see generate.go.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: game_state.proto
+
+package gamepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ProjectileType int32
+
+const (
+	ProjectileType_LASER  ProjectileType = 0
+	ProjectileType_ROCKET ProjectileType = 1
+	ProjectileType_MINE   ProjectileType = 2
+)
+
+// Enum value maps for ProjectileType.
+var (
+	ProjectileType_name = map[int32]string{
+		0: "LASER",
+		1: "ROCKET",
+		2: "MINE",
+	}
+	ProjectileType_value = map[string]int32{
+		"LASER":  0,
+		"ROCKET": 1,
+		"MINE":   2,
+	}
+)
+
+func (x ProjectileType) Enum() *ProjectileType {
+	p := new(ProjectileType)
+	*p = x
+	return p
+}
+
+func (x ProjectileType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProjectileType) Descriptor() protoreflect.EnumDescriptor {
+	return file_game_state_proto_enumTypes[0].Descriptor()
+}
+
+func (ProjectileType) Type() protoreflect.EnumType {
+	return &file_game_state_proto_enumTypes[0]
+}
+
+func (x ProjectileType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProjectileType.Descriptor instead.
+func (ProjectileType) EnumDescriptor() ([]byte, []int) {
+	return file_game_state_proto_rawDescGZIP(), []int{0}
+}
+
+type PickupType int32
+
+const (
+	PickupType_HEALTH       PickupType = 0
+	PickupType_ENERGY       PickupType = 1
+	PickupType_WEAPON_BOOST PickupType = 2
+	PickupType_SHIELD       PickupType = 3
+)
+
+// Enum value maps for PickupType.
+var (
+	PickupType_name = map[int32]string{
+		0: "HEALTH",
+		1: "ENERGY",
+		2: "WEAPON_BOOST",
+		3: "SHIELD",
+	}
+	PickupType_value = map[string]int32{
+		"HEALTH":       0,
+		"ENERGY":       1,
+		"WEAPON_BOOST": 2,
+		"SHIELD":       3,
+	}
+)
+
+func (x PickupType) Enum() *PickupType {
+	p := new(PickupType)
+	*p = x
+	return p
+}
+
+func (x PickupType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PickupType) Descriptor() protoreflect.EnumDescriptor {
+	return file_game_state_proto_enumTypes[1].Descriptor()
+}
+
+func (PickupType) Type() protoreflect.EnumType {
+	return &file_game_state_proto_enumTypes[1]
+}
+
+func (x PickupType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PickupType.Descriptor instead.
+func (PickupType) EnumDescriptor() ([]byte, []int) {
+	return file_game_state_proto_rawDescGZIP(), []int{1}
+}
+
+type Vector2 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             float64                `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vector2) Reset() {
+	*x = Vector2{}
+	mi := &file_game_state_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vector2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vector2) ProtoMessage() {}
+
+func (x *Vector2) ProtoReflect() protoreflect.Message {
+	mi := &file_game_state_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vector2.ProtoReflect.Descriptor instead.
+func (*Vector2) Descriptor() ([]byte, []int) {
+	return file_game_state_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Vector2) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Vector2) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type Spaceship struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Team          string                 `protobuf:"bytes,3,opt,name=team,proto3" json:"team,omitempty"`
+	Enabled       bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Position      *Vector2               `protobuf:"bytes,5,opt,name=position,proto3" json:"position,omitempty"`
+	Rotation      float64                `protobuf:"fixed64,6,opt,name=rotation,proto3" json:"rotation,omitempty"`
+	Velocity      *Vector2               `protobuf:"bytes,7,opt,name=velocity,proto3" json:"velocity,omitempty"`
+	Health        float64                `protobuf:"fixed64,8,opt,name=health,proto3" json:"health,omitempty"`
+	Energy        float64                `protobuf:"fixed64,9,opt,name=energy,proto3" json:"energy,omitempty"`
+	Rockets       int32                  `protobuf:"varint,10,opt,name=rockets,proto3" json:"rockets,omitempty"`
+	Kills         int32                  `protobuf:"varint,11,opt,name=kills,proto3" json:"kills,omitempty"`
+	Score         float64                `protobuf:"fixed64,12,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Spaceship) Reset() {
+	*x = Spaceship{}
+	mi := &file_game_state_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Spaceship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Spaceship) ProtoMessage() {}
+
+func (x *Spaceship) ProtoReflect() protoreflect.Message {
+	mi := &file_game_state_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Spaceship.ProtoReflect.Descriptor instead.
+func (*Spaceship) Descriptor() ([]byte, []int) {
+	return file_game_state_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Spaceship) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Spaceship) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Spaceship) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *Spaceship) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Spaceship) GetPosition() *Vector2 {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Spaceship) GetRotation() float64 {
+	if x != nil {
+		return x.Rotation
+	}
+	return 0
+}
+
+func (x *Spaceship) GetVelocity() *Vector2 {
+	if x != nil {
+		return x.Velocity
+	}
+	return nil
+}
+
+func (x *Spaceship) GetHealth() float64 {
+	if x != nil {
+		return x.Health
+	}
+	return 0
+}
+
+func (x *Spaceship) GetEnergy() float64 {
+	if x != nil {
+		return x.Energy
+	}
+	return 0
+}
+
+func (x *Spaceship) GetRockets() int32 {
+	if x != nil {
+		return x.Rockets
+	}
+	return 0
+}
+
+func (x *Spaceship) GetKills() int32 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *Spaceship) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type Asteroid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Position      *Vector2               `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
+	Radius        float64                `protobuf:"fixed64,4,opt,name=radius,proto3" json:"radius,omitempty"`
+	Velocity      *Vector2               `protobuf:"bytes,5,opt,name=velocity,proto3" json:"velocity,omitempty"`
+	Health        float64                `protobuf:"fixed64,6,opt,name=health,proto3" json:"health,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Asteroid) Reset() {
+	*x = Asteroid{}
+	mi := &file_game_state_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Asteroid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Asteroid) ProtoMessage() {}
+
+func (x *Asteroid) ProtoReflect() protoreflect.Message {
+	mi := &file_game_state_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Asteroid.ProtoReflect.Descriptor instead.
+func (*Asteroid) Descriptor() ([]byte, []int) {
+	return file_game_state_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Asteroid) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Asteroid) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Asteroid) GetPosition() *Vector2 {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Asteroid) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *Asteroid) GetVelocity() *Vector2 {
+	if x != nil {
+		return x.Velocity
+	}
+	return nil
+}
+
+func (x *Asteroid) GetHealth() float64 {
+	if x != nil {
+		return x.Health
+	}
+	return 0
+}
+
+type Projectile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          ProjectileType         `protobuf:"varint,2,opt,name=type,proto3,enum=spacewars.ProjectileType" json:"type,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Position      *Vector2               `protobuf:"bytes,4,opt,name=position,proto3" json:"position,omitempty"`
+	Rotation      float64                `protobuf:"fixed64,5,opt,name=rotation,proto3" json:"rotation,omitempty"`
+	Velocity      *Vector2               `protobuf:"bytes,6,opt,name=velocity,proto3" json:"velocity,omitempty"`
+	Damage        float64                `protobuf:"fixed64,7,opt,name=damage,proto3" json:"damage,omitempty"`
+	Owner         int64                  `protobuf:"varint,8,opt,name=owner,proto3" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Projectile) Reset() {
+	*x = Projectile{}
+	mi := &file_game_state_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Projectile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Projectile) ProtoMessage() {}
+
+func (x *Projectile) ProtoReflect() protoreflect.Message {
+	mi := &file_game_state_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Projectile.ProtoReflect.Descriptor instead.
+func (*Projectile) Descriptor() ([]byte, []int) {
+	return file_game_state_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Projectile) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Projectile) GetType() ProjectileType {
+	if x != nil {
+		return x.Type
+	}
+	return ProjectileType_LASER
+}
+
+func (x *Projectile) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Projectile) GetPosition() *Vector2 {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Projectile) GetRotation() float64 {
+	if x != nil {
+		return x.Rotation
+	}
+	return 0
+}
+
+func (x *Projectile) GetVelocity() *Vector2 {
+	if x != nil {
+		return x.Velocity
+	}
+	return nil
+}
+
+func (x *Projectile) GetDamage() float64 {
+	if x != nil {
+		return x.Damage
+	}
+	return 0
+}
+
+func (x *Projectile) GetOwner() int64 {
+	if x != nil {
+		return x.Owner
+	}
+	return 0
+}
+
+type Pickup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          PickupType             `protobuf:"varint,2,opt,name=type,proto3,enum=spacewars.PickupType" json:"type,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Position      *Vector2               `protobuf:"bytes,4,opt,name=position,proto3" json:"position,omitempty"`
+	LifespanSec   float64                `protobuf:"fixed64,5,opt,name=lifespan_sec,json=lifespanSec,proto3" json:"lifespan_sec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pickup) Reset() {
+	*x = Pickup{}
+	mi := &file_game_state_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pickup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pickup) ProtoMessage() {}
+
+func (x *Pickup) ProtoReflect() protoreflect.Message {
+	mi := &file_game_state_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pickup.ProtoReflect.Descriptor instead.
+func (*Pickup) Descriptor() ([]byte, []int) {
+	return file_game_state_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Pickup) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Pickup) GetType() PickupType {
+	if x != nil {
+		return x.Type
+	}
+	return PickupType_HEALTH
+}
+
+func (x *Pickup) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Pickup) GetPosition() *Vector2 {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Pickup) GetLifespanSec() float64 {
+	if x != nil {
+		return x.LifespanSec
+	}
+	return 0
+}
+
+type GameState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tick          uint64                 `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ElapsedMs     float64                `protobuf:"fixed64,3,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	Seed          int64                  `protobuf:"varint,4,opt,name=seed,proto3" json:"seed,omitempty"`
+	Width         float64                `protobuf:"fixed64,5,opt,name=width,proto3" json:"width,omitempty"`
+	Height        float64                `protobuf:"fixed64,6,opt,name=height,proto3" json:"height,omitempty"`
+	Spaceships    []*Spaceship           `protobuf:"bytes,7,rep,name=spaceships,proto3" json:"spaceships,omitempty"`
+	Asteroids     []*Asteroid            `protobuf:"bytes,8,rep,name=asteroids,proto3" json:"asteroids,omitempty"`
+	Projectiles   []*Projectile          `protobuf:"bytes,9,rep,name=projectiles,proto3" json:"projectiles,omitempty"`
+	Pickups       []*Pickup              `protobuf:"bytes,10,rep,name=pickups,proto3" json:"pickups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameState) Reset() {
+	*x = GameState{}
+	mi := &file_game_state_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameState) ProtoMessage() {}
+
+func (x *GameState) ProtoReflect() protoreflect.Message {
+	mi := &file_game_state_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameState.ProtoReflect.Descriptor instead.
+func (*GameState) Descriptor() ([]byte, []int) {
+	return file_game_state_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GameState) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *GameState) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GameState) GetElapsedMs() float64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *GameState) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *GameState) GetWidth() float64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *GameState) GetHeight() float64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GameState) GetSpaceships() []*Spaceship {
+	if x != nil {
+		return x.Spaceships
+	}
+	return nil
+}
+
+func (x *GameState) GetAsteroids() []*Asteroid {
+	if x != nil {
+		return x.Asteroids
+	}
+	return nil
+}
+
+func (x *GameState) GetProjectiles() []*Projectile {
+	if x != nil {
+		return x.Projectiles
+	}
+	return nil
+}
+
+func (x *GameState) GetPickups() []*Pickup {
+	if x != nil {
+		return x.Pickups
+	}
+	return nil
+}
+
+var File_game_state_proto protoreflect.FileDescriptor
+
+const file_game_state_proto_rawDesc = "" +
+	"\n" +
+	"\x10game_state.proto\x12\tspacewars\"%\n" +
+	"\aVector2\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x01R\x01y\"\xcf\x02\n" +
+	"\tSpaceship\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04team\x18\x03 \x01(\tR\x04team\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\x12.\n" +
+	"\bposition\x18\x05 \x01(\v2\x12.spacewars.Vector2R\bposition\x12\x1a\n" +
+	"\brotation\x18\x06 \x01(\x01R\brotation\x12.\n" +
+	"\bvelocity\x18\a \x01(\v2\x12.spacewars.Vector2R\bvelocity\x12\x16\n" +
+	"\x06health\x18\b \x01(\x01R\x06health\x12\x16\n" +
+	"\x06energy\x18\t \x01(\x01R\x06energy\x12\x18\n" +
+	"\arockets\x18\n" +
+	" \x01(\x05R\arockets\x12\x14\n" +
+	"\x05kills\x18\v \x01(\x05R\x05kills\x12\x14\n" +
+	"\x05score\x18\f \x01(\x01R\x05score\"\xc4\x01\n" +
+	"\bAsteroid\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12.\n" +
+	"\bposition\x18\x03 \x01(\v2\x12.spacewars.Vector2R\bposition\x12\x16\n" +
+	"\x06radius\x18\x04 \x01(\x01R\x06radius\x12.\n" +
+	"\bvelocity\x18\x05 \x01(\v2\x12.spacewars.Vector2R\bvelocity\x12\x16\n" +
+	"\x06health\x18\x06 \x01(\x01R\x06health\"\x8f\x02\n" +
+	"\n" +
+	"Projectile\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12-\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x19.spacewars.ProjectileTypeR\x04type\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\x12.\n" +
+	"\bposition\x18\x04 \x01(\v2\x12.spacewars.Vector2R\bposition\x12\x1a\n" +
+	"\brotation\x18\x05 \x01(\x01R\brotation\x12.\n" +
+	"\bvelocity\x18\x06 \x01(\v2\x12.spacewars.Vector2R\bvelocity\x12\x16\n" +
+	"\x06damage\x18\a \x01(\x01R\x06damage\x12\x14\n" +
+	"\x05owner\x18\b \x01(\x03R\x05owner\"\xb0\x01\n" +
+	"\x06Pickup\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12)\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x15.spacewars.PickupTypeR\x04type\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\x12.\n" +
+	"\bposition\x18\x04 \x01(\v2\x12.spacewars.Vector2R\bposition\x12!\n" +
+	"\flifespan_sec\x18\x05 \x01(\x01R\vlifespanSec\"\xe7\x02\n" +
+	"\tGameState\x12\x12\n" +
+	"\x04tick\x18\x01 \x01(\x04R\x04tick\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x03 \x01(\x01R\telapsedMs\x12\x12\n" +
+	"\x04seed\x18\x04 \x01(\x03R\x04seed\x12\x14\n" +
+	"\x05width\x18\x05 \x01(\x01R\x05width\x12\x16\n" +
+	"\x06height\x18\x06 \x01(\x01R\x06height\x124\n" +
+	"\n" +
+	"spaceships\x18\a \x03(\v2\x14.spacewars.SpaceshipR\n" +
+	"spaceships\x121\n" +
+	"\tasteroids\x18\b \x03(\v2\x13.spacewars.AsteroidR\tasteroids\x127\n" +
+	"\vprojectiles\x18\t \x03(\v2\x15.spacewars.ProjectileR\vprojectiles\x12+\n" +
+	"\apickups\x18\n" +
+	" \x03(\v2\x11.spacewars.PickupR\apickups*1\n" +
+	"\x0eProjectileType\x12\t\n" +
+	"\x05LASER\x10\x00\x12\n" +
+	"\n" +
+	"\x06ROCKET\x10\x01\x12\b\n" +
+	"\x04MINE\x10\x02*B\n" +
+	"\n" +
+	"PickupType\x12\n" +
+	"\n" +
+	"\x06HEALTH\x10\x00\x12\n" +
+	"\n" +
+	"\x06ENERGY\x10\x01\x12\x10\n" +
+	"\fWEAPON_BOOST\x10\x02\x12\n" +
+	"\n" +
+	"\x06SHIELD\x10\x03B;Z9github.com/davidhorak/space-wars/kernel/game/proto;gamepbb\x06proto3"
+
+var (
+	file_game_state_proto_rawDescOnce sync.Once
+	file_game_state_proto_rawDescData []byte
+)
+
+func file_game_state_proto_rawDescGZIP() []byte {
+	file_game_state_proto_rawDescOnce.Do(func() {
+		file_game_state_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_game_state_proto_rawDesc), len(file_game_state_proto_rawDesc)))
+	})
+	return file_game_state_proto_rawDescData
+}
+
+var file_game_state_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_game_state_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_game_state_proto_goTypes = []any{
+	(ProjectileType)(0), // 0: spacewars.ProjectileType
+	(PickupType)(0),     // 1: spacewars.PickupType
+	(*Vector2)(nil),     // 2: spacewars.Vector2
+	(*Spaceship)(nil),   // 3: spacewars.Spaceship
+	(*Asteroid)(nil),    // 4: spacewars.Asteroid
+	(*Projectile)(nil),  // 5: spacewars.Projectile
+	(*Pickup)(nil),      // 6: spacewars.Pickup
+	(*GameState)(nil),   // 7: spacewars.GameState
+}
+var file_game_state_proto_depIdxs = []int32{
+	2,  // 0: spacewars.Spaceship.position:type_name -> spacewars.Vector2
+	2,  // 1: spacewars.Spaceship.velocity:type_name -> spacewars.Vector2
+	2,  // 2: spacewars.Asteroid.position:type_name -> spacewars.Vector2
+	2,  // 3: spacewars.Asteroid.velocity:type_name -> spacewars.Vector2
+	0,  // 4: spacewars.Projectile.type:type_name -> spacewars.ProjectileType
+	2,  // 5: spacewars.Projectile.position:type_name -> spacewars.Vector2
+	2,  // 6: spacewars.Projectile.velocity:type_name -> spacewars.Vector2
+	1,  // 7: spacewars.Pickup.type:type_name -> spacewars.PickupType
+	2,  // 8: spacewars.Pickup.position:type_name -> spacewars.Vector2
+	3,  // 9: spacewars.GameState.spaceships:type_name -> spacewars.Spaceship
+	4,  // 10: spacewars.GameState.asteroids:type_name -> spacewars.Asteroid
+	5,  // 11: spacewars.GameState.projectiles:type_name -> spacewars.Projectile
+	6,  // 12: spacewars.GameState.pickups:type_name -> spacewars.Pickup
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_game_state_proto_init() }
+func file_game_state_proto_init() {
+	if File_game_state_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_game_state_proto_rawDesc), len(file_game_state_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_game_state_proto_goTypes,
+		DependencyIndexes: file_game_state_proto_depIdxs,
+		EnumInfos:         file_game_state_proto_enumTypes,
+		MessageInfos:      file_game_state_proto_msgTypes,
+	}.Build()
+	File_game_state_proto = out.File
+	file_game_state_proto_goTypes = nil
+	file_game_state_proto_depIdxs = nil
+}
@@ -0,0 +1,4 @@
+// Package gamepb holds the protobuf types of the binary game state, generated from game_state.proto.
+package gamepb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative game_state.proto
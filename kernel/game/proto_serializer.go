@@ -0,0 +1,116 @@
+package game
+
+import (
+	gamepb "github.com/davidhorak/space-wars/kernel/game/proto"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// ProtoSerializer encodes the game state as the GameState message of proto/game_state.proto. Only the
+// spaceships, the asteroids, the projectiles and the pickups are encoded, the state the clients render every frame.
+type ProtoSerializer struct{}
+
+func (serializer ProtoSerializer) ContentType() string {
+	return "application/x-protobuf"
+}
+
+func (serializer ProtoSerializer) Encode(game *Game) ([]byte, error) {
+	return protobuf.Marshal(protoState(game))
+}
+
+// protoState converts the game to the generated GameState message.
+func protoState(game *Game) *gamepb.GameState {
+	state := &gamepb.GameState{
+		Tick:      game.manager.tick,
+		Status:    string(game.status),
+		ElapsedMs: game.manager.elapsedMs,
+		Seed:      game.seed,
+		Width:     game.size.Width,
+		Height:    game.size.Height,
+	}
+	for _, gameObject := range game.manager.gameObjects {
+		switch object := gameObject.(type) {
+		case *Spaceship:
+			state.Spaceships = append(state.Spaceships, protoSpaceship(object))
+		case *Asteroid:
+			state.Asteroids = append(state.Asteroids, protoAsteroid(object))
+		case *Projectile:
+			state.Projectiles = append(state.Projectiles, protoProjectile(object))
+		case *Pickup:
+			state.Pickups = append(state.Pickups, protoPickup(object))
+		}
+	}
+	return state
+}
+
+func protoSpaceship(ship *Spaceship) *gamepb.Spaceship {
+	return &gamepb.Spaceship{
+		Id:       ship.id,
+		Name:     ship.name,
+		Team:     ship.team,
+		Enabled:  ship.enabled,
+		Position: protoVector(ship.position),
+		Rotation: ship.rotation,
+		Velocity: protoVector(ship.velocity),
+		Health:   ship.Health(),
+		Energy:   ship.energy,
+		Rockets:  ship.rockets,
+		Kills:    ship.kills,
+		Score:    ship.score,
+	}
+}
+
+func protoAsteroid(asteroid *Asteroid) *gamepb.Asteroid {
+	return &gamepb.Asteroid{
+		Id:       asteroid.id,
+		Enabled:  asteroid.enabled,
+		Position: protoVector(asteroid.position),
+		Radius:   asteroid.radius,
+		Velocity: protoVector(asteroid.velocity),
+		Health:   asteroid.health,
+	}
+}
+
+func protoProjectile(projectile *Projectile) *gamepb.Projectile {
+	message := &gamepb.Projectile{
+		Id:       projectile.id,
+		Enabled:  projectile.enabled,
+		Position: protoVector(projectile.position),
+		Rotation: projectile.rotation,
+		Velocity: protoVector(projectile.velocity),
+		Damage:   projectile.damage,
+	}
+	switch projectile.damageType {
+	case DamageTypeRocket:
+		message.Type = gamepb.ProjectileType_ROCKET
+	case DamageTypeMine:
+		message.Type = gamepb.ProjectileType_MINE
+	}
+	if projectile.owner != nil {
+		message.Owner = projectile.owner.ID()
+	}
+	return message
+}
+
+func protoPickup(pickup *Pickup) *gamepb.Pickup {
+	message := &gamepb.Pickup{
+		Id:          pickup.id,
+		Enabled:     pickup.enabled,
+		Position:    protoVector(pickup.position),
+		LifespanSec: pickup.lifespanSec,
+	}
+	for i, pickupType := range PickupTypes {
+		if pickup.pickupType == pickupType {
+			message.Type = gamepb.PickupType(i)
+		}
+	}
+	return message
+}
+
+// protoVector leaves the zero vector out, as proto3 does with the zero values.
+func protoVector(vector physics.Vector2) *gamepb.Vector2 {
+	if vector == (physics.Vector2{}) {
+		return nil
+	}
+	return &gamepb.Vector2{X: vector.X, Y: vector.Y}
+}
@@ -0,0 +1,94 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	gamepb "github.com/davidhorak/space-wars/kernel/game/proto"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+func TestProtoSerializer_Encode(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 800}, 1234567890)
+	game.SetSerializer(ProtoSerializer{})
+	ship, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "alpha", Team: "red", Position: physics.Vector2{X: 100, Y: 0}})
+	game.AddSpaceship("beta", physics.Vector2{X: 900, Y: 700}, 0)
+	_, _ = game.manager.AddGameObject(NewAsteroid(10, physics.Vector2{X: 500, Y: 400}, 20))
+	_, _ = game.manager.AddGameObject(NewPickup(11, physics.Vector2{X: 300, Y: 300}, PickupWeaponBoost))
+	_ = ship.FireRocket(&game.manager)
+	game.Start()
+	game.Update(16)
+
+	encoded, err := game.Encode()
+	assert.NoError(t, err)
+	assert.Equal(t, "application/x-protobuf", game.Serializer().ContentType())
+
+	var state gamepb.GameState
+	assert.NoError(t, protobuf.Unmarshal(encoded, &state))
+	assert.Equal(t, uint64(1), state.Tick)
+	assert.Equal(t, "running", state.Status)
+	assert.Equal(t, 16.0, state.ElapsedMs)
+	assert.Equal(t, int64(1234567890), state.Seed)
+	assert.Equal(t, 1000.0, state.Width)
+	assert.Equal(t, 800.0, state.Height)
+	assert.Len(t, state.Spaceships, 2)
+	assert.Len(t, state.Asteroids, 1)
+	assert.Len(t, state.Projectiles, 1)
+	assert.Len(t, state.Pickups, 1)
+
+	spaceship := state.Spaceships[0]
+	assert.Equal(t, ship.ID(), spaceship.Id)
+	assert.Equal(t, "alpha", spaceship.Name)
+	assert.Equal(t, "red", spaceship.Team)
+	assert.True(t, spaceship.Enabled)
+	assert.Equal(t, ship.Position().X, spaceship.Position.X)
+	assert.Equal(t, ship.Health(), spaceship.Health)
+	assert.Equal(t, int32(MaxRockets-1), spaceship.Rockets)
+	assert.Equal(t, int32(0), spaceship.Kills)
+
+	asteroid := state.Asteroids[0]
+	assert.Equal(t, int64(10), asteroid.Id)
+	assert.Equal(t, 20.0, asteroid.Radius)
+	assert.Nil(t, asteroid.Velocity)
+	assert.Equal(t, float64(20*AsteroidHealthPerRadius), asteroid.Health)
+
+	projectile := state.Projectiles[0]
+	assert.Equal(t, gamepb.ProjectileType_ROCKET, projectile.Type)
+	assert.Equal(t, float64(RocketDamage), projectile.Damage)
+	assert.Equal(t, ship.ID(), projectile.Owner)
+
+	pickup := state.Pickups[0]
+	assert.Equal(t, int64(11), pickup.Id)
+	assert.Equal(t, gamepb.PickupType_WEAPON_BOOST, pickup.Type)
+	assert.Equal(t, PickupLifespanSec-0.016, pickup.LifespanSec)
+
+	t.Run("Negative values", func(t *testing.T) {
+		ship.position = physics.Vector2{X: 0, Y: -2}
+		ship.rotation = -math.Pi
+		encoded, err := game.Encode()
+		assert.NoError(t, err)
+
+		var state gamepb.GameState
+		assert.NoError(t, protobuf.Unmarshal(encoded, &state))
+		assert.Equal(t, -math.Pi, state.Spaceships[0].Rotation)
+		assert.Equal(t, 0.0, state.Spaceships[0].Position.X)
+		assert.Equal(t, -2.0, state.Spaceships[0].Position.Y)
+	})
+}
+
+// The pickup types are encoded by their order, it follows the PickupType enum of the schema.
+func TestProtoSerializer_PickupTypes(t *testing.T) {
+	expected := map[PickupType]gamepb.PickupType{
+		PickupHealth:      gamepb.PickupType_HEALTH,
+		PickupEnergy:      gamepb.PickupType_ENERGY,
+		PickupWeaponBoost: gamepb.PickupType_WEAPON_BOOST,
+		PickupShield:      gamepb.PickupType_SHIELD,
+	}
+	assert.Len(t, gamepb.PickupType_name, len(PickupTypes))
+	for _, pickupType := range PickupTypes {
+		pickup := protoPickup(NewPickup(1, physics.Vector2{}, pickupType))
+		assert.Equal(t, expected[pickupType], pickup.Type, pickupType)
+	}
+}
@@ -0,0 +1,202 @@
+package game
+
+import (
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// Radar limits what the spaceship sees to the objects within the range and the cone around its heading,
+// for the fog of war rulesets. The spaceships have no radar by default, their bots see the whole game.
+type Radar struct {
+	rangeDistance float64 // 0 when not equipped
+	coneRad       float64 // Full angle centered on the heading, 2π for all around
+	noise         float64 // Standard deviation of the reported position error
+}
+
+// Contact is an object detected by the radar, Name is set for the spaceships only.
+type Contact struct {
+	ID       int64
+	Type     string
+	Name     string
+	Position physics.Vector2 // With the radar noise
+	Velocity physics.Vector2
+	Radius   float64
+	Distance float64
+	Bearing  float64 // Relative to the heading, positive counterclockwise
+}
+
+func (radar *Radar) Equipped() bool {
+	return radar.rangeDistance > 0
+}
+
+func (radar *Radar) Range() float64 {
+	return radar.rangeDistance
+}
+
+func (radar *Radar) ConeRad() float64 {
+	return radar.coneRad
+}
+
+func (radar *Radar) Noise() float64 {
+	return radar.noise
+}
+
+// WithRadar equips the spaceship with the radar, the cone is clamped to 2π and a non-positive one sees all around.
+func WithRadar(rangeDistance, coneRad, noise float64) SpaceshipOption {
+	return func(ship *Spaceship) {
+		if coneRad <= 0 || coneRad > 2*math.Pi {
+			coneRad = 2 * math.Pi
+		}
+		ship.radar = Radar{rangeDistance: math.Max(rangeDistance, 0), coneRad: coneRad, noise: math.Max(noise, 0)}
+	}
+}
+
+func (ship *Spaceship) Radar() *Radar {
+	return &ship.radar
+}
+
+// Scan returns the contacts of the last radar sweep, done at the end of every spaceship update.
+// Nil without a radar.
+func (ship *Spaceship) Scan() []Contact {
+	return append([]Contact(nil), ship.contacts...)
+}
+
+func (ship *Spaceship) scan(gameManager *GameManager) {
+	ship.contacts = nil
+	if !ship.radar.Equipped() {
+		return
+	}
+
+	reach := ship.radar.rangeDistance
+	bounds := physics.AABB{
+		Min: physics.Vector2{X: ship.position.X - reach, Y: ship.position.Y - reach},
+		Max: physics.Vector2{X: ship.position.X + reach, Y: ship.position.Y + reach},
+	}
+	heading := physics.FromAngle(ship.rotation)
+	for _, gameObject := range gameManager.Neighbors(bounds) {
+		contact, ok := newContact(gameObject)
+		if !ok || gameObject == GameObject(ship) {
+			continue
+		}
+		offset := contact.Position.Subtract(ship.position)
+		contact.Distance = offset.Magnitude()
+		if contact.Distance > reach {
+			continue
+		}
+		if contact.Distance > 0 {
+			contact.Bearing = heading.AngleTo(offset)
+		}
+		if math.Abs(contact.Bearing) > ship.radar.coneRad/2 {
+			continue
+		}
+		if ship.radar.noise > 0 {
+			contact.Position.X += gameManager.Rand().NormFloat64() * ship.radar.noise
+			contact.Position.Y += gameManager.Rand().NormFloat64() * ship.radar.noise
+		}
+		ship.contacts = append(ship.contacts, contact)
+	}
+}
+
+// newContact describes the physical objects, the explosions and the trigger zones are not detected.
+func newContact(gameObject GameObject) (Contact, bool) {
+	contact := Contact{ID: gameObject.ID(), Position: gameObject.Position()}
+	switch object := gameObject.(type) {
+	case *Spaceship:
+		contact.Type, contact.Name, contact.Velocity, contact.Radius = "spaceship", object.name, object.velocity, ShipSize/2
+	case *Asteroid:
+		contact.Type, contact.Radius = "asteroid", object.radius
+	case *Projectile:
+		contact.Type, contact.Velocity = "projectile", object.velocity
+	case *Drone:
+		contact.Type = "drone"
+	default:
+		return Contact{}, false
+	}
+	return contact, true
+}
+
+func (contact Contact) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"id":   contact.ID,
+		"type": contact.Type,
+		"name": contact.Name,
+		"position": map[string]interface{}{
+			"x": contact.Position.X,
+			"y": contact.Position.Y,
+		},
+		"velocity": map[string]interface{}{
+			"x": contact.Velocity.X,
+			"y": contact.Velocity.Y,
+		},
+		"radius":   contact.Radius,
+		"distance": contact.Distance,
+		"bearing":  contact.Bearing,
+	}
+}
+
+func (radar *Radar) serialize(contacts []Contact) map[string]interface{} {
+	serialized := make([]interface{}, 0, len(contacts))
+	for _, contact := range contacts {
+		serialized = append(serialized, contact.Serialize())
+	}
+	return map[string]interface{}{
+		"range":    radar.rangeDistance,
+		"coneRad":  radar.coneRad,
+		"noise":    radar.noise,
+		"contacts": serialized,
+	}
+}
+
+func deserializeRadar(data map[string]interface{}) (Radar, []Contact, error) {
+	var radar Radar
+	var err error
+	if radar.rangeDistance, err = number(data, "range"); err != nil {
+		return Radar{}, nil, err
+	}
+	if radar.coneRad, err = number(data, "coneRad"); err != nil {
+		return Radar{}, nil, err
+	}
+	if radar.noise, err = number(data, "noise"); err != nil {
+		return Radar{}, nil, err
+	}
+	rawContacts, err := list(data, "contacts")
+	if err != nil {
+		return Radar{}, nil, err
+	}
+
+	var contacts []Contact
+	for _, rawContact := range rawContacts {
+		serialized, ok := rawContact.(map[string]interface{})
+		if !ok {
+			return Radar{}, nil, ErrInvalidState{Field: "contacts"}
+		}
+		contact := Contact{}
+		id, err := number(serialized, "id")
+		if err != nil {
+			return Radar{}, nil, err
+		}
+		contact.ID = int64(id)
+		if contact.Type, err = text(serialized, "type"); err != nil {
+			return Radar{}, nil, err
+		}
+		contact.Name, _ = serialized["name"].(string)
+		if contact.Position, err = vector(serialized, "position"); err != nil {
+			return Radar{}, nil, err
+		}
+		if contact.Velocity, err = vector(serialized, "velocity"); err != nil {
+			return Radar{}, nil, err
+		}
+		if contact.Radius, err = number(serialized, "radius"); err != nil {
+			return Radar{}, nil, err
+		}
+		if contact.Distance, err = number(serialized, "distance"); err != nil {
+			return Radar{}, nil, err
+		}
+		if contact.Bearing, err = number(serialized, "bearing"); err != nil {
+			return Radar{}, nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+	return radar, contacts, nil
+}
@@ -0,0 +1,94 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpaceship_Scan(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 500, Y: 500}, 0, WithRadar(300, math.Pi/2, 0))
+	ahead := NewSpaceship(2, "ahead", physics.Vector2{X: 700, Y: 500}, 0)
+	behind := NewSpaceship(3, "behind", physics.Vector2{X: 300, Y: 500}, 0)
+	far := NewSpaceship(4, "far", physics.Vector2{X: 900, Y: 500}, 0)
+	for _, spaceship := range []*Spaceship{ship, ahead, behind, far} {
+		_ = gameManager.AddSpaceship(spaceship)
+	}
+	_, _ = gameManager.AddGameObject(NewAsteroid(5, physics.Vector2{X: 600, Y: 600}, 20))
+	_, _ = gameManager.AddGameObject(NewExplosion(6, physics.Vector2{X: 600, Y: 500}, 10, 1))
+
+	ship.scan(&gameManager)
+	contacts := ship.Scan()
+	assert.Len(t, contacts, 2)
+	assert.Equal(t, Contact{ID: 2, Type: "spaceship", Name: "ahead", Position: physics.Vector2{X: 700, Y: 500},
+		Radius: ShipSize / 2, Distance: 200}, contacts[0])
+	assert.Equal(t, "asteroid", contacts[1].Type)
+	assert.Equal(t, 20.0, contacts[1].Radius)
+	assert.InDelta(t, math.Pi/4, contacts[1].Bearing, 1e-9)
+
+	// The returned contacts are a copy
+	contacts[0].Name = "changed"
+	assert.Equal(t, "ahead", ship.Scan()[0].Name)
+
+	t.Run("Noise", func(t *testing.T) {
+		noisy := NewSpaceship(7, "noisy", physics.Vector2{X: 500, Y: 500}, 0, WithRadar(300, 0, 5))
+		noisy.scan(&gameManager)
+		assert.Len(t, noisy.Scan(), 4)
+		assert.Equal(t, 2*math.Pi, noisy.Radar().ConeRad())
+		assert.NotEqual(t, physics.Vector2{X: 700, Y: 500}, noisy.Scan()[1].Position)
+	})
+
+	t.Run("No radar", func(t *testing.T) {
+		ahead.scan(&gameManager)
+		assert.False(t, ahead.Radar().Equipped())
+		assert.Nil(t, ahead.Scan())
+	})
+}
+
+func TestRadar_Serialize(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 500, Y: 500}, 0, WithRadar(300, math.Pi, 2))
+	_ = gameManager.AddSpaceship(ship)
+	_ = gameManager.AddSpaceship(NewSpaceship(2, "other", physics.Vector2{X: 600, Y: 500}, 0))
+	ship.scan(&gameManager)
+
+	serialized := ship.Serialize()["radar"].(map[string]interface{})
+	assert.Equal(t, 300.0, serialized["range"])
+	assert.Equal(t, math.Pi, serialized["coneRad"])
+	assert.Equal(t, 2.0, serialized["noise"])
+	assert.Len(t, serialized["contacts"], 1)
+
+	deserialized, err := DeserializeSpaceship(ship.Serialize())
+	assert.NoError(t, err)
+	assert.Equal(t, ship.radar, deserialized.radar)
+	assert.Equal(t, ship.Scan(), deserialized.Scan())
+
+	serialized["contacts"] = []interface{}{"ship"}
+	_, _, err = deserializeRadar(serialized)
+	assert.Equal(t, ErrInvalidState{Field: "contacts"}, err)
+}
+
+func TestGameState_Radar(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	alpha, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 500}})
+	game.AddSpaceship("beta", physics.Vector2{X: 300, Y: 500}, 0)
+	game.AddSpaceship("gamma", physics.Vector2{X: 900, Y: 500}, 0)
+	WithRadar(400, 0, 0)(alpha)
+	game.Start()
+
+	var states []GameState
+	game.AddBot("alpha", BotStrategyFunc(func(spaceship *Spaceship, gameManager *GameManager, deltaTimeMs float64) {
+		states = append(states, newGameState(spaceship, gameManager, deltaTimeMs))
+	}))
+	game.Update(16)
+	game.Update(16)
+
+	// Nothing seen before the first sweep
+	assert.Empty(t, states[0].Spaceships)
+	assert.Len(t, states[1].Spaceships, 1)
+	assert.Equal(t, "beta", states[1].Spaceships[0].Name)
+	assert.Len(t, states[1].Contacts, 1)
+}
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
+
+// RaycastHit is the first game object hit by a ray, see GameManager.Raycast.
+type RaycastHit struct {
+	Object   GameObject
+	Point    physics.Vector2
+	Distance float64 // From the origin
+}
+
+// Raycast casts the ray from the origin in the direction, up to the max distance, against the enabled
+// colliding game objects accepted by the filter, nil for all, and returns the first one hit. The ray
+// goes straight, it does not wrap around the arena.
+func (manager *GameManager) Raycast(origin, direction physics.Vector2, maxDistance float64, filter func(GameObject) bool) (RaycastHit, bool) {
+	if direction.Magnitude() == 0 || maxDistance <= 0 {
+		return RaycastHit{}, false
+	}
+	heading := direction.Normalize()
+	end := origin.Add(heading.Multiply(maxDistance))
+
+	var candidates []GameObject
+	var colliders []collider.Collider
+	for _, gameObject := range manager.Neighbors(collider.SweptBounds(origin, end, 0)) {
+		if filter != nil && !filter(gameObject) {
+			continue
+		}
+		candidates = append(candidates, gameObject)
+		colliders = append(colliders, gameObject.Collider())
+	}
+	hit, ok := collider.Raycast(origin, direction, maxDistance, colliders)
+	if !ok {
+		return RaycastHit{}, false
+	}
+	return RaycastHit{Object: candidates[hit.Index], Point: hit.Point, Distance: hit.Distance}, true
+}
+
+// HasLineOfSight reports whether no enabled asteroid blocks the straight line between the positions
+// of the game objects. The other game objects, e.g. the spaceships, do not block the sight.
+func (manager *GameManager) HasLineOfSight(a, b GameObject) bool {
+	from, to := a.Position(), b.Position()
+	distance := from.Distance(to)
+	if distance == 0 {
+		return true
+	}
+	_, blocked := manager.Raycast(from, to.Subtract(from), distance, func(gameObject GameObject) bool {
+		_, ok := gameObject.(*Asteroid)
+		return ok && gameObject != a && gameObject != b
+	})
+	return !blocked
+}
@@ -0,0 +1,57 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameManager_Raycast(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	asteroid := NewAsteroid(2, physics.Vector2{X: 100, Y: 0}, 20)
+	target := NewSpaceship(3, "target", physics.Vector2{X: 200, Y: 0}, 0)
+	gameManager.AddGameObjects([]GameObject{ship, asteroid, target})
+
+	// From within the ship's own collider
+	hit, ok := gameManager.Raycast(ship.Position(), physics.Vector2{X: 1, Y: 0}, 500, nil)
+	assert.True(t, ok)
+	assert.Equal(t, ship, hit.Object)
+
+	notShip := func(gameObject GameObject) bool { return gameObject != ship }
+	hit, ok = gameManager.Raycast(ship.Position(), physics.Vector2{X: 1, Y: 0}, 500, notShip)
+	assert.True(t, ok)
+	assert.Equal(t, asteroid, hit.Object)
+	assert.InDelta(t, 80, hit.Distance, 1e-9)
+	assert.InDelta(t, 80, hit.Point.X, 1e-9)
+
+	_, ok = gameManager.Raycast(ship.Position(), physics.Vector2{X: 0, Y: 1}, 500, notShip)
+	assert.False(t, ok)
+
+	t.Run("Disabled", func(t *testing.T) {
+		asteroid.SetEnabled(false)
+		defer asteroid.SetEnabled(true)
+		hit, ok := gameManager.Raycast(ship.Position(), physics.Vector2{X: 1, Y: 0}, 500, notShip)
+		assert.True(t, ok)
+		assert.Equal(t, target, hit.Object)
+	})
+}
+
+func TestGameManager_HasLineOfSight(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	behindAsteroid := NewSpaceship(2, "hidden", physics.Vector2{X: 200, Y: 0}, 0)
+	inSight := NewSpaceship(3, "visible", physics.Vector2{X: 0, Y: 200}, 0)
+	blocker := NewSpaceship(4, "blocker", physics.Vector2{X: 0, Y: 100}, 0)
+	asteroid := NewAsteroid(5, physics.Vector2{X: 100, Y: 0}, 20)
+	gameManager.AddGameObjects([]GameObject{ship, behindAsteroid, inSight, blocker, asteroid})
+
+	assert.False(t, gameManager.HasLineOfSight(ship, behindAsteroid))
+	assert.False(t, gameManager.HasLineOfSight(behindAsteroid, ship))
+	// The spaceships do not block the sight
+	assert.True(t, gameManager.HasLineOfSight(ship, inSight))
+	// Of the asteroid itself
+	assert.True(t, gameManager.HasLineOfSight(ship, asteroid))
+	assert.True(t, gameManager.HasLineOfSight(ship, ship))
+}
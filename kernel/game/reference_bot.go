@@ -0,0 +1,105 @@
+package game
+
+import (
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+const (
+	// Heading error below which the bots stop steering
+	BotSteeringToleranceRad = 0.05
+	// The bots steer by the heading error expected after the time at the current angular velocity,
+	// so the spaceships of the inertial flight model counter-thrust before overshooting the heading
+	BotSteeringLookaheadSec = 0.25
+	// Heading error below which the chaser fires
+	ChaserFireAngleRad = 0.15
+	// Distance within which the chaser fires, about a second of the laser flight
+	ChaserFireRange = LaserVelocitySec
+)
+
+// IdleBot never issues any command, a sitting target for testing.
+type IdleBot struct{}
+
+func (bot IdleBot) Think(state GameState) []Command {
+	return nil
+}
+
+// ChaserBot flies at full thrust towards the nearest enemy spaceship and fires the laser
+// once it is lined up within range. The spaceships of the same non-empty team are spared.
+type ChaserBot struct{}
+
+func (bot ChaserBot) Think(state GameState) []Command {
+	target, ok := nearestEnemy(state)
+	if !ok {
+		return []Command{{Action: CommandSetEngineThrust, Args: []float64{0, 0, 0}}}
+	}
+
+	toTarget := target.Position.Subtract(state.Self.Position)
+	headingError := headingErrorTo(state.Self, toTarget)
+	commands := []Command{steer(state.Self, headingError, MaxThrust)}
+	if math.Abs(headingError) < ChaserFireAngleRad && toTarget.Magnitude() <= ChaserFireRange &&
+		state.Self.Energy >= EnergyConsumptionLaser {
+		commands = append(commands, Command{Action: CommandFireLaser})
+	}
+	return commands
+}
+
+// OrbiterBot circles counterclockwise around the center at the radius, correcting its heading
+// towards the orbit when it drifts away. The zero center stands for the middle of the arena.
+type OrbiterBot struct {
+	Center physics.Vector2
+	Radius float64
+	Thrust float64 // Main thrust, 0-100
+}
+
+func (bot OrbiterBot) Think(state GameState) []Command {
+	center := bot.Center
+	if center == (physics.Vector2{}) {
+		center = physics.Vector2{X: state.Size.Width / 2, Y: state.Size.Height / 2}
+	}
+
+	fromCenter := state.Self.Position.Subtract(center)
+	distance := fromCenter.Magnitude()
+	// Tangent heading, turned inwards or outwards by up to a quarter turn by the radial error
+	correction := 0.0
+	if bot.Radius > 0 {
+		correction = math.Max(-1, math.Min(1, (distance-bot.Radius)/bot.Radius)) * math.Pi / 4
+	}
+	heading := physics.FromAngle(fromCenter.Angle() + math.Pi/2 + correction)
+	return []Command{steer(state.Self, headingErrorTo(state.Self, heading), bot.Thrust)}
+}
+
+func nearestEnemy(state GameState) (SpaceshipState, bool) {
+	var nearest SpaceshipState
+	found := false
+	nearestDistance := math.Inf(1)
+	for _, other := range state.Spaceships {
+		if state.Self.Team != "" && other.Team == state.Self.Team {
+			continue
+		}
+		distance := other.Position.Distance(state.Self.Position)
+		if distance < nearestDistance {
+			nearest, nearestDistance, found = other, distance, true
+		}
+	}
+	return nearest, found
+}
+
+// headingErrorTo returns the signed angle from the spaceship heading to the direction, positive counterclockwise.
+func headingErrorTo(self SpaceshipState, direction physics.Vector2) float64 {
+	heading := physics.FromAngle(self.Rotation)
+	return heading.AngleTo(direction)
+}
+
+// steer turns the spaceship by the side thrusters, the left one turns it counterclockwise.
+func steer(self SpaceshipState, headingError, mainThrust float64) Command {
+	headingError -= self.AngularVelocity * BotSteeringLookaheadSec
+	left, right := 0.0, 0.0
+	if headingError > BotSteeringToleranceRad {
+		left = MaxThrust
+	} else if headingError < -BotSteeringToleranceRad {
+		right = MaxThrust
+	}
+	return Command{Action: CommandSetEngineThrust, Args: []float64{mainThrust, left, right}}
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdleBot_Think(t *testing.T) {
+	assert.Empty(t, IdleBot{}.Think(GameState{}))
+}
+
+func TestChaserBot_Think(t *testing.T) {
+	self := SpaceshipState{Name: "self", Position: physics.Vector2{X: 100, Y: 100}, Energy: MaxEnergy}
+
+	t.Run("Steers towards the nearest enemy", func(t *testing.T) {
+		state := GameState{Self: self, Spaceships: []SpaceshipState{
+			{Name: "far", Position: physics.Vector2{X: 100, Y: -900}},
+			{Name: "near", Position: physics.Vector2{X: 100, Y: 400}},
+		}}
+		// Facing +X, the target is counterclockwise
+		assert.Equal(t, []Command{{Action: CommandSetEngineThrust, Args: []float64{MaxThrust, MaxThrust, 0}}}, ChaserBot{}.Think(state))
+
+		state.Self.Rotation = math.Pi
+		assert.Equal(t, []Command{{Action: CommandSetEngineThrust, Args: []float64{MaxThrust, 0, MaxThrust}}}, ChaserBot{}.Think(state))
+	})
+
+	t.Run("Fires once lined up within range", func(t *testing.T) {
+		state := GameState{Self: self, Spaceships: []SpaceshipState{{Name: "target", Position: physics.Vector2{X: 300, Y: 110}}}}
+		assert.Equal(t, []Command{
+			{Action: CommandSetEngineThrust, Args: []float64{MaxThrust, 0, 0}},
+			{Action: CommandFireLaser},
+		}, ChaserBot{}.Think(state))
+
+		state.Self.Energy = EnergyConsumptionLaser - 1
+		assert.Len(t, ChaserBot{}.Think(state), 1)
+		state.Self.Energy = MaxEnergy
+		state.Spaceships[0].Position = physics.Vector2{X: 100 + ChaserFireRange + 1, Y: 100}
+		assert.Len(t, ChaserBot{}.Think(state), 1)
+	})
+
+	t.Run("Spares the teammates", func(t *testing.T) {
+		state := GameState{Self: self, Spaceships: []SpaceshipState{{Name: "mate", Team: "red", Position: physics.Vector2{X: 300, Y: 100}}}}
+		state.Self.Team = "red"
+		assert.Equal(t, []Command{{Action: CommandSetEngineThrust, Args: []float64{0, 0, 0}}}, ChaserBot{}.Think(state))
+	})
+}
+
+func TestChaserBot_Game(t *testing.T) {
+	game := NewGame(physics.Size{Width: 2000, Height: 2000}, 1234567890)
+	game.AddSpaceship("chaser", physics.Vector2{X: 200, Y: 1000}, math.Pi/2)
+	game.AddSpaceship("target", physics.Vector2{X: 1000, Y: 1000}, 0)
+	game.AddBot("chaser", NewBotStrategy(ChaserBot{}))
+	game.AddBot("target", NewBotStrategy(IdleBot{}))
+	game.Start()
+
+	target, _ := game.manager.GetSpaceship("target")
+	for i := 0; i < 1000 && target.Health() == MaxHealth; i++ {
+		game.Update(16)
+	}
+	assert.Less(t, target.Health(), float64(MaxHealth))
+}
+
+func TestOrbiterBot_Think(t *testing.T) {
+	bot := OrbiterBot{Radius: 100, Thrust: 50}
+	size := physics.Size{Width: 1000, Height: 1000}
+
+	// On the orbit heading along the tangent
+	state := GameState{Size: size, Self: SpaceshipState{Position: physics.Vector2{X: 600, Y: 500}, Rotation: math.Pi / 2}}
+	assert.Equal(t, []Command{{Action: CommandSetEngineThrust, Args: []float64{50, 0, 0}}}, bot.Think(state))
+
+	// Outside the orbit turns inwards
+	state.Self.Position = physics.Vector2{X: 800, Y: 500}
+	assert.Equal(t, []Command{{Action: CommandSetEngineThrust, Args: []float64{50, MaxThrust, 0}}}, bot.Think(state))
+
+	// Inside the orbit turns outwards, around the custom center
+	bot.Center = physics.Vector2{X: 200, Y: 200}
+	state.Self.Position = physics.Vector2{X: 250, Y: 200}
+	assert.Equal(t, []Command{{Action: CommandSetEngineThrust, Args: []float64{50, 0, MaxThrust}}}, bot.Think(state))
+}
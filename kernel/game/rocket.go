@@ -8,9 +8,16 @@ import (
 )
 
 func NewRocketProjectile(id int64, position physics.Vector2, rotation float64, owner *Spaceship) *Projectile {
+	projectile := &Projectile{}
+	initRocketProjectile(projectile, id, position, rotation, owner)
+	return projectile
+}
+
+// initRocketProjectile initializes the projectile as NewRocketProjectile.
+func initRocketProjectile(projectile *Projectile, id int64, position physics.Vector2, rotation float64, owner *Spaceship) {
 	direction := physics.Vector2{X: math.Cos(rotation), Y: math.Sin(rotation)}
 
-	return &Projectile{
+	*projectile = Projectile{
 		id:                   id,
 		damageType:           DamageTypeRocket,
 		enabled:              true,
@@ -0,0 +1,137 @@
+package game
+
+import "errors"
+
+// ShipStats is the match record of a spaceship since it joined or since the last reset.
+type ShipStats struct {
+	Kills int
+	// Kills of the spaceships it damaged, but another one destroyed
+	Assists            int
+	Deaths             int
+	AsteroidsDestroyed int
+	// Damage after the armor and the shield, the self-inflicted one excluded
+	DamageDealt float64
+	// Time alive since joining, until the death for a destroyed spaceship
+	SurvivalMs float64
+}
+
+type shipRecord struct {
+	stats     ShipStats
+	joinedMs  float64
+	diedMs    float64
+	dead      bool
+	damagedBy []string // Damage dealers since the last death, credited with the assists
+}
+
+// ScoreboardEntry is the spaceship's score alongside its match record.
+type ScoreboardEntry struct {
+	Name  string
+	Team  string
+	Score float64
+	ShipStats
+}
+
+// Scoreboard is the snapshot of the spaceships ranked by the score, see GameManager.SpaceshipsByScore.
+type Scoreboard []ScoreboardEntry
+
+// Scoreboard returns the current standings of the spaceships.
+func (manager *GameManager) Scoreboard() Scoreboard {
+	scoreboard := Scoreboard{}
+	for _, spaceship := range manager.SpaceshipsByScore() {
+		entry := ScoreboardEntry{
+			Name:  spaceship.name,
+			Team:  spaceship.team,
+			Score: spaceship.score,
+		}
+		if record, ok := manager.records[spaceship.name]; ok {
+			entry.ShipStats = record.stats
+			if record.dead {
+				entry.SurvivalMs = record.diedMs - record.joinedMs
+			} else {
+				entry.SurvivalMs = manager.elapsedMs - record.joinedMs
+			}
+		}
+		entry.Kills = int(spaceship.kills)
+		scoreboard = append(scoreboard, entry)
+	}
+	return scoreboard
+}
+
+// Winner returns the name of the top ranked spaceship.
+func (scoreboard Scoreboard) Winner() (string, error) {
+	if len(scoreboard) == 0 {
+		return "", errors.New("no spaceships in the game")
+	}
+	return scoreboard[0].Name, nil
+}
+
+func (scoreboard Scoreboard) Serialize() []interface{} {
+	serialized := make([]interface{}, 0, len(scoreboard))
+	for _, entry := range scoreboard {
+		serialized = append(serialized, map[string]interface{}{
+			"name":               entry.Name,
+			"team":               entry.Team,
+			"score":              entry.Score,
+			"kills":              entry.Kills,
+			"assists":            entry.Assists,
+			"deaths":             entry.Deaths,
+			"asteroidsDestroyed": entry.AsteroidsDestroyed,
+			"damageDealt":        entry.DamageDealt,
+			"survivalMs":         entry.SurvivalMs,
+		})
+	}
+	return serialized
+}
+
+// RecordAsteroidDestroyed credits the spaceship with destroying an asteroid.
+func (manager *GameManager) RecordAsteroidDestroyed(spaceship *Spaceship) {
+	if record, ok := manager.records[spaceship.name]; ok {
+		record.stats.AsteroidsDestroyed++
+	}
+}
+
+func (manager *GameManager) recordDamage(victim *Spaceship, damageDealer *Spaceship, damage float64) {
+	if damageDealer == nil || damageDealer == victim {
+		return
+	}
+	if record, ok := manager.records[damageDealer.name]; ok {
+		record.stats.DamageDealt += damage
+	}
+	if record, ok := manager.records[victim.name]; ok {
+		for _, name := range record.damagedBy {
+			if name == damageDealer.name {
+				return
+			}
+		}
+		record.damagedBy = append(record.damagedBy, damageDealer.name)
+	}
+}
+
+// recordDeath counts the death and credits the assists, the kill is counted by the killer itself.
+func (manager *GameManager) recordDeath(victim *Spaceship, killer *Spaceship) {
+	record, ok := manager.records[victim.name]
+	if !ok {
+		return
+	}
+	record.stats.Deaths++
+	record.dead = true
+	record.diedMs = manager.elapsedMs
+
+	for _, name := range record.damagedBy {
+		if killer != nil && name == killer.name {
+			continue
+		}
+		if assistant, ok := manager.records[name]; ok {
+			assistant.stats.Assists++
+		}
+	}
+	record.damagedBy = nil
+}
+
+// resetRecords starts new records for the spaceships in the game.
+func (manager *GameManager) resetRecords() {
+	manager.records = map[string]*shipRecord{}
+	for name := range manager.spaceShips {
+		manager.records[name] = &shipRecord{joinedMs: manager.elapsedMs}
+	}
+}
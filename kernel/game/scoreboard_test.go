@@ -0,0 +1,73 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameManager_Scoreboard(t *testing.T) {
+	manager := NewGameManager()
+	alpha := NewSpaceship(1, "alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	beta := NewSpaceship(2, "beta", physics.Vector2{X: 300, Y: 100}, 0)
+	gamma := NewSpaceship(3, "gamma", physics.Vector2{X: 500, Y: 100}, 0)
+	gamma.SetTeam("blue")
+	manager.AddSpaceship(alpha)
+	manager.AddSpaceship(beta)
+	manager.Tick(500)
+	manager.AddSpaceship(gamma)
+
+	manager.Tick(1000)
+	gamma.TakeDamage(30, &manager, beta)
+	gamma.TakeZoneDamage(HullZoneFront, 10, &manager, beta)
+	gamma.TakeDamage(20, &manager, gamma)
+	gamma.TakeDamage(MaxHealth, &manager, alpha)
+	alpha.AddScore(10)
+	manager.RecordAsteroidDestroyed(beta)
+	manager.Tick(500)
+
+	assert.Equal(t, Scoreboard{
+		{Name: "alpha", Score: ScorePerKill + 10, ShipStats: ShipStats{Kills: 1, DamageDealt: MaxHealth, SurvivalMs: 2000}},
+		{Name: "beta", ShipStats: ShipStats{Assists: 1, AsteroidsDestroyed: 1, DamageDealt: 40, SurvivalMs: 2000}},
+		{Name: "gamma", Team: "blue", ShipStats: ShipStats{Deaths: 1, SurvivalMs: 1000}},
+	}, manager.Scoreboard())
+
+	winner, err := manager.Scoreboard().Winner()
+	assert.NoError(t, err)
+	assert.Equal(t, "alpha", winner)
+
+	// The records restart with the round
+	manager.Reset()
+	assert.Equal(t, ShipStats{Kills: 0}, manager.Scoreboard()[0].ShipStats)
+
+	manager.RemoveSpaceship("gamma")
+	assert.NotContains(t, manager.records, "gamma")
+	manager.Clear()
+	_, err = manager.Scoreboard().Winner()
+	assert.Error(t, err)
+}
+
+func TestScoreboard_Serialize(t *testing.T) {
+	scoreboard := Scoreboard{{Name: "alpha", Team: "red", Score: 150, ShipStats: ShipStats{
+		Kills:              1,
+		Assists:            2,
+		Deaths:             3,
+		AsteroidsDestroyed: 4,
+		DamageDealt:        100,
+		SurvivalMs:         2000,
+	}}}
+
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"name":               "alpha",
+		"team":               "red",
+		"score":              150.0,
+		"kills":              1,
+		"assists":            2,
+		"deaths":             3,
+		"asteroidsDestroyed": 4,
+		"damageDealt":        100.0,
+		"survivalMs":         2000.0,
+	}}, scoreboard.Serialize())
+	assert.Equal(t, []interface{}{}, Scoreboard{}.Serialize())
+}
@@ -7,9 +7,20 @@ import (
 )
 
 func SeedAsteroids(random *rand.Rand, width, height float64, maxAttempts int) []GameObject {
+	config := DefaultGameConfig()
+	return seedAsteroids(random, DefaultObjectFactory{}, GlobalIDs{}, config.MinAsteroids, config.MaxAsteroids, width, height, maxAttempts)
+}
+
+// seedAsteroids places minCount to maxCount asteroids, both inclusive.
+func seedAsteroids(random *rand.Rand, factory ObjectFactory, ids IDGenerator, minCount, maxCount int, width, height float64, maxAttempts int) []GameObject {
+	count := random.Intn(maxCount-minCount+1) + minCount
+	return placeAsteroids(random, factory, ids, count, width, height, maxAttempts)
+}
+
+// placeAsteroids places up to count asteroids apart from each other, fewer when it runs out of the attempts.
+func placeAsteroids(random *rand.Rand, factory ObjectFactory, ids IDGenerator, count int, width, height float64, maxAttempts int) []GameObject {
 	asteroids := make([]GameObject, 0)
-	count := random.Intn(MaxAsteroids-MinAsteroids) + MinAsteroids
-	for i := 0; i <= count && maxAttempts > 0; i++ {
+	for i := 0; i < count && maxAttempts > 0; i++ {
 		maxAttempts--
 		radius := random.Float64()*(MaxAsteroidSize-MinAsteroidSize) + MinAsteroidSize
 		x := radius + (random.Float64() * (width - 2*radius))
@@ -29,7 +40,7 @@ func SeedAsteroids(random *rand.Rand, width, height float64, maxAttempts int) []
 			continue
 		}
 
-		asteroids = append(asteroids, NewAsteroid(NewUUID(), physics.Vector2{X: x, Y: y}, radius))
+		asteroids = append(asteroids, factory.NewAsteroid(ids.NextID(), physics.Vector2{X: x, Y: y}, radius))
 	}
 
 	return asteroids
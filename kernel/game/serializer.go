@@ -0,0 +1,44 @@
+package game
+
+import "encoding/json"
+
+// Serializer encodes the game state streamed to the clients, see Game.SetSerializer.
+type Serializer interface {
+	Encode(game *Game) ([]byte, error)
+	ContentType() string
+}
+
+// JSONSerializer encodes the Serialize output as JSON, the default one.
+type JSONSerializer struct{}
+
+func (serializer JSONSerializer) Encode(game *Game) ([]byte, error) {
+	return json.Marshal(game.Serialize())
+}
+
+func (serializer JSONSerializer) ContentType() string {
+	return "application/json"
+}
+
+// SetSerializer replaces the serializer of Encode, nil restores the JSONSerializer.
+func (game *Game) SetSerializer(serializer Serializer) {
+	game.serializer = serializer
+}
+
+func (game *Game) Serializer() Serializer {
+	if game.serializer == nil {
+		return JSONSerializer{}
+	}
+	return game.serializer
+}
+
+// Encode returns the game state encoded by the game's serializer.
+func (game *Game) Encode() ([]byte, error) {
+	return game.Serializer().Encode(game)
+}
+
+// Encode returns the game state encoded by the game's serializer, see Game.Encode.
+func (view *SpectatorView) Encode() ([]byte, error) {
+	view.game.mutex.RLock()
+	defer view.game.mutex.RUnlock()
+	return view.game.Encode()
+}
@@ -0,0 +1,32 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGame_Encode(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	assert.Equal(t, JSONSerializer{}, game.Serializer())
+	assert.Equal(t, "application/json", game.Serializer().ContentType())
+
+	encoded, err := game.Encode()
+	assert.NoError(t, err)
+	decoded := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Len(t, decoded["gameObjects"], 1)
+
+	game.SetSerializer(ProtoSerializer{})
+	view := NewSpectatorView(game)
+	encoded, err = view.Encode()
+	assert.NoError(t, err)
+	expected, _ := ProtoSerializer{}.Encode(game)
+	assert.Equal(t, expected, encoded)
+
+	game.SetSerializer(nil)
+	assert.Equal(t, JSONSerializer{}, game.Serializer())
+}
@@ -0,0 +1,96 @@
+package game
+
+import "math"
+
+// Shield absorbs the incoming damage before it reaches the hull, and recharges
+// once no hit was taken for the recharge delay.
+type Shield struct {
+	current            float64
+	max                float64
+	rechargeRate       float64 // per millisecond
+	rechargeDelayMs    float64
+	timeSinceLastHitMs float64
+}
+
+func (shield *Shield) Current() float64 {
+	return shield.current
+}
+
+func (shield *Shield) Max() float64 {
+	return shield.max
+}
+
+// absorb drains the shield by the damage and returns the overflow.
+func (shield *Shield) absorb(damage float64) float64 {
+	if damage <= 0 {
+		return damage
+	}
+	shield.timeSinceLastHitMs = 0
+	absorbed := math.Min(shield.current, damage)
+	shield.current -= absorbed
+	return damage - absorbed
+}
+
+// update recharges the shield by up to the limit, returns the recharged points.
+func (shield *Shield) update(deltaTimeMs float64, limit float64) float64 {
+	shield.timeSinceLastHitMs += deltaTimeMs
+	if shield.timeSinceLastHitMs < shield.rechargeDelayMs {
+		return 0
+	}
+	recharged := math.Max(0, math.Min(math.Min(shield.rechargeRate*deltaTimeMs, shield.max-shield.current), limit))
+	shield.current += recharged
+	return recharged
+}
+
+// recharge restores the shield by the points, up to the max, regardless of the recharge delay.
+func (shield *Shield) recharge(points float64) {
+	shield.current = math.Min(shield.current+math.Max(points, 0), shield.max)
+}
+
+func (shield *Shield) reset() {
+	shield.current = shield.max
+	shield.timeSinceLastHitMs = 0
+}
+
+func (shield *Shield) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"current":         shield.current,
+		"max":             shield.max,
+		"rechargeRate":    shield.rechargeRate,
+		"rechargeDelayMs": shield.rechargeDelayMs,
+	}
+}
+
+func deserializeShield(data map[string]interface{}) (Shield, error) {
+	var shield Shield
+	var err error
+	if shield.current, err = number(data, "current"); err != nil {
+		return Shield{}, err
+	}
+	if shield.max, err = number(data, "max"); err != nil {
+		return Shield{}, err
+	}
+	if shield.rechargeRate, err = number(data, "rechargeRate"); err != nil {
+		return Shield{}, err
+	}
+	if shield.rechargeDelayMs, err = number(data, "rechargeDelayMs"); err != nil {
+		return Shield{}, err
+	}
+	return shield, nil
+}
+
+// WithShield equips the ship with the shield, the ships have no shield by default.
+func WithShield(max, rechargeRate, rechargeDelayMs float64) SpaceshipOption {
+	return func(ship *Spaceship) {
+		ship.shield = Shield{
+			current:         max,
+			max:             max,
+			rechargeRate:    rechargeRate,
+			rechargeDelayMs: rechargeDelayMs,
+		}
+	}
+}
+
+func (ship *Spaceship) Shield() *Shield {
+	return &ship.shield
+}
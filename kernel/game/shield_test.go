@@ -0,0 +1,92 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpaceship_NoShield(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	assert.Equal(t, 0.0, ship.Shield().Max())
+
+	ship.TakeDamage(10, &gameManager, nil)
+	assert.Equal(t, 90.0, ship.Health())
+}
+
+func TestShield_Absorb(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0, WithShield(50, 0.01, 1000))
+	assert.Equal(t, 50.0, ship.Shield().Current())
+
+	// Absorbed
+	ship.TakeDamage(30, &gameManager, nil)
+	assert.Equal(t, 20.0, ship.Shield().Current())
+	assert.Equal(t, float64(MaxHealth), ship.Health())
+
+	// Only the overflow reaches the hull
+	ship.TakeZoneDamage(HullZoneFront, 30, &gameManager, nil)
+	assert.Equal(t, 0.0, ship.Shield().Current())
+	assert.Equal(t, [4]float64{90, 100, 100, 100}, ship.HullZones())
+}
+
+func TestShield_Recharge(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0, WithShield(50, 0.01, 1000))
+	ship.TakeDamage(50, &gameManager, nil)
+
+	// Delayed after the hit
+	ship.Update(500, &gameManager)
+	assert.Equal(t, 0.0, ship.Shield().Current())
+	ship.Update(400, &gameManager)
+	assert.Equal(t, 0.0, ship.Shield().Current())
+
+	ship.Update(100, &gameManager)
+	assert.InDelta(t, 1.0, ship.Shield().Current(), 1e-9)
+	ship.Update(1000, &gameManager)
+	assert.InDelta(t, 11.0, ship.Shield().Current(), 1e-9)
+
+	// A new hit restarts the delay
+	ship.TakeDamage(1, &gameManager, nil)
+	ship.Update(500, &gameManager)
+	assert.InDelta(t, 10.0, ship.Shield().Current(), 1e-9)
+
+	// Capped at the max
+	ship.Update(10000, &gameManager)
+	assert.Equal(t, 50.0, ship.Shield().Current())
+
+	ship.TakeDamage(50, &gameManager, nil)
+	ship.Reset()
+	assert.Equal(t, 50.0, ship.Shield().Current())
+}
+
+func TestShield_RechargeEnergy(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0, WithShield(50, 0.01, 0))
+	ship.TakeDamage(50, &gameManager, nil)
+
+	// 10 points recharged for 5 energy, then recharged by the reactor
+	ship.energy = 50
+	ship.Update(1000, &gameManager)
+	assert.InDelta(t, 10.0, ship.Shield().Current(), 1e-9)
+	assert.InDelta(t, 50-10*EnergyConsumptionShieldPoint+EnergyRechargeRateSec, ship.Energy(), 1e-9)
+
+	// Limited by the energy left
+	ship.energy = 1
+	ship.Update(1000, &gameManager)
+	assert.InDelta(t, 12.0, ship.Shield().Current(), 1e-9)
+	assert.InDelta(t, EnergyRechargeRateSec, ship.Energy(), 1e-9)
+}
+
+func TestShield_Serialize(t *testing.T) {
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0, WithShield(50, 0.01, 1000))
+
+	assert.Equal(t, map[string]interface{}{
+		"current":         50.0,
+		"max":             50.0,
+		"rechargeRate":    0.01,
+		"rechargeDelayMs": 1000.0,
+	}, ship.Serialize()["shield"])
+}
@@ -0,0 +1,413 @@
+package game
+
+import (
+	"encoding/json"
+	"math/rand"
+)
+
+// countingSource is the seeded random numbers source counting its draws, so that a snapshot can
+// restore the generator by replaying them on a new source of the same seed.
+type countingSource struct {
+	source rand.Source64
+	draws  uint64
+}
+
+func newCountingSource(seed int64) *countingSource {
+	return &countingSource{source: rand.NewSource(seed).(rand.Source64)}
+}
+
+func (source *countingSource) Int63() int64 {
+	source.draws++
+	return source.source.Int63()
+}
+
+func (source *countingSource) Uint64() uint64 {
+	source.draws++
+	return source.source.Uint64()
+}
+
+func (source *countingSource) Seed(seed int64) {
+	source.source.Seed(seed)
+	source.draws = 0
+}
+
+// skip advances the source by the draws, each draw advances it by a single step.
+func (source *countingSource) skip(draws uint64) {
+	for ; draws > 0; draws-- {
+		source.Uint64()
+	}
+}
+
+// Snapshot captures the game in progress, so that a server can resume it by LoadSnapshot, e.g. after
+// a crash, with the same outcome. Unlike the Checkpoint of the Serialize output, it also keeps the
+// state of the random numbers, the pending commands, the boosts and stuns, the projectiles' payload,
+// the stats, the scoreboard records and the weapon slots' cooldowns. Not captured are the game options,
+// the queued actions and everything held by the callbacks: the bots, the maneuvers, the triggers, the
+// weapons themselves and the event subscriptions. The weapons re-equipped on the restored spaceships
+// land on their slots, cooling down as before.
+func (game *Game) Snapshot() ([]byte, error) {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
+	serialized := game.Serialize()
+	serialized["snapshot"] = game.snapshot()
+	return json.Marshal(serialized)
+}
+
+// LoadSnapshot restores the game from its Snapshot. The options are the ones the game was created
+// with, which the snapshot does not capture, e.g. WithPickups or WithSuddenDeath.
+func LoadSnapshot(data []byte, options ...GameOption) (*Game, error) {
+	serialized := make(map[string]interface{})
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return nil, err
+	}
+	serialized, err := migrations.Migrate(serialized)
+	if err != nil {
+		return nil, err
+	}
+	game, err := deserialize(serialized, options...)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := object(serialized, "snapshot")
+	if err != nil {
+		return nil, err
+	}
+	if err := game.restoreSnapshot(snapshot); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+func (game *Game) snapshot() map[string]interface{} {
+	manager := &game.manager
+	ships := map[string]interface{}{}
+	for name, ship := range manager.spaceShips {
+		commands := make([]interface{}, 0, len(ship.commands))
+		for _, queued := range ship.commands {
+			args := make([]interface{}, len(queued.command.Args))
+			for i, arg := range queued.command.Args {
+				args[i] = arg
+			}
+			commands = append(commands, map[string]interface{}{
+				"action":     queued.command.Action,
+				"args":       args,
+				"delayTicks": queued.delayTicks,
+			})
+		}
+		weapons := make([]interface{}, len(ship.weapons))
+		for i, slot := range ship.weapons {
+			weapons[i] = map[string]interface{}{
+				"name":        slot.name,
+				"cooldownSec": slot.cooldownSec,
+			}
+		}
+		ships[name] = map[string]interface{}{
+			"stunTimerSec":       ship.stunTimerSec,
+			"speedMultiplier":    ship.speedMultiplier,
+			"speedBoostTimerMs":  ship.speedBoostTimerMs,
+			"damageMultiplier":   ship.damageMultiplier,
+			"damageBoostTimerMs": ship.damageBoostTimerMs,
+			"commandDelayTicks":  ship.commandDelayTicks,
+			"commands":           commands,
+			"weapons":            weapons,
+		}
+	}
+
+	projectiles := make([]interface{}, 0)
+	for _, gameObject := range manager.gameObjects {
+		projectile, ok := gameObject.(*Projectile)
+		if !ok {
+			continue
+		}
+		projectiles = append(projectiles, map[string]interface{}{
+			"id":                   projectile.id,
+			"accelerationRate":     projectile.accelerationRate,
+			"explosionRadius":      projectile.explosionRadius,
+			"explosionDurationSec": projectile.explosionDurationSec,
+			"chainJumps":           projectile.chainJumps,
+			"chainRadius":          projectile.chainRadius,
+			"splashRadius":         projectile.splashRadius,
+			"splashDamage":         projectile.splashDamage,
+		})
+	}
+
+	records := map[string]interface{}{}
+	for name, record := range manager.records {
+		damagedBy := make([]interface{}, len(record.damagedBy))
+		for i, dealer := range record.damagedBy {
+			damagedBy[i] = dealer
+		}
+		records[name] = map[string]interface{}{
+			"kills":              record.stats.Kills,
+			"assists":            record.stats.Assists,
+			"deaths":             record.stats.Deaths,
+			"asteroidsDestroyed": record.stats.AsteroidsDestroyed,
+			"damageDealt":        record.stats.DamageDealt,
+			"joinedMs":           record.joinedMs,
+			"diedMs":             record.diedMs,
+			"dead":               record.dead,
+			"damagedBy":          damagedBy,
+		}
+	}
+
+	return map[string]interface{}{
+		"randDraws":          manager.randSource.draws,
+		"fixedStepMs":        game.fixedStepMs,
+		"accumulatorMs":      game.accumulatorMs,
+		"endReason":          game.endReason,
+		"managerEndReason":   manager.endReason,
+		"gracefulEndTimerMs": manager.gracefulEndTimerMs,
+		"projectilesFired":   manager.projectilesFired,
+		"asteroidsDestroyed": manager.asteroidsDestroyed,
+		"pickupsNextMs":      manager.pickups.nextMs,
+		"ships":              ships,
+		"projectiles":        projectiles,
+		"records":            records,
+	}
+}
+
+func (game *Game) restoreSnapshot(data map[string]interface{}) error {
+	manager := &game.manager
+	draws, err := number(data, "randDraws")
+	if err != nil {
+		return err
+	}
+	manager.seedRand(game.seed)
+	manager.randSource.skip(uint64(draws))
+
+	if game.fixedStepMs, err = number(data, "fixedStepMs"); err != nil {
+		return err
+	}
+	if game.accumulatorMs, err = number(data, "accumulatorMs"); err != nil {
+		return err
+	}
+	if game.endReason, err = text(data, "endReason"); err != nil {
+		return err
+	}
+	if manager.endReason, err = text(data, "managerEndReason"); err != nil {
+		return err
+	}
+	if manager.gracefulEndTimerMs, err = number(data, "gracefulEndTimerMs"); err != nil {
+		return err
+	}
+	projectilesFired, err := number(data, "projectilesFired")
+	if err != nil {
+		return err
+	}
+	manager.projectilesFired = int(projectilesFired)
+	asteroidsDestroyed, err := number(data, "asteroidsDestroyed")
+	if err != nil {
+		return err
+	}
+	manager.asteroidsDestroyed = int(asteroidsDestroyed)
+	if manager.pickups.nextMs, err = number(data, "pickupsNextMs"); err != nil {
+		return err
+	}
+
+	ships, err := object(data, "ships")
+	if err != nil {
+		return err
+	}
+	for name, ship := range manager.spaceShips {
+		if _, ok := ships[name]; !ok {
+			continue
+		}
+		serialized, err := object(ships, name)
+		if err != nil {
+			return err
+		}
+		if err := restoreShipSnapshot(ship, serialized); err != nil {
+			return err
+		}
+	}
+
+	projectiles, err := list(data, "projectiles")
+	if err != nil {
+		return err
+	}
+	for _, rawProjectile := range projectiles {
+		serialized, ok := rawProjectile.(map[string]interface{})
+		if !ok {
+			return ErrInvalidState{Field: "projectiles"}
+		}
+		id, err := number(serialized, "id")
+		if err != nil {
+			return err
+		}
+		// Skipped by the deserialization, e.g. of a gone owner
+		projectile, ok := manager.GetGameObjectByID(int64(id)).(*Projectile)
+		if !ok {
+			continue
+		}
+		if err := restoreProjectileSnapshot(projectile, serialized); err != nil {
+			return err
+		}
+	}
+
+	records, err := object(data, "records")
+	if err != nil {
+		return err
+	}
+	for name := range records {
+		serialized, err := object(records, name)
+		if err != nil {
+			return err
+		}
+		record, err := deserializeShipRecord(serialized)
+		if err != nil {
+			return err
+		}
+		manager.records[name] = record
+	}
+	return nil
+}
+
+func restoreShipSnapshot(ship *Spaceship, data map[string]interface{}) error {
+	var err error
+	if ship.stunTimerSec, err = number(data, "stunTimerSec"); err != nil {
+		return err
+	}
+	if ship.speedMultiplier, err = number(data, "speedMultiplier"); err != nil {
+		return err
+	}
+	if ship.speedBoostTimerMs, err = number(data, "speedBoostTimerMs"); err != nil {
+		return err
+	}
+	if ship.damageMultiplier, err = number(data, "damageMultiplier"); err != nil {
+		return err
+	}
+	if ship.damageBoostTimerMs, err = number(data, "damageBoostTimerMs"); err != nil {
+		return err
+	}
+	delayTicks, err := number(data, "commandDelayTicks")
+	if err != nil {
+		return err
+	}
+	ship.commandDelayTicks = int(delayTicks)
+
+	commands, err := list(data, "commands")
+	if err != nil {
+		return err
+	}
+	ship.commands = nil
+	for _, rawCommand := range commands {
+		serialized, ok := rawCommand.(map[string]interface{})
+		if !ok {
+			return ErrInvalidState{Field: "commands"}
+		}
+		action, err := text(serialized, "action")
+		if err != nil {
+			return err
+		}
+		rawArgs, err := list(serialized, "args")
+		if err != nil {
+			return err
+		}
+		args := make([]float64, len(rawArgs))
+		for i, rawArg := range rawArgs {
+			if args[i], ok = toNumber(rawArg); !ok {
+				return ErrInvalidState{Field: "args"}
+			}
+		}
+		delayTicks, err := number(serialized, "delayTicks")
+		if err != nil {
+			return err
+		}
+		ship.commands = append(ship.commands, queuedCommand{
+			command:    Command{Ship: ship.name, Action: action, Args: args},
+			delayTicks: int(delayTicks),
+		})
+	}
+
+	// The slots by their index, the weapons are re-equipped onto them by the name
+	weapons, err := list(data, "weapons")
+	if err != nil {
+		return err
+	}
+	ship.weapons = nil
+	for _, rawWeapon := range weapons {
+		serialized, ok := rawWeapon.(map[string]interface{})
+		if !ok {
+			return ErrInvalidState{Field: "weapons"}
+		}
+		name, err := text(serialized, "name")
+		if err != nil {
+			return err
+		}
+		cooldownSec, err := number(serialized, "cooldownSec")
+		if err != nil {
+			return err
+		}
+		ship.weapons = append(ship.weapons, weaponSlot{name: name, cooldownSec: cooldownSec})
+	}
+	return nil
+}
+
+func restoreProjectileSnapshot(projectile *Projectile, data map[string]interface{}) error {
+	var err error
+	if projectile.accelerationRate, err = number(data, "accelerationRate"); err != nil {
+		return err
+	}
+	if projectile.explosionRadius, err = number(data, "explosionRadius"); err != nil {
+		return err
+	}
+	if projectile.explosionDurationSec, err = number(data, "explosionDurationSec"); err != nil {
+		return err
+	}
+	chainJumps, err := number(data, "chainJumps")
+	if err != nil {
+		return err
+	}
+	projectile.chainJumps = int(chainJumps)
+	if projectile.chainRadius, err = number(data, "chainRadius"); err != nil {
+		return err
+	}
+	if projectile.splashRadius, err = number(data, "splashRadius"); err != nil {
+		return err
+	}
+	if projectile.splashDamage, err = number(data, "splashDamage"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func deserializeShipRecord(data map[string]interface{}) (*shipRecord, error) {
+	record := &shipRecord{}
+	counts := map[string]*int{
+		"kills":              &record.stats.Kills,
+		"assists":            &record.stats.Assists,
+		"deaths":             &record.stats.Deaths,
+		"asteroidsDestroyed": &record.stats.AsteroidsDestroyed,
+	}
+	for key, count := range counts {
+		value, err := number(data, key)
+		if err != nil {
+			return nil, err
+		}
+		*count = int(value)
+	}
+	var err error
+	if record.stats.DamageDealt, err = number(data, "damageDealt"); err != nil {
+		return nil, err
+	}
+	if record.joinedMs, err = number(data, "joinedMs"); err != nil {
+		return nil, err
+	}
+	if record.diedMs, err = number(data, "diedMs"); err != nil {
+		return nil, err
+	}
+	record.dead, _ = data["dead"].(bool)
+	damagedBy, err := list(data, "damagedBy")
+	if err != nil {
+		return nil, err
+	}
+	for _, rawDealer := range damagedBy {
+		dealer, ok := rawDealer.(string)
+		if !ok {
+			return nil, ErrInvalidState{Field: "damagedBy"}
+		}
+		record.damagedBy = append(record.damagedBy, dealer)
+	}
+	return record, nil
+}
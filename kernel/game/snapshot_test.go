@@ -0,0 +1,98 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGame_Snapshot(t *testing.T) {
+	options := []GameOption{WithPickups(500, 1000)}
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, options...)
+	game.SeedAsteroids()
+	_, _ = game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 500}, CommandDelayTicks: 2})
+	game.AddSpaceship("beta", physics.Vector2{X: 900, Y: 500}, 3.14)
+	game.Start()
+
+	play := func(game *Game, from, to int) {
+		for i := from; i < to; i++ {
+			if i%20 == 0 {
+				_ = game.EnqueueCommand(Command{Ship: "alpha", Action: CommandFireRocket})
+				_ = game.EnqueueCommand(Command{Ship: "beta", Action: CommandFireLaser})
+				_ = game.EnqueueCommand(Command{Ship: "beta", Action: CommandSetEngineThrust, Args: []float64{float64(i%3) / 2, 0, 0.5}})
+			}
+			game.Update(16)
+		}
+	}
+	state := func(game *Game) string {
+		serialized := game.Serialize()
+		delete(serialized, "timestamp")
+		for _, log := range serialized["logs"].([]interface{}) {
+			delete(log.(map[string]interface{}), "time")
+		}
+		data, _ := json.Marshal(serialized)
+		return string(data)
+	}
+
+	play(game, 0, 121)
+	alpha, _ := game.manager.GetSpaceship("alpha")
+	assert.NoError(t, alpha.BoostSpeed(1.5, 1000))
+	assert.NotEmpty(t, game.manager.Query(func(gameObject GameObject) bool { _, ok := gameObject.(*Projectile); return ok }))
+	assert.NotZero(t, alpha.PendingCommands())
+
+	snapshot, err := game.Snapshot()
+	assert.NoError(t, err)
+	restored, err := LoadSnapshot(snapshot, options...)
+	assert.NoError(t, err)
+	assert.Equal(t, state(game), state(restored))
+	assert.Equal(t, game.manager.randSource.draws, restored.manager.randSource.draws)
+	assert.Equal(t, game.manager.Scoreboard(), restored.manager.Scoreboard())
+	restoredAlpha, _ := restored.manager.GetSpaceship("alpha")
+	assert.Equal(t, alpha.PendingCommands(), restoredAlpha.PendingCommands())
+	assert.Equal(t, alpha.MaxSpeed(), restoredAlpha.MaxSpeed())
+
+	// Resumes the same
+	play(game, 121, 400)
+	play(restored, 121, 400)
+	assert.Equal(t, state(game), state(restored))
+	assert.Equal(t, game.manager.Rand().Int63(), restored.manager.Rand().Int63())
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := LoadSnapshot([]byte("{"))
+		assert.Error(t, err)
+
+		// The plain Serialize output
+		data, _ := json.Marshal(game.Serialize())
+		_, err = LoadSnapshot(data)
+		assert.Error(t, err)
+	})
+}
+
+func TestGame_Snapshot_WeaponCooldown(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 500}, 0)
+	game.Start()
+	alpha, _ := game.manager.GetSpaceship("alpha")
+	alpha.EquipWeapon(LaserWeapon{})
+	slot := alpha.EquipWeapon(RocketWeapon{})
+	assert.NoError(t, alpha.FireWeapon(slot, &game.manager))
+	game.Update(16)
+	cooldownSec := alpha.WeaponCooldownSec(slot)
+	assert.Greater(t, cooldownSec, 0.0)
+
+	snapshot, err := game.Snapshot()
+	assert.NoError(t, err)
+	restored, err := LoadSnapshot(snapshot)
+	assert.NoError(t, err)
+	restoredAlpha, _ := restored.manager.GetSpaceship("alpha")
+	assert.Equal(t, []Weapon{nil, nil}, restoredAlpha.Weapons())
+	assert.EqualError(t, restoredAlpha.FireWeapon(slot, &restored.manager), "no weapon in slot 1")
+
+	// Re-equipped in any order, the weapons land on their slots still cooling down
+	assert.Equal(t, slot, restoredAlpha.EquipWeapon(RocketWeapon{}))
+	assert.Equal(t, 0, restoredAlpha.EquipWeapon(LaserWeapon{}))
+	assert.Equal(t, cooldownSec, restoredAlpha.WeaponCooldownSec(slot))
+	assert.EqualError(t, restoredAlpha.FireWeapon(slot, &restored.manager), "rocket is still cooling down")
+}
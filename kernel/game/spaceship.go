@@ -2,6 +2,7 @@ package game
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"time"
 
@@ -10,14 +11,34 @@ import (
 )
 
 type Engine struct {
-	mainThrust  float64 // 0-100
-	leftThrust  float64 // 0-100
-	rightThrust float64 // 0-100
+	mainThrust  float64         // 0-100
+	leftThrust  float64         // 0-100
+	rightThrust float64         // 0-100
+	rawThrust   physics.Vector2 // World space override, 0-100 magnitude
+}
+
+type HullZone int
+
+const (
+	HullZoneFront HullZone = iota
+	HullZoneRear
+	HullZoneLeft
+	HullZoneRight
+)
+
+type SpaceshipConfig struct {
+	Name              string          `json:"name"`
+	Team              string          `json:"team,omitempty"` // Empty for no team
+	Position          physics.Vector2 `json:"position"`
+	Rotation          float64         `json:"rotation"`
+	CommandDelayTicks int             `json:"commandDelayTicks,omitempty"` // See WithCommandDelay
+	Kinematics        *Kinematics     `json:"kinematics,omitempty"`        // Nil for the default flight model
 }
 
 type Spaceship struct {
 	id                   int64
 	name                 string
+	team                 string
 	enabled              bool
 	rotation             float64
 	startRotation        float64
@@ -25,29 +46,111 @@ type Spaceship struct {
 	startPosition        physics.Vector2
 	gunPosition          physics.Vector2 // Relative to the ship's position, orientation to rad 0
 	velocity             physics.Vector2
-	health               float64 // 0-100
+	angularVelocity      float64     // Radians per second, of the inertial flight model
+	kinematics           *Kinematics // Nil for the default flight model
+	hullZones            [4]float64  // minHealth-maxHealth per HullZone
+	minHealth            float64     // Death threshold
+	maxHealth            float64
+	energyRechargeRate   float64 // Per second
 	energy               float64 // 0-100
 	engine               Engine
 	rockets              int32
 	kills                int32
 	score                float64
-	collider             collider.CircleCollider
+	collider             collider.Collider
 	laserReloadTimerSec  float64
 	rocketReloadTimerSec float64
+	maxTurnRate          float64 // radians per millisecond
+	turnBudget           float64 // radians left to turn within the current tick
+	maneuver             Maneuver
+	maneuverStep         int
+	maneuverTurn         float64 // Radians of the maneuver steps left to turn, beyond the turn budget so far
+	stunTimerSec         float64
+	speedMultiplier      float64 // Max speed multiplier of the active boost, 1 when none
+	speedBoostTimerMs    float64
+	damageMultiplier     float64 // Projectile damage multiplier of the active boost, 1 when none
+	damageBoostTimerMs   float64
+	shield               Shield
+	deflector            Deflector
+	radar                Radar
+	contacts             []Contact // Of the last radar sweep
+	armorReduction       float64   // Flat damage reduction
+	inbox                []Message
+	commands             []queuedCommand
+	commandDelayTicks    int
+	onKill               []func(victim GameObject, gameManager *GameManager)
+	speedTriggers        []speedTrigger
+	zoneTriggers         []zoneTrigger
+	weapons              []weaponSlot
+}
+
+type weaponSlot struct {
+	name        string // Of the weapon, the slot restored by a snapshot keeps it until the weapon is re-equipped
+	weapon      Weapon // Nil until re-equipped after a snapshot
+	cooldownSec float64
+}
+
+type zoneTrigger struct {
+	zone     physics.AABB
+	callback func(gameManager *GameManager)
+	onEnter  bool // Fires on entering the zone, on leaving otherwise
+	inside   bool
+}
+
+type speedTrigger struct {
+	threshold float64
+	callback  func(speed float64, gameManager *GameManager)
+	above     bool
+}
+
+type SpaceshipOption func(ship *Spaceship)
+
+func WithTeam(team string) SpaceshipOption {
+	return func(ship *Spaceship) {
+		ship.team = team
+	}
+}
+
+// WithCollider replaces the default circle collider, e.g. by a polygon collider of the hull.
+// The collider is moved to the ship position and rotation.
+func WithCollider(shipCollider collider.Collider) SpaceshipOption {
+	return func(ship *Spaceship) {
+		ship.collider = shipCollider
+	}
+}
+
+// WithMaxHealth sets the health of every hull zone, ignored unless above the minimum health.
+func WithMaxHealth(maxHealth float64) SpaceshipOption {
+	return func(ship *Spaceship) {
+		_ = ship.SetHealthBar(ship.minHealth, maxHealth)
+	}
 }
 
-func NewSpaceship(id int64, name string, position physics.Vector2, rotation float64) *Spaceship {
+func WithEnergyRechargeRate(rateSec float64) SpaceshipOption {
+	return func(ship *Spaceship) {
+		ship.energyRechargeRate = math.Max(rateSec, 0)
+	}
+}
+
+func NewSpaceship(id int64, name string, position physics.Vector2, rotation float64, options ...SpaceshipOption) *Spaceship {
 	ship := &Spaceship{
-		id:            id,
-		enabled:       true,
-		name:          name,
-		position:      position,
-		startPosition: position,
-		rotation:      rotation,
-		startRotation: rotation,
-		// TODO: Create polygon collider
-		collider:    *collider.NewCircleCollider(position, ShipSize/2),
-		gunPosition: physics.Vector2{X: ShipSize / 2, Y: 0},
+		id:                 id,
+		enabled:            true,
+		name:               name,
+		position:           position,
+		startPosition:      position,
+		rotation:           rotation,
+		startRotation:      rotation,
+		collider:           collider.NewCircleCollider(position, ShipSize/2),
+		gunPosition:        physics.Vector2{X: ShipSize / 2, Y: 0},
+		maxTurnRate:        MaxTurnRateMs,
+		minHealth:          0,
+		maxHealth:          MaxHealth,
+		energyRechargeRate: EnergyRechargeRateSec,
+		deflector:          defaultDeflector(),
+	}
+	for _, option := range options {
+		option(ship)
 	}
 	ship.Reset()
 	return ship
@@ -57,6 +160,18 @@ func (ship *Spaceship) ID() int64 {
 	return ship.id
 }
 
+func (ship *Spaceship) Name() string {
+	return ship.name
+}
+
+func (ship *Spaceship) Team() string {
+	return ship.team
+}
+
+func (ship *Spaceship) SetTeam(team string) {
+	ship.team = team
+}
+
 func (ship *Spaceship) Enabled() bool {
 	return ship.enabled
 }
@@ -69,7 +184,7 @@ func (ship *Spaceship) Reset() {
 	ship.enabled = true
 	ship.position = ship.startPosition
 	ship.rotation = ship.startRotation
-	ship.health = MaxHealth
+	ship.hullZones = [4]float64{ship.maxHealth, ship.maxHealth, ship.maxHealth, ship.maxHealth}
 	ship.energy = MaxEnergy
 	ship.rockets = MaxRockets
 	ship.engine = Engine{
@@ -83,8 +198,28 @@ func (ship *Spaceship) Reset() {
 		X: 0,
 		Y: 0,
 	}
+	ship.angularVelocity = 0
 	ship.laserReloadTimerSec = 0
 	ship.rocketReloadTimerSec = 0
+	for i := range ship.weapons {
+		ship.weapons[i].cooldownSec = 0
+	}
+	ship.turnBudget = 0
+	ship.maneuver = nil
+	ship.maneuverStep = 0
+	ship.maneuverTurn = 0
+	ship.stunTimerSec = 0
+	ship.speedMultiplier = 1
+	ship.speedBoostTimerMs = 0
+	ship.damageMultiplier = 1
+	ship.damageBoostTimerMs = 0
+	ship.shield.reset()
+	ship.deflector.raised = false
+	ship.contacts = nil
+	ship.inbox = nil
+	ship.commands = nil
+	ship.collider.SetPosition(ship.position)
+	ship.collider.SetRotation(ship.rotation)
 }
 
 func (ship *Spaceship) Position() physics.Vector2 {
@@ -95,6 +230,60 @@ func (ship *Spaceship) SetPosition(position physics.Vector2) {
 	ship.position = position
 }
 
+func (ship *Spaceship) Velocity() physics.Vector2 {
+	return ship.velocity
+}
+
+// Speed returns the magnitude of the velocity.
+func (ship *Spaceship) Speed() float64 {
+	return ship.velocity.Magnitude()
+}
+
+// OnSpeedExceeds registers the callback invoked once the speed rises above the threshold.
+// It fires again only after the speed drops to the threshold or below and rises above it again.
+func (ship *Spaceship) OnSpeedExceeds(threshold float64, callback func(speed float64, gameManager *GameManager)) {
+	ship.speedTriggers = append(ship.speedTriggers, speedTrigger{threshold: threshold, callback: callback})
+}
+
+func (ship *Spaceship) checkSpeed(gameManager *GameManager) {
+	speed := ship.Speed()
+	for i := range ship.speedTriggers {
+		trigger := &ship.speedTriggers[i]
+		if speed <= trigger.threshold {
+			trigger.above = false
+			continue
+		}
+		if !trigger.above {
+			trigger.above = true
+			trigger.callback(speed, gameManager)
+		}
+	}
+}
+
+// OnZoneEnter registers the callback invoked on the update the ship moves into the zone, the boundary included.
+func (ship *Spaceship) OnZoneEnter(zone physics.AABB, callback func(gameManager *GameManager)) {
+	ship.zoneTriggers = append(ship.zoneTriggers, zoneTrigger{zone: zone, callback: callback, onEnter: true, inside: zone.Contains(ship.position)})
+}
+
+// OnZoneExit registers the callback invoked on the update the ship moves out of the zone.
+func (ship *Spaceship) OnZoneExit(zone physics.AABB, callback func(gameManager *GameManager)) {
+	ship.zoneTriggers = append(ship.zoneTriggers, zoneTrigger{zone: zone, callback: callback, onEnter: false, inside: zone.Contains(ship.position)})
+}
+
+func (ship *Spaceship) checkZones(gameManager *GameManager) {
+	for i := range ship.zoneTriggers {
+		trigger := &ship.zoneTriggers[i]
+		inside := trigger.zone.Contains(ship.position)
+		if inside == trigger.inside {
+			continue
+		}
+		trigger.inside = inside
+		if inside == trigger.onEnter {
+			trigger.callback(gameManager)
+		}
+	}
+}
+
 func (ship *Spaceship) SetStartPosition(position physics.Vector2) {
 	ship.startPosition = position
 }
@@ -103,20 +292,55 @@ func (ship *Spaceship) SetStartRotation(rotation float64) {
 	ship.startRotation = rotation
 }
 
+func (ship *Spaceship) MaxTurnRate() float64 {
+	return ship.maxTurnRate
+}
+
+func (ship *Spaceship) SetMaxTurnRate(rate float64) {
+	ship.maxTurnRate = rate
+}
+
+// Rotate turns the ship by the delta angle (in radians).
+// The turn is clamped to the max turn rate over the last tick duration,
+// the remaining budget is restored on the next Update.
+func (ship *Spaceship) Rotate(deltaAngle float64) {
+	deltaAngle = math.Max(-ship.turnBudget, math.Min(deltaAngle, ship.turnBudget))
+	ship.turnBudget -= math.Abs(deltaAngle)
+	ship.rotation += deltaAngle
+	ship.collider.SetRotation(ship.rotation)
+}
+
 func (ship *Spaceship) Update(deltaTimeMs float64, gameManager *GameManager) {
 	deltaTimeSec := deltaTimeMs / 1000
+	ship.turnBudget = ship.maxTurnRate * deltaTimeMs
+	// The shield recharge is paid by the energy, an empty reactor leaves the shield down
+	recharged := ship.shield.update(deltaTimeMs, ship.energy/EnergyConsumptionShieldPoint)
+	ship.energy -= recharged * EnergyConsumptionShieldPoint
 
 	ship.gunManagement(deltaTimeSec)
+	ship.boostManagement(deltaTimeMs)
+	ship.maneuverManagement(deltaTimeSec, gameManager)
 	ship.energyManagement(deltaTimeSec)
+	ship.deflectorManagement(deltaTimeSec, gameManager)
 	if ship.energy <= 0 {
 		ship.SetEngineThrust(0, 0, 0)
-		return
+		ship.engine.rawThrust = physics.Vector2{}
+	}
+	// The default flight model stalls without energy, the inertial one drifts on
+	if ship.energy > 0 || ship.kinematics != nil {
+		ship.move(deltaTimeSec)
 	}
-	ship.move(deltaTimeSec)
+	ship.checkSpeed(gameManager)
+	ship.checkZones(gameManager)
+	ship.scan(gameManager)
+}
+
+func (ship *Spaceship) UpdatePriority() int {
+	return SpaceshipUpdatePriority
 }
 
 func (ship *Spaceship) Collider() collider.Collider {
-	return &ship.collider
+	return ship.collider
 }
 
 func (ship *Spaceship) SetEngineThrust(main, left, right float64) error {
@@ -136,6 +360,21 @@ func (ship *Spaceship) SetEngineThrust(main, left, right float64) error {
 	return nil
 }
 
+// ThrustForward sets the main thrust, pushing the ship along its heading.
+func (ship *Spaceship) ThrustForward(magnitude float64) error {
+	return ship.SetEngineThrust(magnitude, ship.engine.leftThrust, ship.engine.rightThrust)
+}
+
+// Thrust applies the thrust in the world space direction regardless of the ship heading,
+// as a raw override of the engine. The magnitude of the direction is the thrust 0-100.
+func (ship *Spaceship) Thrust(direction physics.Vector2) error {
+	if direction.Magnitude() > MaxThrust {
+		return errors.New("thrust must be between 0 and 100")
+	}
+	ship.engine.rawThrust = direction
+	return nil
+}
+
 func (ship *Spaceship) FireLaser(gameManager *GameManager) error {
 	if ship.energy < EnergyConsumptionLaser {
 		return errors.New("not enough energy")
@@ -145,15 +384,12 @@ func (ship *Spaceship) FireLaser(gameManager *GameManager) error {
 		return errors.New("laser is still cooling down")
 	}
 
+	if _, err := gameManager.AddGameObject(gameManager.newLaserProjectile(ship.muzzle(), ship.rotation, ship)); err != nil {
+		return err
+	}
+
 	ship.energy -= EnergyConsumptionLaser
 	ship.laserReloadTimerSec = LaserReloadSec
-
-	gameManager.AddGameObject(NewLaserProjectile(
-		NewUUID(),
-		ship.position.Add(ship.gunPosition.Rotate(ship.rotation)),
-		ship.rotation,
-		ship,
-	))
 	return nil
 }
 
@@ -168,18 +404,89 @@ func (ship *Spaceship) FireRocket(gameManager *GameManager) error {
 		return errors.New("rocket is not ready to be fired")
 	}
 
+	if _, err := gameManager.AddGameObject(gameManager.newRocketProjectile(ship.muzzle(), ship.rotation, ship)); err != nil {
+		return err
+	}
+
 	ship.rockets--
 	ship.energy -= EnergyConsumptionRocket
 	ship.rocketReloadTimerSec = RocketReloadSec
-	gameManager.AddGameObject(NewRocketProjectile(
-		NewUUID(),
-		ship.position.Add(ship.gunPosition.Rotate(ship.rotation)),
-		ship.rotation,
-		ship,
-	))
 	return nil
 }
 
+// muzzle returns the position the ship's projectiles are fired from.
+func (ship *Spaceship) muzzle() physics.Vector2 {
+	return ship.position.Add(ship.gunPosition.Rotate(ship.rotation))
+}
+
+// EquipWeapon mounts the weapon on the next slot, or replaces the weapon of the same name in its slot.
+// Returns the slot to fire the weapon by. The slots are independent of FireLaser and FireRocket.
+// After LoadSnapshot the weapon lands on its former slot with the cooldown it had.
+func (ship *Spaceship) EquipWeapon(weapon Weapon) int {
+	for i := range ship.weapons {
+		if ship.weapons[i].name == weapon.Name() {
+			ship.weapons[i].weapon = weapon
+			return i
+		}
+	}
+	ship.weapons = append(ship.weapons, weaponSlot{name: weapon.Name(), weapon: weapon})
+	return len(ship.weapons) - 1
+}
+
+// Weapons returns the mounted weapons by their slots, nil for the slots not re-equipped after LoadSnapshot.
+func (ship *Spaceship) Weapons() []Weapon {
+	weapons := make([]Weapon, len(ship.weapons))
+	for i, slot := range ship.weapons {
+		weapons[i] = slot.weapon
+	}
+	return weapons
+}
+
+// WeaponCooldownSec returns the time until the weapon of the slot is ready, 0 for an empty slot.
+func (ship *Spaceship) WeaponCooldownSec(slot int) float64 {
+	if slot < 0 || slot >= len(ship.weapons) {
+		return 0
+	}
+	return ship.weapons[slot].cooldownSec
+}
+
+// FireWeapon fires the weapon of the slot, consuming its energy cost and starting its cooldown.
+func (ship *Spaceship) FireWeapon(slot int, gameManager *GameManager) error {
+	if slot < 0 || slot >= len(ship.weapons) {
+		return fmt.Errorf("no weapon in slot %d", slot)
+	}
+	mounted := &ship.weapons[slot]
+	if mounted.weapon == nil {
+		return fmt.Errorf("no weapon in slot %d", slot)
+	}
+	if ship.energy < mounted.weapon.EnergyCost() {
+		return errors.New("not enough energy")
+	}
+	if mounted.cooldownSec > 0 {
+		return fmt.Errorf("%s is still cooling down", mounted.weapon.Name())
+	}
+
+	if err := mounted.weapon.Fire(ship, gameManager); err != nil {
+		return err
+	}
+	ship.energy -= mounted.weapon.EnergyCost()
+	mounted.cooldownSec = mounted.weapon.CooldownSec()
+	return nil
+}
+
+// ProjectileCount returns the number of the ship's projectiles in flight.
+func (ship *Spaceship) ProjectileCount(gameManager *GameManager) int {
+	return gameManager.Count(func(gameObject GameObject) bool {
+		projectile, ok := gameObject.(*Projectile)
+		return ok && projectile.enabled && projectile.owner == ship
+	})
+}
+
+// OnKill registers the callback invoked when the ship destroys a victim, after the kill is scored.
+func (ship *Spaceship) OnKill(callback func(victim GameObject, gameManager *GameManager)) {
+	ship.onKill = append(ship.onKill, callback)
+}
+
 func (ship *Spaceship) HasKilled(target *Spaceship) {
 	ship.kills++
 	ship.score += ScorePerKill
@@ -188,28 +495,152 @@ func (ship *Spaceship) HasKilled(target *Spaceship) {
 func (ship *Spaceship) OnCollision(other GameObject, gameManager *GameManager, order int) {
 	switch other.(type) {
 	case *Asteroid:
-		ship.TakeDamage(MaxHealth, gameManager, nil)
-		gameManager.Logger().Collision(time.Now(), ship.name, "an asteroid")
+		ship.TakeZoneDamage(ship.HitZone(other.Position()), ship.collisionDamage(other, gameManager), gameManager, nil)
+		gameManager.Logger().Collision(time.Now(), ship.id, ship.name, "an asteroid", ship.Health())
 	case *Spaceship:
-		ship.TakeDamage(MaxHealth, gameManager, nil)
+		ship.TakeZoneDamage(ship.HitZone(other.Position()), ship.collisionDamage(other, gameManager), gameManager, nil)
 		if order == 0 {
-			gameManager.Logger().Collision(time.Now(), ship.name, other.(*Spaceship).name)
+			gameManager.Logger().Collision(time.Now(), ship.id, ship.name, other.(*Spaceship).name, ship.Health())
 		}
 	default:
 		return
 	}
 }
 
+func (ship *Spaceship) collisionDamage(other GameObject, gameManager *GameManager) float64 {
+	return gameManager.DamageCalculator().Calculate(other, ship, relativeVelocity(other, ship))
+}
+
+// Energy returns the reactor charge, 0-MaxEnergy. The thrust, the weapons and the shield recharge consume it.
+func (ship *Spaceship) Energy() float64 {
+	return ship.energy
+}
+
+// Health returns the average integrity of the hull zones.
+func (ship *Spaceship) Health() float64 {
+	total := 0.0
+	for _, integrity := range ship.hullZones {
+		total += integrity
+	}
+	return total / float64(len(ship.hullZones))
+}
+
+func (ship *Spaceship) MinHealth() float64 {
+	return ship.minHealth
+}
+
+func (ship *Spaceship) MaxHealth() float64 {
+	return ship.maxHealth
+}
+
+// SetHealthBar sets the death threshold and the maximum health, and restores the hull zones to the maximum.
+// The threshold can be negative, e.g. for the poison modes where the ship survives below zero.
+func (ship *Spaceship) SetHealthBar(min, max float64) error {
+	if min >= max {
+		return fmt.Errorf("invalid health bar: min %.2f must be lower than max %.2f", min, max)
+	}
+	ship.minHealth = min
+	ship.maxHealth = max
+	ship.hullZones = [4]float64{max, max, max, max}
+	return nil
+}
+
+// Heal repairs all the hull zones evenly, up to the maximum health.
+func (ship *Spaceship) Heal(amount float64) {
+	if !ship.enabled {
+		return
+	}
+	for zone := range ship.hullZones {
+		ship.hullZones[zone] = math.Min(ship.hullZones[zone]+amount, ship.maxHealth)
+	}
+}
+
+func (ship *Spaceship) HullZones() [4]float64 {
+	return ship.hullZones
+}
+
+// HitZone returns the hull zone facing the point, relative to the ship rotation.
+// The right side is clockwise from the heading, i.e. the positive angle in the screen coordinates.
+func (ship *Spaceship) HitZone(point physics.Vector2) HullZone {
+	angle := math.Atan2(point.Y-ship.position.Y, point.X-ship.position.X) - ship.rotation
+	angle = math.Remainder(angle, 2*math.Pi)
+
+	switch {
+	case math.Abs(angle) <= math.Pi/4:
+		return HullZoneFront
+	case math.Abs(angle) >= 3*math.Pi/4:
+		return HullZoneRear
+	case angle > 0:
+		return HullZoneRight
+	default:
+		return HullZoneLeft
+	}
+}
+
+// TakeDamage damages all the hull zones evenly, the deflector, the armor and the shield mitigate the damage first.
 func (ship *Spaceship) TakeDamage(damage float64, gameManager *GameManager, damageDealer *Spaceship) {
-	ship.health -= damage
-	ship.health = math.Max(ship.health, 0)
-	if ship.health <= 0 {
-		ship.destroy(gameManager)
-		if damageDealer != nil {
-			gameManager.Logger().Kill(time.Now(), ship.name, damageDealer.name)
-			damageDealer.HasKilled(ship)
+	damage = ship.mitigate(ship.deflector.deflectSpread(damage))
+	gameManager.recordDamage(ship, damageDealer, damage)
+	for zone := range ship.hullZones {
+		ship.hullZones[zone] = math.Max(ship.hullZones[zone]-damage, ship.minHealth)
+	}
+	ship.checkHull(gameManager, damageDealer)
+}
+
+// TakeZoneDamage damages a single hull zone, depleting any zone destroys the ship.
+// The deflector, the armor and the shield mitigate the damage first.
+func (ship *Spaceship) TakeZoneDamage(zone HullZone, damage float64, gameManager *GameManager, damageDealer *Spaceship) {
+	damage = ship.mitigate(ship.deflector.deflect(zone, damage))
+	gameManager.recordDamage(ship, damageDealer, damage)
+	ship.hullZones[zone] = math.Max(ship.hullZones[zone]-damage, ship.minHealth)
+	ship.checkHull(gameManager, damageDealer)
+}
+
+// EquipArmor sets the flat reduction of all the incoming damage, clamped between 0 and the max health.
+func (ship *Spaceship) EquipArmor(reduction float64) {
+	ship.armorReduction = math.Max(0, math.Min(reduction, ship.maxHealth))
+}
+
+func (ship *Spaceship) ArmorReduction() float64 {
+	return ship.armorReduction
+}
+
+// mitigate reduces the damage by the armor, the shield absorbs the rest.
+func (ship *Spaceship) mitigate(damage float64) float64 {
+	damage = math.Max(damage-ship.armorReduction, 0)
+	return ship.shield.absorb(damage)
+}
+
+func (ship *Spaceship) checkHull(gameManager *GameManager, damageDealer *Spaceship) {
+	destroyed := false
+	for _, integrity := range ship.hullZones {
+		if integrity <= ship.minHealth {
+			destroyed = true
+			break
 		}
 	}
+	if !destroyed {
+		return
+	}
+
+	ship.hullZones = [4]float64{ship.minHealth, ship.minHealth, ship.minHealth, ship.minHealth}
+	ship.destroy(gameManager)
+	gameManager.recordDeath(ship, damageDealer)
+	gameManager.publish(ShipDestroyed{Tick: gameManager.tick, Ship: ship, Killer: damageDealer})
+	if damageDealer != nil {
+		damageDealer.HasKilled(ship)
+		for _, callback := range damageDealer.onKill {
+			callback(ship, gameManager)
+		}
+	}
+}
+
+func (ship *Spaceship) Score() float64 {
+	return ship.score
+}
+
+func (ship *Spaceship) KillCount() int32 {
+	return ship.kills
 }
 
 func (ship *Spaceship) AddScore(score float64) {
@@ -217,12 +648,13 @@ func (ship *Spaceship) AddScore(score float64) {
 }
 
 func (ship *Spaceship) Serialize() map[string]interface{} {
-	return map[string]interface{}{
+	serialized := map[string]interface{}{
 		"type":      "spaceship",
 		"id":        ship.id,
 		"enabled":   ship.enabled,
-		"destroyed": ship.health <= 0,
+		"destroyed": ship.Health() <= ship.minHealth,
 		"name":      ship.name,
+		"team":      ship.team,
 		"startPosition": map[string]interface{}{
 			"x": ship.startPosition.X,
 			"y": ship.startPosition.Y,
@@ -236,12 +668,29 @@ func (ship *Spaceship) Serialize() map[string]interface{} {
 			"x": ship.velocity.X,
 			"y": ship.velocity.Y,
 		},
-		"health": ship.health,
-		"energy": ship.energy,
+		"health":    ship.Health(),
+		"minHealth": ship.minHealth,
+		"maxHealth": ship.maxHealth,
+		"hullZones": []interface{}{
+			ship.hullZones[HullZoneFront],
+			ship.hullZones[HullZoneRear],
+			ship.hullZones[HullZoneLeft],
+			ship.hullZones[HullZoneRight],
+		},
+		"shield":         ship.shield.Serialize(),
+		"deflector":      ship.deflector.Serialize(),
+		"radar":          ship.radar.serialize(ship.contacts),
+		"armorReduction": ship.armorReduction,
+		"energy":         ship.energy,
+		"energyRecharge": ship.energyRechargeRate,
 		"engine": map[string]interface{}{
 			"mainThrust":  ship.engine.mainThrust,
 			"leftThrust":  ship.engine.leftThrust,
 			"rightThrust": ship.engine.rightThrust,
+			"rawThrust": map[string]interface{}{
+				"x": ship.engine.rawThrust.X,
+				"y": ship.engine.rawThrust.Y,
+			},
 		},
 		"rockets":              ship.rockets,
 		"kills":                ship.kills,
@@ -249,7 +698,211 @@ func (ship *Spaceship) Serialize() map[string]interface{} {
 		"laserReloadTimerSec":  ship.laserReloadTimerSec,
 		"rocketReloadTimerSec": ship.rocketReloadTimerSec,
 		"collider":             ship.collider.Serialize(),
-		// TODO: Add collider, if polygon
+	}
+	if ship.kinematics != nil {
+		serialized["kinematics"] = ship.kinematics.serialize()
+		serialized["angularVelocity"] = ship.angularVelocity
+	}
+	return serialized
+}
+
+// DeserializeSpaceship restores the spaceship from its Serialize output. The fields added
+// to the serialization later are optional and keep their defaults when missing.
+func DeserializeSpaceship(data map[string]interface{}) (*Spaceship, error) {
+	id, enabled, position, err := gameObjectHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	name, err := text(data, "name")
+	if err != nil {
+		return nil, err
+	}
+	rotation, err := number(data, "rotation")
+	if err != nil {
+		return nil, err
+	}
+
+	ship := NewSpaceship(id, name, position, rotation)
+	ship.enabled = enabled
+	if ship.velocity, err = vector(data, "velocity"); err != nil {
+		return nil, err
+	}
+	if _, ok := data["startPosition"]; ok {
+		if ship.startPosition, err = vector(data, "startPosition"); err != nil {
+			return nil, err
+		}
+	}
+	if ship.minHealth, err = optionalNumber(data, "minHealth", ship.minHealth); err != nil {
+		return nil, err
+	}
+	if ship.maxHealth, err = optionalNumber(data, "maxHealth", ship.maxHealth); err != nil {
+		return nil, err
+	}
+	if ship.energyRechargeRate, err = optionalNumber(data, "energyRecharge", ship.energyRechargeRate); err != nil {
+		return nil, err
+	}
+	health, err := number(data, "health")
+	if err != nil {
+		return nil, err
+	}
+	ship.hullZones = [4]float64{health, health, health, health}
+	if hullZones, ok := data["hullZones"].([]interface{}); ok && len(hullZones) == len(ship.hullZones) {
+		for zone, rawIntegrity := range hullZones {
+			integrity, ok := toNumber(rawIntegrity)
+			if !ok {
+				return nil, ErrInvalidState{Field: "hullZones"}
+			}
+			ship.hullZones[zone] = integrity
+		}
+	}
+	if ship.energy, err = number(data, "energy"); err != nil {
+		return nil, err
+	}
+
+	engine, err := object(data, "engine")
+	if err != nil {
+		return nil, err
+	}
+	if ship.engine.mainThrust, err = number(engine, "mainThrust"); err != nil {
+		return nil, err
+	}
+	if ship.engine.leftThrust, err = number(engine, "leftThrust"); err != nil {
+		return nil, err
+	}
+	if ship.engine.rightThrust, err = number(engine, "rightThrust"); err != nil {
+		return nil, err
+	}
+	if _, ok := engine["rawThrust"]; ok {
+		if ship.engine.rawThrust, err = vector(engine, "rawThrust"); err != nil {
+			return nil, err
+		}
+	}
+
+	rockets, err := number(data, "rockets")
+	if err != nil {
+		return nil, err
+	}
+	ship.rockets = int32(rockets)
+	kills, err := number(data, "kills")
+	if err != nil {
+		return nil, err
+	}
+	ship.kills = int32(kills)
+	if ship.score, err = number(data, "score"); err != nil {
+		return nil, err
+	}
+	if ship.laserReloadTimerSec, err = number(data, "laserReloadTimerSec"); err != nil {
+		return nil, err
+	}
+	if ship.rocketReloadTimerSec, err = number(data, "rocketReloadTimerSec"); err != nil {
+		return nil, err
+	}
+	if _, ok := data["shield"]; ok {
+		shield, err := object(data, "shield")
+		if err != nil {
+			return nil, err
+		}
+		if ship.shield, err = deserializeShield(shield); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := data["deflector"]; ok {
+		deflector, err := object(data, "deflector")
+		if err != nil {
+			return nil, err
+		}
+		if ship.deflector, err = deserializeDeflector(deflector); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := data["radar"]; ok {
+		radar, err := object(data, "radar")
+		if err != nil {
+			return nil, err
+		}
+		if ship.radar, ship.contacts, err = deserializeRadar(radar); err != nil {
+			return nil, err
+		}
+	}
+	if ship.armorReduction, err = optionalNumber(data, "armorReduction", ship.armorReduction); err != nil {
+		return nil, err
+	}
+	if team, ok := data["team"].(string); ok {
+		ship.team = team
+	}
+	if _, ok := data["kinematics"]; ok {
+		serializedKinematics, err := object(data, "kinematics")
+		if err != nil {
+			return nil, err
+		}
+		kinematics, err := deserializeKinematics(serializedKinematics)
+		if err != nil {
+			return nil, err
+		}
+		ship.kinematics = &kinematics
+		if ship.angularVelocity, err = optionalNumber(data, "angularVelocity", 0); err != nil {
+			return nil, err
+		}
+	}
+	return ship, nil
+}
+
+// MaxSpeed returns the max velocity per second, of the inertial flight model if any, scaled by
+// the active speed boost.
+func (ship *Spaceship) MaxSpeed() float64 {
+	if ship.kinematics != nil {
+		return ship.kinematics.MaxVelocitySec * ship.speedMultiplier
+	}
+	return MaxVelocitySec * ship.speedMultiplier
+}
+
+// BoostSpeed scales the max speed by the multiplier for the duration, below 1 slows the ship down.
+// A new boost replaces the active one.
+func (ship *Spaceship) BoostSpeed(multiplier float64, durationMs float64) error {
+	if multiplier <= 0 {
+		return errors.New("speed multiplier must be positive")
+	}
+	if durationMs <= 0 {
+		return errors.New("boost duration must be positive")
+	}
+	ship.speedMultiplier = multiplier
+	ship.speedBoostTimerMs = durationMs
+	return nil
+}
+
+// DamageMultiplier returns the multiplier of the damage dealt by the ship's projectiles, 1 when not boosted.
+func (ship *Spaceship) DamageMultiplier() float64 {
+	return ship.damageMultiplier
+}
+
+// BoostDamage scales the damage of the ship's projectile hits by the multiplier for the duration,
+// the projectiles fired before the boost included. A new boost replaces the active one.
+func (ship *Spaceship) BoostDamage(multiplier float64, durationMs float64) error {
+	if multiplier <= 0 {
+		return errors.New("damage multiplier must be positive")
+	}
+	if durationMs <= 0 {
+		return errors.New("boost duration must be positive")
+	}
+	ship.damageMultiplier = multiplier
+	ship.damageBoostTimerMs = durationMs
+	return nil
+}
+
+func (ship *Spaceship) boostManagement(deltaTimeMs float64) {
+	if ship.speedBoostTimerMs > 0 {
+		ship.speedBoostTimerMs -= deltaTimeMs
+		if ship.speedBoostTimerMs <= 0 {
+			ship.speedBoostTimerMs = 0
+			ship.speedMultiplier = 1
+		}
+	}
+	if ship.damageBoostTimerMs > 0 {
+		ship.damageBoostTimerMs -= deltaTimeMs
+		if ship.damageBoostTimerMs <= 0 {
+			ship.damageBoostTimerMs = 0
+			ship.damageMultiplier = 1
+		}
 	}
 }
 
@@ -263,12 +916,15 @@ func (ship *Spaceship) gunManagement(deltaTimeSec float64) {
 	if ship.rocketReloadTimerSec < 0 {
 		ship.rocketReloadTimerSec = 0
 	}
+	for i := range ship.weapons {
+		ship.weapons[i].cooldownSec = math.Max(0, ship.weapons[i].cooldownSec-deltaTimeSec)
+	}
 }
 
 func (ship *Spaceship) energyManagement(deltaTimeSec float64) {
 	// TODO: Investigate if this is needed
 	// if ship.engine.mainThrust == 0 && ship.engine.leftThrust == 0 && ship.engine.rightThrust == 0 {
-	ship.energy += deltaTimeSec * EnergyRechargeRateSec
+	ship.energy += deltaTimeSec * ship.energyRechargeRate
 	ship.energy = math.Min(ship.energy, MaxEnergy)
 	// 	return
 	// }
@@ -276,10 +932,16 @@ func (ship *Spaceship) energyManagement(deltaTimeSec float64) {
 	ship.energy -= ship.engine.mainThrust / MaxThrust * deltaTimeSec * EnergyConsumptionMainThrustSec
 	ship.energy -= ship.engine.leftThrust / MaxThrust * deltaTimeSec * EnergyConsumptionSideThrustSec
 	ship.energy -= ship.engine.rightThrust / MaxThrust * deltaTimeSec * EnergyConsumptionSideThrustSec
+	ship.energy -= ship.engine.rawThrust.Magnitude() / MaxThrust * deltaTimeSec * EnergyConsumptionMainThrustSec
 	ship.energy = math.Max(ship.energy, 0)
 }
 
 func (ship *Spaceship) move(deltaTimeSec float64) {
+	if ship.kinematics != nil {
+		ship.integrate(deltaTimeSec)
+		return
+	}
+
 	direction := physics.Vector2{X: 1, Y: 0}
 
 	mainThrust := direction.Rotate(ship.rotation)
@@ -288,16 +950,18 @@ func (ship *Spaceship) move(deltaTimeSec float64) {
 	leftThrust = leftThrust.Multiply(ship.engine.leftThrust / MaxThrust * SideThrustPowerCoefficient * deltaTimeSec * AccelerationCoefficient)
 	rightThrust := direction.Rotate(ship.rotation - math.Pi/2)
 	rightThrust = rightThrust.Multiply(ship.engine.rightThrust / MaxThrust * SideThrustPowerCoefficient * deltaTimeSec * AccelerationCoefficient)
+	rawThrust := ship.engine.rawThrust.Multiply(deltaTimeSec * AccelerationCoefficient / MaxThrust)
 
 	drag := direction.Rotate(ship.rotation + math.Pi)
 	// TODO: investigate if this should be divided by deltaTimeSec
-	drag = drag.Multiply(ship.velocity.Magnitude() / MaxVelocitySec * deltaTimeSec * DragCoefficient)
+	drag = drag.Multiply(ship.velocity.Magnitude() / ship.MaxSpeed() * deltaTimeSec * DragCoefficient)
 
 	ship.velocity = ship.velocity.Add(mainThrust)
 	ship.velocity = ship.velocity.Add(leftThrust)
 	ship.velocity = ship.velocity.Add(rightThrust)
+	ship.velocity = ship.velocity.Add(rawThrust)
 	ship.velocity = ship.velocity.Add(drag)
-	ship.velocity = ship.velocity.Clamp(MaxVelocitySec / deltaTimeSec)
+	ship.velocity = ship.velocity.Clamp(ship.MaxSpeed() / deltaTimeSec)
 
 	ship.position = ship.position.Add(ship.velocity.Multiply(deltaTimeSec))
 	if ship.velocity.Magnitude() > 0 {
@@ -305,13 +969,12 @@ func (ship *Spaceship) move(deltaTimeSec float64) {
 	}
 
 	ship.collider.SetPosition(ship.position)
-	// TODO: Apply rotation for the polygon collider
+	ship.collider.SetRotation(ship.rotation)
 }
 
 func (ship *Spaceship) destroy(gameManager *GameManager) {
-	ship.enabled = false
-	gameManager.AddGameObject(NewExplosion(
-		NewUUID(),
+	gameManager.DisableGameObject(ship)
+	gameManager.AddGameObject(gameManager.newExplosion(
 		physics.Vector2{
 			X: ship.position.X - float64(ShipExplosionRadius),
 			Y: ship.position.Y - float64(ShipExplosionRadius),
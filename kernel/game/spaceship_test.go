@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
 	"github.com/davidhorak/space-wars/kernel/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,11 +22,11 @@ func TestSpaceship_NewSpaceship(t *testing.T) {
 	assert.Equal(t, math.Pi/2, ship.rotation)
 	assert.Equal(t, math.Pi/2, ship.startRotation)
 	assert.Equal(t, physics.Vector2{X: 0, Y: 0}, ship.collider.Position())
-	assert.Equal(t, float64(15), ship.collider.Radius())
+	assert.Equal(t, float64(15), ship.collider.(*collider.CircleCollider).Radius())
 	assert.Equal(t, physics.Vector2{X: 15, Y: 0}, ship.gunPosition)
 	assert.Equal(t, int32(0), ship.kills)
 	assert.Equal(t, float64(0), ship.score)
-	assert.Equal(t, float64(100), ship.health)
+	assert.Equal(t, float64(100), ship.Health())
 	assert.Equal(t, float64(100), ship.energy)
 	assert.Equal(t, int32(10), ship.rockets)
 	assert.Equal(t, Engine{
@@ -38,6 +39,25 @@ func TestSpaceship_NewSpaceship(t *testing.T) {
 	assert.Equal(t, float64(0), ship.rocketReloadTimerSec)
 }
 
+func TestSpaceship_NewSpaceship_WithCollider(t *testing.T) {
+	hull := collider.NewPolygonCollider(physics.Vector2{X: 0, Y: 0}, 0, physics.Polygon{Vertices: []physics.Vector2{
+		{X: 15, Y: 0},
+		{X: -15, Y: 10},
+		{X: -15, Y: -10},
+	}})
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 50}, math.Pi/2, WithCollider(hull))
+
+	assert.Equal(t, hull, ship.Collider())
+	assert.Equal(t, physics.Vector2{X: 100, Y: 50}, hull.Position())
+	assert.Equal(t, math.Pi/2, hull.Rotation())
+	assert.Equal(t, "polygon", ship.Serialize()["collider"].(map[string]interface{})["type"])
+
+	asteroid := NewAsteroid(1, physics.Vector2{X: 100, Y: 68}, 5)
+	assert.True(t, ship.Collider().CollidesWith(asteroid.Collider()))
+	asteroid = NewAsteroid(2, physics.Vector2{X: 125, Y: 50}, 5)
+	assert.False(t, ship.Collider().CollidesWith(asteroid.Collider()))
+}
+
 func TestSpaceship_ID(t *testing.T) {
 	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
 
@@ -68,7 +88,7 @@ func TestSpaceship_Reset(t *testing.T) {
 	assert.Equal(t, math.Pi/2, ship.rotation)
 	assert.Equal(t, int32(0), ship.kills)
 	assert.Equal(t, float64(0), ship.score)
-	assert.Equal(t, float64(100), ship.health)
+	assert.Equal(t, float64(100), ship.Health())
 	assert.Equal(t, float64(100), ship.energy)
 	assert.Equal(t, int32(10), ship.rockets)
 	assert.Equal(t, Engine{
@@ -96,6 +116,13 @@ func TestSpaceship_SetPosition(t *testing.T) {
 	assert.Equal(t, newPosition, ship.Position())
 }
 
+func TestSpaceship_Velocity(t *testing.T) {
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	ship.velocity = physics.Vector2{X: 1, Y: 2}
+
+	assert.Equal(t, physics.Vector2{X: 1, Y: 2}, ship.Velocity())
+}
+
 func TestSpaceship_SetStartPosition(t *testing.T) {
 	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
 
@@ -114,6 +141,81 @@ func TestSpaceship_SetStartRotation(t *testing.T) {
 	assert.Equal(t, newRotation, ship.startRotation)
 }
 
+func TestSpaceship_MaxTurnRate(t *testing.T) {
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	assert.Equal(t, float64(MaxTurnRateMs), ship.MaxTurnRate())
+
+	ship.SetMaxTurnRate(0.001)
+	assert.Equal(t, 0.001, ship.MaxTurnRate())
+}
+
+func TestSpaceship_Rotate(t *testing.T) {
+	t.Run("Requires Update to turn", func(t *testing.T) {
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+
+		ship.Rotate(math.Pi)
+		assert.Equal(t, float64(0), ship.rotation)
+	})
+
+	t.Run("Rapid rotation is clamped", func(t *testing.T) {
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+		ship.SetMaxTurnRate(0.001)
+		ship.Update(100, nil)
+
+		ship.Rotate(math.Pi)
+		assert.InDelta(t, 0.1, ship.rotation, 1e-9)
+
+		ship.Rotate(-math.Pi)
+		assert.InDelta(t, 0.1, ship.rotation, 1e-9)
+
+		ship.Update(100, nil)
+		ship.Rotate(-math.Pi)
+		assert.InDelta(t, 0, ship.rotation, 1e-9)
+	})
+
+	t.Run("Slow rotation is unaffected", func(t *testing.T) {
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+		ship.SetMaxTurnRate(0.001)
+		ship.Update(100, nil)
+
+		ship.Rotate(0.05)
+		assert.InDelta(t, 0.05, ship.rotation, 1e-9)
+		ship.Rotate(-0.02)
+		assert.InDelta(t, 0.03, ship.rotation, 1e-9)
+	})
+}
+
+func TestSpaceship_ThrustForward(t *testing.T) {
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+	ship.Update(250, nil)
+	ship.Rotate(math.Pi / 2)
+
+	assert.NoError(t, ship.ThrustForward(100))
+	ship.Update(100, nil)
+
+	// Moves along the new heading
+	assert.InDelta(t, 100, ship.position.X, 1e-9)
+	assert.Greater(t, ship.position.Y, 100.0)
+	assert.InDelta(t, math.Pi/2, ship.rotation, 1e-9)
+	assert.Equal(t, math.Pi/2, ship.Serialize()["rotation"])
+
+	assert.Error(t, ship.ThrustForward(101))
+	assert.Error(t, ship.ThrustForward(-1))
+}
+
+func TestSpaceship_Thrust(t *testing.T) {
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+
+	// Ignores the heading
+	assert.NoError(t, ship.Thrust(physics.Vector2{X: 0, Y: -100}))
+	ship.Update(100, nil)
+	assert.InDelta(t, 100, ship.position.X, 1e-9)
+	assert.Less(t, ship.position.Y, 100.0)
+	assert.Less(t, ship.energy, float64(MaxEnergy))
+
+	assert.Error(t, ship.Thrust(physics.Vector2{X: 100, Y: 100}))
+}
+
 func TestSpaceship_Update(t *testing.T) {
 	gameManager := NewGameManager()
 	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
@@ -223,6 +325,39 @@ func TestSpaceship_FireRocket(t *testing.T) {
 	assert.Contains(t, "not enough rockets", err.Error())
 }
 
+func TestSpaceship_ProjectileCount(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	other := NewSpaceship(1, "other", physics.Vector2{X: 500, Y: 0}, 0)
+	_ = gameManager.AddSpaceship(ship)
+	_ = gameManager.AddSpaceship(other)
+	assert.Equal(t, 0, ship.ProjectileCount(&gameManager))
+
+	assert.NoError(t, ship.FireLaser(&gameManager))
+	assert.NoError(t, ship.FireRocket(&gameManager))
+	assert.NoError(t, other.FireLaser(&gameManager))
+	assert.Equal(t, 2, ship.ProjectileCount(&gameManager))
+	assert.Equal(t, 1, other.ProjectileCount(&gameManager))
+
+	// Hit
+	laser := gameManager.Query(func(gameObject GameObject) bool {
+		projectile, ok := gameObject.(*Projectile)
+		return ok && projectile.owner == ship && projectile.damageType == DamageTypeLaser
+	})[0].(*Projectile)
+	laser.OnCollision(other, &gameManager, 0)
+	assert.Equal(t, 1, ship.ProjectileCount(&gameManager))
+
+	// Expired
+	rocket := gameManager.Query(func(gameObject GameObject) bool {
+		projectile, ok := gameObject.(*Projectile)
+		return ok && projectile.owner == ship
+	})[0].(*Projectile)
+	rocket.lifespanSec = 0.1
+	rocket.Update(100, &gameManager)
+	assert.Equal(t, 0, ship.ProjectileCount(&gameManager))
+	assert.Equal(t, 1, other.ProjectileCount(&gameManager))
+}
+
 func TestSpaceship_HasKilled(t *testing.T) {
 	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
 	other := NewSpaceship(1, "other", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
@@ -236,6 +371,208 @@ func TestSpaceship_HasKilled(t *testing.T) {
 	assert.Equal(t, 100.0, ship.score)
 }
 
+func TestSpaceship_OnKill(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	other := NewSpaceship(1, "other", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	third := NewSpaceship(2, "third", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+
+	var first, second []GameObject
+	ship.OnKill(func(victim GameObject, gm *GameManager) {
+		assert.Equal(t, &gameManager, gm)
+		// The kill is scored before the callbacks
+		assert.Equal(t, int32(len(first)+1), ship.kills)
+		first = append(first, victim)
+	})
+	ship.OnKill(func(victim GameObject, gm *GameManager) {
+		second = append(second, victim)
+	})
+
+	// Not lethal
+	other.TakeDamage(50, &gameManager, ship)
+	assert.Empty(t, first)
+
+	other.TakeDamage(50, &gameManager, ship)
+	assert.Equal(t, []GameObject{other}, first)
+	assert.Equal(t, []GameObject{other}, second)
+
+	// Killed by someone else
+	third.TakeZoneDamage(HullZoneFront, 100, &gameManager, other)
+	assert.Len(t, first, 1)
+
+	// Killed by a collision, without a damage dealer
+	third = NewSpaceship(2, "third", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	third.OnCollision(NewAsteroid(3, physics.Vector2{X: 0, Y: 0}, 10), &gameManager, 0)
+	assert.Len(t, first, 1)
+
+	// Killed by a projectile
+	third = NewSpaceship(2, "third", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	rocket := NewRocketProjectile(4, physics.Vector2{X: 0, Y: 0}, 0, ship)
+	third.TakeDamage(100-rocket.Damage(), &gameManager, nil)
+	rocket.OnCollision(third, &gameManager, 0)
+	assert.Equal(t, []GameObject{other, third}, first)
+	assert.Equal(t, []GameObject{other, third}, second)
+}
+
+func TestSpaceship_OnSpeedExceeds(t *testing.T) {
+	t.Run("Fires once per crossing", func(t *testing.T) {
+		gameManager := NewGameManager()
+		ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+		var speeds []float64
+		ship.OnSpeedExceeds(100, func(speed float64, gm *GameManager) {
+			speeds = append(speeds, speed)
+		})
+
+		ship.velocity = physics.Vector2{X: 100, Y: 0}
+		ship.checkSpeed(&gameManager)
+		assert.Empty(t, speeds)
+
+		ship.velocity = physics.Vector2{X: 0, Y: 150}
+		ship.checkSpeed(&gameManager)
+		assert.Equal(t, []float64{150}, speeds)
+
+		// Still above
+		ship.velocity = physics.Vector2{X: 0, Y: 200}
+		ship.checkSpeed(&gameManager)
+		assert.Equal(t, []float64{150}, speeds)
+
+		ship.velocity = physics.Vector2{X: 0, Y: 50}
+		ship.checkSpeed(&gameManager)
+		ship.velocity = physics.Vector2{X: 120, Y: 0}
+		ship.checkSpeed(&gameManager)
+		assert.Equal(t, []float64{150, 120}, speeds)
+	})
+
+	t.Run("Checked on update", func(t *testing.T) {
+		gameManager := NewGameManager()
+		ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+		fired := 0
+		ship.OnSpeedExceeds(50, func(speed float64, gm *GameManager) {
+			assert.Greater(t, speed, 50.0)
+			assert.Equal(t, ship.Speed(), speed)
+			fired++
+		})
+		ship.ThrustForward(100)
+
+		for ship.Speed() <= 50 {
+			assert.Equal(t, 0, fired)
+			ship.Update(16, &gameManager)
+		}
+		assert.Equal(t, 1, fired)
+		ship.Update(16, &gameManager)
+		assert.Equal(t, 1, fired)
+	})
+}
+
+func TestSpaceship_BoostSpeed(t *testing.T) {
+	// Full thrust for the given time, returns the reached speed
+	cruise := func(ship *Spaceship, gameManager *GameManager, durationMs float64) float64 {
+		for elapsed := 0.0; elapsed < durationMs; elapsed += 16 {
+			ship.energy = 100
+			ship.Update(16, gameManager)
+		}
+		return ship.Speed()
+	}
+
+	gameManager := NewGameManager()
+	normal := NewSpaceship(0, "normal", physics.Vector2{X: 0, Y: 0}, 0)
+	normal.ThrustForward(100)
+	normalSpeed := cruise(normal, &gameManager, 2000)
+	assert.Equal(t, float64(MaxVelocitySec), normal.MaxSpeed())
+
+	t.Run("Slow mode", func(t *testing.T) {
+		ship := NewSpaceship(1, "slow", physics.Vector2{X: 0, Y: 0}, 0)
+		ship.ThrustForward(100)
+		assert.NoError(t, ship.BoostSpeed(0.5, 2000))
+		assert.Equal(t, MaxVelocitySec*0.5, ship.MaxSpeed())
+
+		slowSpeed := cruise(ship, &gameManager, 1984)
+		assert.Less(t, slowSpeed, normalSpeed)
+		assert.Equal(t, MaxVelocitySec*0.5, ship.MaxSpeed())
+
+		// Restored on expiry
+		ship.Update(16, &gameManager)
+		assert.Equal(t, float64(MaxVelocitySec), ship.MaxSpeed())
+		terminal := NewSpaceship(4, "terminal", physics.Vector2{X: 0, Y: 0}, 0)
+		terminal.ThrustForward(100)
+		assert.InDelta(t, cruise(terminal, &gameManager, 20000), cruise(ship, &gameManager, 20000), 1)
+	})
+
+	t.Run("Speed boost", func(t *testing.T) {
+		ship := NewSpaceship(2, "fast", physics.Vector2{X: 0, Y: 0}, 0)
+		ship.ThrustForward(100)
+		assert.NoError(t, ship.BoostSpeed(2, 2000))
+		assert.Equal(t, MaxVelocitySec*2.0, ship.MaxSpeed())
+		assert.Greater(t, cruise(ship, &gameManager, 1984), normalSpeed)
+	})
+
+	t.Run("Invalid boost", func(t *testing.T) {
+		ship := NewSpaceship(3, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+		assert.Error(t, ship.BoostSpeed(0, 1000))
+		assert.Error(t, ship.BoostSpeed(2, 0))
+		assert.Equal(t, float64(MaxVelocitySec), ship.MaxSpeed())
+	})
+}
+
+func TestSpaceship_OnZoneEnter(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+	near := physics.AABB{Min: physics.Vector2{X: 150, Y: 50}, Max: physics.Vector2{X: 200, Y: 150}}
+	far := physics.AABB{Min: physics.Vector2{X: 250, Y: 50}, Max: physics.Vector2{X: 300, Y: 150}}
+	var events []string
+	ship.OnZoneEnter(near, func(gm *GameManager) { events = append(events, "enter near") })
+	ship.OnZoneExit(near, func(gm *GameManager) { events = append(events, "exit near") })
+	ship.OnZoneEnter(far, func(gm *GameManager) { events = append(events, "enter far") })
+	ship.OnZoneExit(far, func(gm *GameManager) { events = append(events, "exit far") })
+	ship.ThrustForward(100)
+
+	var expected []string
+	for ship.position.X < 350 {
+		wasInNear, wasInFar := near.Contains(ship.position), far.Contains(ship.position)
+		ship.energy = 100
+		ship.Update(16, &gameManager)
+		inNear, inFar := near.Contains(ship.position), far.Contains(ship.position)
+
+		// Fires on the very tick of the crossing
+		if !wasInNear && inNear {
+			expected = append(expected, "enter near")
+		}
+		if wasInNear && !inNear {
+			expected = append(expected, "exit near")
+		}
+		if !wasInFar && inFar {
+			expected = append(expected, "enter far")
+		}
+		if wasInFar && !inFar {
+			expected = append(expected, "exit far")
+		}
+		assert.Equal(t, expected, events)
+	}
+	assert.Equal(t, []string{"enter near", "exit near", "enter far", "exit far"}, events)
+}
+
+func TestSpaceship_OnZoneEnter_AlreadyInside(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+	zone := physics.AABB{Min: physics.Vector2{X: 0, Y: 0}, Max: physics.Vector2{X: 200, Y: 200}}
+	entered, exited := 0, 0
+	ship.OnZoneEnter(zone, func(gm *GameManager) { entered++ })
+	ship.OnZoneExit(zone, func(gm *GameManager) { exited++ })
+
+	ship.Update(16, &gameManager)
+	assert.Equal(t, 0, entered)
+
+	ship.position = physics.Vector2{X: 300, Y: 100}
+	ship.Update(16, &gameManager)
+	assert.Equal(t, 1, exited)
+	ship.Update(16, &gameManager)
+	assert.Equal(t, 1, exited)
+
+	ship.position = physics.Vector2{X: 200, Y: 200}
+	ship.Update(16, &gameManager)
+	assert.Equal(t, 1, entered)
+}
+
 func TestSpaceship_TakeDamage(t *testing.T) {
 	gameManager := NewGameManager()
 	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
@@ -243,15 +580,100 @@ func TestSpaceship_TakeDamage(t *testing.T) {
 
 	ship.TakeDamage(10, &gameManager, other)
 
-	assert.Equal(t, float64(90), ship.health)
+	assert.Equal(t, float64(90), ship.Health())
 
 	ship.TakeDamage(100, &gameManager, other)
 
-	assert.Equal(t, 0.0, ship.health)
+	assert.Equal(t, 0.0, ship.Health())
 	assert.Equal(t, int32(1), other.kills)
 	assert.Equal(t, float64(100), other.score)
 }
 
+func TestSpaceship_SetHealthBar(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	other := NewSpaceship(1, "other", physics.Vector2{X: 0, Y: 0}, 0)
+	assert.Equal(t, 0.0, ship.MinHealth())
+	assert.Equal(t, float64(MaxHealth), ship.MaxHealth())
+
+	assert.NoError(t, ship.SetHealthBar(10, 1000))
+	assert.Equal(t, 1000.0, ship.Health())
+
+	// Healing cannot exceed the max
+	ship.TakeDamage(50, &gameManager, other)
+	ship.Heal(80)
+	assert.Equal(t, 1000.0, ship.Health())
+
+	// Death at the min
+	ship.TakeDamage(989, &gameManager, other)
+	assert.True(t, ship.enabled)
+	assert.Equal(t, 11.0, ship.Health())
+	ship.TakeDamage(1, &gameManager, other)
+	assert.False(t, ship.enabled)
+	assert.Equal(t, 10.0, ship.Health())
+	assert.Equal(t, int32(1), other.kills)
+
+	// Reset restores the max
+	ship.Reset()
+	assert.Equal(t, 1000.0, ship.Health())
+
+	// Invalid bar
+	assert.Error(t, ship.SetHealthBar(10, 10))
+	assert.Equal(t, 10.0, ship.MinHealth())
+}
+
+func TestSpaceship_SetHealthBar_Poison(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	assert.NoError(t, ship.SetHealthBar(-10, 100))
+
+	ship.TakeDamage(105, &gameManager, nil)
+	assert.True(t, ship.enabled)
+	assert.Equal(t, -5.0, ship.Health())
+	assert.Equal(t, false, ship.Serialize()["destroyed"])
+
+	ship.TakeDamage(10, &gameManager, nil)
+	assert.False(t, ship.enabled)
+	assert.Equal(t, -10.0, ship.Health())
+	assert.Equal(t, true, ship.Serialize()["destroyed"])
+	assert.Equal(t, -10.0, ship.Serialize()["minHealth"])
+}
+
+func TestSpaceship_EquipArmor(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+
+	ship.EquipArmor(5)
+	ship.TakeDamage(15, &gameManager, nil)
+	assert.Equal(t, 90.0, ship.Health())
+
+	// Damage below the armor is absorbed
+	ship.TakeZoneDamage(HullZoneFront, 3, &gameManager, nil)
+	assert.Equal(t, 90.0, ship.Health())
+	assert.Equal(t, 5.0, ship.Serialize()["armorReduction"])
+
+	// Disabled
+	ship.EquipArmor(0)
+	ship.TakeDamage(10, &gameManager, nil)
+	assert.Equal(t, 80.0, ship.Health())
+
+	// Clamped
+	ship.EquipArmor(-5)
+	assert.Equal(t, 0.0, ship.ArmorReduction())
+	ship.EquipArmor(1000)
+	assert.Equal(t, float64(MaxHealth), ship.ArmorReduction())
+}
+
+func TestSpaceship_EquipArmor_BeforeShield(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, 0, WithShield(10, 0, 0))
+	ship.EquipArmor(5)
+
+	ship.TakeDamage(12, &gameManager, nil)
+	assert.Equal(t, 3.0, ship.Shield().Current())
+	assert.Equal(t, float64(MaxHealth), ship.Health())
+}
+
 func TestSpaceship_OnCollision(t *testing.T) {
 	gameManager := NewGameManager()
 	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
@@ -260,15 +682,116 @@ func TestSpaceship_OnCollision(t *testing.T) {
 	ship.OnCollision(other, &gameManager, 0)
 	other.OnCollision(ship, &gameManager, 0)
 
-	assert.Equal(t, 0.0, ship.health)
-	assert.Equal(t, 0.0, other.health)
+	assert.Equal(t, 0.0, ship.Health())
+	assert.Equal(t, 0.0, other.Health())
 
 	// Unexpected object
 	ship = NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
 	explosion := NewExplosion(0, physics.Vector2{X: 0, Y: 0}, 10, 1)
 	ship.OnCollision(explosion, &gameManager, 0)
 
-	assert.Equal(t, 100.0, ship.health)
+	assert.Equal(t, 100.0, ship.Health())
+}
+
+func TestSpaceship_HitZone(t *testing.T) {
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, math.Pi/2)
+
+	assert.Equal(t, HullZoneFront, ship.HitZone(physics.Vector2{X: 100, Y: 150}))
+	assert.Equal(t, HullZoneRear, ship.HitZone(physics.Vector2{X: 100, Y: 50}))
+	assert.Equal(t, HullZoneLeft, ship.HitZone(physics.Vector2{X: 150, Y: 100}))
+	assert.Equal(t, HullZoneRight, ship.HitZone(physics.Vector2{X: 50, Y: 100}))
+}
+
+func TestSpaceship_TakeZoneDamage(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	other := NewSpaceship(1, "other", physics.Vector2{X: 0, Y: 0}, 0)
+
+	ship.TakeZoneDamage(HullZoneLeft, 40, &gameManager, other)
+	assert.Equal(t, [4]float64{100, 100, 60, 100}, ship.HullZones())
+	assert.Equal(t, float64(90), ship.Health())
+	assert.True(t, ship.Enabled())
+
+	// Depleting a single zone destroys the ship
+	ship.TakeZoneDamage(HullZoneLeft, 60, &gameManager, other)
+	assert.Equal(t, [4]float64{0, 0, 0, 0}, ship.HullZones())
+	assert.Equal(t, 0.0, ship.Health())
+	assert.False(t, ship.Enabled())
+	assert.Equal(t, int32(1), other.kills)
+}
+
+func TestSpaceship_OnCollision_HullZones(t *testing.T) {
+	t.Run("Asteroid collision depletes the hit zone", func(t *testing.T) {
+		gameManager := NewGameManager()
+		ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+		ship.OnCollision(NewAsteroid(1, physics.Vector2{X: 120, Y: 100}, 10), &gameManager, 0)
+
+		assert.Equal(t, "0.00", gameManager.Logger().Logs()[0].meta["health"])
+		assert.Equal(t, 0.0, ship.Health())
+		assert.False(t, ship.Enabled())
+	})
+
+	t.Run("Damage applies to the hit zone only", func(t *testing.T) {
+		gameManager := NewGameManager()
+		front := NewSpaceship(0, "front", physics.Vector2{X: 100, Y: 100}, 0)
+		rear := NewSpaceship(1, "rear", physics.Vector2{X: 100, Y: 100}, 0)
+
+		front.TakeZoneDamage(front.HitZone(physics.Vector2{X: 120, Y: 100}), 30, &gameManager, nil)
+		rear.TakeZoneDamage(rear.HitZone(physics.Vector2{X: 80, Y: 100}), 30, &gameManager, nil)
+
+		assert.Equal(t, [4]float64{70, 100, 100, 100}, front.HullZones())
+		assert.Equal(t, [4]float64{100, 70, 100, 100}, rear.HullZones())
+	})
+}
+
+func TestSpaceship_Serialize_HullZones(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+	ship.TakeZoneDamage(HullZoneRight, 20, &gameManager, nil)
+
+	serialized := ship.Serialize()
+	assert.Equal(t, []interface{}{100.0, 100.0, 100.0, 80.0}, serialized["hullZones"])
+	assert.Equal(t, 95.0, serialized["health"])
+	assert.Equal(t, false, serialized["destroyed"])
+}
+
+func TestDeserializeSpaceship(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 100, Y: 200}, math.Pi/2)
+	ship.SetTeam("red")
+	ship.SetEngineThrust(100, 20, 0)
+	ship.Update(100, &gameManager)
+	ship.TakeZoneDamage(HullZoneRight, 20, &gameManager, nil)
+	ship.FireRocket(&gameManager)
+
+	deserialized, err := DeserializeSpaceship(ship.Serialize())
+	assert.NoError(t, err)
+	assert.Equal(t, ship.Serialize(), deserialized.Serialize())
+	assert.Equal(t, ship.startPosition, deserialized.startPosition)
+
+	t.Run("Optional fields keep their defaults", func(t *testing.T) {
+		serialized := ship.Serialize()
+		for _, key := range []string{"startPosition", "minHealth", "maxHealth", "hullZones", "shield", "armorReduction", "team"} {
+			delete(serialized, key)
+		}
+		deserialized, err := DeserializeSpaceship(serialized)
+		assert.NoError(t, err)
+		assert.Equal(t, ship.Position(), deserialized.startPosition)
+		assert.Equal(t, [4]float64{95, 95, 95, 95}, deserialized.HullZones())
+		assert.Equal(t, "", deserialized.Team())
+	})
+
+	t.Run("Invalid fields", func(t *testing.T) {
+		serialized := ship.Serialize()
+		serialized["engine"] = map[string]interface{}{"mainThrust": 100.0}
+		_, err := DeserializeSpaceship(serialized)
+		assert.Equal(t, ErrInvalidState{Field: "leftThrust"}, err)
+
+		serialized = ship.Serialize()
+		serialized["hullZones"] = []interface{}{100.0, "full", 100.0, 100.0}
+		_, err = DeserializeSpaceship(serialized)
+		assert.Equal(t, ErrInvalidState{Field: "hullZones"}, err)
+	})
 }
 
 func TestSpaceship_Move_Basic(t *testing.T) {
@@ -0,0 +1,41 @@
+package game
+
+// SpectatorView is a read-only view of a game, safe to use from a different goroutine than the
+// one updating the game. It reads the game state under the game's read lock.
+type SpectatorView struct {
+	game *Game
+}
+
+func NewSpectatorView(game *Game) *SpectatorView {
+	return &SpectatorView{game: game}
+}
+
+func (view *SpectatorView) Status() Status {
+	view.game.mutex.RLock()
+	defer view.game.mutex.RUnlock()
+	return view.game.Status()
+}
+
+func (view *SpectatorView) Serialize() map[string]interface{} {
+	view.game.mutex.RLock()
+	defer view.game.mutex.RUnlock()
+	return view.game.Serialize()
+}
+
+// SerializeDelta returns the game state changed after the since tick, see Game.SerializeDelta.
+func (view *SpectatorView) SerializeDelta(since uint64) map[string]interface{} {
+	view.game.mutex.RLock()
+	defer view.game.mutex.RUnlock()
+	return view.game.SerializeDelta(since)
+}
+
+// SerializeFor returns the game state as the player sees it, see Game.SerializeFor.
+func (view *SpectatorView) SerializeFor(playerName string) (map[string]interface{}, error) {
+	view.game.mutex.RLock()
+	defer view.game.mutex.RUnlock()
+	return view.game.SerializeFor(playerName)
+}
+
+func (view *SpectatorView) StatusChan() <-chan Status {
+	return view.game.StatusChan()
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+// Spectator is the read-only subset of the game API.
+type Spectator interface {
+	Status() Status
+	Serialize() map[string]interface{}
+	SerializeDelta(since uint64) map[string]interface{}
+	StatusChan() <-chan Status
+}
+
+func TestSpectatorView(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 500, Y: 500}, 0)
+	var view Spectator = NewSpectatorView(game)
+
+	assert.Equal(t, Initialized, view.Status())
+	game.Start()
+	assert.Equal(t, Running, view.Status())
+	assert.Equal(t, Running, <-view.StatusChan())
+
+	game.SpaceshipAction("alpha", func(spaceShip *Spaceship, gameManager *GameManager) {
+		spaceShip.position = physics.Vector2{X: 200, Y: 200}
+	})
+	serialized := view.Serialize()
+	alpha := serialized["gameObjects"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"x": 200.0, "y": 200.0}, alpha["position"])
+
+	// Only the game objects and status, once a tick passed
+	assert.Equal(t, map[string]interface{}{"tick": uint64(0)}, view.SerializeDelta(0))
+	game.Update(16)
+	delta := view.SerializeDelta(0)
+	assert.Equal(t, uint64(1), delta["tick"])
+	assert.Equal(t, "running", delta["status"])
+	assert.Len(t, delta["gameObjects"], 2)
+	assert.NotContains(t, delta, "logs")
+	assert.Equal(t, map[string]interface{}{"tick": uint64(1)}, view.SerializeDelta(1))
+
+	// The view does not expose the game, a type assertion to *Game fails
+	_, ok := view.(interface{}).(*Game)
+	assert.False(t, ok)
+}
+
+func TestSpectatorView_Concurrent(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("beta", physics.Vector2{X: 500, Y: 500}, 0)
+	game.Start()
+	view := NewSpectatorView(game)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			view.Serialize()
+			view.SerializeDelta(uint64(i))
+			view.Status()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		game.Update(16)
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint64(100), view.SerializeDelta(0)["tick"])
+}
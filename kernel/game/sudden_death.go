@@ -0,0 +1,107 @@
+package game
+
+import (
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// SuddenDeath is the final phase of the match, see WithSuddenDeath. Once it starts, the safe zone
+// shrinks from the arena edges towards the center and the spaceships outside take the damage, and the
+// asteroids speed up, the ones at rest drifting towards the center.
+type SuddenDeath struct {
+	StartMs                 float64 // Elapsed game time the phase starts at
+	ShrinkRateSec           float64 // Distance the zone edges move in per second, 0 keeps the zone the arena
+	MinZoneSize             float64 // Width and height the zone stops shrinking at
+	ZoneDamageSec           float64 // Taken per second by the spaceships outside the zone
+	AsteroidAccelerationSec float64 // Speed the asteroids gain per second, 0 keeps them as they are
+}
+
+// suddenDeathPhase tracks the SuddenDeath rules of the GameManager, disabled while not enabled.
+type suddenDeathPhase struct {
+	rules   SuddenDeath
+	enabled bool
+	started bool
+}
+
+// WithSuddenDeath enables the sudden death phase, it runs until the game ends.
+func WithSuddenDeath(rules SuddenDeath) GameOption {
+	return func(game *Game) {
+		rules.StartMs = math.Max(rules.StartMs, 0)
+		rules.MinZoneSize = math.Max(rules.MinZoneSize, 0)
+		game.manager.suddenDeath = suddenDeathPhase{rules: rules, enabled: true}
+	}
+}
+
+// WithScoreTarget ends the game once any spaceship scores the target, or when at most one
+// spaceship is left.
+func WithScoreTarget(score float64) GameOption {
+	return func(game *Game) {
+		game.manager.RegisterEndCondition("lastShipStanding", LastShipStanding)
+		game.manager.RegisterEndCondition("scoreTarget", ScoreTarget(score))
+	}
+}
+
+// SuddenDeathStarted reports whether the sudden death phase is on.
+func (manager *GameManager) SuddenDeathStarted() bool {
+	return manager.suddenDeath.started
+}
+
+// SafeZone returns the area the spaceships are safe in, the whole arena until the sudden death
+// phase shrinks it.
+func (manager *GameManager) SafeZone() physics.AABB {
+	arena := physics.AABB{Max: physics.Vector2{X: manager.size.Width, Y: manager.size.Height}}
+	phase := &manager.suddenDeath
+	if !phase.started || phase.rules.ShrinkRateSec <= 0 {
+		return arena
+	}
+
+	maxInset := math.Max(0, (math.Min(manager.size.Width, manager.size.Height)-phase.rules.MinZoneSize)/2)
+	inset := math.Min((manager.elapsedMs-phase.rules.StartMs)/1000*phase.rules.ShrinkRateSec, maxInset)
+	return physics.AABB{
+		Min: physics.Vector2{X: inset, Y: inset},
+		Max: physics.Vector2{X: manager.size.Width - inset, Y: manager.size.Height - inset},
+	}
+}
+
+// suddenDeathStep starts the phase once its time comes and applies it for the step.
+func (manager *GameManager) suddenDeathStep(deltaTimeMs float64) {
+	phase := &manager.suddenDeath
+	if !phase.enabled || manager.elapsedMs < phase.rules.StartMs {
+		return
+	}
+	if !phase.started {
+		phase.started = true
+		manager.logger.LogEvent(LogLevelInfo, "Sudden death started", 0, nil)
+		manager.publish(SuddenDeathStarted{Tick: manager.tick})
+	}
+
+	deltaTimeSec := deltaTimeMs / 1000
+	if phase.rules.ZoneDamageSec > 0 {
+		zone := manager.SafeZone()
+		for _, spaceShip := range manager.Spaceships() {
+			if spaceShip.enabled && !zone.Contains(spaceShip.position) {
+				spaceShip.TakeDamage(phase.rules.ZoneDamageSec*deltaTimeSec, manager, nil)
+			}
+		}
+	}
+
+	if phase.rules.AsteroidAccelerationSec > 0 {
+		center := physics.Vector2{X: manager.size.Width / 2, Y: manager.size.Height / 2}
+		for _, gameObject := range manager.gameObjects {
+			asteroid, ok := gameObject.(*Asteroid)
+			if !ok || !asteroid.enabled {
+				continue
+			}
+			direction := asteroid.velocity
+			if direction == (physics.Vector2{}) {
+				direction = center.Subtract(asteroid.position)
+			}
+			if direction == (physics.Vector2{}) {
+				continue
+			}
+			direction = direction.Normalize()
+			asteroid.velocity = asteroid.velocity.Add(direction.Multiply(phase.rules.AsteroidAccelerationSec * deltaTimeSec))
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGame_SuddenDeath(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithSuddenDeath(SuddenDeath{
+		StartMs:                 1000,
+		ShrinkRateSec:           100,
+		MinZoneSize:             200,
+		ZoneDamageSec:           10,
+		AsteroidAccelerationSec: 5,
+	}))
+	inside, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "inside", Position: physics.Vector2{X: 500, Y: 500}})
+	outside, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "outside", Position: physics.Vector2{X: 100, Y: 500}})
+	resting := NewAsteroid(game.manager.NewID(), physics.Vector2{X: 500, Y: 300}, 10)
+	drifting := NewAsteroid(game.manager.NewID(), physics.Vector2{X: 800, Y: 800}, 10)
+	drifting.SetVelocity(physics.Vector2{X: 10, Y: 0})
+	game.manager.AddGameObjects([]GameObject{resting, drifting})
+
+	var started []GameEvent
+	game.manager.Events().Subscribe(func(event GameEvent) {
+		if _, ok := event.(SuddenDeathStarted); ok {
+			started = append(started, event)
+		}
+	})
+	game.Start()
+
+	game.Update(500)
+	assert.False(t, game.manager.SuddenDeathStarted())
+	assert.Equal(t, physics.AABB{Max: physics.Vector2{X: 1000, Y: 1000}}, game.manager.SafeZone())
+	assert.NotContains(t, game.Serialize(), "safeZone")
+
+	game.Update(1000)
+	assert.True(t, game.manager.SuddenDeathStarted())
+	assert.Len(t, started, 1)
+	zone := game.manager.SafeZone()
+	assert.InDelta(t, 50, zone.Min.X, 1e-9)
+	assert.InDelta(t, 950, zone.Max.Y, 1e-9)
+	assert.Contains(t, game.Serialize(), "safeZone")
+	assert.Equal(t, float64(MaxHealth), inside.Health())
+	assert.Equal(t, float64(MaxHealth), outside.Health())
+
+	// Speeds up the drifting one, the resting one heads to the center
+	assert.InDelta(t, 15, drifting.Velocity().X, 1e-9)
+	assert.InDelta(t, 0, resting.Velocity().X, 1e-9)
+	assert.InDelta(t, 5, resting.Velocity().Y, 1e-9)
+
+	game.Update(1000)
+	assert.Len(t, started, 1)
+	assert.InDelta(t, 150, game.manager.SafeZone().Min.X, 1e-9)
+	assert.Equal(t, float64(MaxHealth), inside.Health())
+	assert.Less(t, outside.Health(), float64(MaxHealth))
+
+	t.Run("Capped by the min zone size", func(t *testing.T) {
+		game.Update(10000)
+		zone := game.manager.SafeZone()
+		assert.InDelta(t, 400, zone.Min.X, 1e-9)
+		assert.InDelta(t, 600, zone.Max.X, 1e-9)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		game.Reset()
+		assert.False(t, game.manager.SuddenDeathStarted())
+	})
+}
+
+func TestGame_WithScoreTarget(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, WithScoreTarget(150))
+	alpha, _ := game.AddSpaceshipWithConfig(SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 100}})
+	game.AddSpaceship("beta", physics.Vector2{X: 900, Y: 900}, 0)
+	game.AddSpaceship("gamma", physics.Vector2{X: 100, Y: 900}, 0)
+	game.Start()
+	assert.Equal(t, []string{"lastShipStanding", "scoreTarget"}, game.manager.EndConditions())
+
+	game.Update(16)
+	assert.Equal(t, Running, game.Status())
+
+	alpha.AddScore(150)
+	game.Update(16)
+	assert.Equal(t, Ended, game.Status())
+	assert.Equal(t, "scoreTarget", game.EndReason())
+}
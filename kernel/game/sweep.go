@@ -0,0 +1,59 @@
+package game
+
+import (
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
+
+// sweepProjectiles catches the projectile hits on the spaceships the discrete check missed, a fast
+// projectile can pass through a spaceship between two ticks when the delta time is large. The projectile
+// is moved back to the first spaceship along its path of the tick and collides with it there.
+func (manager *GameManager) sweepProjectiles() {
+	// Copied, the collisions add the explosions
+	gameObjects := append([]GameObject{}, manager.gameObjects...)
+	for _, gameObject := range gameObjects {
+		projectile, ok := gameObject.(*Projectile)
+		if !ok || !projectile.enabled || projectile.sweepTick != manager.tick {
+			continue
+		}
+		start, end := projectile.sweepStart, projectile.position
+		if start == end {
+			continue
+		}
+
+		radius := projectile.sweepRadius()
+		var target *Spaceship
+		first := math.Inf(1)
+		for _, other := range manager.Neighbors(collider.SweptBounds(start, end, radius)) {
+			spaceship, ok := other.(*Spaceship)
+			if !ok || spaceship == projectile.owner {
+				continue
+			}
+			if t, ok := collider.SweepCircle(start, end, radius, spaceship.Collider()); ok && t < first {
+				first, target = t, spaceship
+			}
+		}
+		if target == nil {
+			continue
+		}
+
+		projectile.position = start.Lerp(end, first)
+		projectile.collider.SetPosition(projectile.position)
+		projectile.OnCollision(target, manager, 0)
+		target.OnCollision(projectile, manager, 1)
+		manager.publish(CollisionOccurred{Tick: manager.tick, A: projectile, B: target})
+	}
+}
+
+// sweepRadius returns the radius of the circle swept along the path, the half width of a square projectile.
+func (projectile *Projectile) sweepRadius() float64 {
+	switch shape := projectile.collider.(type) {
+	case *collider.CircleCollider:
+		return shape.Radius()
+	case *collider.SquareCollider:
+		return math.Min(shape.Size().Width, shape.Size().Height) / 2
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameManager_SweepProjectiles(t *testing.T) {
+	t.Run("Hits the spaceship passed within the tick", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+		game.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 500}, 0)
+		game.AddSpaceship("beta", physics.Vector2{X: 300, Y: 500}, 0)
+		game.Start()
+		alpha, _ := game.manager.GetSpaceship("alpha")
+		beta, _ := game.manager.GetSpaceship("beta")
+
+		assert.NoError(t, alpha.FireLaser(&game.manager))
+		laser := game.manager.gameObjects[2].(*Projectile)
+		// The laser is recycled once the tick ends
+		var hit physics.Vector2
+		game.manager.Events().Subscribe(func(event GameEvent) {
+			if collision, ok := event.(CollisionOccurred); ok && collision.A == GameObject(laser) {
+				hit = laser.Position()
+			}
+		})
+		// One step moves the laser from before beta to past it
+		game.Update(16)
+		game.Update(1000)
+
+		assert.False(t, laser.Enabled())
+		assert.Less(t, beta.Health(), 100.0)
+		assert.InDelta(t, 300-ShipSize/2-LaserWidth/2, hit.X, 1e-6)
+	})
+
+	t.Run("Ignores the owner and the teleported projectiles", func(t *testing.T) {
+		gameManager := NewGameManager()
+		owner := NewSpaceship(1, "owner", physics.Vector2{X: 100, Y: 100}, 0)
+		_ = gameManager.AddSpaceship(owner)
+		gameManager.Tick(16)
+
+		laser := NewLaserProjectile(2, physics.Vector2{X: 0, Y: 100}, 0, owner)
+		_, _ = gameManager.AddGameObject(laser)
+		laser.Update(1000, &gameManager)
+		gameManager.sweepProjectiles()
+		assert.True(t, laser.Enabled())
+
+		other := NewSpaceship(3, "other", physics.Vector2{X: 500, Y: 100}, 0)
+		_ = gameManager.AddSpaceship(other)
+		gameManager.Tick(16)
+		laser.Update(1000, &gameManager)
+		laser.SetPosition(physics.Vector2{X: 900, Y: 100})
+		gameManager.sweepProjectiles()
+		assert.True(t, laser.Enabled())
+		assert.Equal(t, 100.0, other.Health())
+	})
+}
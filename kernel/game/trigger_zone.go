@@ -0,0 +1,116 @@
+package game
+
+import (
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
+
+// trigger is implemented by the game objects detecting the overlaps without any physics response,
+// the collision loop reports the overlaps instead of calling OnCollision.
+type trigger interface {
+	IsTrigger() bool
+	overlap(other GameObject)
+	resolveOverlaps(gameManager *GameManager)
+}
+
+// TriggerZone is an area notifying about the game objects entering and leaving it,
+// e.g. safe zones, objective areas or spawn protection bubbles.
+type TriggerZone struct {
+	id       int64
+	enabled  bool
+	position physics.Vector2
+	radius   float64
+	collider collider.CircleCollider
+	onEnter  func(GameObject, *GameManager)
+	onExit   func(GameObject, *GameManager)
+	inside   []GameObject // Overlapping since the previous tick
+	touched  []GameObject // Overlapping in the current tick
+}
+
+func NewTriggerZone(id int64, position physics.Vector2, radius float64, onEnter, onExit func(GameObject, *GameManager)) *TriggerZone {
+	return &TriggerZone{
+		id:       id,
+		enabled:  true,
+		position: position,
+		radius:   radius,
+		collider: *collider.NewCircleCollider(position, radius),
+		onEnter:  onEnter,
+		onExit:   onExit,
+	}
+}
+
+func (zone *TriggerZone) ID() int64 {
+	return zone.id
+}
+
+func (zone *TriggerZone) Enabled() bool {
+	return zone.enabled
+}
+
+func (zone *TriggerZone) SetEnabled(enabled bool) {
+	zone.enabled = enabled
+}
+
+func (zone *TriggerZone) Position() physics.Vector2 {
+	return zone.position
+}
+
+func (zone *TriggerZone) SetPosition(position physics.Vector2) {
+	zone.position = position
+	zone.collider.SetPosition(position)
+}
+
+func (zone *TriggerZone) IsTrigger() bool {
+	return true
+}
+
+func (zone *TriggerZone) Update(deltaTimeMs float64, gameManager *GameManager) {}
+
+func (zone *TriggerZone) Collider() collider.Collider {
+	return &zone.collider
+}
+
+func (zone *TriggerZone) OnCollision(other GameObject, gameManager *GameManager, order int) {}
+
+func (zone *TriggerZone) overlap(other GameObject) {
+	zone.touched = append(zone.touched, other)
+}
+
+// resolveOverlaps compares the overlaps of the current tick with the previous one,
+// firing onExit for the objects which left and onEnter for the ones which entered.
+func (zone *TriggerZone) resolveOverlaps(gameManager *GameManager) {
+	for _, gameObject := range zone.inside {
+		if !containsGameObject(zone.touched, gameObject) && zone.onExit != nil {
+			zone.onExit(gameObject, gameManager)
+		}
+	}
+	for _, gameObject := range zone.touched {
+		if !containsGameObject(zone.inside, gameObject) && zone.onEnter != nil {
+			zone.onEnter(gameObject, gameManager)
+		}
+	}
+	zone.inside, zone.touched = zone.touched, zone.inside[:0]
+}
+
+func containsGameObject(gameObjects []GameObject, gameObject GameObject) bool {
+	for _, other := range gameObjects {
+		if other.ID() == gameObject.ID() {
+			return true
+		}
+	}
+	return false
+}
+
+func (zone *TriggerZone) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "triggerZone",
+		"id":      zone.id,
+		"enabled": zone.enabled,
+		"position": map[string]interface{}{
+			"x": zone.position.X,
+			"y": zone.position.Y,
+		},
+		"radius":   zone.radius,
+		"collider": zone.collider.Serialize(),
+	}
+}
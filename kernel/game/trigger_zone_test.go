@@ -0,0 +1,116 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTriggerZone(t *testing.T) {
+	zone := NewTriggerZone(1, physics.Vector2{X: 10, Y: 20}, 30, nil, nil)
+
+	assert.Equal(t, int64(1), zone.ID())
+	assert.True(t, zone.Enabled())
+	assert.True(t, zone.IsTrigger())
+	assert.Equal(t, physics.Vector2{X: 10, Y: 20}, zone.Position())
+	assert.Equal(t, 30.0, zone.radius)
+}
+
+func TestTriggerZone_SetPosition(t *testing.T) {
+	zone := NewTriggerZone(1, physics.Vector2{X: 10, Y: 20}, 30, nil, nil)
+	zone.SetPosition(physics.Vector2{X: 50, Y: 60})
+
+	assert.Equal(t, physics.Vector2{X: 50, Y: 60}, zone.Position())
+	assert.Equal(t, physics.Vector2{X: 50, Y: 60}, zone.collider.Position())
+}
+
+func TestTriggerZone_EnterExit(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	var entered, exited []int64
+	zone := NewTriggerZone(1, physics.Vector2{X: 200, Y: 100}, 20,
+		func(gameObject GameObject, gameManager *GameManager) { entered = append(entered, gameObject.ID()) },
+		func(gameObject GameObject, gameManager *GameManager) { exited = append(exited, gameObject.ID()) },
+	)
+	owner := NewSpaceship(2, "owner", physics.Vector2{X: 800, Y: 800}, 0)
+	projectile := NewProjectile(physics.Vector2{X: 100, Y: 100}, physics.Vector2{X: 100, Y: 0}, 0, 100, 10, owner)
+	game.manager.AddGameObjects([]GameObject{zone, projectile})
+
+	// Approaching
+	for i := 0; i < 5; i++ {
+		game.Update(100)
+	}
+	assert.Empty(t, entered)
+
+	// Crossing
+	for i := 0; i < 5; i++ {
+		game.Update(100)
+	}
+	assert.Equal(t, []int64{projectile.ID()}, entered)
+	assert.Empty(t, exited)
+
+	// Leaving
+	for i := 0; i < 10; i++ {
+		game.Update(100)
+	}
+	assert.Equal(t, []int64{projectile.ID()}, entered)
+	assert.Equal(t, []int64{projectile.ID()}, exited)
+
+	// No physics response
+	assert.True(t, projectile.Enabled())
+	assert.Equal(t, physics.Vector2{X: 100, Y: 0}, projectile.Velocity())
+}
+
+func TestTriggerZone_ExitExpired(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	var exited []int64
+	var owners []*Spaceship
+	zone := NewTriggerZone(1, physics.Vector2{X: 100, Y: 100}, 50, nil,
+		func(gameObject GameObject, gameManager *GameManager) {
+			projectile := gameObject.(*Projectile)
+			exited = append(exited, projectile.ID())
+			owners = append(owners, projectile.owner)
+		},
+	)
+	owner := NewSpaceship(2, "owner", physics.Vector2{X: 800, Y: 800}, 0)
+	projectile := game.manager.ProjectilePool().Get(3, physics.Vector2{X: 100, Y: 100}, physics.Vector2{X: 1, Y: 0}, 0, 0.15, 10, owner)
+	game.manager.AddGameObjects([]GameObject{zone, projectile})
+
+	game.Update(100)
+	assert.Empty(t, exited)
+
+	// Expires inside the zone, the exit still sees the projectile before it is recycled
+	game.Update(100)
+	assert.Equal(t, []int64{3}, exited)
+	assert.Equal(t, []*Spaceship{owner}, owners)
+}
+
+func TestTriggerZone_NoPhysicsResponse(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	entered := 0
+	zone := NewTriggerZone(1, physics.Vector2{X: 100, Y: 100}, 50,
+		func(gameObject GameObject, gameManager *GameManager) { entered++ },
+		nil,
+	)
+	game.manager.AddGameObject(zone)
+	game.AddSpaceship("ship", physics.Vector2{X: 100, Y: 100}, 0)
+	ship, _ := game.manager.GetSpaceship("ship")
+
+	game.Update(16)
+	game.Update(16)
+
+	assert.Equal(t, 1, entered)
+	assert.Equal(t, float64(MaxHealth), ship.Health())
+	assert.Equal(t, physics.Vector2{X: 0, Y: 0}, ship.Velocity())
+	assert.Equal(t, physics.Vector2{X: 100, Y: 100}, ship.Position())
+}
+
+func TestTriggerZone_Serialize(t *testing.T) {
+	zone := NewTriggerZone(1, physics.Vector2{X: 10, Y: 20}, 30, nil, nil)
+	serialized := zone.Serialize()
+
+	assert.Equal(t, "triggerZone", serialized["type"])
+	assert.Equal(t, int64(1), serialized["id"])
+	assert.Equal(t, true, serialized["enabled"])
+	assert.Equal(t, 30.0, serialized["radius"])
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"errors"
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// Weapon is an armament mounted on a spaceship slot, see Spaceship.EquipWeapon. The spaceship
+// checks the energy and the cooldown, the weapon spawns the projectiles.
+type Weapon interface {
+	Name() string
+	CooldownSec() float64
+	EnergyCost() float64
+	// Fire spawns the projectiles, on an error the spaceship keeps the energy and the weapon stays ready.
+	Fire(ship *Spaceship, gameManager *GameManager) error
+}
+
+// LaserWeapon fires a single laser, the same as Spaceship.FireLaser.
+type LaserWeapon struct{}
+
+func (weapon LaserWeapon) Name() string         { return "laser" }
+func (weapon LaserWeapon) CooldownSec() float64 { return LaserReloadSec }
+func (weapon LaserWeapon) EnergyCost() float64  { return EnergyConsumptionLaser }
+
+func (weapon LaserWeapon) Fire(ship *Spaceship, gameManager *GameManager) error {
+	_, err := gameManager.AddGameObject(gameManager.newLaserProjectile(ship.muzzle(), ship.rotation, ship))
+	return err
+}
+
+// RocketWeapon fires a rocket from the spaceship's rockets, its explosion damages the spaceships
+// around the impact, see Projectile.Splash. The zero value uses the RocketSplashRadius and RocketSplashDamage.
+type RocketWeapon struct {
+	SplashRadius float64
+	SplashDamage float64
+}
+
+func (weapon RocketWeapon) Name() string         { return "rocket" }
+func (weapon RocketWeapon) CooldownSec() float64 { return RocketReloadSec }
+func (weapon RocketWeapon) EnergyCost() float64  { return EnergyConsumptionRocket }
+
+func (weapon RocketWeapon) Fire(ship *Spaceship, gameManager *GameManager) error {
+	if ship.rockets == 0 {
+		return errors.New("not enough rockets")
+	}
+
+	rocket := gameManager.newRocketProjectile(ship.muzzle(), ship.rotation, ship)
+	if weapon.SplashRadius == 0 && weapon.SplashDamage == 0 {
+		rocket.Splash(RocketSplashRadius, RocketSplashDamage)
+	} else {
+		rocket.Splash(weapon.SplashRadius, weapon.SplashDamage)
+	}
+	if _, err := gameManager.AddGameObject(rocket); err != nil {
+		return err
+	}
+	ship.rockets--
+	return nil
+}
+
+// MineWeapon drops a mine behind the spaceship, it stays in place until hit or expired.
+type MineWeapon struct{}
+
+func (weapon MineWeapon) Name() string         { return "mine" }
+func (weapon MineWeapon) CooldownSec() float64 { return MineReloadSec }
+func (weapon MineWeapon) EnergyCost() float64  { return EnergyConsumptionMine }
+
+func (weapon MineWeapon) Fire(ship *Spaceship, gameManager *GameManager) error {
+	behind := physics.FromAngle(ship.rotation + math.Pi)
+	position := ship.position.Add(behind.Multiply(MineDropDistance))
+	_, err := gameManager.AddGameObject(gameManager.newMineProjectile(position, ship))
+	return err
+}
+
+// SpreadWeapon fires a fan of lasers evenly spread over the angle. The zero value fires
+// the SpreadProjectiles over the SpreadAngleRad.
+type SpreadWeapon struct {
+	Projectiles int
+	AngleRad    float64
+}
+
+func (weapon SpreadWeapon) Name() string         { return "spread" }
+func (weapon SpreadWeapon) CooldownSec() float64 { return SpreadReloadSec }
+func (weapon SpreadWeapon) EnergyCost() float64  { return EnergyConsumptionSpread }
+
+// Fire adds the lasers one by one, the ones added before a rejected one stay in flight.
+func (weapon SpreadWeapon) Fire(ship *Spaceship, gameManager *GameManager) error {
+	projectiles, angle := weapon.Projectiles, weapon.AngleRad
+	if projectiles <= 0 {
+		projectiles, angle = SpreadProjectiles, SpreadAngleRad
+	}
+
+	for i := 0; i < projectiles; i++ {
+		rotation := ship.rotation
+		if projectiles > 1 {
+			rotation += -angle/2 + angle*float64(i)/float64(projectiles-1)
+		}
+		laser := gameManager.newLaserProjectile(ship.muzzle(), rotation, ship)
+		if _, err := gameManager.AddGameObject(laser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLaserWeapon_Fire(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+
+	assert.NoError(t, LaserWeapon{}.Fire(ship, &gameManager))
+	laser := gameManager.gameObjects[0].(*Projectile)
+	assert.Equal(t, DamageTypeLaser, laser.damageType)
+	assert.InDelta(t, 15, laser.position.Y, 0.1)
+	assert.Equal(t, ship, laser.owner)
+}
+
+func TestRocketWeapon_Fire(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, 0)
+
+	assert.NoError(t, RocketWeapon{}.Fire(ship, &gameManager))
+	rocket := gameManager.gameObjects[0].(*Projectile)
+	assert.Equal(t, DamageTypeRocket, rocket.damageType)
+	assert.Equal(t, 60.0, rocket.splashRadius)
+	assert.Equal(t, 30.0, rocket.splashDamage)
+	assert.Equal(t, int32(MaxRockets-1), ship.rockets)
+
+	assert.NoError(t, RocketWeapon{SplashRadius: 10, SplashDamage: 5}.Fire(ship, &gameManager))
+	rocket = gameManager.gameObjects[1].(*Projectile)
+	assert.Equal(t, 10.0, rocket.splashRadius)
+	assert.Equal(t, 5.0, rocket.splashDamage)
+
+	ship.rockets = 0
+	assert.EqualError(t, RocketWeapon{}.Fire(ship, &gameManager), "not enough rockets")
+	assert.Len(t, gameManager.gameObjects, 2)
+}
+
+func TestMineWeapon_Fire(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+
+	assert.NoError(t, MineWeapon{}.Fire(ship, &gameManager))
+	mine := gameManager.gameObjects[0].(*Projectile)
+	assert.Equal(t, DamageTypeMine, mine.damageType)
+	assert.InDelta(t, 100-MineDropDistance, mine.position.X, 1e-9)
+	assert.InDelta(t, 100, mine.position.Y, 1e-9)
+	assert.Equal(t, physics.Vector2{}, mine.velocity)
+}
+
+func TestSpreadWeapon_Fire(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+
+	assert.NoError(t, SpreadWeapon{}.Fire(ship, &gameManager))
+	assert.Len(t, gameManager.gameObjects, SpreadProjectiles)
+	assert.InDelta(t, -SpreadAngleRad/2, gameManager.gameObjects[0].(*Projectile).rotation, 1e-9)
+	assert.InDelta(t, 0, gameManager.gameObjects[1].(*Projectile).rotation, 1e-9)
+	assert.InDelta(t, SpreadAngleRad/2, gameManager.gameObjects[2].(*Projectile).rotation, 1e-9)
+
+	gameManager = NewGameManager()
+	assert.NoError(t, SpreadWeapon{Projectiles: 1, AngleRad: 1}.Fire(ship, &gameManager))
+	assert.Len(t, gameManager.gameObjects, 1)
+	assert.Equal(t, 0.0, gameManager.gameObjects[0].(*Projectile).rotation)
+}
+
+func TestProjectile_Splash(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 0, Y: 0}, 0)
+	target := NewSpaceship(2, "target", physics.Vector2{X: 200, Y: 0}, 0)
+	near := NewSpaceship(3, "near", physics.Vector2{X: 230, Y: 0}, 0)
+	far := NewSpaceship(4, "far", physics.Vector2{X: 400, Y: 0}, 0)
+	for _, ship := range []*Spaceship{owner, target, near, far} {
+		_ = gameManager.AddSpaceship(ship)
+	}
+
+	rocket := NewRocketProjectile(5, physics.Vector2{X: 200, Y: 0}, 0, owner)
+	rocket.Splash(60, 30)
+	rocket.OnCollision(target, &gameManager, 0)
+
+	assert.Less(t, target.Health(), 100.0)
+	assert.Less(t, near.Health(), 100.0)
+	assert.Greater(t, near.Health(), target.Health())
+	assert.Equal(t, 100.0, far.Health())
+	assert.Equal(t, 100.0, owner.Health())
+	assert.False(t, rocket.Enabled())
+}
+
+func TestSpaceship_FireWeapon(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 100, Y: 100}, 0)
+	ship.energy = MaxEnergy
+
+	assert.EqualError(t, ship.FireWeapon(0, &gameManager), "no weapon in slot 0")
+
+	assert.Equal(t, 0, ship.EquipWeapon(LaserWeapon{}))
+	assert.Equal(t, 1, ship.EquipWeapon(SpreadWeapon{}))
+	assert.Equal(t, 1, ship.EquipWeapon(SpreadWeapon{Projectiles: 5, AngleRad: 1}))
+	assert.Equal(t, []Weapon{LaserWeapon{}, SpreadWeapon{Projectiles: 5, AngleRad: 1}}, ship.Weapons())
+
+	assert.NoError(t, ship.FireWeapon(1, &gameManager))
+	assert.Len(t, gameManager.gameObjects, 5)
+	assert.Equal(t, 85.0, ship.energy)
+	assert.Equal(t, SpreadReloadSec, ship.WeaponCooldownSec(1))
+	assert.Equal(t, 0.0, ship.WeaponCooldownSec(0))
+	assert.Equal(t, 0.0, ship.WeaponCooldownSec(5))
+
+	assert.EqualError(t, ship.FireWeapon(1, &gameManager), "spread is still cooling down")
+	// The slots cool down independently
+	assert.NoError(t, ship.FireWeapon(0, &gameManager))
+
+	ship.gunManagement(SpreadReloadSec)
+	assert.Equal(t, 0.0, ship.WeaponCooldownSec(1))
+
+	ship.energy = 0
+	assert.EqualError(t, ship.FireWeapon(1, &gameManager), "not enough energy")
+}
@@ -0,0 +1,19 @@
+package physics
+
+// AABB is an axis-aligned bounding box.
+type AABB struct {
+	Min Vector2
+	Max Vector2
+}
+
+// Intersects checks if two boxes overlap, touching boxes intersect.
+func (aabb *AABB) Intersects(other AABB) bool {
+	return aabb.Min.X <= other.Max.X && aabb.Max.X >= other.Min.X &&
+		aabb.Min.Y <= other.Max.Y && aabb.Max.Y >= other.Min.Y
+}
+
+// Contains checks if the point lies within the box, the boundary included.
+func (aabb *AABB) Contains(point Vector2) bool {
+	return point.X >= aabb.Min.X && point.X <= aabb.Max.X &&
+		point.Y >= aabb.Min.Y && point.Y <= aabb.Max.Y
+}
@@ -0,0 +1,51 @@
+package physics
+
+import "testing"
+
+func TestAABB_Intersects(t *testing.T) {
+	box := AABB{Min: Vector2{X: 0, Y: 0}, Max: Vector2{X: 2, Y: 2}}
+
+	var tests = []struct {
+		description string
+		other       AABB
+		expected    bool
+	}{
+		{"Overlapping", AABB{Min: Vector2{X: 1, Y: 1}, Max: Vector2{X: 3, Y: 3}}, true},
+		{"Contained", AABB{Min: Vector2{X: 0.5, Y: 0.5}, Max: Vector2{X: 1, Y: 1}}, true},
+		{"Touching edge", AABB{Min: Vector2{X: 2, Y: 0}, Max: Vector2{X: 3, Y: 2}}, true},
+		{"Touching corner", AABB{Min: Vector2{X: 2, Y: 2}, Max: Vector2{X: 3, Y: 3}}, true},
+		{"Separated on X", AABB{Min: Vector2{X: 2.1, Y: 0}, Max: Vector2{X: 3, Y: 2}}, false},
+		{"Separated on Y", AABB{Min: Vector2{X: 0, Y: -2}, Max: Vector2{X: 2, Y: -0.1}}, false},
+	}
+
+	for _, test := range tests {
+		if result := box.Intersects(test.other); result != test.expected {
+			t.Errorf("%s: Intersects(%v) = %v; expected %v", test.description, test.other, result, test.expected)
+		}
+		if result := test.other.Intersects(box); result != test.expected {
+			t.Errorf("%s: %v.Intersects(%v) = %v; expected %v", test.description, test.other, box, result, test.expected)
+		}
+	}
+}
+
+func TestAABB_Contains(t *testing.T) {
+	box := AABB{Min: Vector2{X: 0, Y: 0}, Max: Vector2{X: 2, Y: 2}}
+
+	var tests = []struct {
+		description string
+		point       Vector2
+		expected    bool
+	}{
+		{"Inside", Vector2{X: 1, Y: 1}, true},
+		{"On edge", Vector2{X: 2, Y: 1}, true},
+		{"On corner", Vector2{X: 0, Y: 0}, true},
+		{"Outside on X", Vector2{X: 2.1, Y: 1}, false},
+		{"Outside on Y", Vector2{X: 1, Y: -0.1}, false},
+	}
+
+	for _, test := range tests {
+		if result := box.Contains(test.point); result != test.expected {
+			t.Errorf("%s: Contains(%v) = %v; expected %v", test.description, test.point, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package physics
+
+import "math"
+
+type Circle struct {
+	Center Vector2
+	Radius float64
+}
+
+// Contains checks if the point is inside the circle, including its edge.
+func (circle *Circle) Contains(point Vector2) bool {
+	return circle.Center.Distance(point) <= circle.Radius
+}
+
+// Intersects checks if two circles overlap, touching circles intersect.
+func (circle *Circle) Intersects(other Circle) bool {
+	return circle.Center.Distance(other.Center) <= circle.Radius+other.Radius
+}
+
+func (circle *Circle) Area() float64 {
+	return math.Pi * circle.Radius * circle.Radius
+}
+
+func (circle *Circle) Circumference() float64 {
+	return 2 * math.Pi * circle.Radius
+}
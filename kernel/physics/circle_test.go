@@ -0,0 +1,72 @@
+package physics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/utils"
+)
+
+func TestCircle_Contains(t *testing.T) {
+	circle := Circle{Center: Vector2{X: 1, Y: 1}, Radius: 2}
+
+	var tests = []struct {
+		description string
+		point       Vector2
+		expected    bool
+	}{
+		{"Center", Vector2{X: 1, Y: 1}, true},
+		{"Inside", Vector2{X: 2, Y: 2}, true},
+		{"Edge", Vector2{X: 3, Y: 1}, true},
+		{"Outside", Vector2{X: 3.01, Y: 1}, false},
+		{"Outside diagonally", Vector2{X: 3, Y: 3}, false},
+	}
+
+	for _, test := range tests {
+		result := circle.Contains(test.point)
+		if result != test.expected {
+			t.Errorf("%s: Contains(%v) = %v; expected %v", test.description, test.point, result, test.expected)
+		}
+	}
+}
+
+func TestCircle_Intersects(t *testing.T) {
+	circle := Circle{Center: Vector2{X: 0, Y: 0}, Radius: 2}
+
+	var tests = []struct {
+		description string
+		other       Circle
+		expected    bool
+	}{
+		{"Separated", Circle{Center: Vector2{X: 5, Y: 0}, Radius: 1}, false},
+		{"Touching", Circle{Center: Vector2{X: 3, Y: 0}, Radius: 1}, true},
+		{"Overlapping", Circle{Center: Vector2{X: 2, Y: 2}, Radius: 1}, true},
+		{"Contained", Circle{Center: Vector2{X: 0.5, Y: 0}, Radius: 0.5}, true},
+	}
+
+	for _, test := range tests {
+		result := circle.Intersects(test.other)
+		if result != test.expected {
+			t.Errorf("%s: Intersects(%v) = %v; expected %v", test.description, test.other, result, test.expected)
+		}
+		// Symmetric
+		result = test.other.Intersects(circle)
+		if result != test.expected {
+			t.Errorf("%s: %v.Intersects(%v) = %v; expected %v", test.description, test.other, circle, result, test.expected)
+		}
+	}
+}
+
+func TestCircle_Area(t *testing.T) {
+	circle := Circle{Center: Vector2{X: 1, Y: 1}, Radius: 2}
+	if !utils.AlmostEqual(circle.Area(), 4*math.Pi) {
+		t.Errorf("Area() = %v; expected %v", circle.Area(), 4*math.Pi)
+	}
+}
+
+func TestCircle_Circumference(t *testing.T) {
+	circle := Circle{Center: Vector2{X: 1, Y: 1}, Radius: 2}
+	if !utils.AlmostEqual(circle.Circumference(), 4*math.Pi) {
+		t.Errorf("Circumference() = %v; expected %v", circle.Circumference(), 4*math.Pi)
+	}
+}
@@ -0,0 +1,179 @@
+package collider
+
+import (
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// CapsuleCollider is a segment grown by the radius, e.g. the hitbox of an elongated spaceship.
+// The segment runs along the rotation, centered at the position.
+type CapsuleCollider struct {
+	enabled  bool
+	position physics.Vector2
+	// Rotation in radians
+	rotation   float64
+	halfLength float64 // Of the segment, the caps excluded
+	radius     float64
+}
+
+func NewCapsuleCollider(position physics.Vector2, rotation, length, radius float64) *CapsuleCollider {
+	return &CapsuleCollider{
+		enabled:    true,
+		position:   position,
+		rotation:   rotation,
+		halfLength: math.Max(length, 0) / 2,
+		radius:     radius,
+	}
+}
+
+func (capsule *CapsuleCollider) Enabled() bool {
+	return capsule.enabled
+}
+
+func (capsule *CapsuleCollider) SetEnabled(enabled bool) {
+	capsule.enabled = enabled
+}
+
+func (capsule *CapsuleCollider) Position() physics.Vector2 {
+	return capsule.position
+}
+
+func (capsule *CapsuleCollider) SetPosition(position physics.Vector2) {
+	capsule.position = position
+}
+
+func (capsule *CapsuleCollider) Rotation() float64 {
+	return capsule.rotation
+}
+
+func (capsule *CapsuleCollider) SetRotation(rotation float64) {
+	capsule.rotation = rotation
+}
+
+// Length returns the length of the segment, the caps excluded.
+func (capsule *CapsuleCollider) Length() float64 {
+	return capsule.halfLength * 2
+}
+
+func (capsule *CapsuleCollider) Radius() float64 {
+	return capsule.radius
+}
+
+// Segment returns the absolute end points of the segment.
+func (capsule *CapsuleCollider) Segment() (physics.Vector2, physics.Vector2) {
+	axis := physics.FromAngle(capsule.rotation)
+	offset := axis.Multiply(capsule.halfLength)
+	return capsule.position.Subtract(offset), capsule.position.Add(offset)
+}
+
+func (capsule *CapsuleCollider) CollidesWith(other Collider) bool {
+	switch other := other.(type) {
+	case *CircleCollider:
+		return capsuleCollidesWithCircle(*capsule, *other)
+	case *CapsuleCollider:
+		return capsuleCollidesWithCapsule(*capsule, *other)
+	case *SquareCollider:
+		return capsuleCollidesWithPolygon(*capsule, other.Absolute())
+	case *PolygonCollider:
+		return capsuleCollidesWithPolygon(*capsule, other.Absolute())
+	default:
+		return false
+	}
+}
+
+func (capsule *CapsuleCollider) Bounds() physics.AABB {
+	start, end := capsule.Segment()
+	return SweptBounds(start, end, capsule.radius)
+}
+
+func (capsule *CapsuleCollider) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "capsule",
+		"enabled": capsule.enabled,
+		"position": map[string]interface{}{
+			"x": capsule.position.X,
+			"y": capsule.position.Y,
+		},
+		"rotation": capsule.rotation,
+		"length":   capsule.Length(),
+		"radius":   capsule.radius,
+	}
+}
+
+func capsuleCollidesWithCircle(capsule CapsuleCollider, circle CircleCollider) bool {
+	start, end := capsule.Segment()
+	return pointSegmentDistance(circle.position, start, end) <= capsule.radius+circle.radius
+}
+
+func capsuleCollidesWithCapsule(capsule CapsuleCollider, other CapsuleCollider) bool {
+	start, end := capsule.Segment()
+	otherStart, otherEnd := other.Segment()
+	return segmentSegmentDistance(start, end, otherStart, otherEnd) <= capsule.radius+other.radius
+}
+
+// capsuleCollidesWithPolygon checks the segment against the polygon grown by the radius: the
+// segment inside or crossing the polygon, or passing within the radius of its boundary.
+func capsuleCollidesWithPolygon(capsule CapsuleCollider, polygon physics.Polygon) bool {
+	if len(polygon.Vertices) < 3 {
+		return false
+	}
+	start, end := capsule.Segment()
+	if satPolygonCollidesWithCircle(polygon, start, capsule.radius) {
+		return true
+	}
+	for _, edge := range polygon.Edges() {
+		if segmentSegmentDistance(start, end, edge.Start, edge.End) <= capsule.radius {
+			return true
+		}
+	}
+	return false
+}
+
+func pointSegmentDistance(point, start, end physics.Vector2) float64 {
+	if start == end {
+		return point.Distance(start)
+	}
+	edge := physics.Edge{Start: start, End: end}
+	closest := edge.ClosestPoint(point)
+	return closest.Distance(point)
+}
+
+// segmentSegmentDistance returns 0 for the crossing segments, otherwise the closest distance is
+// between an end point and the other segment.
+func segmentSegmentDistance(start, end, otherStart, otherEnd physics.Vector2) float64 {
+	if _, ok := segmentIntersection(start, end, otherStart, otherEnd); ok {
+		return 0
+	}
+	return math.Min(
+		math.Min(pointSegmentDistance(start, otherStart, otherEnd), pointSegmentDistance(end, otherStart, otherEnd)),
+		math.Min(pointSegmentDistance(otherStart, start, end), pointSegmentDistance(otherEnd, start, end)),
+	)
+}
+
+// sweepCircleCapsule casts the circle center against the capsule grown by the radius: the segment
+// moved by the sum of the radii to both sides and the circles at its end points.
+func sweepCircleCapsule(start, end physics.Vector2, radius float64, capsule CapsuleCollider) (float64, bool) {
+	segmentStart, segmentEnd := capsule.Segment()
+	reach := radius + capsule.radius
+	if pointSegmentDistance(start, segmentStart, segmentEnd) <= reach {
+		return 0, true
+	}
+
+	first, hit := math.Inf(1), false
+	for _, center := range []physics.Vector2{segmentStart, segmentEnd} {
+		if t, ok := sweepCircleCircle(start, end, center, reach); ok && t < first {
+			first, hit = t, true
+		}
+	}
+	if capsule.halfLength > 0 {
+		normal := physics.FromAngle(capsule.rotation + math.Pi/2)
+		for _, side := range []float64{reach, -reach} {
+			shift := normal.Multiply(side)
+			if t, ok := segmentIntersection(start, end, segmentStart.Add(shift), segmentEnd.Add(shift)); ok && t < first {
+				first, hit = t, true
+			}
+		}
+	}
+	return first, hit
+}
@@ -0,0 +1,108 @@
+package collider
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapsuleCollider_Segment(t *testing.T) {
+	capsule := NewCapsuleCollider(physics.Vector2{X: 10, Y: 10}, math.Pi/2, 20, 5)
+	start, end := capsule.Segment()
+	assert.InDelta(t, 10, start.X, 1e-9)
+	assert.InDelta(t, 0, start.Y, 1e-9)
+	assert.InDelta(t, 10, end.X, 1e-9)
+	assert.InDelta(t, 20, end.Y, 1e-9)
+	assert.Equal(t, 20.0, capsule.Length())
+
+	bounds := capsule.Bounds()
+	assert.InDelta(t, 5, bounds.Min.X, 1e-9)
+	assert.InDelta(t, -5, bounds.Min.Y, 1e-9)
+	assert.InDelta(t, 15, bounds.Max.X, 1e-9)
+	assert.InDelta(t, 25, bounds.Max.Y, 1e-9)
+}
+
+func TestCapsuleCollider_CollidesWith(t *testing.T) {
+	// Horizontal, from (-10, 0) to (10, 0), radius 5
+	capsule := NewCapsuleCollider(physics.Vector2{X: 0, Y: 0}, 0, 20, 5)
+
+	t.Run("Circle", func(t *testing.T) {
+		// Along the side, past the cap and diagonal to the cap
+		assert.True(t, capsule.CollidesWith(NewCircleCollider(physics.Vector2{X: 0, Y: 9}, 4)))
+		assert.False(t, capsule.CollidesWith(NewCircleCollider(physics.Vector2{X: 0, Y: 10}, 4)))
+		assert.True(t, capsule.CollidesWith(NewCircleCollider(physics.Vector2{X: 18, Y: 0}, 3)))
+		assert.False(t, capsule.CollidesWith(NewCircleCollider(physics.Vector2{X: 14, Y: 8}, 3)))
+		assert.True(t, NewCircleCollider(physics.Vector2{X: 0, Y: 9}, 4).CollidesWith(capsule))
+	})
+
+	t.Run("Capsule", func(t *testing.T) {
+		// Crossing, parallel within the reach and beyond it
+		assert.True(t, capsule.CollidesWith(NewCapsuleCollider(physics.Vector2{X: 0, Y: 0}, math.Pi/2, 40, 1)))
+		assert.True(t, capsule.CollidesWith(NewCapsuleCollider(physics.Vector2{X: 5, Y: 9}, 0, 20, 4)))
+		assert.False(t, capsule.CollidesWith(NewCapsuleCollider(physics.Vector2{X: 5, Y: 10}, 0, 20, 4)))
+		// End to end
+		assert.True(t, capsule.CollidesWith(NewCapsuleCollider(physics.Vector2{X: 29, Y: 0}, 0, 20, 5)))
+		assert.False(t, capsule.CollidesWith(NewCapsuleCollider(physics.Vector2{X: 31, Y: 0}, 0, 20, 5)))
+	})
+
+	t.Run("Polygon", func(t *testing.T) {
+		square := NewSquareCollider(physics.Vector2{X: 0, Y: 10}, 0, physics.Size{Width: 4, Height: 12})
+		assert.True(t, capsule.CollidesWith(square))
+		assert.True(t, square.CollidesWith(capsule))
+		assert.False(t, capsule.CollidesWith(NewSquareCollider(physics.Vector2{X: 0, Y: 10}, 0, physics.Size{Width: 4, Height: 8})))
+
+		triangle := NewPolygonCollider(physics.Vector2{X: 14, Y: 4}, 0, physics.Polygon{Vertices: []physics.Vector2{{X: 0, Y: 0}, {X: 5, Y: 0}, {X: 0, Y: 5}}})
+		assert.False(t, capsule.CollidesWith(triangle))
+		triangle.SetPosition(physics.Vector2{X: 12, Y: 2})
+		assert.True(t, capsule.CollidesWith(triangle))
+		assert.True(t, triangle.CollidesWith(capsule))
+
+		// Within the polygon
+		large := NewSquareCollider(physics.Vector2{X: 0, Y: 0}, 0, physics.Size{Width: 100, Height: 100})
+		assert.True(t, capsule.CollidesWith(large))
+	})
+
+	t.Run("Rotated", func(t *testing.T) {
+		rotated := NewCapsuleCollider(physics.Vector2{X: 0, Y: 0}, math.Pi/4, 20, 2)
+		assert.True(t, rotated.CollidesWith(NewCircleCollider(physics.Vector2{X: 7, Y: 7}, 1)))
+		assert.False(t, rotated.CollidesWith(NewCircleCollider(physics.Vector2{X: 7, Y: -7}, 1)))
+	})
+}
+
+func TestCapsuleCollider_Serialize(t *testing.T) {
+	capsule := NewCapsuleCollider(physics.Vector2{X: 1, Y: 2}, 0.5, 20, 5)
+	assert.Equal(t, map[string]interface{}{
+		"type":    "capsule",
+		"enabled": true,
+		"position": map[string]interface{}{
+			"x": 1.0,
+			"y": 2.0,
+		},
+		"rotation": 0.5,
+		"length":   20.0,
+		"radius":   5.0,
+	}, capsule.Serialize())
+}
+
+func TestSweepCircle_Capsule(t *testing.T) {
+	// A vertical capsule the circle would tunnel through between the start and the end
+	capsule := NewCapsuleCollider(physics.Vector2{X: 50, Y: 0}, math.Pi/2, 40, 1)
+
+	fraction, ok := SweepCircle(physics.Vector2{X: 0, Y: 10}, physics.Vector2{X: 100, Y: 10}, 2, capsule)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.47, fraction, 1e-9)
+
+	// The cap
+	fraction, ok = SweepCircle(physics.Vector2{X: 50, Y: 100}, physics.Vector2{X: 50, Y: 0}, 2, capsule)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.77, fraction, 1e-9)
+
+	_, ok = SweepCircle(physics.Vector2{X: 0, Y: 30}, physics.Vector2{X: 100, Y: 30}, 2, capsule)
+	assert.False(t, ok)
+
+	fraction, ok = SweepCircle(physics.Vector2{X: 51, Y: 0}, physics.Vector2{X: 100, Y: 0}, 2, capsule)
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, fraction)
+}
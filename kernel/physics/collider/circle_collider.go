@@ -1,8 +1,6 @@
 package collider
 
 import (
-	"math"
-
 	"github.com/davidhorak/space-wars/kernel/physics"
 )
 
@@ -38,6 +36,11 @@ func (circle *CircleCollider) Radius() float64 {
 
 func (circle *CircleCollider) SetRotation(rotation float64) {}
 
+// Circle returns the circle shape at the collider position.
+func (circle *CircleCollider) Circle() physics.Circle {
+	return physics.Circle{Center: circle.position, Radius: circle.radius}
+}
+
 func NewCircleCollider(position physics.Vector2, radius float64) *CircleCollider {
 	return &CircleCollider{
 		enabled:  true,
@@ -54,11 +57,20 @@ func (circle *CircleCollider) CollidesWith(other Collider) bool {
 		return circleCollidesWithCircle(*circle, *other)
 	case *PolygonCollider:
 		return other.CollidesWith(circle)
+	case *CapsuleCollider:
+		return other.CollidesWith(circle)
 	default:
 		return false
 	}
 }
 
+func (circle *CircleCollider) Bounds() physics.AABB {
+	return physics.AABB{
+		Min: physics.Vector2{X: circle.position.X - circle.radius, Y: circle.position.Y - circle.radius},
+		Max: physics.Vector2{X: circle.position.X + circle.radius, Y: circle.position.Y + circle.radius},
+	}
+}
+
 func (circle *CircleCollider) Serialize() map[string]interface{} {
 	return map[string]interface{}{
 		"type":    "circle",
@@ -72,6 +84,6 @@ func (circle *CircleCollider) Serialize() map[string]interface{} {
 }
 
 func circleCollidesWithCircle(circle CircleCollider, other CircleCollider) bool {
-	distance := math.Sqrt(math.Pow(circle.position.X-other.position.X, 2) + math.Pow(circle.position.Y-other.position.Y, 2))
-	return distance <= circle.radius+other.radius
+	shape := circle.Circle()
+	return shape.Intersects(other.Circle())
 }
@@ -50,6 +50,16 @@ func TestCircleCollider_Radius(t *testing.T) {
 	assert.Equal(t, expected, circle_collider.Radius())
 }
 
+func TestCircleCollider_Circle(t *testing.T) {
+	circle := NewCircleCollider(physics.Vector2{X: 1, Y: 2}, 3)
+	assert.Equal(t, physics.Circle{Center: physics.Vector2{X: 1, Y: 2}, Radius: 3}, circle.Circle())
+}
+
+func TestCircleCollider_Bounds(t *testing.T) {
+	circle := NewCircleCollider(physics.Vector2{X: 1, Y: 2}, 3)
+	assert.Equal(t, physics.AABB{Min: physics.Vector2{X: -2, Y: -1}, Max: physics.Vector2{X: 4, Y: 5}}, circle.Bounds())
+}
+
 func TestCircleCollider_CollidesWithSquare(t *testing.T) {
 	var tests = []struct {
 		description string
@@ -10,5 +10,7 @@ type Collider interface {
 	Rotation() float64
 	SetRotation(rotation float64)
 	CollidesWith(other Collider) bool
+	// Bounds returns the axis-aligned bounding box, used to skip the exact check for distant colliders.
+	Bounds() physics.AABB
 	Serialize() map[string]interface{}
 }
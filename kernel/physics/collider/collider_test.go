@@ -0,0 +1,46 @@
+package collider
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// Any colliding pair must have overlapping bounds, otherwise the broad-phase would miss collisions.
+func TestCollider_Bounds_NoFalseNegatives(t *testing.T) {
+	random := rand.New(rand.NewSource(42))
+	randomCollider := func() Collider {
+		position := physics.Vector2{X: random.Float64() * 20, Y: random.Float64() * 20}
+		rotation := random.Float64() * 6.28
+		switch random.Intn(3) {
+		case 0:
+			return NewCircleCollider(position, 0.5+random.Float64()*3)
+		case 1:
+			return NewSquareCollider(position, rotation, physics.Size{Width: 0.5 + random.Float64()*4, Height: 0.5 + random.Float64()*4})
+		default:
+			return NewPolygonCollider(position, rotation, physics.Polygon{Vertices: []physics.Vector2{
+				{X: 1 + random.Float64()*2, Y: 0},
+				{X: -1 - random.Float64()*2, Y: 1 + random.Float64()*2},
+				{X: -1 - random.Float64()*2, Y: -1 - random.Float64()*2},
+			}})
+		}
+	}
+
+	collisions := 0
+	for i := 0; i < 5000; i++ {
+		a := randomCollider()
+		b := randomCollider()
+		if !a.CollidesWith(b) {
+			continue
+		}
+		collisions++
+		boundsA := a.Bounds()
+		if !boundsA.Intersects(b.Bounds()) {
+			t.Fatalf("%v collides with %v but the bounds %v and %v do not intersect", a.Serialize(), b.Serialize(), boundsA, b.Bounds())
+		}
+	}
+	if collisions == 0 {
+		t.Fatal("expected some collisions to be generated")
+	}
+}
@@ -56,11 +56,18 @@ func (polygon *PolygonCollider) CollidesWith(other Collider) bool {
 		return polygonCollidesWithCircle(*polygon, *other)
 	case *PolygonCollider:
 		return polygonCollidesWithPolygon(*polygon, *other)
+	case *CapsuleCollider:
+		return other.CollidesWith(polygon)
 	default:
 		return false
 	}
 }
 
+// Polygon returns the polygon relative to the position, without the rotation applied.
+func (polygon *PolygonCollider) Polygon() physics.Polygon {
+	return polygon.polygon
+}
+
 func (polygon *PolygonCollider) IsRotated() bool {
 	return polygon.rotation != 0
 }
@@ -89,6 +96,12 @@ func (polygon *PolygonCollider) Rotated() physics.Polygon {
 	return physics.Polygon{Vertices: rotatedVertices}
 }
 
+func (polygon *PolygonCollider) Bounds() physics.AABB {
+	absolute := polygon.Absolute()
+	minX, minY, maxX, maxY := absolute.Bounds()
+	return physics.AABB{Min: physics.Vector2{X: minX, Y: minY}, Max: physics.Vector2{X: maxX, Y: maxY}}
+}
+
 func (polygon *PolygonCollider) Serialize() map[string]interface{} {
 	vertices := make([]map[string]interface{}, len(polygon.polygon.Vertices))
 	for i, vertex := range polygon.polygon.Vertices {
@@ -111,22 +124,9 @@ func (polygon *PolygonCollider) Serialize() map[string]interface{} {
 }
 
 func polygonCollidesWithCircle(polygon PolygonCollider, circle CircleCollider) bool {
-	circleCenter := circle.position
-	circleRadius := circle.radius
-	abs := polygon.Absolute()
-
-	for _, edge := range abs.Edges() {
-		closestPoint := edge.ClosestPoint(circleCenter)
-		distance := math.Sqrt(math.Pow(closestPoint.X-circleCenter.X, 2) + math.Pow(closestPoint.Y-circleCenter.Y, 2))
-		if distance <= circleRadius {
-			return true
-		}
-	}
-	return false
+	return satPolygonCollidesWithCircle(polygon.Absolute(), circle.position, circle.radius)
 }
 
 func polygonCollidesWithPolygon(polygon PolygonCollider, other PolygonCollider) bool {
-	a := polygon.Absolute()
-	b := other.Absolute()
-	return a.Intersects(b)
+	return satPolygonCollidesWithPolygon(polygon.Absolute(), other.Absolute())
 }
@@ -44,6 +44,12 @@ func TestPolygonCollider_SetRotation(t *testing.T) {
 	assert.Equal(t, math.Pi/2, polygon.Rotation())
 }
 
+func TestPolygonCollider_Polygon(t *testing.T) {
+	vertices := []physics.Vector2{{X: -1, Y: -1}, {X: 1, Y: -1}, {X: 1, Y: 1}, {X: -1, Y: 1}}
+	polygon := NewPolygonCollider(physics.Vector2{X: 5, Y: 5}, math.Pi/2, physics.Polygon{Vertices: vertices})
+	assert.Equal(t, vertices, polygon.Polygon().Vertices)
+}
+
 func TestPolygonCollider_IsRotated(t *testing.T) {
 	var tests = []struct {
 		rotation float64
@@ -194,6 +200,11 @@ func TestPolygonCollider_CollidesWithCircle(t *testing.T) {
 		{X: 1, Y: 1},
 		{X: -1, Y: 1},
 	}}
+	polygonTriangle := physics.Polygon{Vertices: []physics.Vector2{
+		{X: 0, Y: -2},
+		{X: 2, Y: 2},
+		{X: -2, Y: 2},
+	}}
 
 	var tests = []struct {
 		description string
@@ -266,11 +277,63 @@ func TestPolygonCollider_CollidesWithCircle(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			description: "Circle inside the triangle",
+			polygon: PolygonCollider{
+				position: physics.Vector2{X: 10, Y: 10},
+				rotation: 0,
+				polygon:  polygonTriangle,
+			},
+			circle: CircleCollider{
+				position: physics.Vector2{X: 10, Y: 10.5},
+				radius:   0.5,
+			},
+			expected: true,
+		},
+		{
+			description: "Circle touching the triangle edge",
+			polygon: PolygonCollider{
+				position: physics.Vector2{X: 0, Y: 0},
+				rotation: 0,
+				polygon:  polygonTriangle,
+			},
+			circle: CircleCollider{
+				position: physics.Vector2{X: 0, Y: 4},
+				radius:   2,
+			},
+			expected: true,
+		},
+		{
+			description: "Circle overlapping the triangle tip",
+			polygon: PolygonCollider{
+				position: physics.Vector2{X: 0, Y: 0},
+				rotation: 0,
+				polygon:  polygonTriangle,
+			},
+			circle: CircleCollider{
+				position: physics.Vector2{X: 0, Y: -2.5},
+				radius:   1,
+			},
+			expected: true,
+		},
+		{
+			description: "Circle separated from the triangle",
+			polygon: PolygonCollider{
+				position: physics.Vector2{X: 0, Y: 0},
+				rotation: 0,
+				polygon:  polygonTriangle,
+			},
+			circle: CircleCollider{
+				position: physics.Vector2{X: 3, Y: -2},
+				radius:   1,
+			},
+			expected: false,
+		},
 	}
 
 	for _, test := range tests {
 		result := test.polygon.CollidesWith(&test.circle)
-		assert.Equal(t, test.expected, result)
+		assert.Equal(t, test.expected, result, test.description)
 	}
 }
 
@@ -282,6 +345,12 @@ func TestPolygonCollider_CollidesWithPolygon(t *testing.T) {
 		{X: -1, Y: 1},
 	}}
 	polygonSquareRotated45 := polygonSquare.Rotate(math.Pi / 4)
+	polygonHorizontalBar := physics.Polygon{Vertices: []physics.Vector2{
+		{X: -3, Y: -1},
+		{X: 3, Y: -1},
+		{X: 3, Y: 1},
+		{X: -3, Y: 1},
+	}}
 
 	tests := []struct {
 		description string
@@ -331,6 +400,20 @@ func TestPolygonCollider_CollidesWithPolygon(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			description: "Crossing bars, no vertex inside the other polygon",
+			polygon: PolygonCollider{
+				position: physics.Vector2{X: 0, Y: 0},
+				rotation: 0,
+				polygon:  polygonHorizontalBar,
+			},
+			other: PolygonCollider{
+				position: physics.Vector2{X: 0, Y: 0},
+				rotation: math.Pi / 2,
+				polygon:  polygonHorizontalBar,
+			},
+			expected: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -357,6 +440,19 @@ func TestPolygonCollider_CollidesWithOther(t *testing.T) {
 	assert.False(t, result)
 }
 
+func TestPolygonCollider_Bounds(t *testing.T) {
+	polygon := NewPolygonCollider(physics.Vector2{X: 10, Y: 10}, math.Pi/2, physics.Polygon{Vertices: []physics.Vector2{
+		{X: 2, Y: 0},
+		{X: -1, Y: 1},
+		{X: -1, Y: -1},
+	}})
+	bounds := polygon.Bounds()
+	assert.InDelta(t, 9, bounds.Min.X, 1e-9)
+	assert.InDelta(t, 9, bounds.Min.Y, 1e-9)
+	assert.InDelta(t, 11, bounds.Max.X, 1e-9)
+	assert.InDelta(t, 12, bounds.Max.Y, 1e-9)
+}
+
 func TestPolygonCollider_Serialize(t *testing.T) {
 	polygon_collider := NewPolygonCollider(
 		physics.Vector2{X: 0, Y: 0},
@@ -0,0 +1,45 @@
+package collider
+
+import (
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// RaycastHit is the first collider hit by a ray, see Raycast.
+type RaycastHit struct {
+	Index    int // Of the hit collider in the set
+	Point    physics.Vector2
+	Distance float64 // From the origin
+}
+
+// Raycast casts the ray from the origin in the direction, up to the max distance, against the
+// colliders and returns the first one hit. The disabled colliders are skipped, the ones containing
+// the origin are hit at the origin. The ties go to the first collider of the set.
+func Raycast(origin, direction physics.Vector2, maxDistance float64, colliders []Collider) (RaycastHit, bool) {
+	if direction.Magnitude() == 0 || maxDistance <= 0 {
+		return RaycastHit{}, false
+	}
+	direction = direction.Normalize()
+	end := origin.Add(direction.Multiply(maxDistance))
+	bounds := SweptBounds(origin, end, 0)
+
+	hit, found := RaycastHit{Distance: math.Inf(1)}, false
+	for i, target := range colliders {
+		if target == nil || !target.Enabled() {
+			continue
+		}
+		targetBounds := target.Bounds()
+		if !bounds.Intersects(targetBounds) {
+			continue
+		}
+		if t, ok := SweepCircle(origin, end, 0, target); ok && t*maxDistance < hit.Distance {
+			hit, found = RaycastHit{Index: i, Distance: t * maxDistance}, true
+		}
+	}
+	if !found {
+		return RaycastHit{}, false
+	}
+	hit.Point = origin.Lerp(end, hit.Distance/maxDistance)
+	return hit, true
+}
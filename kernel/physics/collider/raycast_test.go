@@ -0,0 +1,44 @@
+package collider
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRaycast(t *testing.T) {
+	far := NewCircleCollider(physics.Vector2{X: 100, Y: 0}, 10)
+	near := NewSquareCollider(physics.Vector2{X: 50, Y: 0}, 0, physics.Size{Width: 10, Height: 10})
+	disabled := NewCircleCollider(physics.Vector2{X: 20, Y: 0}, 5)
+	disabled.SetEnabled(false)
+	colliders := []Collider{far, near, disabled, nil}
+
+	hit, ok := Raycast(physics.Vector2{X: 0, Y: 0}, physics.Vector2{X: 2, Y: 0}, 200, colliders)
+	assert.True(t, ok)
+	assert.Equal(t, 1, hit.Index)
+	assert.InDelta(t, 45, hit.Distance, 1e-9)
+	assert.InDelta(t, 45, hit.Point.X, 1e-9)
+	assert.InDelta(t, 0, hit.Point.Y, 1e-9)
+
+	// Past the near one
+	hit, ok = Raycast(physics.Vector2{X: 60, Y: 0}, physics.Vector2{X: 1, Y: 0}, 200, colliders)
+	assert.True(t, ok)
+	assert.Equal(t, 0, hit.Index)
+	assert.InDelta(t, 30, hit.Distance, 1e-9)
+
+	// Short of the target, the wrong way or no direction
+	_, ok = Raycast(physics.Vector2{X: 0, Y: 0}, physics.Vector2{X: 1, Y: 0}, 40, colliders)
+	assert.False(t, ok)
+	_, ok = Raycast(physics.Vector2{X: 0, Y: 0}, physics.Vector2{X: -1, Y: 0}, 200, colliders)
+	assert.False(t, ok)
+	_, ok = Raycast(physics.Vector2{X: 0, Y: 0}, physics.Vector2{}, 200, colliders)
+	assert.False(t, ok)
+
+	// From within a collider
+	hit, ok = Raycast(physics.Vector2{X: 100, Y: 5}, physics.Vector2{X: 0, Y: 1}, 200, colliders)
+	assert.True(t, ok)
+	assert.Equal(t, 0, hit.Index)
+	assert.Equal(t, 0.0, hit.Distance)
+	assert.Equal(t, physics.Vector2{X: 100, Y: 5}, hit.Point)
+}
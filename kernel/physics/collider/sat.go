@@ -0,0 +1,85 @@
+package collider
+
+import (
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// Separating Axis Theorem helpers.
+// Resources:
+//   - https://en.wikipedia.org/wiki/Hyperplane_separation_theorem
+//
+// Simplification:
+//   - The polygons are assumed to be convex, a concave polygon is tested as its convex hull.
+//   - Touching shapes are considered colliding.
+
+// satPolygonCollidesWithCircle checks the edge normals of the polygon and the axis
+// from the closest polygon vertex to the circle center.
+func satPolygonCollidesWithCircle(polygon physics.Polygon, center physics.Vector2, radius float64) bool {
+	if len(polygon.Vertices) < 3 {
+		return false
+	}
+
+	closestVertex := polygon.Vertices[0]
+	for _, vertex := range polygon.Vertices[1:] {
+		if vertex.Distance(center) < closestVertex.Distance(center) {
+			closestVertex = vertex
+		}
+	}
+
+	axes := edgeNormals(polygon)
+	axes = append(axes, center.Subtract(closestVertex))
+	for _, axis := range axes {
+		axis = axis.Normalize()
+		if axis.X == 0 && axis.Y == 0 {
+			continue
+		}
+		polygonMin, polygonMax := projectPolygon(polygon, axis)
+		centerProjection := axis.Dot(center)
+		if centerProjection+radius < polygonMin || centerProjection-radius > polygonMax {
+			return false
+		}
+	}
+	return true
+}
+
+// satPolygonCollidesWithPolygon checks the edge normals of both polygons.
+func satPolygonCollidesWithPolygon(polygon physics.Polygon, other physics.Polygon) bool {
+	if len(polygon.Vertices) < 3 || len(other.Vertices) < 3 {
+		return false
+	}
+
+	axes := append(edgeNormals(polygon), edgeNormals(other)...)
+	for _, axis := range axes {
+		axis = axis.Normalize()
+		if axis.X == 0 && axis.Y == 0 {
+			continue
+		}
+		minA, maxA := projectPolygon(polygon, axis)
+		minB, maxB := projectPolygon(other, axis)
+		if maxA < minB || maxB < minA {
+			return false
+		}
+	}
+	return true
+}
+
+func edgeNormals(polygon physics.Polygon) []physics.Vector2 {
+	edges := polygon.Edges()
+	normals := make([]physics.Vector2, len(edges))
+	for i, edge := range edges {
+		normals[i] = physics.Vector2{X: -(edge.End.Y - edge.Start.Y), Y: edge.End.X - edge.Start.X}
+	}
+	return normals
+}
+
+func projectPolygon(polygon physics.Polygon, axis physics.Vector2) (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, vertex := range polygon.Vertices {
+		projection := axis.Dot(vertex)
+		min = math.Min(min, projection)
+		max = math.Max(max, projection)
+	}
+	return min, max
+}
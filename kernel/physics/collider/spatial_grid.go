@@ -0,0 +1,146 @@
+package collider
+
+import (
+	"math"
+	"sort"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+type gridCell struct {
+	x int
+	y int
+}
+
+// SpatialGrid is a uniform grid broad-phase, it buckets the bounding boxes by the cells they overlap
+// so only the boxes sharing a cell are tested against each other. The ids are chosen by the caller,
+// small non-negative integers, e.g. the indexes of the objects. The results are sorted by the ids
+// to keep the collision order deterministic.
+type SpatialGrid struct {
+	cellSize float64
+	cells    map[gridCell]int // Index of the cell's ids
+	ids      [][]int
+	bounds   []physics.AABB // By id
+	inserted []bool         // By id
+	count    int
+}
+
+func NewSpatialGrid(cellSize float64) *SpatialGrid {
+	return &SpatialGrid{
+		cellSize: cellSize,
+		cells:    map[gridCell]int{},
+	}
+}
+
+func (grid *SpatialGrid) CellSize() float64 {
+	return grid.cellSize
+}
+
+// Len returns the number of the inserted boxes.
+func (grid *SpatialGrid) Len() int {
+	return grid.count
+}
+
+// Clear removes all the boxes, keeping the allocated cells for the next build.
+func (grid *SpatialGrid) Clear() {
+	for i := range grid.ids {
+		grid.ids[i] = grid.ids[i][:0]
+	}
+	clear(grid.inserted)
+	grid.count = 0
+}
+
+// Insert adds the box under the id, an id inserted again keeps the first box.
+func (grid *SpatialGrid) Insert(id int, bounds physics.AABB) {
+	if id >= len(grid.bounds) {
+		grid.bounds = append(grid.bounds, make([]physics.AABB, id+1-len(grid.bounds))...)
+		grid.inserted = append(grid.inserted, make([]bool, id+1-len(grid.inserted))...)
+	}
+	if grid.inserted[id] {
+		return
+	}
+	grid.bounds[id] = bounds
+	grid.inserted[id] = true
+	grid.count++
+
+	minCell, maxCell := grid.cellRange(bounds)
+	for x := minCell.x; x <= maxCell.x; x++ {
+		for y := minCell.y; y <= maxCell.y; y++ {
+			cell := gridCell{x: x, y: y}
+			index, ok := grid.cells[cell]
+			if !ok {
+				index = len(grid.ids)
+				grid.cells[cell] = index
+				grid.ids = append(grid.ids, nil)
+			}
+			grid.ids[index] = append(grid.ids[index], id)
+		}
+	}
+}
+
+// Query returns the ids of the boxes intersecting the bounds, in ascending order.
+func (grid *SpatialGrid) Query(bounds physics.AABB) []int {
+	var result []int
+	minCell, maxCell := grid.cellRange(bounds)
+	for x := minCell.x; x <= maxCell.x; x++ {
+		for y := minCell.y; y <= maxCell.y; y++ {
+			cell := gridCell{x: x, y: y}
+			index, ok := grid.cells[cell]
+			if !ok {
+				continue
+			}
+			for _, id := range grid.ids[index] {
+				other := grid.bounds[id]
+				// A box spanning several cells is reported by the first cell shared with the bounds only
+				if bounds.Intersects(other) && grid.firstSharedCell(bounds, other) == cell {
+					result = append(result, id)
+				}
+			}
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+// Pairs returns the pairs of the intersecting boxes, the lower id first, sorted by the first
+// and then by the second id: the same order as the nested loop over all the boxes.
+func (grid *SpatialGrid) Pairs() [][2]int {
+	var pairs [][2]int
+	for cell, index := range grid.cells {
+		ids := grid.ids[index]
+		for i := 0; i < len(ids); i++ {
+			a := grid.bounds[ids[i]]
+			for j := i + 1; j < len(ids); j++ {
+				b := grid.bounds[ids[j]]
+				// A pair sharing several cells is reported by the first of them only
+				if !a.Intersects(b) || grid.firstSharedCell(a, b) != cell {
+					continue
+				}
+				pairs = append(pairs, [2]int{min(ids[i], ids[j]), max(ids[i], ids[j])})
+			}
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+	return pairs
+}
+
+func (grid *SpatialGrid) cell(point physics.Vector2) gridCell {
+	return gridCell{
+		x: int(math.Floor(point.X / grid.cellSize)),
+		y: int(math.Floor(point.Y / grid.cellSize)),
+	}
+}
+
+func (grid *SpatialGrid) cellRange(bounds physics.AABB) (gridCell, gridCell) {
+	return grid.cell(bounds.Min), grid.cell(bounds.Max)
+}
+
+// firstSharedCell returns the cell of the intersection's min corner, the lowest cell both boxes overlap.
+func (grid *SpatialGrid) firstSharedCell(a, b physics.AABB) gridCell {
+	return grid.cell(physics.Vector2{X: math.Max(a.Min.X, b.Min.X), Y: math.Max(a.Min.Y, b.Min.Y)})
+}
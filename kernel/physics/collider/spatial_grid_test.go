@@ -0,0 +1,62 @@
+package collider
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func box(minX, minY, maxX, maxY float64) physics.AABB {
+	return physics.AABB{Min: physics.Vector2{X: minX, Y: minY}, Max: physics.Vector2{X: maxX, Y: maxY}}
+}
+
+func TestSpatialGrid_Query(t *testing.T) {
+	grid := NewSpatialGrid(10)
+	grid.Insert(3, box(0, 0, 5, 5))
+	grid.Insert(1, box(8, 8, 25, 12)) // Spans several cells
+	grid.Insert(2, box(100, 100, 105, 105))
+	grid.Insert(4, box(-15, -15, -5, -5))
+
+	assert.Equal(t, 4, grid.Len())
+	assert.Equal(t, []int{1, 3}, grid.Query(box(4, 4, 9, 9)))
+	assert.Equal(t, []int{1}, grid.Query(box(20, 10, 21, 11)))
+	assert.Equal(t, []int{4}, grid.Query(box(-6, -6, -1, -1)))
+	// Sharing a cell is not enough, the boxes must intersect
+	assert.Nil(t, grid.Query(box(6, 0, 7, 1)))
+
+	grid.Clear()
+	assert.Equal(t, 0, grid.Len())
+	assert.Nil(t, grid.Query(box(4, 4, 9, 9)))
+}
+
+func TestSpatialGrid_Pairs(t *testing.T) {
+	grid := NewSpatialGrid(10)
+	grid.Insert(0, box(0, 0, 15, 15))
+	grid.Insert(1, box(12, 12, 30, 30))
+	grid.Insert(2, box(2, 2, 4, 4))
+	grid.Insert(3, box(28, 28, 35, 35))
+	grid.Insert(4, box(16, 0, 18, 2)) // Shares a cell with 0 without touching it
+
+	assert.Equal(t, [][2]int{{0, 1}, {0, 2}, {1, 3}}, grid.Pairs())
+}
+
+func TestSpatialGrid_MatchesPairwise(t *testing.T) {
+	grid := NewSpatialGrid(7)
+	var boxes []physics.AABB
+	for i := 0; i < 50; i++ {
+		x, y := float64(i*37%100), float64(i*53%100)
+		boxes = append(boxes, box(x, y, x+float64(i%9), y+float64(i%5)))
+		grid.Insert(i, boxes[i])
+	}
+
+	var expected [][2]int
+	for i := range boxes {
+		for j := i + 1; j < len(boxes); j++ {
+			if boxes[i].Intersects(boxes[j]) {
+				expected = append(expected, [2]int{i, j})
+			}
+		}
+	}
+	assert.Equal(t, expected, grid.Pairs())
+}
@@ -63,6 +63,8 @@ func (square *SquareCollider) CollidesWith(other Collider) bool {
 		return squareCollidesWithCircle(*square, *other)
 	case *PolygonCollider:
 		return squareCollidesWithPolygon(*square, *other)
+	case *CapsuleCollider:
+		return other.CollidesWith(square)
 	default:
 		return false
 	}
@@ -99,6 +101,12 @@ func (square *SquareCollider) Polygon() physics.Polygon {
 	return polygon
 }
 
+func (square *SquareCollider) Bounds() physics.AABB {
+	absolute := square.Absolute()
+	minX, minY, maxX, maxY := absolute.Bounds()
+	return physics.AABB{Min: physics.Vector2{X: minX, Y: minY}, Max: physics.Vector2{X: maxX, Y: maxY}}
+}
+
 func (square *SquareCollider) Serialize() map[string]interface{} {
 	return map[string]interface{}{
 		"type":    "square",
@@ -132,10 +140,8 @@ func squareCollidesWithSquare(box1 SquareCollider, box2 SquareCollider) bool {
 		return true
 	}
 
-	// If one of the boxes is rotated, use polygon intersection
-	polygon1 := box1.Absolute()
-	polygon2 := box2.Absolute()
-	return polygon1.Intersects(polygon2)
+	// If one of the boxes is rotated, use the separating axis test
+	return satPolygonCollidesWithPolygon(box1.Absolute(), box2.Absolute())
 }
 
 func squareCollidesWithCircle(square SquareCollider, circle CircleCollider) bool {
@@ -153,6 +159,5 @@ func squareCollidesWithCircle(square SquareCollider, circle CircleCollider) bool
 }
 
 func squareCollidesWithPolygon(square SquareCollider, polygon PolygonCollider) bool {
-	polygon1 := square.Absolute()
-	return polygon1.Intersects(polygon.Absolute())
+	return satPolygonCollidesWithPolygon(square.Absolute(), polygon.Absolute())
 }
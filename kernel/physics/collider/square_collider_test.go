@@ -198,6 +198,18 @@ func TestSquareCollider_Polygon(t *testing.T) {
 	}
 }
 
+func TestSquareCollider_Bounds(t *testing.T) {
+	square := NewSquareCollider(physics.Vector2{X: 10, Y: 10}, 0, physics.Size{Width: 4, Height: 2})
+	assert.Equal(t, physics.AABB{Min: physics.Vector2{X: 8, Y: 9}, Max: physics.Vector2{X: 12, Y: 11}}, square.Bounds())
+
+	square = NewSquareCollider(physics.Vector2{X: 0, Y: 0}, math.Pi/4, physics.Size{Width: 2, Height: 2})
+	bounds := square.Bounds()
+	assert.InDelta(t, -math.Sqrt2, bounds.Min.X, 1e-9)
+	assert.InDelta(t, -math.Sqrt2, bounds.Min.Y, 1e-9)
+	assert.InDelta(t, math.Sqrt2, bounds.Max.X, 1e-9)
+	assert.InDelta(t, math.Sqrt2, bounds.Max.Y, 1e-9)
+}
+
 func TestSquareCollider_Serialize(t *testing.T) {
 	square_collider := NewSquareCollider(
 		physics.Vector2{X: 5, Y: 10},
@@ -311,6 +323,20 @@ func TestSquareCollider_CollidesWithSquare(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			description: "Rotated bars crossing, no corner inside the other bar",
+			square1: SquareCollider{
+				position: physics.Vector2{X: 0, Y: 0},
+				rotation: math.Pi / 2,
+				size:     physics.Size{Width: 6, Height: 2},
+			},
+			square2: SquareCollider{
+				position: physics.Vector2{X: 0, Y: 0},
+				rotation: 0,
+				size:     physics.Size{Width: 6, Height: 2},
+			},
+			expected: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -400,6 +426,12 @@ func TestSquareCollider_CollidesWithCircle(t *testing.T) {
 }
 
 func TestSquareCollider_CollidesWithPolygon(t *testing.T) {
+	polygonTriangle := physics.Polygon{Vertices: []physics.Vector2{
+		{X: 0, Y: -2},
+		{X: 2, Y: 2},
+		{X: -2, Y: 2},
+	}}
+
 	var tests = []struct {
 		description string
 		square      SquareCollider
@@ -427,11 +459,53 @@ func TestSquareCollider_CollidesWithPolygon(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			description: "Square inside a polygon",
+			square: SquareCollider{
+				position: physics.Vector2{X: 0, Y: 0},
+				rotation: 0,
+				size:     physics.Size{Width: 1, Height: 1},
+			},
+			polygon: PolygonCollider{
+				position: physics.Vector2{X: 0, Y: 0},
+				rotation: 0,
+				polygon:  polygonTriangle,
+			},
+			expected: true,
+		},
+		{
+			description: "Square overlapping a polygon corner",
+			square: SquareCollider{
+				position: physics.Vector2{X: 2.5, Y: 2.5},
+				rotation: 0,
+				size:     physics.Size{Width: 2, Height: 2},
+			},
+			polygon: PolygonCollider{
+				position: physics.Vector2{X: 0, Y: 0},
+				rotation: 0,
+				polygon:  polygonTriangle,
+			},
+			expected: true,
+		},
+		{
+			description: "Square separated from a polygon by its slanted edge",
+			square: SquareCollider{
+				position: physics.Vector2{X: 2, Y: -1},
+				rotation: 0,
+				size:     physics.Size{Width: 1, Height: 1},
+			},
+			polygon: PolygonCollider{
+				position: physics.Vector2{X: 0, Y: 0},
+				rotation: 0,
+				polygon:  polygonTriangle,
+			},
+			expected: false,
+		},
 	}
 
 	for _, test := range tests {
 		result := test.square.CollidesWith(&test.polygon)
-		assert.Equal(t, test.expected, result)
+		assert.Equal(t, test.expected, result, test.description)
 	}
 }
 
@@ -0,0 +1,115 @@
+package collider
+
+import (
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// Continuous collision detection helpers.
+// A fast circle can pass through a thin collider between two steps without ever overlapping it,
+// the sweep tests the whole path of the step instead of its end position.
+//
+// Simplification:
+//   - The circle moves along a straight line within the step, the target stays in place.
+//   - The polygons are assumed to be convex, as in the SAT checks.
+
+// SweepCircle moves the circle of the radius from the start to the end and returns the fraction
+// of the path, 0-1, at which it first touches the target. A circle touching the target already at
+// the start hits at 0.
+func SweepCircle(start, end physics.Vector2, radius float64, target Collider) (float64, bool) {
+	switch target := target.(type) {
+	case *CircleCollider:
+		return sweepCircleCircle(start, end, target.position, radius+target.radius)
+	case *SquareCollider:
+		return sweepCirclePolygon(start, end, radius, target.Absolute())
+	case *PolygonCollider:
+		return sweepCirclePolygon(start, end, radius, target.Absolute())
+	case *CapsuleCollider:
+		return sweepCircleCapsule(start, end, radius, *target)
+	default:
+		return 0, false
+	}
+}
+
+// SweptBounds returns the bounding box of the circle's whole path, for the broad-phase queries.
+func SweptBounds(start, end physics.Vector2, radius float64) physics.AABB {
+	return physics.AABB{
+		Min: physics.Vector2{X: math.Min(start.X, end.X) - radius, Y: math.Min(start.Y, end.Y) - radius},
+		Max: physics.Vector2{X: math.Max(start.X, end.X) + radius, Y: math.Max(start.Y, end.Y) + radius},
+	}
+}
+
+// sweepCircleCircle solves |start + t * path - center| = radius for the first t.
+func sweepCircleCircle(start, end, center physics.Vector2, radius float64) (float64, bool) {
+	offset := start.Subtract(center)
+	c := offset.Dot(offset) - radius*radius
+	if c <= 0 {
+		return 0, true
+	}
+
+	path := end.Subtract(start)
+	a := path.Dot(path)
+	if a == 0 {
+		return 0, false
+	}
+	b := 2 * offset.Dot(path)
+	discriminant := b*b - 4*a*c
+	if discriminant < 0 {
+		return 0, false
+	}
+	t := (-b - math.Sqrt(discriminant)) / (2 * a)
+	if t < 0 || t > 1 {
+		return 0, false
+	}
+	return t, true
+}
+
+// sweepCirclePolygon casts the circle center against the polygon grown by the radius: the edges
+// moved by the radius to both sides and the circles at the vertices. Coming from the outside, the
+// first hit is always on the outer boundary.
+func sweepCirclePolygon(start, end physics.Vector2, radius float64, polygon physics.Polygon) (float64, bool) {
+	if len(polygon.Vertices) < 3 {
+		return 0, false
+	}
+	if satPolygonCollidesWithCircle(polygon, start, radius) {
+		return 0, true
+	}
+
+	first, hit := math.Inf(1), false
+	for _, vertex := range polygon.Vertices {
+		if t, ok := sweepCircleCircle(start, end, vertex, radius); ok && t < first {
+			first, hit = t, true
+		}
+	}
+	for _, edge := range polygon.Edges() {
+		direction := edge.End.Subtract(edge.Start)
+		normal := physics.Vector2{X: -direction.Y, Y: direction.X}
+		normal = normal.Normalize()
+		for _, side := range []float64{radius, -radius} {
+			shift := normal.Multiply(side)
+			if t, ok := segmentIntersection(start, end, edge.Start.Add(shift), edge.End.Add(shift)); ok && t < first {
+				first, hit = t, true
+			}
+		}
+	}
+	return first, hit
+}
+
+// segmentIntersection returns the fraction of the first segment at which it crosses the second one,
+// the parallel segments never cross.
+func segmentIntersection(start, end, otherStart, otherEnd physics.Vector2) (float64, bool) {
+	path := end.Subtract(start)
+	other := otherEnd.Subtract(otherStart)
+	denominator := path.Cross(other)
+	if denominator == 0 {
+		return 0, false
+	}
+	offset := otherStart.Subtract(start)
+	t := offset.Cross(other) / denominator
+	u := offset.Cross(path) / denominator
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return 0, false
+	}
+	return t, true
+}
@@ -0,0 +1,68 @@
+package collider
+
+import (
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSweepCircle_Circle(t *testing.T) {
+	target := NewCircleCollider(physics.Vector2{X: 50, Y: 0}, 10)
+
+	// Passes through the target within the step
+	fraction, ok := SweepCircle(physics.Vector2{X: 0, Y: 0}, physics.Vector2{X: 100, Y: 0}, 2, target)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.38, fraction, 1e-9)
+
+	// Grazes the target
+	fraction, ok = SweepCircle(physics.Vector2{X: 0, Y: 12}, physics.Vector2{X: 100, Y: 12}, 2, target)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.5, fraction, 1e-6)
+
+	// Misses, stops short or moves away
+	_, ok = SweepCircle(physics.Vector2{X: 0, Y: 13}, physics.Vector2{X: 100, Y: 13}, 2, target)
+	assert.False(t, ok)
+	_, ok = SweepCircle(physics.Vector2{X: 0, Y: 0}, physics.Vector2{X: 30, Y: 0}, 2, target)
+	assert.False(t, ok)
+	_, ok = SweepCircle(physics.Vector2{X: 30, Y: 0}, physics.Vector2{X: 0, Y: 0}, 2, target)
+	assert.False(t, ok)
+
+	// Touching at the start
+	fraction, ok = SweepCircle(physics.Vector2{X: 45, Y: 0}, physics.Vector2{X: 45, Y: 0}, 2, target)
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, fraction)
+}
+
+func TestSweepCircle_Polygon(t *testing.T) {
+	// A thin wall the circle would tunnel through between the start and the end
+	wall := NewSquareCollider(physics.Vector2{X: 50, Y: 0}, 0, physics.Size{Width: 2, Height: 40})
+	fraction, ok := SweepCircle(physics.Vector2{X: 0, Y: 0}, physics.Vector2{X: 100, Y: 0}, 1, wall)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.48, fraction, 1e-9)
+	assert.False(t, NewCircleCollider(physics.Vector2{X: 100, Y: 0}, 1).CollidesWith(wall))
+
+	// Around the corner, the vertex circles
+	fraction, ok = SweepCircle(physics.Vector2{X: 0, Y: 20.5}, physics.Vector2{X: 100, Y: 20.5}, 1, wall)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.49-math.Sqrt(0.75)/100, fraction, 1e-9)
+	_, ok = SweepCircle(physics.Vector2{X: 0, Y: 22}, physics.Vector2{X: 100, Y: 22}, 1, wall)
+	assert.False(t, ok)
+
+	// Rotated polygon
+	diamond := NewPolygonCollider(physics.Vector2{X: 50, Y: 50}, math.Pi/4, physics.Polygon{Vertices: []physics.Vector2{
+		{X: -10, Y: -10}, {X: 10, Y: -10}, {X: 10, Y: 10}, {X: -10, Y: 10},
+	}})
+	fraction, ok = SweepCircle(physics.Vector2{X: 0, Y: 50}, physics.Vector2{X: 100, Y: 50}, 0, diamond)
+	assert.True(t, ok)
+	assert.InDelta(t, (50-10*math.Sqrt2)/100, fraction, 1e-6)
+
+	_, ok = SweepCircle(physics.Vector2{X: 0, Y: 0}, physics.Vector2{X: 100, Y: 0}, 1, &MockCollider{})
+	assert.False(t, ok)
+}
+
+func TestSweptBounds(t *testing.T) {
+	assert.Equal(t, physics.AABB{Min: physics.Vector2{X: -2, Y: 3}, Max: physics.Vector2{X: 12, Y: 22}},
+		SweptBounds(physics.Vector2{X: 10, Y: 5}, physics.Vector2{X: 0, Y: 20}, 2))
+}
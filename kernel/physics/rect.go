@@ -0,0 +1,15 @@
+package physics
+
+// Rect is a rectangle given by its top left corner and size.
+type Rect struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+// Contains checks if the point lies inside the rectangle, the boundary included.
+func (rect *Rect) Contains(point Vector2) bool {
+	return point.X >= rect.X && point.X <= rect.X+rect.Width &&
+		point.Y >= rect.Y && point.Y <= rect.Y+rect.Height
+}
@@ -0,0 +1,28 @@
+package physics
+
+import "testing"
+
+func TestRect_Contains(t *testing.T) {
+	rect := Rect{X: 10, Y: 20, Width: 30, Height: 40}
+
+	var tests = []struct {
+		description string
+		point       Vector2
+		expected    bool
+	}{
+		{"Inside", Vector2{X: 20, Y: 30}, true},
+		{"Top left corner", Vector2{X: 10, Y: 20}, true},
+		{"Bottom right corner", Vector2{X: 40, Y: 60}, true},
+		{"On the edge", Vector2{X: 25, Y: 60}, true},
+		{"Left", Vector2{X: 9.9, Y: 30}, false},
+		{"Right", Vector2{X: 40.1, Y: 30}, false},
+		{"Above", Vector2{X: 20, Y: 19.9}, false},
+		{"Below", Vector2{X: 20, Y: 60.1}, false},
+	}
+
+	for _, test := range tests {
+		if result := rect.Contains(test.point); result != test.expected {
+			t.Errorf("%s: Contains(%v) = %v; expected %v", test.description, test.point, result, test.expected)
+		}
+	}
+}
@@ -1,6 +1,27 @@
 package physics
 
+import "encoding/json"
+
 type Size struct {
 	Width  float64
 	Height float64
 }
+
+type sizeJSON struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// MarshalJSON encodes the size as {"width": …, "height": …}, the keys used by the Serialize methods.
+func (size Size) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sizeJSON(size))
+}
+
+func (size *Size) UnmarshalJSON(data []byte) error {
+	var decoded sizeJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*size = Size(decoded)
+	return nil
+}
@@ -0,0 +1,40 @@
+package physics
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSize_JSON(t *testing.T) {
+	tests := []struct {
+		size     Size
+		expected string
+	}{
+		{Size{Width: 0, Height: 0}, `{"width":0,"height":0}`},
+		{Size{Width: -1024, Height: -768}, `{"width":-1024,"height":-768}`},
+		{Size{Width: 1024.5, Height: 0.75}, `{"width":1024.5,"height":0.75}`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.size)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(data) != test.expected {
+			t.Errorf("Expected %v, got %v", test.expected, string(data))
+		}
+
+		var result Size
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result != test.size {
+			t.Errorf("Expected %v, got %v", test.size, result)
+		}
+	}
+
+	var result Size
+	if err := json.Unmarshal([]byte(`[]`), &result); err == nil {
+		t.Errorf("Expected an error for an invalid size")
+	}
+}
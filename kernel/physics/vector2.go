@@ -1,6 +1,10 @@
 package physics
 
-import "math"
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
 
 type Vector2 struct {
 	X float64
@@ -23,8 +27,8 @@ func (vector *Vector2) Dot(other Vector2) float64 {
 	return vector.X*other.X + vector.Y*other.Y
 }
 
-// Cross returns the cross product of the vector and another vector.
-// The cross product of two vectors is a vector that is perpendicular to both of them.
+// Cross returns the 2D cross product of the vector and another vector, the Z component of the 3D one.
+// It is positive when the other vector is counterclockwise from the vector and zero when they are parallel.
 func (vector *Vector2) Cross(other Vector2) float64 {
 	return vector.X*other.Y - vector.Y*other.X
 }
@@ -34,6 +38,33 @@ func (vector *Vector2) Magnitude() float64 {
 	return math.Sqrt(vector.X*vector.X + vector.Y*vector.Y)
 }
 
+// Angle returns the angle of the vector from the positive X axis, in radians within [-Pi, Pi].
+// The angle of a zero vector is 0.
+func (vector *Vector2) Angle() float64 {
+	return math.Atan2(vector.Y, vector.X)
+}
+
+// ToAngle returns the heading of the vector in radians, the same as Angle. It is the inverse of FromAngle.
+func (vector *Vector2) ToAngle() float64 {
+	return vector.Angle()
+}
+
+// FromAngle returns the unit vector heading in the direction of the angle in radians.
+func FromAngle(angle float64) Vector2 {
+	return Vector2{X: math.Cos(angle), Y: math.Sin(angle)}
+}
+
+// AngleTo returns the signed angle rotating the vector onto the other vector, in radians within [-Pi, Pi].
+// The angle is 0 when either of the vectors is a zero vector.
+func (vector *Vector2) AngleTo(other Vector2) float64 {
+	return math.Atan2(vector.Cross(other), vector.Dot(other))
+}
+
+// Lerp linearly interpolates between the vector, at t 0, and the other vector, at t 1.
+func (vector *Vector2) Lerp(other Vector2, t float64) Vector2 {
+	return Vector2{vector.X + (other.X-vector.X)*t, vector.Y + (other.Y-vector.Y)*t}
+}
+
 // Clamp limits the magnitude of the vector to the given maximum length.
 func (vector *Vector2) Clamp(maxLength float64) Vector2 {
 	magnitude := vector.Magnitude()
@@ -55,6 +86,11 @@ func (vector *Vector2) Multiply(scalar float64) Vector2 {
 	return Vector2{vector.X * scalar, vector.Y * scalar}
 }
 
+// Scale returns the vector multiplied by the factor, same as Multiply.
+func (vector *Vector2) Scale(factor float64) Vector2 {
+	return vector.Multiply(factor)
+}
+
 func (vector *Vector2) Distance(other Vector2) float64 {
 	return math.Sqrt(math.Pow(vector.X-other.X, 2) + math.Pow(vector.Y-other.Y, 2))
 }
@@ -81,3 +117,38 @@ func (vector *Vector2) RotateAround(origin Vector2, radians float64) Vector2 {
 		Y: x*sin + y*cos + origin.Y,
 	}
 }
+
+// WithX returns a copy of the vector with the given X.
+// The value receiver allows chaining, e.g. vector.WithX(1).WithY(2).
+func (vector Vector2) WithX(x float64) Vector2 {
+	return Vector2{x, vector.Y}
+}
+
+// WithY returns a copy of the vector with the given Y.
+func (vector Vector2) WithY(y float64) Vector2 {
+	return Vector2{vector.X, y}
+}
+
+// String returns the vector in the "(x, y)" format.
+func (vector Vector2) String() string {
+	return fmt.Sprintf("(%v, %v)", vector.X, vector.Y)
+}
+
+type vector2JSON struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// MarshalJSON encodes the vector as {"x": …, "y": …}, the keys used by the Serialize methods.
+func (vector Vector2) MarshalJSON() ([]byte, error) {
+	return json.Marshal(vector2JSON(vector))
+}
+
+func (vector *Vector2) UnmarshalJSON(data []byte) error {
+	var decoded vector2JSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*vector = Vector2(decoded)
+	return nil
+}
@@ -1,6 +1,7 @@
 package physics
 
 import (
+	"encoding/json"
 	"math"
 	"testing"
 
@@ -15,6 +16,7 @@ func TestVector2_Normalize(t *testing.T) {
 	}{
 		{Vector2{X: 3, Y: 4}, Vector2{X: 0.6, Y: 0.8}},
 		{Vector2{X: 0, Y: 0}, Vector2{X: 0, Y: 0}},
+		{Vector2{X: 0, Y: 1}, Vector2{X: 0, Y: 1}},
 		{Vector2{X: -3, Y: -4}, Vector2{X: -0.6, Y: -0.8}},
 	}
 
@@ -34,6 +36,7 @@ func TestVector2_Dot(t *testing.T) {
 	}{
 		{Vector2{X: 3, Y: 4}, Vector2{X: 5, Y: 12}, 63},
 		{Vector2{X: 0, Y: 0}, Vector2{X: 0, Y: 0}, 0},
+		{Vector2{X: 1, Y: 0}, Vector2{X: 0, Y: 1}, 0},
 		{Vector2{X: -3, Y: -4}, Vector2{X: -5, Y: -12}, 63},
 	}
 
@@ -54,6 +57,10 @@ func TestVector2_Cross(t *testing.T) {
 		{Vector2{X: 3, Y: 4}, Vector2{X: 5, Y: 12}, 16},
 		{Vector2{X: 0, Y: 0}, Vector2{X: 0, Y: 0}, 0},
 		{Vector2{X: -3, Y: -4}, Vector2{X: -5, Y: -12}, 16},
+		{Vector2{X: 1, Y: 0}, Vector2{X: 0, Y: 1}, 1},
+		{Vector2{X: 0, Y: 1}, Vector2{X: 1, Y: 0}, -1},
+		{Vector2{X: 2, Y: 3}, Vector2{X: 4, Y: 6}, 0},
+		{Vector2{X: 2, Y: 3}, Vector2{X: -2, Y: -3}, 0},
 	}
 
 	for _, test := range tests {
@@ -71,6 +78,7 @@ func TestVector2_Magnitude(t *testing.T) {
 	}{
 		{Vector2{X: 3, Y: 4}, 5},
 		{Vector2{X: 0, Y: 0}, 0},
+		{Vector2{X: 0, Y: -1}, 1},
 		{Vector2{X: -3, Y: -4}, 5},
 	}
 
@@ -214,3 +222,208 @@ func TestVector2_RotateAround(t *testing.T) {
 		}
 	}
 }
+
+func TestVector2_WithX(t *testing.T) {
+	vector := Vector2{X: 3, Y: 4}
+	result := vector.WithX(5)
+
+	if result != (Vector2{X: 5, Y: 4}) {
+		t.Errorf("Expected %v, got %v", Vector2{X: 5, Y: 4}, result)
+	}
+	if vector != (Vector2{X: 3, Y: 4}) {
+		t.Errorf("Expected the original to be unchanged, got %v", vector)
+	}
+}
+
+func TestVector2_WithY(t *testing.T) {
+	vector := Vector2{X: 3, Y: 4}
+	result := vector.WithY(5)
+
+	if result != (Vector2{X: 3, Y: 5}) {
+		t.Errorf("Expected %v, got %v", Vector2{X: 3, Y: 5}, result)
+	}
+	if vector != (Vector2{X: 3, Y: 4}) {
+		t.Errorf("Expected the original to be unchanged, got %v", vector)
+	}
+}
+
+func TestVector2_WithX_WithY_Chained(t *testing.T) {
+	vector := Vector2{X: 3, Y: 4}
+	result := vector.WithX(1).WithY(2)
+
+	if result != (Vector2{X: 1, Y: 2}) {
+		t.Errorf("Expected %v, got %v", Vector2{X: 1, Y: 2}, result)
+	}
+}
+
+func TestVector2_Angle(t *testing.T) {
+	tests := []struct {
+		vector   Vector2
+		expected float64
+	}{
+		{Vector2{X: 0, Y: 0}, 0},
+		{Vector2{X: 1, Y: 0}, 0},
+		{Vector2{X: 0, Y: 1}, math.Pi / 2},
+		{Vector2{X: -3, Y: 3}, 3 * math.Pi / 4},
+		{Vector2{X: -1, Y: -1}, -3 * math.Pi / 4},
+	}
+
+	for _, test := range tests {
+		result := test.vector.Angle()
+		if !utils.AlmostEqual(result, test.expected) {
+			t.Errorf("Expected %v, got %v", test.expected, result)
+		}
+	}
+}
+
+func TestFromAngle(t *testing.T) {
+	tests := []struct {
+		angle    float64
+		expected Vector2
+	}{
+		{0, Vector2{X: 1, Y: 0}},
+		{math.Pi / 2, Vector2{X: 0, Y: 1}},
+		{math.Pi, Vector2{X: -1, Y: 0}},
+		{-math.Pi / 4, Vector2{X: math.Sqrt2 / 2, Y: -math.Sqrt2 / 2}},
+	}
+
+	for _, test := range tests {
+		result := FromAngle(test.angle)
+		if !utils.AlmostEqualVector2(result, test.expected) {
+			t.Errorf("Expected %v, got %v", test.expected, result)
+		}
+	}
+}
+
+func TestVector2_ToAngle(t *testing.T) {
+	tests := []Vector2{
+		{X: 1, Y: 0},
+		{X: 0, Y: 3},
+		{X: -2, Y: 2},
+		{X: 3, Y: -4},
+	}
+
+	for _, vector := range tests {
+		if vector.ToAngle() != vector.Angle() {
+			t.Errorf("Expected %v, got %v", vector.Angle(), vector.ToAngle())
+		}
+		result := FromAngle(vector.ToAngle())
+		if expected := vector.Normalize(); !utils.AlmostEqualVector2(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	}
+}
+
+func TestVector2_AngleTo(t *testing.T) {
+	tests := []struct {
+		vector   Vector2
+		other    Vector2
+		expected float64
+	}{
+		{Vector2{X: 0, Y: 0}, Vector2{X: 1, Y: 0}, 0},
+		{Vector2{X: 1, Y: 0}, Vector2{X: 0, Y: 1}, math.Pi / 2},
+		{Vector2{X: 0, Y: 1}, Vector2{X: 1, Y: 0}, -math.Pi / 2},
+		{Vector2{X: 2, Y: 2}, Vector2{X: -5, Y: 0}, 3 * math.Pi / 4},
+		{Vector2{X: 3, Y: 0}, Vector2{X: 6, Y: 0}, 0},
+	}
+
+	for _, test := range tests {
+		result := test.vector.AngleTo(test.other)
+		if !utils.AlmostEqual(result, test.expected) {
+			t.Errorf("Expected %v, got %v", test.expected, result)
+		}
+	}
+}
+
+func TestVector2_Lerp(t *testing.T) {
+	tests := []struct {
+		vector   Vector2
+		other    Vector2
+		t        float64
+		expected Vector2
+	}{
+		{Vector2{X: 0, Y: 0}, Vector2{X: 0, Y: 0}, 0.5, Vector2{X: 0, Y: 0}},
+		{Vector2{X: 1, Y: 0}, Vector2{X: 0, Y: 1}, 0.5, Vector2{X: 0.5, Y: 0.5}},
+		{Vector2{X: 2, Y: 4}, Vector2{X: 6, Y: -4}, 0, Vector2{X: 2, Y: 4}},
+		{Vector2{X: 2, Y: 4}, Vector2{X: 6, Y: -4}, 1, Vector2{X: 6, Y: -4}},
+		{Vector2{X: 2, Y: 4}, Vector2{X: 6, Y: -4}, 0.25, Vector2{X: 3, Y: 2}},
+	}
+
+	for _, test := range tests {
+		result := test.vector.Lerp(test.other, test.t)
+		if result != test.expected {
+			t.Errorf("Expected %v, got %v", test.expected, result)
+		}
+	}
+}
+
+func TestVector2_Scale(t *testing.T) {
+	tests := []struct {
+		vector   Vector2
+		factor   float64
+		expected Vector2
+	}{
+		{Vector2{X: 0, Y: 0}, 3, Vector2{X: 0, Y: 0}},
+		{Vector2{X: 1, Y: 0}, 3, Vector2{X: 3, Y: 0}},
+		{Vector2{X: -3, Y: 4}, 0.5, Vector2{X: -1.5, Y: 2}},
+	}
+
+	for _, test := range tests {
+		result := test.vector.Scale(test.factor)
+		if result != test.expected {
+			t.Errorf("Expected %v, got %v", test.expected, result)
+		}
+	}
+}
+
+func TestVector2_String(t *testing.T) {
+	tests := []struct {
+		vector   Vector2
+		expected string
+	}{
+		{Vector2{X: 0, Y: 0}, "(0, 0)"},
+		{Vector2{X: -3, Y: 4}, "(-3, 4)"},
+		{Vector2{X: 1.5, Y: -0.25}, "(1.5, -0.25)"},
+	}
+
+	for _, test := range tests {
+		result := test.vector.String()
+		if result != test.expected {
+			t.Errorf("Expected %v, got %v", test.expected, result)
+		}
+	}
+}
+
+func TestVector2_JSON(t *testing.T) {
+	tests := []struct {
+		vector   Vector2
+		expected string
+	}{
+		{Vector2{X: 0, Y: 0}, `{"x":0,"y":0}`},
+		{Vector2{X: -3, Y: -4}, `{"x":-3,"y":-4}`},
+		{Vector2{X: 1.5, Y: -0.25}, `{"x":1.5,"y":-0.25}`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.vector)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(data) != test.expected {
+			t.Errorf("Expected %v, got %v", test.expected, string(data))
+		}
+
+		var result Vector2
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result != test.vector {
+			t.Errorf("Expected %v, got %v", test.vector, result)
+		}
+	}
+
+	var result Vector2
+	if err := json.Unmarshal([]byte(`{"x":"invalid"}`), &result); err == nil {
+		t.Errorf("Expected an error for an invalid vector")
+	}
+}
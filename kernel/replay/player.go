@@ -0,0 +1,78 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+)
+
+var ErrReplayEnded = errors.New("replay has ended")
+
+// ErrDesync is returned when the played back state differs from the recorded one,
+// e.g. the simulation changed since the recording.
+type ErrDesync struct {
+	Frame    int
+	Expected uint64
+	Actual   uint64
+}
+
+func (err ErrDesync) Error() string {
+	return fmt.Sprintf("replay desync at frame %d: state hash %d, expected %d", err.Frame, err.Actual, err.Expected)
+}
+
+// Player plays the replay back frame by frame.
+type Player struct {
+	replay *Replay
+	game   *game.Game
+	frame  int
+}
+
+func NewPlayer(replay *Replay) (*Player, error) {
+	instance, err := replay.newGame()
+	if err != nil {
+		return nil, err
+	}
+	return &Player{replay: replay, game: instance}, nil
+}
+
+// Game returns the played back game, to be inspected between the steps.
+func (player *Player) Game() *game.Game {
+	return player.game
+}
+
+// Frame returns the number of the frames played.
+func (player *Player) Frame() int {
+	return player.frame
+}
+
+func (player *Player) Done() bool {
+	return player.frame >= len(player.replay.Frames)
+}
+
+// Step plays the next frame, ErrReplayEnded after the last one.
+func (player *Player) Step() error {
+	if player.Done() {
+		return ErrReplayEnded
+	}
+
+	frame := player.replay.Frames[player.frame]
+	if err := update(player.game, frame); err != nil {
+		return err
+	}
+	player.frame++
+	if hash := player.game.StateHash(); hash != frame.StateHash {
+		return ErrDesync{Frame: player.frame - 1, Expected: frame.StateHash, Actual: hash}
+	}
+	return nil
+}
+
+// Run plays all the remaining frames.
+func (player *Player) Run() error {
+	for !player.Done() {
+		if err := player.Step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package replay
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func record(t *testing.T) *Replay {
+	recorder := NewRecorder(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	assert.NoError(t, recorder.SeedAsteroids())
+	assert.NoError(t, recorder.AddSpaceship(game.SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 500}}))
+	assert.NoError(t, recorder.AddSpaceship(game.SpaceshipConfig{Name: "beta", Position: physics.Vector2{X: 900, Y: 500}, Rotation: 3.14}))
+
+	for tick := 0; tick < 120; tick++ {
+		switch {
+		case tick == 0:
+			assert.NoError(t, recorder.Command(game.Command{Ship: "alpha", Action: game.CommandSetEngineThrust, Args: []float64{100, 0, 20}}))
+			assert.NoError(t, recorder.Command(game.Command{Ship: "beta", Action: game.CommandSetEngineThrust, Args: []float64{60, 10, 0}}))
+		case tick%20 == 0:
+			assert.NoError(t, recorder.Command(game.Command{Ship: "alpha", Action: game.CommandFireLaser}))
+			assert.NoError(t, recorder.Command(game.Command{Ship: "beta", Action: game.CommandFireRocket}))
+		}
+		assert.NoError(t, recorder.Update(16))
+	}
+	return recorder.Replay()
+}
+
+func TestPlayer(t *testing.T) {
+	data, err := record(t).Marshal()
+	assert.NoError(t, err)
+	replay, err := Load(data)
+	assert.NoError(t, err)
+
+	player, err := NewPlayer(replay)
+	assert.NoError(t, err)
+	assert.Equal(t, game.Initialized, player.Game().Status())
+
+	// Frame by frame
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, player.Step())
+		assert.Equal(t, i+1, player.Frame())
+		assert.Equal(t, replay.Frames[i].StateHash, player.Game().StateHash())
+	}
+
+	assert.NoError(t, player.Run())
+	assert.True(t, player.Done())
+	assert.Equal(t, 120, player.Frame())
+	assert.ErrorIs(t, player.Step(), ErrReplayEnded)
+}
+
+func TestPlayer_Desync(t *testing.T) {
+	replay := record(t)
+	replay.Frames[5].StateHash++
+
+	player, err := NewPlayer(replay)
+	assert.NoError(t, err)
+	err = player.Run()
+	assert.ErrorAs(t, err, &ErrDesync{})
+	assert.Equal(t, 5, err.(ErrDesync).Frame)
+	assert.Equal(t, 6, player.Frame())
+}
+
+func TestNewPlayer_InvalidSetup(t *testing.T) {
+	replay := record(t)
+	replay.Spaceships = append(replay.Spaceships, replay.Spaceships[0])
+
+	_, err := NewPlayer(replay)
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package replay
+
+import (
+	"errors"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// Recorder runs the game and records it. All the changes must go through the recorder,
+// the ones made directly on the game are not replayed.
+type Recorder struct {
+	game    *game.Game
+	replay  Replay
+	pending []game.Command
+}
+
+func NewRecorder(size physics.Size, seed int64) *Recorder {
+	return &Recorder{
+		game: game.NewGame(size, seed),
+		replay: Replay{
+			Version:    Version,
+			Seed:       seed,
+			Size:       size,
+			Spaceships: []game.SpaceshipConfig{},
+			Frames:     []Frame{},
+		},
+	}
+}
+
+// Game returns the recorded game, to be read only.
+func (recorder *Recorder) Game() *game.Game {
+	return recorder.game
+}
+
+// SeedAsteroids seeds the asteroids, see Game.SeedAsteroids. Only before the first update.
+func (recorder *Recorder) SeedAsteroids() error {
+	if err := recorder.checkSetup(); err != nil {
+		return err
+	}
+	recorder.game.SeedAsteroids()
+	recorder.replay.SeedAsteroids = true
+	return nil
+}
+
+// AddSpaceship adds the spaceship, see Game.AddSpaceshipWithConfig. Only before the first update.
+func (recorder *Recorder) AddSpaceship(config game.SpaceshipConfig) error {
+	if err := recorder.checkSetup(); err != nil {
+		return err
+	}
+	if _, err := recorder.game.AddSpaceshipWithConfig(config); err != nil {
+		return err
+	}
+	recorder.replay.Spaceships = append(recorder.replay.Spaceships, config)
+	return nil
+}
+
+func (recorder *Recorder) checkSetup() error {
+	if len(recorder.replay.Frames) > 0 {
+		return errors.New("the game setup is recorded before the first update only")
+	}
+	return nil
+}
+
+// Command queues the command applied before the next update.
+func (recorder *Recorder) Command(command game.Command) error {
+	if err := command.Validate(); err != nil {
+		return err
+	}
+	recorder.pending = append(recorder.pending, command)
+	return nil
+}
+
+// Update applies the queued commands, updates the game and records the frame.
+// The first update starts the game.
+func (recorder *Recorder) Update(deltaTimeMs float64) error {
+	frame := Frame{DeltaTimeMs: deltaTimeMs, Commands: recorder.pending}
+	recorder.pending = nil
+	if err := update(recorder.game, frame); err != nil {
+		return err
+	}
+	frame.StateHash = recorder.game.StateHash()
+	recorder.replay.Frames = append(recorder.replay.Frames, frame)
+	return nil
+}
+
+// Replay returns the recording so far.
+func (recorder *Recorder) Replay() *Replay {
+	replay := recorder.replay
+	replay.Spaceships = append([]game.SpaceshipConfig{}, recorder.replay.Spaceships...)
+	replay.Frames = append([]Frame{}, recorder.replay.Frames...)
+	return &replay
+}
@@ -0,0 +1,39 @@
+package replay
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	recorder := NewRecorder(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	assert.NoError(t, recorder.SeedAsteroids())
+	assert.NoError(t, recorder.AddSpaceship(game.SpaceshipConfig{Name: "alpha", Position: physics.Vector2{X: 100, Y: 100}}))
+	assert.NoError(t, recorder.AddSpaceship(game.SpaceshipConfig{Name: "beta", Position: physics.Vector2{X: 900, Y: 900}}))
+	assert.Error(t, recorder.AddSpaceship(game.SpaceshipConfig{Name: "alpha"}))
+
+	assert.Error(t, recorder.Command(game.Command{Ship: "alpha", Action: "selfDestruct"}))
+	assert.NoError(t, recorder.Command(game.Command{Ship: "alpha", Action: game.CommandFireLaser}))
+	assert.NoError(t, recorder.Update(16))
+	assert.Equal(t, game.Running, recorder.Game().Status())
+	assert.NoError(t, recorder.Update(16))
+
+	// The setup is closed once the game runs
+	assert.Error(t, recorder.SeedAsteroids())
+	assert.Error(t, recorder.AddSpaceship(game.SpaceshipConfig{Name: "gamma"}))
+
+	replay := recorder.Replay()
+	assert.True(t, replay.SeedAsteroids)
+	assert.Len(t, replay.Spaceships, 2)
+	assert.Len(t, replay.Frames, 2)
+	assert.Equal(t, []game.Command{{Ship: "alpha", Action: game.CommandFireLaser}}, replay.Frames[0].Commands)
+	assert.Empty(t, replay.Frames[1].Commands)
+	assert.Equal(t, recorder.Game().StateHash(), replay.Frames[1].StateHash)
+
+	// Unknown spaceship
+	assert.NoError(t, recorder.Command(game.Command{Ship: "gamma", Action: game.CommandFireLaser}))
+	assert.Error(t, recorder.Update(16))
+}
@@ -0,0 +1,81 @@
+// Package replay records the matches and plays them back deterministically, frame by frame.
+// The random draws are reproduced from the recorded seed, the spaceship actions from the recorded
+// commands, and every frame keeps the state hash to detect the desync of the playback.
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+const Version = 1
+
+// Frame is a single Game.Update with the commands applied right before it.
+type Frame struct {
+	DeltaTimeMs float64        `json:"deltaTimeMs"`
+	Commands    []game.Command `json:"commands,omitempty"`
+	StateHash   uint64         `json:"stateHash"` // Game.StateHash after the update
+}
+
+type Replay struct {
+	Version       int                    `json:"version"`
+	Seed          int64                  `json:"seed"`
+	Size          physics.Size           `json:"size"`
+	SeedAsteroids bool                   `json:"seedAsteroids"`
+	Spaceships    []game.SpaceshipConfig `json:"spaceships"`
+	Frames        []Frame                `json:"frames"`
+}
+
+func (replay *Replay) Marshal() ([]byte, error) {
+	return json.Marshal(replay)
+}
+
+// Load decodes the replay file, see Replay.Marshal.
+func Load(data []byte) (*Replay, error) {
+	var replay Replay
+	if err := json.Unmarshal(data, &replay); err != nil {
+		return nil, err
+	}
+	if replay.Version != Version {
+		return nil, fmt.Errorf("unsupported replay version: %d", replay.Version)
+	}
+	return &replay, nil
+}
+
+// newGame creates the game in the recorded initial state.
+func (replay *Replay) newGame() (*game.Game, error) {
+	instance := game.NewGame(replay.Size, replay.Seed)
+	if replay.SeedAsteroids {
+		instance.SeedAsteroids()
+	}
+	for _, config := range replay.Spaceships {
+		if _, err := instance.AddSpaceshipWithConfig(config); err != nil {
+			return nil, err
+		}
+	}
+	return instance, nil
+}
+
+// update applies the frame's commands and updates the game, it is shared by the recording and the playback.
+func update(instance *game.Game, frame Frame) error {
+	if instance.Status() == game.Initialized {
+		instance.Start()
+	}
+
+	actions := make([]game.NamedAction, 0, len(frame.Commands))
+	for _, command := range frame.Commands {
+		action, err := command.NamedAction()
+		if err != nil {
+			return err
+		}
+		actions = append(actions, action)
+	}
+	if err := instance.BatchSpaceshipActions(actions); err != nil {
+		return err
+	}
+	instance.Update(frame.DeltaTimeMs)
+	return nil
+}
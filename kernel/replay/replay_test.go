@@ -0,0 +1,39 @@
+package replay
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplay_Marshal(t *testing.T) {
+	replay := &Replay{
+		Version:       Version,
+		Seed:          1234567890,
+		Size:          physics.Size{Width: 1000, Height: 800},
+		SeedAsteroids: true,
+		Spaceships: []game.SpaceshipConfig{
+			{Name: "alpha", Team: "red", Position: physics.Vector2{X: 100, Y: 100}, Rotation: 1.5},
+		},
+		Frames: []Frame{
+			{DeltaTimeMs: 16, Commands: []game.Command{{Ship: "alpha", Action: game.CommandFireLaser}}, StateHash: 42},
+			{DeltaTimeMs: 16, StateHash: 43},
+		},
+	}
+
+	data, err := replay.Marshal()
+	assert.NoError(t, err)
+	loaded, err := Load(data)
+	assert.NoError(t, err)
+	assert.Equal(t, replay, loaded)
+}
+
+func TestLoad(t *testing.T) {
+	_, err := Load([]byte(`{"version": 2}`))
+	assert.EqualError(t, err, "unsupported replay version: 2")
+
+	_, err = Load([]byte(`invalid`))
+	assert.Error(t, err)
+}
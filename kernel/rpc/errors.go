@@ -0,0 +1,41 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ErrGameNotFound struct {
+	GameID string
+}
+
+func (err ErrGameNotFound) Error() string {
+	return fmt.Sprintf("game not found: %s", err.GameID)
+}
+
+func (err ErrGameNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, err.Error())
+}
+
+// statusError converts the errors of the game to the gRPC status errors, the other ones are kept.
+func statusError(err error) error {
+	var spaceshipNotFound game.ErrSpaceshipNotFound
+	var duplicateName game.ErrDuplicateSpaceshipName
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &spaceshipNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &duplicateName):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		return err
+	}
+}
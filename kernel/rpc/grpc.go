@@ -0,0 +1,15 @@
+package rpc
+
+import (
+	"google.golang.org/grpc"
+	// Registers the gzip compressor, so the clients sending grpc-encoding: gzip are served
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+// NewServer returns the gRPC server serving the service, e.g. by its Serve on a net.Listener. The
+// deadlines, the compression and the rest of the protocol are handled by the gRPC server.
+func NewServer(service *Service, options ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(options...)
+	RegisterOrchestratorServer(server, service)
+	return server
+}
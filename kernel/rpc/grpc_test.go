@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(t *testing.T) (*Service, *grpc.ClientConn, OrchestratorClient) {
+	service := NewService()
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewServer(service)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return service, conn, NewOrchestratorClient(conn)
+}
+
+func TestNewServer(t *testing.T) {
+	service, conn, client := newTestClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateGame(ctx, &CreateGameRequest{Width: 1000, Height: 1000, Seed: 1, TimeLimitMs: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", created.GameId)
+	for _, name := range []string{"alpha", "beta"} {
+		_, err = client.AddSpaceship(ctx, &AddSpaceshipRequest{GameId: created.GameId, Name: name})
+		assert.NoError(t, err)
+	}
+	_, err = client.SubmitAction(ctx, &SubmitActionRequest{GameId: created.GameId, Ship: "alpha", Action: "fireLaser"})
+	assert.NoError(t, err)
+
+	t.Run("StreamState", func(t *testing.T) {
+		stream, err := client.StreamState(ctx, &StreamStateRequest{GameId: created.GameId})
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			service.mutex.Lock()
+			defer service.mutex.Unlock()
+			return len(service.matches[created.GameId].streams) == 1
+		}, time.Second, time.Millisecond)
+		for i := 0; i < 10; i++ {
+			service.Tick(16)
+		}
+
+		var updates []*StateUpdate
+		for {
+			update, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			updates = append(updates, update)
+		}
+		assert.Len(t, updates, 7)
+		assert.Equal(t, uint64(1), updates[0].Tick)
+		assert.Equal(t, "ended", updates[6].Status)
+	})
+
+	t.Run("GetResult", func(t *testing.T) {
+		result, err := client.GetResult(ctx, &GetResultRequest{GameId: created.GameId})
+		assert.NoError(t, err)
+		assert.Equal(t, "ended", result.Status)
+		assert.Len(t, result.Standings, 2)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := client.GetResult(ctx, &GetResultRequest{GameId: "unknown"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "game not found: unknown", status.Convert(err).Message())
+
+		_, err = client.AddSpaceship(ctx, &AddSpaceshipRequest{GameId: created.GameId, Name: ""})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		err = conn.Invoke(ctx, "/spacewars.rpc.Orchestrator/Launch", &GetResultRequest{}, &GetResultResponse{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		waiting, err := client.CreateGame(ctx, &CreateGameRequest{Width: 1000, Height: 1000})
+		assert.NoError(t, err)
+
+		// No tick comes before the deadline
+		deadline, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		stream, err := client.StreamState(deadline, &StreamStateRequest{GameId: waiting.GameId})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Eventually(t, func() bool {
+			service.mutex.Lock()
+			defer service.mutex.Unlock()
+			return len(service.matches[waiting.GameId].streams) == 0
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("Compression", func(t *testing.T) {
+		result, err := client.GetResult(ctx, &GetResultRequest{GameId: created.GameId}, grpc.UseCompressor(gzip.Name))
+		assert.NoError(t, err)
+		assert.Equal(t, "ended", result.Status)
+	})
+}
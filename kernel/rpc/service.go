@@ -0,0 +1,273 @@
+// Package rpc serves the matches over gRPC, so that other services can orchestrate them without
+// linking the kernel. The Orchestrator service of orchestrator.proto creates the games, adds the
+// spaceships, submits their actions as game.Command, streams the state after every tick and reports
+// the result. The host drives the games by calling Service.Tick, like the server package's Tick.
+//
+// The messages and the service stubs are generated from orchestrator.proto by protoc-gen-go and
+// protoc-gen-go-grpc, see generate.go. NewServer serves the Service by the gRPC server.
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StateBufferSize is the number of the state updates buffered per stream, the updates of a stream
+// falling further behind are dropped.
+const StateBufferSize = 64
+
+type match struct {
+	game       *game.Game
+	spectator  *game.SpectatorView
+	spaceships int
+	streams    map[chan *StateUpdate]struct{}
+}
+
+// Service is the Orchestrator service, safe for concurrent use.
+type Service struct {
+	UnimplementedOrchestratorServer
+	mutex   sync.Mutex // Guards the matches, and the games against Tick
+	matches map[string]*match
+	lastID  int
+}
+
+func NewService() *Service {
+	return &Service{matches: map[string]*match{}}
+}
+
+// CreateGame creates the game, it starts with the first Tick once it has two spaceships.
+func (service *Service) CreateGame(ctx context.Context, request *CreateGameRequest) (*CreateGameResponse, error) {
+	if request.Width <= 0 || request.Height <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "width and height must be positive")
+	}
+	if request.TimeLimitMs < 0 {
+		return nil, status.Error(codes.InvalidArgument, "time limit must not be negative")
+	}
+
+	instance := game.NewGame(physics.Size{Width: request.Width, Height: request.Height}, request.Seed, game.WithTimeLimitMs(request.TimeLimitMs))
+	if request.SeedAsteroids {
+		instance.SeedAsteroids()
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.lastID++
+	gameID := strconv.Itoa(service.lastID)
+	service.matches[gameID] = &match{
+		game:      instance,
+		spectator: game.NewSpectatorView(instance),
+		streams:   map[chan *StateUpdate]struct{}{},
+	}
+	return &CreateGameResponse{GameId: gameID}, nil
+}
+
+func (service *Service) AddSpaceship(ctx context.Context, request *AddSpaceshipRequest) (*AddSpaceshipResponse, error) {
+	if request.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "spaceship name must not be empty")
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	match, err := service.match(request.GameId)
+	if err != nil {
+		return nil, err
+	}
+	if match.game.Status() == game.Ended {
+		return nil, status.Error(codes.FailedPrecondition, "game has ended")
+	}
+	spaceship, err := match.game.AddSpaceshipWithConfig(game.SpaceshipConfig{
+		Name:     request.Name,
+		Team:     request.Team,
+		Position: physics.Vector2{X: request.X, Y: request.Y},
+		Rotation: request.Rotation,
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	match.spaceships++
+	return &AddSpaceshipResponse{Id: spaceship.ID()}, nil
+}
+
+func (service *Service) SubmitAction(ctx context.Context, request *SubmitActionRequest) (*SubmitActionResponse, error) {
+	command := game.Command{Ship: request.Ship, Action: request.Action, Args: request.Args}
+	if err := command.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	service.mutex.Lock()
+	match, err := service.match(request.GameId)
+	service.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if err := match.game.EnqueueCommand(command); err != nil {
+		return nil, statusError(err)
+	}
+	return &SubmitActionResponse{}, nil
+}
+
+// StreamState sends the state after every tick of the game, until the game ends or the stream's
+// context is done, e.g. by the client's deadline. An ended game's state is sent once.
+func (service *Service) StreamState(request *StreamStateRequest, stream Orchestrator_StreamStateServer) error {
+	service.mutex.Lock()
+	match, err := service.match(request.GameId)
+	if err != nil {
+		service.mutex.Unlock()
+		return err
+	}
+	if match.game.Status() == game.Ended {
+		update, err := match.stateUpdate()
+		service.mutex.Unlock()
+		if err != nil {
+			return err
+		}
+		return stream.Send(update)
+	}
+	updates := make(chan *StateUpdate, StateBufferSize)
+	match.streams[updates] = struct{}{}
+	service.mutex.Unlock()
+
+	defer func() {
+		service.mutex.Lock()
+		delete(match.streams, updates)
+		service.mutex.Unlock()
+	}()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return statusError(stream.Context().Err())
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// GetResult reports the standings of the game, ranked by the score, and its winner once it ends.
+func (service *Service) GetResult(ctx context.Context, request *GetResultRequest) (*GetResultResponse, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	match, err := service.match(request.GameId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetResultResponse{
+		Status:    string(match.game.Status()),
+		EndReason: match.game.EndReason(),
+		Standings: []*Standing{},
+	}
+	if match.game.Status() == game.Ended {
+		// No winner of a game without the spaceships
+		response.Winner, _ = match.game.Winner()
+	}
+	for _, entry := range match.game.Scoreboard() {
+		response.Standings = append(response.Standings, &Standing{
+			Name:    entry.Name,
+			Team:    entry.Team,
+			Score:   entry.Score,
+			Kills:   int32(entry.Kills),
+			Assists: int32(entry.Assists),
+			Deaths:  int32(entry.Deaths),
+		})
+	}
+	return response, nil
+}
+
+// Tick starts the games having two spaceships, updates the running ones by the delta time and sends
+// their state to the streams. The streams of the games ended by the tick are closed.
+func (service *Service) Tick(deltaTimeMs float64) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	gameIDs := make([]string, 0, len(service.matches))
+	for gameID := range service.matches {
+		gameIDs = append(gameIDs, gameID)
+	}
+	sort.Strings(gameIDs)
+	for _, gameID := range gameIDs {
+		match := service.matches[gameID]
+		if match.game.Status() == game.Initialized && match.spaceships >= 2 {
+			match.game.Start()
+		}
+		if match.game.Status() != game.Running {
+			continue
+		}
+		match.game.Update(deltaTimeMs)
+		match.broadcast()
+	}
+}
+
+// RemoveGame closes the game and its streams.
+func (service *Service) RemoveGame(gameID string) error {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	match, err := service.match(gameID)
+	if err != nil {
+		return err
+	}
+	match.close()
+	match.game.Close()
+	delete(service.matches, gameID)
+	return nil
+}
+
+// match returns the match of the game, the caller holds the mutex.
+func (service *Service) match(gameID string) (*match, error) {
+	match, ok := service.matches[gameID]
+	if !ok {
+		return nil, ErrGameNotFound{GameID: gameID}
+	}
+	return match, nil
+}
+
+func (match *match) stateUpdate() (*StateUpdate, error) {
+	state := match.spectator.Serialize()
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("serialize state: %w", err)
+	}
+	tick, _ := state["tick"].(uint64)
+	elapsedMs, _ := state["elapsedMs"].(float64)
+	return &StateUpdate{Tick: tick, ElapsedMs: elapsedMs, Status: string(match.game.Status()), StateJson: string(data)}, nil
+}
+
+// broadcast sends the state to the streams, closing them once the game ends.
+func (match *match) broadcast() {
+	if len(match.streams) > 0 {
+		update, err := match.stateUpdate()
+		if err != nil {
+			match.close()
+			return
+		}
+		for updates := range match.streams {
+			select {
+			case updates <- update:
+			default:
+			}
+		}
+	}
+	if match.game.Status() == game.Ended {
+		match.close()
+	}
+}
+
+func (match *match) close() {
+	for updates := range match.streams {
+		close(updates)
+		delete(match.streams, updates)
+	}
+}
@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates chan *StateUpdate
+}
+
+func (stream *testStream) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *testStream) Send(update *StateUpdate) error {
+	stream.updates <- update
+	return nil
+}
+
+func newTestGame(t *testing.T, service *Service) string {
+	ctx := context.Background()
+	created, err := service.CreateGame(ctx, &CreateGameRequest{Width: 1000, Height: 1000, Seed: 1234567890, TimeLimitMs: 1000})
+	assert.NoError(t, err)
+	_, err = service.AddSpaceship(ctx, &AddSpaceshipRequest{GameId: created.GameId, Name: "alpha", X: 100, Y: 100})
+	assert.NoError(t, err)
+	return created.GameId
+}
+
+func TestService_CreateGame(t *testing.T) {
+	service := NewService()
+	ctx := context.Background()
+	_, err := service.CreateGame(ctx, &CreateGameRequest{Width: 0, Height: 100})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	first := newTestGame(t, service)
+	second := newTestGame(t, service)
+	assert.NotEqual(t, first, second)
+
+	t.Run("AddSpaceship", func(t *testing.T) {
+		added, err := service.AddSpaceship(ctx, &AddSpaceshipRequest{GameId: first, Name: "beta", X: 900, Y: 900, Team: "red"})
+		assert.NoError(t, err)
+		assert.NotZero(t, added.Id)
+
+		_, err = service.AddSpaceship(ctx, &AddSpaceshipRequest{GameId: first, Name: "beta"})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		_, err = service.AddSpaceship(ctx, &AddSpaceshipRequest{GameId: "unknown", Name: "gamma"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestService_Tick(t *testing.T) {
+	service := NewService()
+	ctx := context.Background()
+	gameID := newTestGame(t, service)
+
+	// Waits for the second spaceship
+	service.Tick(16)
+	result, err := service.GetResult(ctx, &GetResultRequest{GameId: gameID})
+	assert.NoError(t, err)
+	assert.Equal(t, string(game.Initialized), result.Status)
+
+	_, err = service.AddSpaceship(ctx, &AddSpaceshipRequest{GameId: gameID, Name: "beta", X: 900, Y: 900})
+	assert.NoError(t, err)
+	service.Tick(16)
+	result, _ = service.GetResult(ctx, &GetResultRequest{GameId: gameID})
+	assert.Equal(t, string(game.Running), result.Status)
+	assert.Len(t, result.Standings, 2)
+	assert.Empty(t, result.Winner)
+
+	_, err = service.SubmitAction(ctx, &SubmitActionRequest{GameId: gameID, Ship: "alpha", Action: game.CommandFireLaser})
+	assert.NoError(t, err)
+	_, err = service.SubmitAction(ctx, &SubmitActionRequest{GameId: gameID, Ship: "alpha", Action: "selfDestruct"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.SubmitAction(ctx, &SubmitActionRequest{GameId: gameID, Ship: "gamma", Action: game.CommandFireLaser})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	t.Run("GetResult", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			service.Tick(16)
+		}
+		result, err := service.GetResult(ctx, &GetResultRequest{GameId: gameID})
+		assert.NoError(t, err)
+		assert.Equal(t, string(game.Ended), result.Status)
+		assert.Equal(t, "timeLimitReached", result.EndReason)
+		assert.NotEmpty(t, result.Winner)
+		assert.Equal(t, result.Winner, result.Standings[0].Name)
+
+		_, err = service.AddSpaceship(ctx, &AddSpaceshipRequest{GameId: gameID, Name: "gamma"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestService_StreamState(t *testing.T) {
+	service := NewService()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gameID := newTestGame(t, service)
+	_, _ = service.AddSpaceship(ctx, &AddSpaceshipRequest{GameId: gameID, Name: "beta", X: 900, Y: 900})
+
+	stream := &testStream{ctx: ctx, updates: make(chan *StateUpdate, 100)}
+	done := make(chan error)
+	go func() { done <- service.StreamState(&StreamStateRequest{GameId: gameID}, stream) }()
+	assert.Eventually(t, func() bool {
+		service.mutex.Lock()
+		defer service.mutex.Unlock()
+		return len(service.matches[gameID].streams) == 1
+	}, time.Second, time.Millisecond)
+
+	service.Tick(16)
+	service.Tick(16)
+	first, second := <-stream.updates, <-stream.updates
+	assert.Equal(t, uint64(1), first.Tick)
+	assert.Equal(t, uint64(2), second.Tick)
+	assert.Equal(t, 32.0, second.ElapsedMs)
+	assert.Equal(t, string(game.Running), second.Status)
+	var state map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(second.StateJson), &state))
+	assert.Len(t, state["gameObjects"], 2)
+
+	// Ends with the game
+	for i := 0; i < 100; i++ {
+		service.Tick(16)
+	}
+	assert.NoError(t, <-done)
+
+	t.Run("Ended", func(t *testing.T) {
+		stream := &testStream{ctx: ctx, updates: make(chan *StateUpdate, 1)}
+		assert.NoError(t, service.StreamState(&StreamStateRequest{GameId: gameID}, stream))
+		assert.Equal(t, string(game.Ended), (<-stream.updates).Status)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		gameID := newTestGame(t, service)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := service.StreamState(&StreamStateRequest{GameId: gameID}, &testStream{ctx: ctx})
+		assert.Equal(t, codes.Canceled, status.Code(err))
+		assert.Empty(t, service.matches[gameID].streams)
+	})
+}
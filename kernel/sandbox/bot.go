@@ -0,0 +1,233 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// Bot is the game.Bot of a module instance, see the package documentation for its interface. Once
+// disqualified, by exceeding its limits or trapping, it issues no more commands and its runtime is closed.
+type Bot struct {
+	runtime      wazero.Runtime
+	instance     api.Module
+	limits       Limits
+	state        []byte // Encoded for the think in progress
+	commands     []game.Command
+	output       io.Writer
+	disqualified error
+}
+
+// hostFunction is a function the bots import from the HostModule, it reads the arguments off the stack
+// and writes the results to it.
+type hostFunction struct {
+	params  []api.ValueType
+	results []api.ValueType
+	call    api.GoModuleFunc
+}
+
+// NewBot instantiates the module for a new bot in its own runtime and calls its init, if exported. The
+// output receives the bot's logs, nil discards them.
+func (module *Module) NewBot(limits Limits, output io.Writer) (*Bot, error) {
+	if output == nil {
+		output = io.Discard
+	}
+	for _, size := range tableLimits(module.binary) {
+		if size > uint64(limits.TableElements) {
+			return nil, ErrInvalidModule{Reason: fmt.Sprintf("table of %d elements over the limit of %d", size, limits.TableElements)}
+		}
+	}
+	ctx := context.Background()
+	bot := &Bot{runtime: module.newRuntime(ctx, limits.MemoryPages), limits: limits, output: output}
+	if err := bot.instantiate(ctx, module); err != nil {
+		_ = bot.runtime.Close(ctx)
+		return nil, err
+	}
+	bot.commands = nil
+	return bot, nil
+}
+
+func (bot *Bot) instantiate(ctx context.Context, module *Module) error {
+	compiled, err := module.compile(ctx, bot.runtime)
+	if err != nil {
+		// It compiled in Compile, only the bot's memory limit fails it
+		return ErrMemoryLimitExceeded{Pages: bot.limits.MemoryPages}
+	}
+	functions := bot.hostFunctions()
+	for _, imported := range compiled.ImportedFunctions() {
+		moduleName, name, _ := imported.Import()
+		function, ok := functions[name]
+		if moduleName != HostModule || !ok {
+			return ErrUnknownImport{Module: moduleName, Name: name}
+		}
+		if !slices.Equal(imported.ParamTypes(), function.params) || !slices.Equal(imported.ResultTypes(), function.results) {
+			return ErrInvalidModule{Reason: fmt.Sprintf("import %s.%s declared as %s, expected %s", moduleName, name,
+				signature(imported.ParamTypes(), imported.ResultTypes()), signature(function.params, function.results))}
+		}
+	}
+	for _, imported := range compiled.ImportedMemories() {
+		moduleName, name, _ := imported.Import()
+		return ErrUnknownImport{Module: moduleName, Name: name}
+	}
+	if _, ok := compiled.ExportedFunctions()["think"]; !ok {
+		return ErrMissingExport{Name: "think"}
+	}
+
+	host := bot.runtime.NewHostModuleBuilder(HostModule)
+	for name, function := range functions {
+		host.NewFunctionBuilder().WithGoModuleFunction(function.call, function.params, function.results).Export(name)
+	}
+	if _, err := host.Instantiate(ctx); err != nil {
+		return err
+	}
+	// The start function runs metered, the WASI _start is not called
+	config := wazero.NewModuleConfig().WithStartFunctions()
+	if err := metered(bot.limits, func(ctx context.Context) error {
+		bot.instance, err = bot.runtime.InstantiateModule(ctx, compiled, config)
+		return err
+	}); err != nil {
+		return err
+	}
+	if _, ok := compiled.ExportedFunctions()["init"]; ok {
+		return bot.call("init")
+	}
+	return nil
+}
+
+func (bot *Bot) call(name string) error {
+	return metered(bot.limits, func(ctx context.Context) error {
+		_, err := bot.instance.ExportedFunction(name).Call(ctx)
+		return err
+	})
+}
+
+// Think calls the module's think, see Bot.
+func (bot *Bot) Think(state game.GameState) []game.Command {
+	if bot.disqualified != nil {
+		return nil
+	}
+	bot.state = encodeState(bot.state[:0], state)
+	bot.commands = nil
+	if err := bot.call("think"); err != nil {
+		bot.disqualified = err
+		_ = bot.runtime.Close(context.Background())
+		return nil
+	}
+	return bot.commands
+}
+
+// Disqualified returns why the bot is disqualified, nil if it is not.
+func (bot *Bot) Disqualified() error {
+	return bot.disqualified
+}
+
+// Strategy adapts the bot to the BotStrategy like game.NewBotStrategy, and takes its spaceship out
+// of the match by disabling it once the bot is disqualified.
+func (bot *Bot) Strategy() game.BotStrategy {
+	strategy := game.NewBotStrategy(bot)
+	return game.BotStrategyFunc(func(spaceship *game.Spaceship, gameManager *game.GameManager, deltaTimeMs float64) {
+		strategy.Update(spaceship, gameManager, deltaTimeMs)
+		if bot.disqualified != nil && spaceship.Enabled() {
+			spaceship.SetEnabled(false)
+			gameManager.Logger().LogEvent(game.LogLevelWarning, fmt.Sprintf("\"%s\" is disqualified: %s", spaceship.Name(), bot.disqualified),
+				spaceship.ID(), map[string]interface{}{"reason": bot.disqualified.Error()})
+		}
+	})
+}
+
+func (bot *Bot) hostFunctions() map[string]hostFunction {
+	f64, i32 := api.ValueTypeF64, api.ValueTypeI32
+	command := func(action string, args ...float64) {
+		bot.commands = append(bot.commands, game.Command{Action: action, Args: args})
+	}
+	return map[string]hostFunction{
+		"state_size": {results: []api.ValueType{i32}, call: func(ctx context.Context, module api.Module, stack []uint64) {
+			stack[0] = uint64(len(bot.state))
+		}},
+		"read_state": {params: []api.ValueType{i32}, call: func(ctx context.Context, module api.Module, stack []uint64) {
+			if memory := module.Memory(); memory == nil || !memory.Write(uint32(stack[0]), bot.state) {
+				panic(ErrTrap{Reason: "out of bounds memory access"})
+			}
+		}},
+		"set_engine": {params: []api.ValueType{f64, f64, f64}, call: func(ctx context.Context, module api.Module, stack []uint64) {
+			command(game.CommandSetEngineThrust, api.DecodeF64(stack[0]), api.DecodeF64(stack[1]), api.DecodeF64(stack[2]))
+		}},
+		"fire_laser": {call: func(ctx context.Context, module api.Module, stack []uint64) {
+			command(game.CommandFireLaser)
+		}},
+		"fire_rocket": {call: func(ctx context.Context, module api.Module, stack []uint64) {
+			command(game.CommandFireRocket)
+		}},
+		"fire_weapon": {params: []api.ValueType{i32}, call: func(ctx context.Context, module api.Module, stack []uint64) {
+			command(game.CommandFireWeapon, float64(api.DecodeI32(stack[0])))
+		}},
+		"set_deflector": {params: []api.ValueType{i32}, call: func(ctx context.Context, module api.Module, stack []uint64) {
+			command(game.CommandSetDeflector, float64(api.DecodeU32(stack[0])))
+		}},
+		"log": {params: []api.ValueType{i32, i32}, call: func(ctx context.Context, module api.Module, stack []uint64) {
+			memory := module.Memory()
+			if memory == nil {
+				panic(ErrTrap{Reason: "out of bounds memory access"})
+			}
+			message, ok := memory.Read(api.DecodeU32(stack[0]), min(api.DecodeU32(stack[1]), MaxLogLength))
+			if !ok {
+				panic(ErrTrap{Reason: "out of bounds memory access"})
+			}
+			_, _ = fmt.Fprintln(bot.output, strings.ToValidUTF8(string(message), "\uFFFD"))
+		}},
+	}
+}
+
+// signature formats the function type like "[i32 i32] -> [f64]".
+func signature(params, results []api.ValueType) string {
+	names := func(types []api.ValueType) []string {
+		var names []string
+		for _, valueType := range types {
+			names = append(names, api.ValueTypeName(valueType))
+		}
+		return names
+	}
+	return fmt.Sprintf("%v -> %v", names(params), names(results))
+}
+
+// encodeState appends the encoded state to the buffer, see the package documentation.
+func encodeState(buffer []byte, state game.GameState) []byte {
+	float := func(value float64) {
+		buffer = binary.LittleEndian.AppendUint64(buffer, math.Float64bits(value))
+	}
+	spaceship := func(spaceship game.SpaceshipState) {
+		teammate := 0.0
+		if state.Self.Team != "" && spaceship.Team == state.Self.Team && spaceship.Name != state.Self.Name {
+			teammate = 1
+		}
+		for _, value := range []float64{spaceship.Position.X, spaceship.Position.Y, spaceship.Velocity.X, spaceship.Velocity.Y,
+			spaceship.Rotation, spaceship.AngularVelocity, spaceship.Health, spaceship.Energy, float64(spaceship.Rockets), teammate} {
+			float(value)
+		}
+	}
+
+	float(state.ElapsedMs)
+	float(state.DeltaTimeMs)
+	float(state.Size.Width)
+	float(state.Size.Height)
+	spaceship(state.Self)
+	buffer = binary.LittleEndian.AppendUint32(buffer, uint32(len(state.Spaceships)))
+	buffer = binary.LittleEndian.AppendUint32(buffer, uint32(len(state.Asteroids)))
+	for _, other := range state.Spaceships {
+		spaceship(other)
+	}
+	for _, asteroid := range state.Asteroids {
+		float(asteroid.Center.X)
+		float(asteroid.Center.Y)
+		float(asteroid.Radius)
+	}
+	return buffer
+}
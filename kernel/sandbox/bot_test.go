@@ -0,0 +1,182 @@
+package sandbox
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+// mirrorModule is a bot setting the main thrust to its x, and firing the laser once it sees a spaceship.
+func mirrorModule() []byte {
+	module := &testModule{memory: [][]byte{ops(0x00, u32(1))}}
+	none := module.addType(nil, nil)
+	readState := module.importFunction(HostModule, "read_state", module.addType(i32Types, nil))
+	setEngine := module.importFunction(HostModule, "set_engine", module.addType([]valueType{typeF64, typeF64, typeF64}, nil))
+	fireLaser := module.importFunction(HostModule, "fire_laser", none)
+	log := module.importFunction(HostModule, "log", module.addType([]valueType{typeI32, typeI32}, nil))
+	module.data = [][]byte{ops(u32(0), opI32Const, s32(1024), opEnd, name("thinking"))}
+	think := module.addFunction(none, nil,
+		opI32Const, s32(0), opCall, u32(readState),
+		opI32Const, s32(0), opF64Load, u32(3), u32(32),
+		opF64Const, f64Bytes(0), opF64Const, f64Bytes(0), opCall, u32(setEngine),
+		opI32Const, s32(0), opI32Load, u32(2), u32(StateHeaderSize-8),
+		opIf, 0x40, opCall, u32(fireLaser), opEnd,
+		opI32Const, s32(1024), opI32Const, s32(8), opCall, u32(log))
+	module.export("think", exportFunction, think)
+	module.export("memory", exportMemory, 0)
+	return module.bytes()
+}
+
+func newModule(t *testing.T, binary []byte) *Module {
+	module, err := Compile(binary)
+	assert.NoError(t, err)
+	return module
+}
+
+func TestModule_NewBot(t *testing.T) {
+	var output bytes.Buffer
+	bot, err := newModule(t, mirrorModule()).NewBot(DefaultLimits(), &output)
+	assert.NoError(t, err)
+	assert.NotNil(t, bot)
+
+	module := &testModule{}
+	module.export("init", exportFunction, module.addFunction(module.addType(nil, nil), nil))
+	_, err = newModule(t, module.bytes()).NewBot(DefaultLimits(), nil)
+	assert.Equal(t, ErrMissingExport{Name: "think"}, err)
+
+	module = &testModule{}
+	module.importFunction("wasi_snapshot_preview1", "fd_write", module.addType(nil, nil))
+	_, err = newModule(t, module.bytes()).NewBot(DefaultLimits(), nil)
+	assert.Equal(t, ErrUnknownImport{Module: "wasi_snapshot_preview1", Name: "fd_write"}, err)
+
+	module = &testModule{}
+	none := module.addType(nil, nil)
+	module.export("think", exportFunction, module.addFunction(none, nil))
+	module.export("init", exportFunction, module.addFunction(none, nil, opUnreachable))
+	_, err = newModule(t, module.bytes()).NewBot(DefaultLimits(), nil)
+	assert.Equal(t, ErrTrap{Reason: "unreachable"}, err)
+
+	module = &testModule{}
+	module.importFunction(HostModule, "read_state", module.addType(nil, nil))
+	_, err = newModule(t, module.bytes()).NewBot(DefaultLimits(), nil)
+	assert.Equal(t, ErrInvalidModule{Reason: "import spacewars.read_state declared as [] -> [], expected [i32] -> []"}, err)
+
+	t.Run("Limits the memory", func(t *testing.T) {
+		module := &testModule{memory: [][]byte{ops(0x00, u32(8))}}
+		module.export("think", exportFunction, module.addFunction(module.addType(nil, nil), nil))
+		_, err := newModule(t, module.bytes()).NewBot(Limits{Fuel: DefaultFuel, MemoryPages: 4}, nil)
+		assert.Equal(t, ErrMemoryLimitExceeded{Pages: 4}, err)
+
+		// The grow beyond the limit fails without a trap
+		module = &testModule{memory: [][]byte{ops(0x00, u32(1))}}
+		module.export("think", exportFunction, module.addFunction(module.addType(nil, nil), nil))
+		module.export("init", exportFunction, module.addFunction(module.addType(nil, nil), nil,
+			opI32Const, s32(4), opMemoryGrow, 0x00, opI32Const, s32(-1), opI32Ne, opIf, 0x40, opUnreachable, opEnd))
+		_, err = newModule(t, module.bytes()).NewBot(Limits{Fuel: DefaultFuel, MemoryPages: 4}, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Limits the table", func(t *testing.T) {
+		// Rejected before the table of 1 GB is allocated
+		module := &testModule{table: [][]byte{ops(0x70, 0x00, u32(1<<27))}}
+		module.export("think", exportFunction, module.addFunction(module.addType(nil, nil), nil))
+		_, err := newModule(t, module.bytes()).NewBot(DefaultLimits(), nil)
+		assert.Equal(t, ErrInvalidModule{Reason: "table of 134217728 elements over the limit of 65536"}, err)
+
+		module = &testModule{table: [][]byte{ops(0x70, 0x01, u32(1), u32(DefaultTableElements+1))}}
+		module.export("think", exportFunction, module.addFunction(module.addType(nil, nil), nil))
+		_, err = newModule(t, module.bytes()).NewBot(DefaultLimits(), nil)
+		assert.Equal(t, ErrInvalidModule{Reason: "table of 65537 elements over the limit of 65536"}, err)
+	})
+}
+
+func TestBot_Think(t *testing.T) {
+	var output bytes.Buffer
+	bot, err := newModule(t, mirrorModule()).NewBot(DefaultLimits(), &output)
+	assert.NoError(t, err)
+
+	state := game.GameState{Self: game.SpaceshipState{Name: "self", Position: physics.Vector2{X: 60, Y: 10}}}
+	assert.Equal(t, []game.Command{{Action: game.CommandSetEngineThrust, Args: []float64{60, 0, 0}}}, bot.Think(state))
+	assert.Equal(t, "thinking\n", output.String())
+
+	state.Spaceships = []game.SpaceshipState{{Name: "other"}}
+	assert.Equal(t, []game.Command{
+		{Action: game.CommandSetEngineThrust, Args: []float64{60, 0, 0}},
+		{Action: game.CommandFireLaser},
+	}, bot.Think(state))
+	assert.NoError(t, bot.Disqualified())
+}
+
+func TestBot_Disqualified(t *testing.T) {
+	// Calls a function in an endless loop
+	module := &testModule{}
+	none := module.addType(nil, nil)
+	nop := module.addFunction(none, nil)
+	module.export("think", exportFunction, module.addFunction(none, nil, opLoop, 0x40, opCall, u32(nop), opBr, u32(0), opEnd))
+	bot, err := newModule(t, module.bytes()).NewBot(Limits{Fuel: 1000, MemoryPages: 1}, nil)
+	assert.NoError(t, err)
+
+	arena := game.NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	assert.NoError(t, arena.AddSpaceship("wasm", physics.Vector2{X: 100, Y: 100}, 0))
+	assert.NoError(t, arena.AddSpaceship("other", physics.Vector2{X: 500, Y: 500}, 0))
+	assert.NoError(t, arena.AddSpaceship("third", physics.Vector2{X: 900, Y: 500}, 0))
+	assert.NoError(t, arena.AddBot("wasm", bot.Strategy()))
+	arena.Update(16)
+
+	assert.Equal(t, ErrFuelExhausted{Limit: 1000}, bot.Disqualified())
+	assert.Nil(t, bot.Think(game.GameState{}))
+	assert.NoError(t, arena.SpaceshipAction("wasm", func(spaceship *game.Spaceship, gameManager *game.GameManager) {
+		assert.False(t, spaceship.Enabled())
+		logs := gameManager.Logger().Logs()
+		assert.Equal(t, `"wasm" is disqualified: exceeded the budget of 1000 calls`, logs[len(logs)-1].Message())
+	}))
+
+	t.Run("Exceeds the time", func(t *testing.T) {
+		module := &testModule{}
+		module.export("think", exportFunction, module.addFunction(module.addType(nil, nil), nil, opLoop, 0x40, opBr, u32(0), opEnd))
+		bot, err := newModule(t, module.bytes()).NewBot(Limits{Fuel: DefaultFuel, Time: 10 * time.Millisecond, MemoryPages: 1}, nil)
+		assert.NoError(t, err)
+
+		start := time.Now()
+		assert.Nil(t, bot.Think(game.GameState{}))
+		assert.Equal(t, ErrTimeExceeded{Limit: 10 * time.Millisecond}, bot.Disqualified())
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("Traps", func(t *testing.T) {
+		module := &testModule{memory: [][]byte{ops(0x00, u32(1))}}
+		readState := module.importFunction(HostModule, "read_state", module.addType(i32Types, nil))
+		module.export("think", exportFunction, module.addFunction(module.addType(nil, nil), nil,
+			opI32Const, s32(PageSize-8), opCall, u32(readState)))
+		bot, err := newModule(t, module.bytes()).NewBot(DefaultLimits(), nil)
+		assert.NoError(t, err)
+
+		assert.Nil(t, bot.Think(game.GameState{}))
+		assert.Equal(t, ErrTrap{Reason: "out of bounds memory access"}, bot.Disqualified())
+	})
+}
+
+func TestEncodeState(t *testing.T) {
+	state := game.GameState{
+		Self:        game.SpaceshipState{Name: "self", Team: "red", Rockets: 3},
+		Spaceships:  []game.SpaceshipState{{Name: "mate", Team: "red"}, {Name: "enemy", Team: "blue"}},
+		Asteroids:   []physics.Circle{{Center: physics.Vector2{X: 1, Y: 2}, Radius: 3}},
+		ElapsedMs:   100,
+		DeltaTimeMs: 16,
+		Size:        physics.Size{Width: 800, Height: 600},
+	}
+	encoded := encodeState(nil, state)
+	assert.Len(t, encoded, StateHeaderSize+2*SpaceshipRecordSize+AsteroidRecordSize)
+	assert.Equal(t, f64Bytes(100), encoded[0:8])
+	assert.Equal(t, f64Bytes(600), encoded[24:32])
+	assert.Equal(t, f64Bytes(3), encoded[32+8*8:32+9*8])
+	assert.Equal(t, []byte{2, 0, 0, 0, 1, 0, 0, 0}, encoded[StateHeaderSize-8:StateHeaderSize])
+	// The teammate flags
+	assert.Equal(t, f64Bytes(1), encoded[StateHeaderSize+9*8:StateHeaderSize+SpaceshipRecordSize])
+	assert.Equal(t, f64Bytes(0), encoded[StateHeaderSize+SpaceshipRecordSize+9*8:StateHeaderSize+2*SpaceshipRecordSize])
+	assert.Equal(t, f64Bytes(3), encoded[len(encoded)-8:])
+}
@@ -0,0 +1,70 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// budget is the fuel of a call of a bot, carried by the call's context to fuelMeter. wazero does not
+// count the instructions, so the fuel is consumed per function call and the loops without calls are
+// bounded by the time.
+type budget struct {
+	fuel      int64
+	exhausted bool
+	cancel    context.CancelFunc // Of the call, wazero then stops it by closing the module
+}
+
+type budgetKey struct{}
+
+func (budget *budget) consume() {
+	budget.fuel--
+	if budget.fuel < 0 && !budget.exhausted {
+		budget.exhausted = true
+		budget.cancel()
+	}
+}
+
+// fuelMeter consumes the fuel of the call's budget on every function called.
+var fuelMeter = experimental.FunctionListenerFactoryFunc(func(api.FunctionDefinition) experimental.FunctionListener {
+	return experimental.FunctionListenerFunc(func(ctx context.Context, _ api.Module, _ api.FunctionDefinition, _ []uint64, _ experimental.StackIterator) {
+		if budget, ok := ctx.Value(budgetKey{}).(*budget); ok {
+			budget.consume()
+		}
+	})
+})
+
+// metered runs the call within the limits, every call of a bot gets the full fuel and time. The errors
+// are converted to the sandbox's ones.
+func metered(limits Limits, call func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if limits.Time > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, limits.Time)
+		defer cancelTimeout()
+	}
+	budget := &budget{fuel: limits.Fuel, cancel: cancel}
+	err := call(context.WithValue(ctx, budgetKey{}, budget))
+	if err == nil {
+		return nil
+	}
+
+	var exit *sys.ExitError
+	var trap ErrTrap
+	switch {
+	case budget.exhausted:
+		return ErrFuelExhausted{Limit: limits.Fuel}
+	case errors.As(err, &exit) && exit.ExitCode() == sys.ExitCodeDeadlineExceeded:
+		return ErrTimeExceeded{Limit: limits.Time}
+	case errors.As(err, &trap):
+		return trap
+	}
+	// The wasm errors are "wasm error: <reason>" followed by the stack trace
+	message, _, _ := strings.Cut(err.Error(), "\n")
+	return ErrTrap{Reason: strings.TrimPrefix(message, "wasm error: ")}
+}
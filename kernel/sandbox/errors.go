@@ -0,0 +1,65 @@
+package sandbox
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrInvalidModule rejects a binary that is not a WebAssembly module this sandbox runs.
+type ErrInvalidModule struct {
+	Reason string
+}
+
+func (err ErrInvalidModule) Error() string {
+	return fmt.Sprintf("invalid module: %s", err.Reason)
+}
+
+type ErrUnknownImport struct {
+	Module string
+	Name   string
+}
+
+func (err ErrUnknownImport) Error() string {
+	return fmt.Sprintf("unknown import: %s.%s", err.Module, err.Name)
+}
+
+type ErrMissingExport struct {
+	Name string
+}
+
+func (err ErrMissingExport) Error() string {
+	return fmt.Sprintf("missing export: %s", err.Name)
+}
+
+// ErrTrap aborts the call on a runtime fault of the module, e.g. an out of bounds memory access.
+type ErrTrap struct {
+	Reason string
+}
+
+func (err ErrTrap) Error() string {
+	return fmt.Sprintf("trap: %s", err.Reason)
+}
+
+type ErrFuelExhausted struct {
+	Limit int64
+}
+
+func (err ErrFuelExhausted) Error() string {
+	return fmt.Sprintf("exceeded the budget of %d calls", err.Limit)
+}
+
+type ErrTimeExceeded struct {
+	Limit time.Duration
+}
+
+func (err ErrTimeExceeded) Error() string {
+	return fmt.Sprintf("exceeded the time budget of %s", err.Limit)
+}
+
+type ErrMemoryLimitExceeded struct {
+	Pages uint32
+}
+
+func (err ErrMemoryLimitExceeded) Error() string {
+	return fmt.Sprintf("exceeded the memory limit of %d pages", err.Pages)
+}
@@ -0,0 +1,78 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/binary"
+	"slices"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/experimental"
+)
+
+const (
+	// maxModulePages is the page limit of a WebAssembly memory, the modules are validated with it
+	maxModulePages = 65536
+	// sectionTable is the id of the table section, see tableLimits
+	sectionTable = 4
+)
+
+// Module is a compiled WebAssembly module, the bots created from it run in their own runtimes. The
+// features are the ones of WebAssembly 2.0.
+type Module struct {
+	binary []byte
+	cache  wazero.CompilationCache // Shared by the runtimes of the bots, so that the module is compiled once
+}
+
+// Compile validates and compiles the binary module.
+func Compile(binary []byte) (*Module, error) {
+	module := &Module{binary: slices.Clone(binary), cache: wazero.NewCompilationCache()}
+	ctx := context.Background()
+	runtime := module.newRuntime(ctx, maxModulePages)
+	defer runtime.Close(ctx)
+	if _, err := module.compile(ctx, runtime); err != nil {
+		return nil, ErrInvalidModule{Reason: err.Error()}
+	}
+	return module, nil
+}
+
+// newRuntime returns a runtime of the memory limit, its calls are stopped once their context is done.
+func (module *Module) newRuntime(ctx context.Context, memoryPages uint32) wazero.Runtime {
+	config := wazero.NewRuntimeConfig().
+		WithCompilationCache(module.cache).
+		WithMemoryLimitPages(memoryPages).
+		WithCloseOnContextDone(true)
+	return wazero.NewRuntimeWithConfig(ctx, config)
+}
+
+// compile compiles the module with the fuel metering, see budget.
+func (module *Module) compile(ctx context.Context, runtime wazero.Runtime) (wazero.CompiledModule, error) {
+	return runtime.CompileModule(experimental.WithFunctionListenerFactory(ctx, fuelMeter), module.binary)
+}
+
+// tableLimits returns the minimum and the declared maximum sizes of the module's tables, read off its
+// table section as wazero caps them at 1<<27 elements only. The binary is a validated one.
+func tableLimits(wasm []byte) []uint64 {
+	var limits []uint64
+	for data := wasm[8:]; len(data) > 0; {
+		id := data[0]
+		size, n := binary.Uvarint(data[1:])
+		section := data[1+n : 1+n+int(size)]
+		data = data[1+n+int(size):]
+		if id != sectionTable {
+			continue
+		}
+		count, n := binary.Uvarint(section)
+		section = section[n:]
+		for range count {
+			// The element type and the flags, 1 with the maximum
+			flags := section[1]
+			section = section[2:]
+			for range 1 + flags&1 {
+				value, n := binary.Uvarint(section)
+				limits = append(limits, value)
+				section = section[n:]
+			}
+		}
+	}
+	return limits
+}
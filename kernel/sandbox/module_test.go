@@ -0,0 +1,214 @@
+package sandbox
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type valueType byte
+
+// The value types, the opcodes and the export kinds of the test modules.
+const (
+	typeI32 valueType = 0x7F
+	typeI64 valueType = 0x7E
+	typeF32 valueType = 0x7D
+	typeF64 valueType = 0x7C
+
+	opUnreachable = 0x00
+	opLoop        = 0x03
+	opIf          = 0x04
+	opEnd         = 0x0B
+	opBr          = 0x0C
+	opCall        = 0x10
+	opDrop        = 0x1A
+	opLocalGet    = 0x20
+	opI32Load     = 0x28
+	opF64Load     = 0x2B
+	opMemoryGrow  = 0x40
+	opI32Const    = 0x41
+	opF64Const    = 0x44
+	opI32Ne       = 0x47
+	opI32Add      = 0x6A
+
+	exportFunction = 0x00
+	exportMemory   = 0x02
+)
+
+var i32Types = []valueType{typeI32}
+
+// testModule assembles the binary modules of the tests.
+type testModule struct {
+	types     [][]byte
+	imports   [][]byte
+	functions [][]byte
+	table     [][]byte
+	memory    [][]byte
+	globals   [][]byte
+	exports   [][]byte
+	start     []byte
+	elements  [][]byte
+	code      [][]byte
+	data      [][]byte
+}
+
+// ops concatenates the opcodes, given as the ints, and the encoded immediates.
+func ops(parts ...interface{}) []byte {
+	var code []byte
+	for _, part := range parts {
+		switch part := part.(type) {
+		case int:
+			code = append(code, byte(part))
+		case byte:
+			code = append(code, part)
+		case valueType:
+			code = append(code, byte(part))
+		case []byte:
+			code = append(code, part...)
+		}
+	}
+	return code
+}
+
+func u32(value uint32) []byte {
+	return binary.AppendUvarint(nil, uint64(value))
+}
+
+func s64(value int64) []byte {
+	var encoded []byte
+	for {
+		b := byte(value & 0x7F)
+		value >>= 7
+		if value == 0 && b&0x40 == 0 || value == -1 && b&0x40 != 0 {
+			return append(encoded, b)
+		}
+		encoded = append(encoded, b|0x80)
+	}
+}
+
+func s32(value int32) []byte {
+	return s64(int64(value))
+}
+
+func f32Bytes(value float32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, math.Float32bits(value))
+}
+
+func f64Bytes(value float64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, math.Float64bits(value))
+}
+
+func name(value string) []byte {
+	return append(u32(uint32(len(value))), value...)
+}
+
+func vector(items [][]byte) []byte {
+	encoded := u32(uint32(len(items)))
+	for _, item := range items {
+		encoded = append(encoded, item...)
+	}
+	return encoded
+}
+
+func valueTypes(types []valueType) []byte {
+	encoded := u32(uint32(len(types)))
+	for _, valueType := range types {
+		encoded = append(encoded, byte(valueType))
+	}
+	return encoded
+}
+
+func (module *testModule) addType(params, results []valueType) uint32 {
+	module.types = append(module.types, ops(0x60, valueTypes(params), valueTypes(results)))
+	return uint32(len(module.types) - 1)
+}
+
+func (module *testModule) importFunction(moduleName, functionName string, typeIndex uint32) uint32 {
+	module.imports = append(module.imports, ops(name(moduleName), name(functionName), 0x00, u32(typeIndex)))
+	return uint32(len(module.imports) - 1)
+}
+
+// addFunction adds the function of the locals and the code, without the final end, returns its index.
+func (module *testModule) addFunction(typeIndex uint32, locals []valueType, code ...interface{}) uint32 {
+	module.functions = append(module.functions, u32(typeIndex))
+	var groups [][]byte
+	for _, local := range locals {
+		groups = append(groups, ops(u32(1), local))
+	}
+	body := append(vector(groups), ops(code...)...)
+	body = append(body, opEnd)
+	module.code = append(module.code, append(u32(uint32(len(body))), body...))
+	return uint32(len(module.imports) + len(module.functions) - 1)
+}
+
+func (module *testModule) export(exportName string, kind byte, index uint32) {
+	module.exports = append(module.exports, ops(name(exportName), kind, u32(index)))
+}
+
+func (module *testModule) bytes() []byte {
+	binary := []byte("\x00asm\x01\x00\x00\x00")
+	add := func(id byte, content []byte) {
+		binary = append(binary, id)
+		binary = append(binary, u32(uint32(len(content)))...)
+		binary = append(binary, content...)
+	}
+	for _, section := range []struct {
+		id    byte
+		items [][]byte
+	}{{1, module.types}, {2, module.imports}, {3, module.functions}, {4, module.table}, {5, module.memory}, {6, module.globals},
+		{7, module.exports}} {
+		if len(section.items) > 0 {
+			add(section.id, vector(section.items))
+		}
+	}
+	if module.start != nil {
+		add(8, module.start)
+	}
+	for _, section := range []struct {
+		id    byte
+		items [][]byte
+	}{{9, module.elements}, {10, module.code}, {11, module.data}} {
+		if len(section.items) > 0 {
+			add(section.id, vector(section.items))
+		}
+	}
+	return binary
+}
+
+func TestCompile(t *testing.T) {
+	module := &testModule{memory: [][]byte{ops(0x01, u32(1), u32(2))}}
+	add := module.addFunction(module.addType(i32Types, i32Types), nil, opLocalGet, u32(0), opI32Const, s32(1), opI32Add)
+	module.export("add", exportFunction, add)
+	_, err := Compile(module.bytes())
+	assert.NoError(t, err)
+
+	for _, binary := range [][]byte{
+		[]byte("\x00wasm"),
+		[]byte("\x00asm\x02\x00\x00\x00"),
+		func() []byte {
+			module := &testModule{}
+			module.addFunction(module.addType(nil, nil), nil, opI32Const, s32(1), opF64Const, f64Bytes(1), opI32Add, opDrop)
+			return module.bytes()
+		}(),
+		func() []byte {
+			module := &testModule{}
+			module.addFunction(module.addType(nil, nil), nil, opLocalGet, u32(100000), opDrop)
+			return module.bytes()
+		}(),
+	} {
+		_, err := Compile(binary)
+		assert.IsType(t, ErrInvalidModule{}, err)
+	}
+}
+
+func TestTableLimits(t *testing.T) {
+	module := &testModule{memory: [][]byte{ops(0x00, u32(1))}, table: [][]byte{ops(0x70, 0x01, u32(2), u32(1<<20))}}
+	module.addFunction(module.addType(nil, nil), nil)
+	assert.Equal(t, []uint64{2, 1 << 20}, tableLimits(module.bytes()))
+
+	module = &testModule{table: [][]byte{ops(0x70, 0x00, u32(math.MaxUint32))}}
+	assert.Equal(t, []uint64{math.MaxUint32}, tableLimits(module.bytes()))
+	assert.Empty(t, tableLimits((&testModule{}).bytes()))
+}
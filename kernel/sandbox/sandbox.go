@@ -0,0 +1,63 @@
+// Package sandbox runs the untrusted bots compiled to WebAssembly, e.g. for the tournaments. The
+// modules run on wazero, every bot in its own runtime, every call of a bot is metered by its Limits
+// and a bot exceeding them, or trapping, is disqualified.
+//
+// A bot module exports its memory and the function think, called once per tick, and optionally
+// init, called once on the creation of the bot. It may import the functions of the "spacewars" module:
+//
+//	state_size() -> i32                           the size of the encoded game state
+//	read_state(ptr: i32)                          copies the encoded game state to the memory
+//	set_engine(main: f64, left: f64, right: f64)  sets the engine thrust, each 0-100
+//	fire_laser()
+//	fire_rocket()
+//	fire_weapon(slot: i32)
+//	set_deflector(raised: i32)
+//	log(ptr: i32, len: i32)                       writes the UTF-8 message to the bot's output
+//
+// The commands are issued once think returns, in the order of the calls. The game state is encoded
+// in little endian, as float64 unless noted:
+//
+//	elapsedMs, deltaTimeMs, width, height
+//	self                                          a spaceship record
+//	spaceships: u32, asteroids: u32               the counts of the records that follow
+//	spaceships                                    x, y, vx, vy, rotation, angularVelocity, health,
+//	                                              energy, rockets, teammate (1 or 0) each
+//	asteroids                                     x, y, radius each
+package sandbox
+
+import "time"
+
+const (
+	// HostModule is the module name of the functions the bots import
+	HostModule = "spacewars"
+
+	// StateHeaderSize is the size of the encoded game state before the spaceship records
+	StateHeaderSize     = 4*8 + SpaceshipRecordSize + 2*4
+	SpaceshipRecordSize = 10 * 8
+	AsteroidRecordSize  = 3 * 8
+
+	// MaxLogLength caps a log message of a bot, the longer ones are truncated
+	MaxLogLength = 1024
+
+	DefaultFuel        = 1_000_000
+	DefaultTime        = 50 * time.Millisecond
+	DefaultMemoryPages = 256 // 16 MiB
+	// DefaultTableElements bounds the function table, 8 bytes each
+	DefaultTableElements = 1 << 16
+
+	// PageSize is the size of a page of the WebAssembly memory
+	PageSize = 65536
+)
+
+// Limits are the budgets of a bot. Every call of the bot, the init or a think, gets the full Fuel
+// and Time; the memory and the table are capped for the bot's lifetime.
+type Limits struct {
+	Fuel          int64         // Function calls per call, the loops without calls are bounded by the Time
+	Time          time.Duration // Wall clock per call, 0 for no limit
+	MemoryPages   uint32        // Of PageSize
+	TableElements uint32        // Of the function table, declared by the module
+}
+
+func DefaultLimits() Limits {
+	return Limits{Fuel: DefaultFuel, Time: DefaultTime, MemoryPages: DefaultMemoryPages, TableElements: DefaultTableElements}
+}
@@ -0,0 +1,238 @@
+package scripting
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// Bot is the game.Bot of a script, it calls the script's think(self, dt) every tick. The self is
+// the table of the own spaceship: name, team, x, y, vx, vy, rotation, angularVelocity, health, energy
+// and rockets; dt is the delta time in milliseconds. The global arena holds the width, the height
+// and the elapsedMs of the game. The script steers the spaceship by the functions:
+//
+//   - scan() returns the seen objects, the nearest first, as the tables of the type ("spaceship" or
+//     "asteroid", with a radar any of the radar's), name, team, x, y, vx, vy, radius, distance and
+//     bearing, relative to the heading and positive counterclockwise
+//   - thrust(main) sets the main thrust, 0-100
+//   - rotate(direction) fires the side thrusters, -1 turns clockwise at full thrust, 1 counterclockwise
+//   - fire(weapon) fires the "laser", the "rocket" or the weapon of the slot number
+//
+// The thrust and the rotation are kept until changed. A failing think issues no commands, its error
+// is reported by Err. Once disqualified, by exceeding the MaxStringLength or the MemoryLimit, the bot
+// issues no more commands.
+type Bot struct {
+	sandbox       *sandbox
+	think         *lua.LFunction
+	state         game.GameState // Of the think in progress
+	engine        [3]float64     // Main, left and right thrust
+	engineChanged bool
+	commands      []game.Command
+	err           error
+	disqualified  error
+}
+
+// NewBot runs the script in a new sandbox and returns its bot. The output receives the script's
+// prints, nil discards them.
+func (script *Script) NewBot(output io.Writer) (*Bot, error) {
+	bot := &Bot{}
+	sandbox, err := script.run(output, map[string]lua.LGFunction{
+		"scan":   bot.scan,
+		"thrust": bot.thrust,
+		"rotate": bot.rotate,
+		"fire":   bot.fire,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	think, ok := sandbox.state.GetGlobal("think").(*lua.LFunction)
+	if !ok {
+		sandbox.close()
+		return nil, Error{Script: script.name, Line: 0, Message: "the think function is not defined"}
+	}
+	bot.sandbox, bot.think = sandbox, think
+	return bot, nil
+}
+
+// Think calls the script's think, see Bot.
+func (bot *Bot) Think(state game.GameState) []game.Command {
+	if bot.disqualified != nil {
+		return nil
+	}
+	bot.state = state
+	bot.commands = nil
+	bot.engineChanged = false
+	L := bot.sandbox.state
+	arena := L.NewTable()
+	arena.RawSetString("width", lua.LNumber(state.Size.Width))
+	arena.RawSetString("height", lua.LNumber(state.Size.Height))
+	arena.RawSetString("elapsedMs", lua.LNumber(state.ElapsedMs))
+	L.SetGlobal("arena", arena)
+
+	bot.err = bot.sandbox.call(bot.think, selfTable(L, state.Self), lua.LNumber(state.DeltaTimeMs))
+	switch bot.err.(type) {
+	case nil:
+	case ErrStringLimitExceeded, ErrMemoryLimitExceeded:
+		// Frees the memory held by the script
+		bot.disqualified = bot.err
+		bot.sandbox.close()
+		return nil
+	default:
+		return nil
+	}
+	commands := bot.commands
+	if bot.engineChanged {
+		commands = append([]game.Command{{Action: game.CommandSetEngineThrust, Args: append([]float64(nil), bot.engine[:]...)}}, commands...)
+	}
+	return commands
+}
+
+// Err returns the error of the last think, nil when it succeeded.
+func (bot *Bot) Err() error {
+	return bot.err
+}
+
+// Disqualified returns why the bot is disqualified, nil if it is not.
+func (bot *Bot) Disqualified() error {
+	return bot.disqualified
+}
+
+// Strategy adapts the bot to the BotStrategy like game.NewBotStrategy, and takes its spaceship out
+// of the match by disabling it once the bot is disqualified.
+func (bot *Bot) Strategy() game.BotStrategy {
+	strategy := game.NewBotStrategy(bot)
+	return game.BotStrategyFunc(func(spaceship *game.Spaceship, gameManager *game.GameManager, deltaTimeMs float64) {
+		strategy.Update(spaceship, gameManager, deltaTimeMs)
+		if bot.disqualified != nil && spaceship.Enabled() {
+			spaceship.SetEnabled(false)
+			gameManager.Logger().LogEvent(game.LogLevelWarning, fmt.Sprintf("\"%s\" is disqualified: %s", spaceship.Name(), bot.disqualified),
+				spaceship.ID(), map[string]interface{}{"reason": bot.disqualified.Error()})
+		}
+	})
+}
+
+func selfTable(L *lua.LState, self game.SpaceshipState) *lua.LTable {
+	table := L.NewTable()
+	for _, field := range []struct {
+		key   string
+		value lua.LValue
+	}{
+		{"name", lua.LString(self.Name)},
+		{"team", lua.LString(self.Team)},
+		{"x", lua.LNumber(self.Position.X)},
+		{"y", lua.LNumber(self.Position.Y)},
+		{"vx", lua.LNumber(self.Velocity.X)},
+		{"vy", lua.LNumber(self.Velocity.Y)},
+		{"rotation", lua.LNumber(self.Rotation)},
+		{"angularVelocity", lua.LNumber(self.AngularVelocity)},
+		{"health", lua.LNumber(self.Health)},
+		{"energy", lua.LNumber(self.Energy)},
+		{"rockets", lua.LNumber(self.Rockets)},
+	} {
+		table.RawSetString(field.key, field.value)
+	}
+	return table
+}
+
+type sighting struct {
+	kind     string
+	name     string
+	team     string
+	position physics.Vector2
+	velocity physics.Vector2
+	radius   float64
+}
+
+func (bot *Bot) scan(L *lua.LState) int {
+	var sightings []sighting
+	if bot.state.Contacts != nil {
+		teams := map[string]string{}
+		for _, spaceship := range bot.state.Spaceships {
+			teams[spaceship.Name] = spaceship.Team
+		}
+		for _, contact := range bot.state.Contacts {
+			sightings = append(sightings, sighting{kind: contact.Type, name: contact.Name, team: teams[contact.Name],
+				position: contact.Position, velocity: contact.Velocity, radius: contact.Radius})
+		}
+	} else {
+		for _, spaceship := range bot.state.Spaceships {
+			sightings = append(sightings, sighting{kind: "spaceship", name: spaceship.Name, team: spaceship.Team,
+				position: spaceship.Position, velocity: spaceship.Velocity, radius: game.ShipSize / 2})
+		}
+		for _, asteroid := range bot.state.Asteroids {
+			sightings = append(sightings, sighting{kind: "asteroid", position: asteroid.Center, radius: asteroid.Radius})
+		}
+	}
+
+	self := bot.state.Self
+	sort.SliceStable(sightings, func(i, j int) bool {
+		return sightings[i].position.Distance(self.Position) < sightings[j].position.Distance(self.Position)
+	})
+	seen := L.NewTable()
+	for _, sighting := range sightings {
+		offset := sighting.position.Subtract(self.Position)
+		bearing := math.Remainder(offset.Angle()-self.Rotation, 2*math.Pi)
+		object := L.NewTable()
+		object.RawSetString("type", lua.LString(sighting.kind))
+		if sighting.name != "" {
+			object.RawSetString("name", lua.LString(sighting.name))
+			object.RawSetString("team", lua.LString(sighting.team))
+		}
+		object.RawSetString("x", lua.LNumber(sighting.position.X))
+		object.RawSetString("y", lua.LNumber(sighting.position.Y))
+		object.RawSetString("vx", lua.LNumber(sighting.velocity.X))
+		object.RawSetString("vy", lua.LNumber(sighting.velocity.Y))
+		object.RawSetString("radius", lua.LNumber(sighting.radius))
+		object.RawSetString("distance", lua.LNumber(offset.Magnitude()))
+		object.RawSetString("bearing", lua.LNumber(bearing))
+		seen.Append(object)
+	}
+	L.Push(seen)
+	return 1
+}
+
+func (bot *Bot) thrust(L *lua.LState) int {
+	main := float64(L.CheckNumber(1))
+	bot.setEngine(math.Max(0, math.Min(game.MaxThrust, main)), bot.engine[1], bot.engine[2])
+	return 0
+}
+
+func (bot *Bot) rotate(L *lua.LState) int {
+	direction := math.Max(-1, math.Min(1, float64(L.CheckNumber(1))))
+	// The left thruster turns the spaceship counterclockwise, see the reference bots' steering
+	bot.setEngine(bot.engine[0], math.Max(direction, 0)*game.MaxThrust, math.Max(-direction, 0)*game.MaxThrust)
+	return 0
+}
+
+func (bot *Bot) setEngine(main, left, right float64) {
+	engine := [3]float64{main, left, right}
+	if engine != bot.engine {
+		bot.engine = engine
+		bot.engineChanged = true
+	}
+}
+
+func (bot *Bot) fire(L *lua.LState) int {
+	switch weapon := L.Get(1).(type) {
+	case lua.LString:
+		switch weapon {
+		case "laser":
+			bot.commands = append(bot.commands, game.Command{Action: game.CommandFireLaser})
+		case "rocket":
+			bot.commands = append(bot.commands, game.Command{Action: game.CommandFireRocket})
+		default:
+			L.ArgError(1, "unknown weapon '"+string(weapon)+"'")
+		}
+	case lua.LNumber:
+		bot.commands = append(bot.commands, game.Command{Action: game.CommandFireWeapon, Args: []float64{float64(weapon)}})
+	default:
+		L.ArgError(1, "weapon expected, got "+weapon.Type().String())
+	}
+	return 0
+}
@@ -0,0 +1,183 @@
+package scripting
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+const chaserSource = `
+function think(self, dt)
+	for _, object in ipairs(scan()) do
+		if object.type == "spaceship" and (self.team == "" or object.team ~= self.team) then
+			thrust(100)
+			if object.bearing > 0.05 then
+				rotate(1)
+			elseif object.bearing < -0.05 then
+				rotate(-1)
+			else
+				rotate(0)
+				fire("laser")
+			end
+			return
+		end
+	end
+	thrust(0)
+end
+`
+
+func newBot(t *testing.T, source string) *Bot {
+	script, err := Compile("bot", source)
+	assert.NoError(t, err)
+	bot, err := script.NewBot(nil)
+	assert.NoError(t, err)
+	return bot
+}
+
+func TestScript_NewBot(t *testing.T) {
+	script, err := Compile("bot", "x = 1")
+	assert.NoError(t, err)
+	_, err = script.NewBot(nil)
+	assert.EqualError(t, err, "bot:0: the think function is not defined")
+
+	script, err = Compile("bot", "\nerror('broken')\nfunction think() end")
+	assert.NoError(t, err)
+	_, err = script.NewBot(nil)
+	assert.EqualError(t, err, "bot:2: broken")
+
+	script, err = Compile("bot", "print('loaded', type(scan))\nfunction think() end")
+	assert.NoError(t, err)
+	var output bytes.Buffer
+	_, err = script.NewBot(&output)
+	assert.NoError(t, err)
+	assert.Equal(t, "loaded\tfunction\n", output.String())
+}
+
+func TestBot_Think(t *testing.T) {
+	self := game.SpaceshipState{Name: "self", Team: "red", Position: physics.Vector2{X: 100, Y: 100}, Energy: game.MaxEnergy}
+
+	t.Run("Steers towards the nearest enemy", func(t *testing.T) {
+		bot := newBot(t, chaserSource)
+		state := game.GameState{Self: self, Spaceships: []game.SpaceshipState{
+			{Name: "mate", Team: "red", Position: physics.Vector2{X: 110, Y: 100}},
+			{Name: "far", Team: "blue", Position: physics.Vector2{X: 100, Y: -900}},
+			{Name: "near", Team: "blue", Position: physics.Vector2{X: 100, Y: 400}},
+		}}
+		// Facing +X, the target is counterclockwise
+		assert.Equal(t, []game.Command{{Action: game.CommandSetEngineThrust, Args: []float64{game.MaxThrust, game.MaxThrust, 0}}}, bot.Think(state))
+		// The engine is kept until changed
+		assert.Empty(t, bot.Think(state))
+
+		state.Self.Rotation = math.Pi / 2
+		assert.Equal(t, []game.Command{
+			{Action: game.CommandSetEngineThrust, Args: []float64{game.MaxThrust, 0, 0}},
+			{Action: game.CommandFireLaser},
+		}, bot.Think(state))
+		assert.NoError(t, bot.Err())
+	})
+
+	t.Run("Scans the radar contacts", func(t *testing.T) {
+		bot := newBot(t, `
+			function think(self, dt)
+				local seen = scan()
+				assert(#seen == 1 and seen[1].type == "mine" and seen[1].radius == 5, "radar")
+				assert(self.name == "self" and self.x == 100 and self.rockets == 3 and dt == 16, "self")
+				assert(arena.width == 800 and arena.elapsedMs == 500, "arena")
+				fire("rocket")
+				fire(2)
+			end`)
+		self := self
+		self.Rockets = 3
+		state := game.GameState{Self: self, Size: physics.Size{Width: 800, Height: 600}, ElapsedMs: 500, DeltaTimeMs: 16,
+			Spaceships: []game.SpaceshipState{{Name: "other", Position: physics.Vector2{X: 500, Y: 100}}},
+			Contacts:   []game.Contact{{Type: "mine", Position: physics.Vector2{X: 150, Y: 100}, Radius: 5}}}
+		assert.Equal(t, []game.Command{
+			{Action: game.CommandFireRocket},
+			{Action: game.CommandFireWeapon, Args: []float64{2}},
+		}, bot.Think(state))
+		assert.NoError(t, bot.Err())
+	})
+
+	t.Run("Reports the failing think", func(t *testing.T) {
+		bot := newBot(t, "function think(self)\n\tthrust(50)\n\tfire('plasma')\nend")
+		assert.Nil(t, bot.Think(game.GameState{Self: self}))
+		assert.EqualError(t, bot.Err(), "bot:3: bad argument #1 to fire (unknown weapon 'plasma')")
+
+		bot = newBot(t, "function think(self) while true do end end")
+		assert.Nil(t, bot.Think(game.GameState{Self: self}))
+		assert.Equal(t, ErrStepLimitExceeded{Script: "bot", Limit: StepLimit}, bot.Err())
+		// The steps are counted per think
+		bot = newBot(t, "function think(self) for i = 1, 25000 do end end")
+		for i := 0; i < 10; i++ {
+			bot.Think(game.GameState{Self: self})
+		}
+		assert.NoError(t, bot.Err())
+	})
+
+	t.Run("Clamps the engine", func(t *testing.T) {
+		bot := newBot(t, "function think(self) thrust(250) rotate(-3) end")
+		assert.Equal(t, []game.Command{{Action: game.CommandSetEngineThrust, Args: []float64{game.MaxThrust, 0, game.MaxThrust}}},
+			bot.Think(game.GameState{Self: self}))
+	})
+}
+
+func TestBot_Disqualified(t *testing.T) {
+	t.Run("Over the string length", func(t *testing.T) {
+		bot := newBot(t, "keep = {}\nfunction think(self)\n\tfor i = 1, 300 do keep[#keep + 1] = string.rep('x', 2^20) .. i end\nend")
+		assert.Nil(t, bot.Think(game.GameState{}))
+		assert.Equal(t, ErrStringLimitExceeded{Script: "bot", Limit: MaxStringLength}, bot.Disqualified())
+	})
+
+	t.Run("Over the memory", func(t *testing.T) {
+		// Keeps about 10 MB per think
+		bot := newBot(t, "keep = {}\nfunction think(self)\n\tfor i = 1, 300 do keep[#keep + 1] = string.rep('x', 2^15) .. i end\nend")
+		assert.Nil(t, bot.Think(game.GameState{}))
+		assert.NoError(t, bot.Disqualified())
+		assert.Nil(t, bot.Think(game.GameState{}))
+		assert.Equal(t, ErrMemoryLimitExceeded{Script: "bot", Limit: MemoryLimit}, bot.Disqualified())
+		// Without another run
+		assert.Nil(t, bot.Think(game.GameState{}))
+		assert.Equal(t, ErrMemoryLimitExceeded{Script: "bot", Limit: MemoryLimit}, bot.Err())
+
+		// Within a think, in the locals
+		bot = newBot(t, "function think(self)\n\tlocal keep = {}\n\tfor i = 1, 1000 do keep[i] = string.rep('x', 2^15) .. i end\nend")
+		assert.Nil(t, bot.Think(game.GameState{}))
+		assert.Equal(t, ErrMemoryLimitExceeded{Script: "bot", Limit: MemoryLimit}, bot.Disqualified())
+	})
+
+	t.Run("Disables the spaceship", func(t *testing.T) {
+		bot := newBot(t, "function think(self) local s = 'x' while true do s = s .. s end end")
+		arena := game.NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+		assert.NoError(t, arena.AddSpaceship("lua", physics.Vector2{X: 100, Y: 100}, 0))
+		assert.NoError(t, arena.AddSpaceship("other", physics.Vector2{X: 500, Y: 500}, 0))
+		assert.NoError(t, arena.AddSpaceship("third", physics.Vector2{X: 900, Y: 500}, 0))
+		assert.NoError(t, arena.AddBot("lua", bot.Strategy()))
+		arena.Update(16)
+
+		assert.NoError(t, arena.SpaceshipAction("lua", func(spaceship *game.Spaceship, gameManager *game.GameManager) {
+			assert.False(t, spaceship.Enabled())
+			logs := gameManager.Logger().Logs()
+			assert.Equal(t, `"lua" is disqualified: bot: exceeded the string length limit of 65536`, logs[len(logs)-1].Message())
+		}))
+	})
+}
+
+func TestBot_InGame(t *testing.T) {
+	arena := game.NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	assert.NoError(t, arena.AddSpaceship("lua", physics.Vector2{X: 100, Y: 100}, 0))
+	assert.NoError(t, arena.AddSpaceship("target", physics.Vector2{X: 600, Y: 100}, 0))
+	bot := newBot(t, chaserSource)
+	assert.NoError(t, arena.AddBot("lua", game.NewBotStrategy(bot)))
+	arena.Update(16)
+
+	assert.NoError(t, bot.Err())
+	assert.NoError(t, arena.SpaceshipAction("lua", func(ship *game.Spaceship, _ *game.GameManager) {
+		engine := ship.Serialize()["engine"].(map[string]interface{})
+		assert.Equal(t, float64(game.MaxThrust), engine["mainThrust"])
+		assert.Equal(t, 0.0, engine["leftThrust"])
+	}))
+}
@@ -0,0 +1,139 @@
+package scripting
+
+import (
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+const (
+	// Estimated sizes of the values held by a script, see measure
+	valueSize    = 16 // Of a value on the stack or in a table, the strings add their length
+	tableSize    = 64
+	functionSize = 64
+)
+
+// budget caps the runs of a script. It is the context of the Lua state, asked by the interpreter
+// before every instruction: it counts the steps, checks the strings of the running function, the
+// ones built by the previous instruction included, and every MemoryCheckSteps steps measures the
+// memory held by the script. An exceeded limit is raised by the interpreter, pcall does not catch it.
+type budget struct {
+	script string
+	state  *lua.LState
+	steps  int
+	err    error         // The exceeded limit, the memory ones are kept for the next runs
+	done   chan struct{} // Closed once a limit is exceeded
+}
+
+func (budget *budget) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (budget *budget) Done() <-chan struct{} {
+	if budget.err != nil {
+		return budget.done
+	}
+	budget.steps++
+	if budget.steps > StepLimit {
+		budget.exceed(ErrStepLimitExceeded{Script: budget.script, Limit: StepLimit})
+		return budget.done
+	}
+	for i := 1; i <= budget.state.GetTop(); i++ {
+		if text, ok := budget.state.Get(i).(lua.LString); ok && len(text) > MaxStringLength {
+			budget.exceed(ErrStringLimitExceeded{Script: budget.script, Limit: MaxStringLength})
+			return budget.done
+		}
+	}
+	if budget.steps%MemoryCheckSteps == 0 {
+		budget.checkMemory()
+	}
+	return budget.done
+}
+
+func (budget *budget) Err() error {
+	return budget.err
+}
+
+func (budget *budget) Value(key any) any {
+	return nil
+}
+
+// exceed stops the run, the interpreter raises the error before the next instruction.
+func (budget *budget) exceed(err error) {
+	if budget.err == nil {
+		budget.err = err
+		budget.done = make(chan struct{})
+		close(budget.done)
+	}
+}
+
+// reset starts a new run, the steps are counted per run while a script over the memory limits stays over them.
+func (budget *budget) reset() {
+	budget.steps = 0
+	if _, ok := budget.err.(ErrStepLimitExceeded); ok {
+		budget.err, budget.done = nil, nil
+	}
+}
+
+func (budget *budget) checkMemory() {
+	if budget.err == nil && measure(budget.state) > MemoryLimit {
+		budget.exceed(ErrMemoryLimitExceeded{Script: budget.script, Limit: MemoryLimit})
+	}
+}
+
+// measure estimates the bytes held by the values reachable from the globals, the registry and the
+// stack. It stops once over the MemoryLimit.
+func measure(state *lua.LState) int {
+	pending := []lua.LValue{state.G.Global, state.G.Registry}
+	for level := 0; ; level++ {
+		frame, ok := state.GetStack(level)
+		if !ok {
+			break
+		}
+		// The temporaries of the frame included
+		for n := 1; ; n++ {
+			name, value := state.GetLocal(frame, n)
+			if name == "" {
+				break
+			}
+			pending = append(pending, value)
+		}
+	}
+
+	size := 0
+	seen := map[lua.LValue]bool{}
+	for len(pending) > 0 && size <= MemoryLimit {
+		value := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		size += valueSize
+		switch value := value.(type) {
+		case lua.LString:
+			size += len(value)
+		case *lua.LTable:
+			if seen[value] {
+				continue
+			}
+			seen[value] = true
+			size += tableSize
+			if value.Metatable != lua.LNil {
+				pending = append(pending, value.Metatable)
+			}
+			value.ForEach(func(key, element lua.LValue) {
+				pending = append(pending, key, element)
+			})
+		case *lua.LFunction:
+			if seen[value] {
+				continue
+			}
+			seen[value] = true
+			size += functionSize
+			if value.Env != nil {
+				pending = append(pending, value.Env)
+			}
+			for _, upvalue := range value.Upvalues {
+				pending = append(pending, upvalue.Value())
+			}
+		}
+	}
+	return size
+}
@@ -0,0 +1,50 @@
+package scripting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestMeasure(t *testing.T) {
+	sandbox := newSandbox("test", nil)
+	defer sandbox.close()
+	empty := measure(sandbox.state)
+	assert.Greater(t, empty, 0)
+
+	sandbox.state.SetGlobal("text", lua.LString(string(make([]byte, 1000))))
+	assert.Equal(t, empty+valueSize*2+len("text")+1000, measure(sandbox.state))
+
+	// Counted once, with the metatable and the upvalues
+	table := sandbox.state.NewTable()
+	table.Append(lua.LNumber(1))
+	table.Metatable = table
+	sandbox.state.SetGlobal("table", table)
+	sandbox.state.SetGlobal("again", table)
+	size := measure(sandbox.state)
+	assert.NoError(t, sandbox.state.DoString("local up = string.rep('x', 2000) function f() return up end"))
+	assert.Greater(t, measure(sandbox.state), size+2000)
+}
+
+func TestBudget_Reset(t *testing.T) {
+	sandbox := newSandbox("test", nil)
+	defer sandbox.close()
+	budget := sandbox.budget
+
+	budget.steps = StepLimit - 1
+	assert.Nil(t, budget.Done())
+	assert.NotNil(t, budget.Done())
+	assert.Equal(t, ErrStepLimitExceeded{Script: "test", Limit: StepLimit}, budget.Err())
+
+	// The steps are counted per run
+	budget.reset()
+	assert.Nil(t, budget.Done())
+	assert.NoError(t, budget.Err())
+
+	// The memory is not
+	budget.exceed(ErrMemoryLimitExceeded{Script: "test", Limit: MemoryLimit})
+	budget.reset()
+	assert.NotNil(t, budget.Done())
+	assert.Equal(t, ErrMemoryLimitExceeded{Script: "test", Limit: MemoryLimit}, budget.Err())
+}
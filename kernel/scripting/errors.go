@@ -0,0 +1,54 @@
+package scripting
+
+import "fmt"
+
+// Error is an error of the script, of its compilation or its run, at the line of the script.
+type Error struct {
+	Script  string
+	Line    int
+	Message string
+}
+
+func (err Error) Error() string {
+	return fmt.Sprintf("%s:%d: %s", err.Script, err.Line, err.Message)
+}
+
+// ErrStepLimitExceeded aborts the run of the script taking too long, pcall does not catch it.
+type ErrStepLimitExceeded struct {
+	Script string
+	Limit  int
+}
+
+func (err ErrStepLimitExceeded) Error() string {
+	return fmt.Sprintf("%s: exceeded the step limit of %d", err.Script, err.Limit)
+}
+
+// ErrStringLimitExceeded aborts the run of the script building a string over the MaxStringLength,
+// its bot is disqualified.
+type ErrStringLimitExceeded struct {
+	Script string
+	Limit  int
+}
+
+func (err ErrStringLimitExceeded) Error() string {
+	return fmt.Sprintf("%s: exceeded the string length limit of %d", err.Script, err.Limit)
+}
+
+// ErrMemoryLimitExceeded aborts the run of the script holding more than the MemoryLimit, its bot is
+// disqualified.
+type ErrMemoryLimitExceeded struct {
+	Script string
+	Limit  int
+}
+
+func (err ErrMemoryLimitExceeded) Error() string {
+	return fmt.Sprintf("%s: exceeded the memory limit of %d bytes", err.Script, err.Limit)
+}
+
+type ErrScriptNotFound struct {
+	Name string
+}
+
+func (err ErrScriptNotFound) Error() string {
+	return fmt.Sprintf("script not found: %s", err.Name)
+}
@@ -0,0 +1,114 @@
+package scripting
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Host keeps the compiled scripts of a directory, the *.lua files named by their base names.
+// Reload picks up the changed scripts, it is meant to be called between the matches so that the
+// bots of a running match keep their script.
+type Host struct {
+	dir      string
+	mutex    sync.RWMutex
+	scripts  map[string]*Script
+	modified map[string]time.Time
+}
+
+func NewHost(dir string) *Host {
+	return &Host{
+		dir:      dir,
+		scripts:  map[string]*Script{},
+		modified: map[string]time.Time{},
+	}
+}
+
+// Reload compiles the added and the changed scripts and drops the removed ones. It returns the
+// names of the reloaded scripts. A script failing to compile keeps its previous version, its error
+// is joined to the returned error.
+func (host *Host) Reload() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(host.dir, "*.lua"))
+	if err != nil {
+		return nil, err
+	}
+
+	host.mutex.Lock()
+	defer host.mutex.Unlock()
+
+	var reloaded []string
+	var errs []error
+	present := map[string]bool{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		name := strings.TrimSuffix(filepath.Base(path), ".lua")
+		present[name] = true
+		if modified, ok := host.modified[name]; ok && modified.Equal(info.ModTime()) {
+			continue
+		}
+		host.modified[name] = info.ModTime()
+
+		source, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		script, err := Compile(name, string(source))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		host.scripts[name] = script
+		reloaded = append(reloaded, name)
+	}
+
+	for name := range host.modified {
+		if !present[name] {
+			delete(host.modified, name)
+			delete(host.scripts, name)
+		}
+	}
+	return reloaded, errors.Join(errs...)
+}
+
+// Scripts returns the sorted names of the scripts.
+func (host *Host) Scripts() []string {
+	host.mutex.RLock()
+	defer host.mutex.RUnlock()
+
+	names := make([]string, 0, len(host.scripts))
+	for name := range host.scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Script returns the script of the name, ErrScriptNotFound if there is none.
+func (host *Host) Script(name string) (*Script, error) {
+	host.mutex.RLock()
+	defer host.mutex.RUnlock()
+
+	script, ok := host.scripts[name]
+	if !ok {
+		return nil, ErrScriptNotFound{Name: name}
+	}
+	return script, nil
+}
+
+// NewBot returns a new bot of the script of the name, see Script.NewBot.
+func (host *Host) NewBot(name string, output io.Writer) (*Bot, error) {
+	script, err := host.Script(name)
+	if err != nil {
+		return nil, err
+	}
+	return script.NewBot(output)
+}
@@ -0,0 +1,75 @@
+package scripting
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeScript(t *testing.T, dir, name, source string, modified time.Time) {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, os.WriteFile(path, []byte(source), 0o644))
+	assert.NoError(t, os.Chtimes(path, modified, modified))
+}
+
+func TestHost_Reload(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	writeScript(t, dir, "alpha.lua", "function think() thrust(10) end", start)
+	writeScript(t, dir, "beta.lua", "function think() end", start)
+	writeScript(t, dir, "notes.txt", "not a script", start)
+	host := NewHost(dir)
+
+	reloaded, err := host.Reload()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alpha", "beta"}, reloaded)
+	assert.Equal(t, []string{"alpha", "beta"}, host.Scripts())
+
+	// Unchanged scripts are not recompiled
+	reloaded, err = host.Reload()
+	assert.NoError(t, err)
+	assert.Empty(t, reloaded)
+
+	t.Run("Keeps the previous version of a broken script", func(t *testing.T) {
+		before, _ := host.Script("alpha")
+		writeScript(t, dir, "alpha.lua", "function think(", start.Add(time.Minute))
+		reloaded, err := host.Reload()
+		assert.Empty(t, reloaded)
+		assert.EqualError(t, err, "alpha:1: syntax error near '<eof>'")
+		after, _ := host.Script("alpha")
+		assert.Same(t, before, after)
+
+		writeScript(t, dir, "alpha.lua", "function think() thrust(20) end", start.Add(2*time.Minute))
+		reloaded, err = host.Reload()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"alpha"}, reloaded)
+		after, _ = host.Script("alpha")
+		assert.NotSame(t, before, after)
+	})
+
+	t.Run("Drops the removed scripts", func(t *testing.T) {
+		assert.NoError(t, os.Remove(filepath.Join(dir, "beta.lua")))
+		_, err := host.Reload()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"alpha"}, host.Scripts())
+	})
+}
+
+func TestHost_NewBot(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "alpha.lua", "function think() end", time.Now())
+	host := NewHost(dir)
+	_, err := host.Reload()
+	assert.NoError(t, err)
+
+	bot, err := host.NewBot("alpha", nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, bot)
+
+	_, err = host.NewBot("missing", nil)
+	assert.Equal(t, ErrScriptNotFound{Name: "missing"}, err)
+	assert.EqualError(t, err, "script not found: missing")
+}
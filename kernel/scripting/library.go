@@ -0,0 +1,169 @@
+package scripting
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// sandbox is the Lua state of a script, with the restricted libraries and the budget capping its runs.
+type sandbox struct {
+	script string
+	state  *lua.LState
+	budget *budget
+	output io.Writer
+}
+
+// newSandbox opens the basic, table, string and math libraries without the functions reaching out of
+// the sandbox, e.g. load or require, the random numbers and the string functions with an unbounded
+// result. The output receives the prints, nil discards them.
+func newSandbox(script string, output io.Writer) *sandbox {
+	if output == nil {
+		output = io.Discard
+	}
+	state := lua.NewState(lua.Options{CallStackSize: MaxCallDepth, RegistrySize: StackSize, SkipOpenLibs: true})
+	for _, library := range []struct {
+		name string
+		open lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		state.Push(state.NewFunction(library.open))
+		state.Push(lua.LString(library.name))
+		state.Call(1, 0)
+	}
+
+	sandbox := &sandbox{script: script, state: state, budget: &budget{script: script, state: state}, output: output}
+	globals := state.G.Global
+	for _, name := range []string{"collectgarbage", "dofile", "getfenv", "load", "loadfile", "loadstring", "module",
+		"newproxy", "require", "setfenv", "_printregs"} {
+		globals.RawSetString(name, lua.LNil)
+	}
+	globals.RawSetString("print", state.NewFunction(sandbox.print))
+
+	// The string table is the strings' metatable index as well, e.g. for ("x"):rep(3)
+	str := state.GetGlobal(lua.StringLibName).(*lua.LTable)
+	str.RawSetString("dump", lua.LNil)
+	str.RawSetString("gsub", lua.LNil)
+	str.RawSetString("rep", state.NewFunction(sandbox.stringRep))
+	str.RawSetString("format", state.NewFunction(sandbox.bounded(str.RawGetString("format").(*lua.LFunction), formatLength)))
+	table := state.GetGlobal(lua.TabLibName).(*lua.LTable)
+	table.RawSetString("concat", state.NewFunction(sandbox.bounded(table.RawGetString("concat").(*lua.LFunction), concatLength)))
+	math := state.GetGlobal(lua.MathLibName).(*lua.LTable)
+	math.RawSetString("random", lua.LNil)
+	math.RawSetString("randomseed", lua.LNil)
+
+	state.SetContext(sandbox.budget)
+	return sandbox
+}
+
+// call calls the function with the arguments within a new run of the budget, then measures the memory.
+func (sandbox *sandbox) call(function *lua.LFunction, args ...lua.LValue) error {
+	sandbox.budget.reset()
+	err := sandbox.state.CallByParam(lua.P{Fn: function, NRet: 0, Protect: true}, args...)
+	sandbox.budget.checkMemory()
+	if sandbox.budget.err != nil {
+		return sandbox.budget.err
+	}
+	if err != nil {
+		return runError(sandbox.script, err)
+	}
+	return nil
+}
+
+func (sandbox *sandbox) close() {
+	sandbox.state.Close()
+}
+
+// print writes the arguments separated by tabs to the output.
+func (sandbox *sandbox) print(L *lua.LState) int {
+	texts := make([]string, L.GetTop())
+	for i := range texts {
+		texts[i] = L.ToStringMeta(L.Get(i + 1)).String()
+	}
+	fmt.Fprintln(sandbox.output, strings.Join(texts, "\t"))
+	return 0
+}
+
+// stringRep is string.rep failing before building a string over the MaxStringLength.
+func (sandbox *sandbox) stringRep(L *lua.LState) int {
+	text, count := L.CheckString(1), L.CheckInt(2)
+	if count <= 0 {
+		L.Push(lua.LString(""))
+		return 1
+	}
+	if len(text) > MaxStringLength/count {
+		sandbox.budget.exceed(ErrStringLimitExceeded{Script: sandbox.script, Limit: MaxStringLength})
+		L.RaiseError(sandbox.budget.err.Error())
+	}
+	L.Push(lua.LString(strings.Repeat(text, count)))
+	return 1
+}
+
+// bounded wraps the library function building a string, it fails before the call when the length
+// estimated by the arguments is over the MaxStringLength.
+func (sandbox *sandbox) bounded(function *lua.LFunction, length func(L *lua.LState) int) lua.LGFunction {
+	return func(L *lua.LState) int {
+		if length(L) > MaxStringLength {
+			sandbox.budget.exceed(ErrStringLimitExceeded{Script: sandbox.script, Limit: MaxStringLength})
+			L.RaiseError(sandbox.budget.err.Error())
+		}
+		return function.GFunction(L)
+	}
+}
+
+// formatLength bounds the result of string.format. The width and the precision are of 2 digits at
+// most like in Lua 5.1, a quoted argument is escaped to 4 bytes per byte at most.
+func formatLength(L *lua.LState) int {
+	format := L.CheckString(1)
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		for i < len(format) && strings.IndexByte("-+ #0", format[i]) >= 0 {
+			i++
+		}
+		for _, part := range []string{"width", "precision"} {
+			if part == "precision" {
+				if i >= len(format) || format[i] != '.' {
+					break
+				}
+				i++
+			}
+			digits := 0
+			for ; i < len(format) && format[i] >= '0' && format[i] <= '9'; i++ {
+				digits++
+			}
+			if digits > 2 {
+				L.RaiseError("invalid format (width or precision too long)")
+			}
+		}
+	}
+
+	length := len(format)
+	for i := 2; i <= L.GetTop(); i++ {
+		length += 4*len(L.Get(i).String()) + 100
+	}
+	return length
+}
+
+// concatLength is the length of the result of table.concat.
+func concatLength(L *lua.LState) int {
+	table := L.CheckTable(1)
+	separator := L.OptString(2, "")
+	first, last := max(L.OptInt(3, 1), 1), min(L.OptInt(4, table.Len()), table.Len())
+	length := 0
+	for i := first; i <= last; i++ {
+		length += len(table.RawGetInt(i).String())
+		if i < last {
+			length += len(separator)
+		}
+	}
+	return length
+}
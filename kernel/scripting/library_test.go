@@ -0,0 +1,84 @@
+package scripting
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	lua "github.com/yuin/gopher-lua"
+)
+
+func TestLibraries(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		source   string
+		expected []lua.LValue
+	}{
+		{"assert", "return assert(1, 'unused')", []lua.LValue{lua.LNumber(1), lua.LString("unused")}},
+		{"ipairs", "local s = 0 for i, v in ipairs({5, 6, 7}) do s = s + i * v end return s", []lua.LValue{lua.LNumber(38)}},
+		{"pcall", "return pcall(function(x) error('bad ' .. x) end, 'input')", []lua.LValue{lua.LFalse, lua.LString("test:2: bad input")}},
+		{"select", "return select('#', 1, 2, 3), select(2, 'a', 'b', 'c')", []lua.LValue{lua.LNumber(3), lua.LString("b"), lua.LString("c")}},
+		{"varargs", "local function sum(...) local s = 0 for _, v in ipairs({...}) do s = s + v end return s end return sum(1, 2, 3)",
+			[]lua.LValue{lua.LNumber(6)}},
+		{"metatables", "local v = setmetatable({}, {__index = function(_, key) return key .. '!' end}) return v.x",
+			[]lua.LValue{lua.LString("x!")}},
+		{"math", "return math.abs(-2), math.floor(2.7), math.max(1, 5, 3), math.sqrt(16), math.atan2(0, -1) == math.pi",
+			[]lua.LValue{lua.LNumber(2), lua.LNumber(2), lua.LNumber(5), lua.LNumber(4), lua.LTrue}},
+		{"string", "return string.len('abc'), string.sub('hello', 2, -2), ('ab'):rep(2), string.match('ship-42', '%a+-(%d+)')",
+			[]lua.LValue{lua.LNumber(3), lua.LString("ell"), lua.LString("abab"), lua.LString("42")}},
+		{"string.format", "return string.format('%d %5.1f %s', 3, 2.25, 'x')", []lua.LValue{lua.LString("3   2.2 x")}},
+		{"table", "local t = {3, 1} table.insert(t, 2) table.sort(t) return table.concat(t, ','), table.remove(t), #t",
+			[]lua.LValue{lua.LString("1,2,3"), lua.LNumber(3), lua.LNumber(2)}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			values, err := runChunk("\n" + test.source)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestLibraries_Errors(t *testing.T) {
+	for source, message := range map[string]string{
+		"assert(false)":               "test:1: assertion failed!",
+		"assert(nil, 'custom')":       "test:1: custom",
+		"math.floor('x')":             "test:1: bad argument #1 to floor (number expected, got string)",
+		"string.format('%100d', 1)":   "test:1: invalid format (width or precision too long)",
+		"string.format('%5.100f', 1)": "test:1: invalid format (width or precision too long)",
+		"table.sort({1, 'a'})":        "test:1: attempt to compare string with number",
+		"local x = {} + 1":            "test:1: cannot perform add operation between table and number",
+	} {
+		_, err := runChunk(source)
+		assert.EqualError(t, err, message, source)
+	}
+}
+
+func TestLibraries_StringLimit(t *testing.T) {
+	for _, source := range []string{
+		"return string.rep('x', 1e12)",
+		"return ('xy'):rep(1 + 2^15)",
+		"local s = 'x' while true do s = s .. s end",
+		"local t = {} for i = 1, 10 do t[i] = string.rep('x', 2^13) end return table.concat(t)",
+		"local s = string.rep('x', 2^14) return string.format('%s%s%s%s', s, s, s, s)",
+	} {
+		_, err := runChunk(source)
+		assert.Equal(t, ErrStringLimitExceeded{Script: "test", Limit: MaxStringLength}, err, source)
+	}
+
+	values, err := runChunk("local s = string.rep('x', 2^16) return #s, #(s:sub(2) .. 'y')")
+	assert.NoError(t, err)
+	assert.Equal(t, []lua.LValue{lua.LNumber(MaxStringLength), lua.LNumber(MaxStringLength)}, values)
+}
+
+func TestSandbox_Print(t *testing.T) {
+	var output bytes.Buffer
+	sandbox := newSandbox("test", &output)
+	defer sandbox.close()
+	sandbox.state.Push(sandbox.state.NewFunction(sandbox.print))
+	sandbox.state.Push(lua.LString("a"))
+	sandbox.state.Push(lua.LNumber(1))
+	sandbox.state.Push(lua.LNil)
+	sandbox.state.Push(lua.LTrue)
+	sandbox.state.Call(4, 0)
+	assert.Equal(t, "a\t1\tnil\ttrue\n", output.String())
+}
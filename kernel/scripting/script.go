@@ -0,0 +1,102 @@
+// Package scripting runs the bots written as Lua scripts, so that the competitors do not need Go.
+// A script defines the global function think(self, dt), called every tick with its own spaceship,
+// which steers the spaceship by the limited API: scan, thrust, rotate and fire, see Bot. The Host
+// keeps the scripts of a directory, reloaded between the matches.
+//
+// The scripts run on gopher-lua, Lua 5.1, sandboxed to the basic, math, string and table libraries.
+// There is no io, os, load, require, coroutines or string.gsub, and math.random is left out to keep
+// the matches reproducible. Every run, the loading of the script or a think call, is capped by the
+// StepLimit, and the call depth and the stack by MaxCallDepth and StackSize. A bot building a string
+// over the MaxStringLength or holding more than the MemoryLimit is disqualified.
+package scripting
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+const (
+	// StepLimit caps the instructions of a single run of a script, the loading of the script or a think call
+	StepLimit = 100000
+	// MaxCallDepth caps the nested calls, the deeper ones fail with a stack overflow
+	MaxCallDepth = 200
+	// StackSize caps the values on the stack of the calls
+	StackSize = 1 << 14
+	// MaxStringLength caps the strings built by the scripts
+	MaxStringLength = 1 << 16
+	// MemoryLimit caps the estimated bytes of the values held by a script, the globals and the stack
+	MemoryLimit = 1 << 24
+	// MemoryCheckSteps is the steps between the measurements of the memory within a run, it is
+	// measured after every run as well
+	MemoryCheckSteps = 1000
+)
+
+// Script is a compiled Lua script, run by the bots created from it.
+type Script struct {
+	name  string
+	proto *lua.FunctionProto
+}
+
+// Compile parses the source of the script, the name is used in the error messages.
+func Compile(name, source string) (*Script, error) {
+	chunk, err := parse.Parse(strings.NewReader(source), name)
+	if err != nil {
+		var parseErr *parse.Error
+		if errors.As(err, &parseErr) {
+			if parseErr.Pos.Line == parse.EOF {
+				return nil, Error{Script: name, Line: strings.Count(source, "\n") + 1, Message: parseErr.Message + " near '<eof>'"}
+			}
+			return nil, Error{Script: name, Line: parseErr.Pos.Line, Message: parseErr.Message + " near '" + parseErr.Token + "'"}
+		}
+		return nil, err
+	}
+	proto, err := lua.Compile(chunk, name)
+	if err != nil {
+		var compileErr *lua.CompileError
+		if errors.As(err, &compileErr) {
+			return nil, Error{Script: name, Line: compileErr.Line, Message: compileErr.Message}
+		}
+		return nil, err
+	}
+	return &Script{name: name, proto: proto}, nil
+}
+
+func (script *Script) Name() string {
+	return script.name
+}
+
+// run runs the script's chunk in a new sandbox with the api functions, its globals are kept by the
+// returned sandbox.
+func (script *Script) run(output io.Writer, api map[string]lua.LGFunction) (*sandbox, error) {
+	sandbox := newSandbox(script.name, output)
+	for name, function := range api {
+		sandbox.state.SetGlobal(name, sandbox.state.NewFunction(function))
+	}
+	if err := sandbox.call(sandbox.state.NewFunctionFromProto(script.proto)); err != nil {
+		sandbox.close()
+		return nil, err
+	}
+	return sandbox, nil
+}
+
+// runError converts the error raised by the script to Error, its message is prefixed by "script:line: ".
+func runError(script string, err error) error {
+	var apiErr *lua.ApiError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	message := apiErr.Object.String()
+	if rest, ok := strings.CutPrefix(message, script+":"); ok {
+		if position, text, ok := strings.Cut(rest, ": "); ok {
+			if line, err := strconv.Atoi(position); err == nil {
+				return Error{Script: script, Line: line, Message: text}
+			}
+		}
+	}
+	return Error{Script: script, Line: 0, Message: message}
+}
@@ -0,0 +1,82 @@
+package scripting
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// runChunk compiles and runs the source in a new sandbox, returns the values of its return statement.
+func runChunk(source string) ([]lua.LValue, error) {
+	script, err := Compile("test", source)
+	if err != nil {
+		return nil, err
+	}
+	sandbox := newSandbox(script.name, nil)
+	defer sandbox.close()
+	var values []lua.LValue
+	err = sandbox.call(sandbox.state.NewFunction(func(L *lua.LState) int {
+		L.Push(L.NewFunctionFromProto(script.proto))
+		L.Call(0, lua.MultRet)
+		for i := 1; i <= L.GetTop(); i++ {
+			values = append(values, L.Get(i))
+		}
+		return 0
+	}))
+	return values, err
+}
+
+func TestCompile(t *testing.T) {
+	script, err := Compile("bot", "function think(self, dt) end")
+	assert.NoError(t, err)
+	assert.Equal(t, "bot", script.Name())
+
+	_, err = Compile("bot", "local x = \n\n function(")
+	assert.Equal(t, Error{Script: "bot", Line: 3, Message: "syntax error near '<eof>'"}, err)
+	assert.EqualError(t, err, "bot:3: syntax error near '<eof>'")
+
+	_, err = Compile("bot", "x = 1\ny = = 2")
+	assert.EqualError(t, err, "bot:2: syntax error near '='")
+}
+
+func TestScript_Run(t *testing.T) {
+	script, err := Compile("bot", "print('hello', 42, nil)\ncounter = 1")
+	assert.NoError(t, err)
+
+	var output bytes.Buffer
+	sandbox, err := script.run(&output, map[string]lua.LGFunction{
+		"answer": func(L *lua.LState) int { L.Push(lua.LNumber(42)); return 1 },
+	})
+	assert.NoError(t, err)
+	defer sandbox.close()
+	assert.Equal(t, "hello\t42\tnil\n", output.String())
+	assert.Equal(t, lua.LNumber(1), sandbox.state.GetGlobal("counter"))
+	assert.IsType(t, &lua.LFunction{}, sandbox.state.GetGlobal("answer"))
+
+	t.Run("Sandboxed", func(t *testing.T) {
+		values, err := runChunk("return io, os, load, loadstring, require, dofile, coroutine, debug, math.random, string.gsub")
+		assert.NoError(t, err)
+		assert.Equal(t, []lua.LValue{lua.LNil, lua.LNil, lua.LNil, lua.LNil, lua.LNil, lua.LNil, lua.LNil, lua.LNil, lua.LNil, lua.LNil}, values)
+	})
+
+	t.Run("Reports the line", func(t *testing.T) {
+		_, err := runChunk("local x = 1\nlocal y = x + nil")
+		assert.Equal(t, Error{Script: "test", Line: 2, Message: "cannot perform add operation between number and nil"}, err)
+	})
+
+	t.Run("Limits the steps", func(t *testing.T) {
+		_, err := runChunk("while true do end")
+		assert.Equal(t, ErrStepLimitExceeded{Script: "test", Limit: StepLimit}, err)
+
+		_, err = runChunk("return pcall(function() while true do end end)")
+		assert.Equal(t, ErrStepLimitExceeded{Script: "test", Limit: StepLimit}, err)
+	})
+
+	t.Run("Limits the call depth", func(t *testing.T) {
+		_, err := runChunk("local function f() return f() + 1 end\nreturn f()")
+		assert.IsType(t, Error{}, err)
+		assert.Contains(t, err.Error(), "stack overflow")
+	})
+}
@@ -0,0 +1,255 @@
+// Package server exposes a game over WebSocket so the remote bots can control the spaceships.
+//
+// A client joins with {"type": "join", "ship": "alpha"} to take over an existing spaceship, then
+// sends {"type": "command", "action": "setEngineThrust", "args": [100, 0, 0]} with the actions of
+// game.Command. The commands are applied by the next tick, or later by the command delay of the spaceship,
+// and after every tick the clients receive {"type": "state", "tick": 1, "state": {...}} with the
+// serialized game.
+//
+// Every client is written by its own goroutine, a client falling behind skips the stale states and
+// one not reading at all is dropped after the WriteTimeout, so it never holds up the ticks.
+//
+// The browsers connect from the pages of the server's own host, or of the origins it is created with.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+)
+
+const (
+	MessageJoin    = "join"
+	MessageJoined  = "joined"
+	MessageCommand = "command"
+	MessageState   = "state"
+	MessageError   = "error"
+
+	// WriteTimeout drops the client whose message could not be written in time
+	WriteTimeout = 5 * time.Second
+	// replyQueueSize is the replies queued for a client, its read loop waits for the room
+	replyQueueSize = 16
+)
+
+// ClientMessage is a message sent by the client.
+type ClientMessage struct {
+	Type   string    `json:"type"`
+	Ship   string    `json:"ship,omitempty"`
+	Action string    `json:"action,omitempty"`
+	Args   []float64 `json:"args,omitempty"`
+}
+
+// ServerMessage is a message sent to the client.
+type ServerMessage struct {
+	Type    string                 `json:"type"`
+	Ship    string                 `json:"ship,omitempty"`
+	Tick    uint64                 `json:"tick,omitempty"`
+	State   map[string]interface{} `json:"state,omitempty"`
+	Message string                 `json:"message,omitempty"`
+}
+
+type client struct {
+	conn    *websocketConn
+	ship    string        // Empty until joined
+	replies chan []byte   // Sent in order, never dropped
+	state   chan []byte   // The latest state only, a newer one replaces the unsent one
+	done    chan struct{} // Closed once the client disconnects
+	stopped chan struct{} // Closed once the writer stops
+}
+
+// Server serves the game to the WebSocket clients, the host drives the game by calling Tick.
+type Server struct {
+	game      *game.Game
+	spectator *game.SpectatorView
+	mutex     sync.Mutex // Guards the clients and the pending commands
+	clients   map[*client]struct{}
+	pending   []game.Command
+	timeout   time.Duration
+	origins   []string // The origin patterns allowed besides the server's own host
+}
+
+// NewServer returns the server of the game. The origins are the host patterns of the pages allowed to
+// connect besides the server's own, e.g. "example.com" or "*.example.com".
+func NewServer(instance *game.Game, origins ...string) *Server {
+	return &Server{
+		game:      instance,
+		spectator: game.NewSpectatorView(instance),
+		clients:   map[*client]struct{}{},
+		timeout:   WriteTimeout,
+		origins:   origins,
+	}
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and serves the client until it disconnects.
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrade(w, r, server.origins)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	conn.writeTimeout = server.timeout
+
+	c := &client{
+		conn:    conn,
+		replies: make(chan []byte, replyQueueSize),
+		state:   make(chan []byte, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go server.write(c)
+	defer close(c.done)
+
+	server.mutex.Lock()
+	server.clients[c] = struct{}{}
+	server.mutex.Unlock()
+	defer func() {
+		server.mutex.Lock()
+		delete(server.clients, c)
+		server.mutex.Unlock()
+	}()
+
+	for {
+		data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var message ClientMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			server.send(c, ServerMessage{Type: MessageError, Message: "invalid message: " + err.Error()})
+			continue
+		}
+		reply, err := server.handle(c, message)
+		if err != nil {
+			reply = &ServerMessage{Type: MessageError, Message: err.Error()}
+		}
+		if reply != nil {
+			server.send(c, *reply)
+		}
+	}
+}
+
+// handle processes the client message, returns the reply if any.
+func (server *Server) handle(c *client, message ClientMessage) (*ServerMessage, error) {
+	switch message.Type {
+	case MessageJoin:
+		if !server.game.HasSpaceship(message.Ship) {
+			return nil, game.ErrSpaceshipNotFound{Name: message.Ship}
+		}
+
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		if c.ship != "" {
+			return nil, fmt.Errorf("already joined as %s", c.ship)
+		}
+		for other := range server.clients {
+			if other.ship == message.Ship {
+				return nil, fmt.Errorf("space ship is already controlled: %s", message.Ship)
+			}
+		}
+		c.ship = message.Ship
+		return &ServerMessage{Type: MessageJoined, Ship: c.ship}, nil
+	case MessageCommand:
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		if c.ship == "" {
+			return nil, errors.New("join a space ship first")
+		}
+		command := game.Command{Ship: c.ship, Action: message.Action, Args: message.Args}
+		if err := command.Validate(); err != nil {
+			return nil, err
+		}
+		server.pending = append(server.pending, command)
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("invalid message type: %s", message.Type)
+	}
+}
+
+// Tick enqueues the commands received since the previous tick, updates the game and broadcasts its state.
+// The commands are applied once the command delay of their spaceship passes, the ones of the spaceships
+// removed meanwhile are dropped.
+func (server *Server) Tick(deltaTimeMs float64) {
+	server.mutex.Lock()
+	pending := server.pending
+	server.pending = nil
+	server.mutex.Unlock()
+
+	for _, command := range pending {
+		_ = server.game.EnqueueCommand(command)
+	}
+	server.game.Update(deltaTimeMs)
+	server.Broadcast()
+}
+
+// Broadcast queues the game state for all the clients without waiting for them, replacing the states
+// they have not been sent yet.
+func (server *Server) Broadcast() {
+	state := server.spectator.Serialize()
+	tick, _ := state["tick"].(uint64)
+	data, err := json.Marshal(ServerMessage{Type: MessageState, Tick: tick, State: state})
+	if err != nil {
+		return
+	}
+
+	server.mutex.Lock()
+	clients := make([]*client, 0, len(server.clients))
+	for c := range server.clients {
+		clients = append(clients, c)
+	}
+	server.mutex.Unlock()
+
+	for _, c := range clients {
+		// Drop the stale state, then queue the new one unless a concurrent Broadcast did meanwhile
+		select {
+		case <-c.state:
+		default:
+		}
+		select {
+		case c.state <- data:
+		default:
+		}
+	}
+}
+
+// ClientCount returns the number of the connected clients.
+func (server *Server) ClientCount() int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return len(server.clients)
+}
+
+// send queues the reply for the client, waiting for the room unless the writer stopped.
+func (server *Server) send(c *client, message ServerMessage) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	select {
+	case c.replies <- data:
+	case <-c.stopped:
+	}
+}
+
+// write sends the queued replies and states to the client until it disconnects. A failed write, e.g.
+// past the write timeout, closes the connection so the read loop drops the client.
+func (server *Server) write(c *client) {
+	defer close(c.stopped)
+	for {
+		var data []byte
+		select {
+		case data = <-c.replies:
+		case data = <-c.state:
+		case <-c.done:
+			return
+		}
+		if err := c.conn.WriteMessage(data); err != nil {
+			c.conn.Close()
+			return
+		}
+	}
+}
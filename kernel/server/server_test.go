@@ -0,0 +1,133 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer() (*game.Game, *Server, *httptest.Server) {
+	instance := game.NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	instance.AddSpaceship("alpha", physics.Vector2{X: 100, Y: 100}, 0)
+	instance.AddSpaceship("beta", physics.Vector2{X: 500, Y: 500}, 0)
+	instance.Start()
+	server := NewServer(instance)
+	return instance, server, httptest.NewServer(server)
+}
+
+func (client *testClient) send(message ClientMessage) {
+	data, _ := json.Marshal(message)
+	client.write(string(data))
+}
+
+func (client *testClient) receive(t *testing.T) ServerMessage {
+	var message ServerMessage
+	assert.NoError(t, json.Unmarshal([]byte(client.read(t)), &message))
+	return message
+}
+
+func TestServer_Join(t *testing.T) {
+	_, _, httpServer := newTestServer()
+	defer httpServer.Close()
+	client := dial(t, httpServer.URL)
+	defer client.close()
+
+	client.send(ClientMessage{Type: MessageJoin, Ship: "gamma"})
+	assert.Equal(t, ServerMessage{Type: MessageError, Message: "space ship not found: gamma"}, client.receive(t))
+
+	client.send(ClientMessage{Type: MessageJoin, Ship: "alpha"})
+	assert.Equal(t, ServerMessage{Type: MessageJoined, Ship: "alpha"}, client.receive(t))
+
+	client.send(ClientMessage{Type: MessageJoin, Ship: "beta"})
+	assert.Equal(t, ServerMessage{Type: MessageError, Message: "already joined as alpha"}, client.receive(t))
+
+	other := dial(t, httpServer.URL)
+	defer other.close()
+	other.send(ClientMessage{Type: MessageJoin, Ship: "alpha"})
+	assert.Equal(t, ServerMessage{Type: MessageError, Message: "space ship is already controlled: alpha"}, other.receive(t))
+}
+
+func TestServer_Command(t *testing.T) {
+	instance, server, httpServer := newTestServer()
+	defer httpServer.Close()
+	client := dial(t, httpServer.URL)
+	defer client.close()
+
+	client.send(ClientMessage{Type: MessageCommand, Action: game.CommandSetEngineThrust, Args: []float64{100, 0, 0}})
+	assert.Equal(t, ServerMessage{Type: MessageError, Message: "join a space ship first"}, client.receive(t))
+
+	client.send(ClientMessage{Type: MessageJoin, Ship: "alpha"})
+	client.receive(t)
+
+	client.send(ClientMessage{Type: MessageCommand, Action: "selfDestruct"})
+	assert.Equal(t, ServerMessage{Type: MessageError, Message: "invalid action: selfDestruct"}, client.receive(t))
+
+	client.send(ClientMessage{Type: "dance"})
+	assert.Equal(t, ServerMessage{Type: MessageError, Message: "invalid message type: dance"}, client.receive(t))
+
+	client.write("{")
+	assert.Equal(t, MessageError, client.receive(t).Type)
+
+	client.send(ClientMessage{Type: MessageCommand, Action: game.CommandSetEngineThrust, Args: []float64{100, 0, 0}})
+	assert.Eventually(t, func() bool {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		return len(server.pending) == 1
+	}, time.Second, time.Millisecond)
+
+	server.Tick(16)
+	state := client.receive(t)
+	assert.Equal(t, MessageState, state.Type)
+	assert.Equal(t, uint64(1), state.Tick)
+	assert.Len(t, state.State["gameObjects"], 2)
+
+	var velocity physics.Vector2
+	instance.SpaceshipAction("alpha", func(spaceShip *game.Spaceship, gameManager *game.GameManager) {
+		velocity = spaceShip.Velocity()
+	})
+	assert.NotEqual(t, physics.Vector2{}, velocity)
+}
+
+func TestServer_ClientCount(t *testing.T) {
+	_, server, httpServer := newTestServer()
+	defer httpServer.Close()
+
+	client := dial(t, httpServer.URL)
+	assert.Eventually(t, func() bool { return server.ClientCount() == 1 }, time.Second, time.Millisecond)
+
+	client.close()
+	assert.Eventually(t, func() bool { return server.ClientCount() == 0 }, time.Second, time.Millisecond)
+}
+
+func TestServer_Tick_ClientNotReading(t *testing.T) {
+	_, server, httpServer := newTestServer()
+	defer httpServer.Close()
+	server.timeout = 200 * time.Millisecond
+
+	stuck := dialSlow(t, httpServer.URL)
+	defer stuck.close()
+	assert.Eventually(t, func() bool { return server.ClientCount() == 1 }, time.Second, time.Millisecond)
+
+	// The ticks go on while the unread states fill up the connection, until the client is dropped
+	deadline := time.Now().Add(10 * time.Second)
+	for server.ClientCount() > 0 && time.Now().Before(deadline) {
+		start := time.Now()
+		server.Tick(16)
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+		// Paced like a game loop, so the writer gets to fill the connection
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, 0, server.ClientCount())
+
+	// The new clients are served as usual
+	client := dial(t, httpServer.URL)
+	defer client.close()
+	assert.Eventually(t, func() bool { return server.ClientCount() == 1 }, time.Second, time.Millisecond)
+	server.Tick(16)
+	assert.Equal(t, MessageState, client.receive(t).Type)
+}
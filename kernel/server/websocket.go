@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/coder/websocket"
+)
+
+const (
+	// maxMessageSize is the read limit of a client message, a larger one closes the connection
+	maxMessageSize = 64 * 1024
+)
+
+// websocketConn is the server side of a client connection, it exchanges the text messages only.
+type websocketConn struct {
+	conn         *websocket.Conn
+	writeTimeout time.Duration // Of every message, 0 for no timeout
+}
+
+// upgrade performs the opening handshake. The cross-origin requests are rejected unless their origin
+// matches one of the patterns, e.g. "example.com" or "*.example.com".
+func upgrade(w http.ResponseWriter, r *http.Request, origins []string) (*websocketConn, error) {
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{OriginPatterns: origins})
+	if err != nil {
+		return nil, err
+	}
+	conn.SetReadLimit(maxMessageSize)
+	return &websocketConn{conn: conn}, nil
+}
+
+// ReadMessage returns the next text message, answering the pings meanwhile.
+// A binary message closes the connection.
+func (ws *websocketConn) ReadMessage() ([]byte, error) {
+	messageType, message, err := ws.conn.Read(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if messageType != websocket.MessageText {
+		ws.conn.Close(websocket.StatusUnsupportedData, "text messages expected")
+		return nil, websocket.CloseError{Code: websocket.StatusUnsupportedData}
+	}
+	return message, nil
+}
+
+// WriteMessage sends the text message, it is safe for concurrent use.
+func (ws *websocketConn) WriteMessage(message []byte) error {
+	ctx := context.Background()
+	if ws.writeTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, ws.writeTimeout)
+		defer cancel()
+	}
+	return ws.conn.Write(ctx, websocket.MessageText, message)
+}
+
+func (ws *websocketConn) Close() error {
+	return ws.conn.CloseNow()
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// testClient is the client side of a connection, its reads fail the test after a second.
+type testClient struct {
+	conn *websocket.Conn
+}
+
+func dialOptions(t *testing.T, url string, options *websocket.DialOptions) *testClient {
+	conn, _, err := websocket.Dial(context.Background(), strings.Replace(url, "http", "ws", 1), options)
+	assert.NoError(t, err)
+	return &testClient{conn: conn}
+}
+
+func dial(t *testing.T, url string) *testClient {
+	return dialOptions(t, url, nil)
+}
+
+// dialSlow dials a client of a small receive buffer, so a server writing to it without reads blocks soon.
+func dialSlow(t *testing.T, url string) *testClient {
+	transport := &http.Transport{DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, network, address)
+		if err == nil {
+			conn.(*net.TCPConn).SetReadBuffer(1024)
+		}
+		return conn, err
+	}}
+	return dialOptions(t, url, &websocket.DialOptions{HTTPClient: &http.Client{Transport: transport}})
+}
+
+func (client *testClient) write(message string) {
+	client.conn.Write(context.Background(), websocket.MessageText, []byte(message))
+}
+
+func (client *testClient) read(t *testing.T) string {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	messageType, message, err := client.conn.Read(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, websocket.MessageText, messageType)
+	return string(message)
+}
+
+func (client *testClient) close() {
+	client.conn.CloseNow()
+}
+
+// echoServer echoes the text messages back.
+func echoServer(origins ...string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrade(w, r, origins)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			conn.WriteMessage(message)
+		}
+	}))
+}
+
+func TestUpgrade_InvalidHandshake(t *testing.T) {
+	server := echoServer()
+	defer server.Close()
+
+	response, err := http.Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUpgradeRequired, response.StatusCode)
+}
+
+func TestUpgrade_Origin(t *testing.T) {
+	server := echoServer("*.example.com")
+	defer server.Close()
+	url := strings.Replace(server.URL, "http", "ws", 1)
+	withOrigin := func(origin string) *websocket.DialOptions {
+		return &websocket.DialOptions{HTTPHeader: http.Header{"Origin": {origin}}}
+	}
+
+	// The pages of the server's own host and of the allowed origins
+	dialOptions(t, server.URL, withOrigin(server.URL)).close()
+	dialOptions(t, server.URL, withOrigin("https://play.example.com")).close()
+
+	// A cross-site page may not connect on behalf of its visitor
+	_, response, err := websocket.Dial(context.Background(), url, withOrigin("https://evil.com"))
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+}
+
+func TestWebsocketConn(t *testing.T) {
+	server := echoServer()
+	defer server.Close()
+	client := dial(t, server.URL)
+	defer client.close()
+
+	client.write("hello")
+	assert.Equal(t, "hello", client.read(t))
+
+	long := strings.Repeat("x", 300)
+	client.write(long)
+	assert.Equal(t, long, client.read(t))
+}
+
+func TestWebsocketConn_ReadLimit(t *testing.T) {
+	server := echoServer()
+	defer server.Close()
+	client := dial(t, server.URL)
+	defer client.close()
+
+	client.write(strings.Repeat("x", maxMessageSize+1))
+	_, _, err := client.conn.Read(context.Background())
+	assert.Equal(t, websocket.StatusMessageTooBig, websocket.CloseStatus(err))
+}
+
+func TestWebsocketConn_BinaryMessage(t *testing.T) {
+	server := echoServer()
+	defer server.Close()
+	client := dial(t, server.URL)
+	defer client.close()
+
+	client.conn.Write(context.Background(), websocket.MessageBinary, []byte("hi"))
+	_, _, err := client.conn.Read(context.Background())
+	assert.Equal(t, websocket.StatusUnsupportedData, websocket.CloseStatus(err))
+}
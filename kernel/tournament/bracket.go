@@ -0,0 +1,108 @@
+package tournament
+
+// roundRobin pairs everyone with everyone once by the circle method: the first entrant stays
+// in place and the others rotate around it. With an odd count, the one paired with the bye rests.
+func roundRobin(names []string) []Match {
+	players := append([]string(nil), names...)
+	if len(players)%2 == 1 {
+		players = append(players, "")
+	}
+	count := len(players)
+
+	var matches []Match
+	for round := 0; round < count-1; round++ {
+		for i := 0; i < count/2; i++ {
+			home, away := players[i], players[count-1-i]
+			if home == "" || away == "" {
+				continue
+			}
+			// The spawn sides alternate between the rounds
+			if round%2 == 1 {
+				home, away = away, home
+			}
+			matches = append(matches, Match{Round: round, Home: home, Away: away})
+		}
+		players = append([]string{players[0], players[count-1]}, players[1:count-1]...)
+	}
+	return matches
+}
+
+// eliminationRound creates the first round of the bracket, padded to a power of two by the byes
+// of the top seeds. The seeds are placed so the top two can only meet in the final.
+func eliminationRound(names []string) []Match {
+	size := 2
+	for size < len(names) {
+		size *= 2
+	}
+
+	order := bracketOrder(size)
+	matches := make([]Match, 0, size/2)
+	for i := 0; i < size; i += 2 {
+		// The better seed is always the first of the pair, only the second one can be missing
+		match := Match{Home: names[order[i]-1]}
+		if seed := order[i+1]; seed <= len(names) {
+			match.Away = names[seed-1]
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// bracketOrder returns the 1-based seeds in the bracket slots, e.g. 1, 8, 4, 5, 2, 7, 3, 6 for 8.
+// Every seed of the doubled bracket is paired with its complement to size + 1.
+func bracketOrder(size int) []int {
+	order := []int{1}
+	for length := 1; length < size; length *= 2 {
+		next := make([]int, 0, 2*length)
+		for _, seed := range order {
+			next = append(next, seed, 2*length+1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// lastRound returns the matches of the highest scheduled round.
+func (tournament *Tournament) lastRound() []Match {
+	if len(tournament.matches) == 0 {
+		return nil
+	}
+	round := tournament.matches[len(tournament.matches)-1].Round
+	var matches []Match
+	for _, match := range tournament.matches {
+		if match.Round == round {
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// advance schedules the next elimination round once the last one is played, the winners of the neighbouring
+// matches meet. A draw is won by the home entrant, the better seed.
+func (tournament *Tournament) advance() {
+	if tournament.config.Format != SingleElimination {
+		return
+	}
+	last := tournament.lastRound()
+	if len(last) < 2 {
+		return
+	}
+	for _, match := range last {
+		if !match.Played {
+			return
+		}
+	}
+
+	next := make([]Match, 0, len(last)/2)
+	for i := 0; i < len(last); i += 2 {
+		next = append(next, Match{Round: last[i].Round + 1, Home: advancing(last[i]), Away: advancing(last[i+1])})
+	}
+	tournament.schedule(next)
+}
+
+func advancing(match Match) string {
+	if match.Winner == "" {
+		return match.Home
+	}
+	return match.Winner
+}
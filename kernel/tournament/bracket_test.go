@@ -0,0 +1,60 @@
+package tournament
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundRobin(t *testing.T) {
+	matches := roundRobin([]string{"a", "b", "c", "d"})
+	assert.Len(t, matches, 6)
+	pairs := map[[2]string]bool{}
+	for _, match := range matches {
+		pair := [2]string{min(match.Home, match.Away), max(match.Home, match.Away)}
+		assert.False(t, pairs[pair], "%v played twice", pair)
+		pairs[pair] = true
+	}
+	assert.Equal(t, 2, matches[len(matches)-1].Round)
+
+	// The odd entrant out rests every round
+	matches = roundRobin([]string{"a", "b", "c"})
+	assert.Len(t, matches, 3)
+	for _, match := range matches {
+		assert.False(t, match.Bye())
+	}
+}
+
+func TestBracketOrder(t *testing.T) {
+	assert.Equal(t, []int{1, 2}, bracketOrder(2))
+	assert.Equal(t, []int{1, 4, 2, 3}, bracketOrder(4))
+	assert.Equal(t, []int{1, 8, 4, 5, 2, 7, 3, 6}, bracketOrder(8))
+}
+
+func TestEliminationRound(t *testing.T) {
+	matches := eliminationRound([]string{"a", "b", "c", "d", "e"})
+	assert.Equal(t, []Match{
+		{Home: "a"},
+		{Home: "d", Away: "e"},
+		{Home: "b"},
+		{Home: "c"},
+	}, matches)
+}
+
+func TestTournament_Advance(t *testing.T) {
+	config := DefaultConfig()
+	config.Format = SingleElimination
+	tournament, _ := New(config)
+	tournament.schedule([]Match{{Home: "a", Away: "b"}, {Home: "c"}})
+	tournament.advance()
+	assert.Len(t, tournament.matches, 2)
+
+	tournament.matches[0].Played = true
+	tournament.advance()
+	assert.Equal(t, Match{Round: 1, Home: "a", Away: "c", Seed: 2}, tournament.matches[2])
+
+	// The final is the last round
+	tournament.matches[2].Played, tournament.matches[2].Winner = true, "c"
+	tournament.advance()
+	assert.Len(t, tournament.matches, 3)
+}
@@ -0,0 +1,83 @@
+package tournament
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// play plays the match to its end and records the result. The entrants start on the opposite sides
+// of the arena center, facing each other.
+func (tournament *Tournament) play(match *Match) error {
+	home, ok := tournament.entrant(match.Home)
+	if !ok {
+		return fmt.Errorf("entrant not found: %s", match.Home)
+	}
+	away, ok := tournament.entrant(match.Away)
+	if !ok {
+		return fmt.Errorf("entrant not found: %s", match.Away)
+	}
+
+	config := tournament.config
+	instance, err := game.NewGameWithConfig(config.Size, match.Seed, config.Game)
+	if err != nil {
+		return err
+	}
+	defer instance.Close()
+	instance.SeedAsteroids()
+
+	center := physics.Vector2{X: config.Size.Width / 2, Y: config.Size.Height / 2}
+	radius := math.Min(config.Size.Width, config.Size.Height) * 0.4
+	for i, entrant := range []Entrant{home, away} {
+		angle := math.Pi * float64(i)
+		offset := physics.FromAngle(angle)
+		shipConfig := game.SpaceshipConfig{
+			Name:     entrant.Name,
+			Position: center.Add(offset.Multiply(radius)),
+			Rotation: angle + math.Pi,
+		}
+		if _, err := instance.AddSpaceshipWithBot(shipConfig, game.NewBotStrategy(entrant.NewBot())); err != nil {
+			return err
+		}
+	}
+
+	instance.Start()
+	for instance.Status() != game.Ended {
+		instance.Update(config.TickMs)
+	}
+
+	scoreboard := instance.Scoreboard()
+	match.Played = true
+	match.Winner = winner(scoreboard)
+	match.EndReason = instance.EndReason()
+	if match.EndReason == "" {
+		match.EndReason = "lastShipStanding"
+	}
+	summary, _ := instance.Summary()
+	match.Ticks = uint64(summary.Rounds)
+	match.Scores = make(map[string]float64, len(scoreboard))
+	for _, entry := range scoreboard {
+		match.Scores[entry.Name] = entry.Score
+	}
+	return nil
+}
+
+// winner returns the survivor, or the top scorer when both or none survived, empty for a draw of the scores.
+func winner(scoreboard game.Scoreboard) string {
+	var alive []string
+	for _, entry := range scoreboard {
+		if entry.Deaths == 0 {
+			alive = append(alive, entry.Name)
+		}
+	}
+	if len(alive) == 1 {
+		return alive[0]
+	}
+	if len(scoreboard) > 1 && scoreboard[0].Score == scoreboard[1].Score {
+		return ""
+	}
+	name, _ := scoreboard.Winner()
+	return name
+}
@@ -0,0 +1,38 @@
+package tournament
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWinner(t *testing.T) {
+	// The survivor wins even with the lower score
+	assert.Equal(t, "beta", winner(game.Scoreboard{
+		{Name: "alpha", Score: 50, ShipStats: game.ShipStats{Deaths: 1}},
+		{Name: "beta", Score: 20},
+	}))
+	assert.Equal(t, "alpha", winner(game.Scoreboard{{Name: "alpha", Score: 50}, {Name: "beta", Score: 20}}))
+	assert.Equal(t, "", winner(game.Scoreboard{{Name: "alpha", Score: 20}, {Name: "beta", Score: 20}}))
+}
+
+func TestTournament_Play(t *testing.T) {
+	tournament, _ := New(testConfig(RoundRobin))
+	_ = tournament.Register("alpha", newChaser)
+	_ = tournament.Register("beta", newIdle)
+
+	match := Match{Home: "alpha", Away: "beta", Seed: 7}
+	assert.NoError(t, tournament.play(&match))
+	assert.True(t, match.Played)
+	assert.Equal(t, "alpha", match.Winner)
+	assert.Greater(t, match.Ticks, uint64(0))
+	assert.Len(t, match.Scores, 2)
+
+	// Reproducible by the seed
+	again := Match{Home: "alpha", Away: "beta", Seed: 7}
+	_ = tournament.play(&again)
+	assert.Equal(t, match, again)
+
+	assert.EqualError(t, tournament.play(&Match{Home: "alpha", Away: "delta"}), "entrant not found: delta")
+}
@@ -0,0 +1,345 @@
+// Package tournament plays the registered bots against each other in a round-robin or a single-elimination
+// bracket. The matches are played one by one with the Game API, every match with the next seed, and
+// the bracket state can be saved between the matches to resume the tournament later.
+package tournament
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+type Format string
+
+const (
+	RoundRobin        Format = "roundRobin"        // Everyone plays everyone once, ranked by the points
+	SingleElimination Format = "singleElimination" // The loser of a match is out, the seeds follow the registration order
+)
+
+const (
+	PointsWin  = 3
+	PointsDraw = 1
+)
+
+var (
+	ErrNotScheduled = errors.New("tournament is not scheduled")
+	ErrScheduled    = errors.New("tournament is already scheduled")
+	ErrFinished     = errors.New("tournament is finished")
+)
+
+// Entrant is a registered bot, NewBot is called for every match so the bots carry no state between them.
+type Entrant struct {
+	Name   string
+	NewBot func() game.Bot
+}
+
+type Config struct {
+	Format Format
+	Size   physics.Size
+	Seed   int64 // Of the first match, every next match increments it
+	TickMs float64
+	Game   game.GameConfig // TimeLimitMs is required, two idle bots would never end the match
+}
+
+func DefaultConfig() Config {
+	gameConfig := game.DefaultGameConfig()
+	gameConfig.TimeLimitMs = 180000
+	return Config{
+		Format: RoundRobin,
+		Size:   physics.Size{Width: 1920, Height: 1080},
+		Seed:   1,
+		TickMs: 16,
+		Game:   gameConfig,
+	}
+}
+
+func (config Config) Validate() error {
+	if config.Format != RoundRobin && config.Format != SingleElimination {
+		return fmt.Errorf("invalid tournament format: %s", config.Format)
+	}
+	if config.TickMs <= 0 || config.Game.TimeLimitMs <= 0 {
+		return errors.New("the tick and the time limit must be positive")
+	}
+	return config.Game.Validate()
+}
+
+// Match is a scheduled match of the bracket. Away is empty for a bye, the home entrant advances without playing.
+type Match struct {
+	Round     int                `json:"round"`
+	Home      string             `json:"home"`
+	Away      string             `json:"away,omitempty"`
+	Seed      int64              `json:"seed"`
+	Played    bool               `json:"played"`
+	Winner    string             `json:"winner,omitempty"` // Empty for a draw
+	EndReason string             `json:"endReason,omitempty"`
+	Ticks     uint64             `json:"ticks,omitempty"`
+	Scores    map[string]float64 `json:"scores,omitempty"`
+}
+
+func (match Match) Bye() bool {
+	return match.Away == ""
+}
+
+type Standing struct {
+	Name   string  `json:"name"`
+	Played int     `json:"played"`
+	Wins   int     `json:"wins"`
+	Draws  int     `json:"draws"`
+	Losses int     `json:"losses"`
+	Points int     `json:"points"`
+	Score  float64 `json:"score"` // Sum of the match scores
+}
+
+// Tournament is not safe for the concurrent use.
+type Tournament struct {
+	config   Config
+	entrants []Entrant
+	matches  []Match
+	nextSeed int64
+}
+
+func New(config Config) (*Tournament, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &Tournament{config: config, nextSeed: config.Seed}, nil
+}
+
+func (tournament *Tournament) Config() Config {
+	return tournament.config
+}
+
+// Register adds the bot to the tournament, only before it is scheduled.
+func (tournament *Tournament) Register(name string, newBot func() game.Bot) error {
+	if tournament.matches != nil {
+		return ErrScheduled
+	}
+	if name == "" || newBot == nil {
+		return errors.New("the entrant needs a name and a bot")
+	}
+	for _, entrant := range tournament.entrants {
+		if entrant.Name == name {
+			return fmt.Errorf("duplicate entrant name: %s", name)
+		}
+	}
+	tournament.entrants = append(tournament.entrants, Entrant{Name: name, NewBot: newBot})
+	return nil
+}
+
+func (tournament *Tournament) Entrants() []Entrant {
+	return append([]Entrant(nil), tournament.entrants...)
+}
+
+// Schedule creates the matches of the format, all of them for the round robin and the first round of
+// the elimination, the next rounds are scheduled once the previous one is finished.
+func (tournament *Tournament) Schedule() error {
+	if tournament.matches != nil {
+		return ErrScheduled
+	}
+	if len(tournament.entrants) < 2 {
+		return errors.New("a tournament needs at least two entrants")
+	}
+	names := make([]string, len(tournament.entrants))
+	for i, entrant := range tournament.entrants {
+		names[i] = entrant.Name
+	}
+	if tournament.config.Format == RoundRobin {
+		tournament.schedule(roundRobin(names))
+	} else {
+		tournament.schedule(eliminationRound(names))
+	}
+	return nil
+}
+
+// schedule appends the matches and assigns the seeds to the ones to be played.
+func (tournament *Tournament) schedule(matches []Match) {
+	if tournament.matches == nil {
+		tournament.matches = []Match{}
+	}
+	for _, match := range matches {
+		if match.Bye() {
+			match.Played, match.Winner = true, match.Home
+		} else {
+			match.Seed = tournament.nextSeed
+			tournament.nextSeed++
+		}
+		tournament.matches = append(tournament.matches, match)
+	}
+}
+
+// Matches returns the scheduled matches in the playing order.
+func (tournament *Tournament) Matches() []Match {
+	return append([]Match(nil), tournament.matches...)
+}
+
+// PlayNext plays the first match not played yet and returns it, ErrFinished once all are played.
+func (tournament *Tournament) PlayNext() (Match, error) {
+	if tournament.matches == nil {
+		return Match{}, ErrNotScheduled
+	}
+	for i := range tournament.matches {
+		match := &tournament.matches[i]
+		if match.Played {
+			continue
+		}
+		if err := tournament.play(match); err != nil {
+			return Match{}, err
+		}
+		played := *match
+		tournament.advance()
+		return played, nil
+	}
+	return Match{}, ErrFinished
+}
+
+// Run plays all the remaining matches.
+func (tournament *Tournament) Run() error {
+	for {
+		_, err := tournament.PlayNext()
+		if errors.Is(err, ErrFinished) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Finished returns whether all the matches are played, the elimination until the final is played.
+func (tournament *Tournament) Finished() bool {
+	if tournament.matches == nil {
+		return false
+	}
+	for _, match := range tournament.matches {
+		if !match.Played {
+			return false
+		}
+	}
+	return tournament.config.Format == RoundRobin || len(tournament.lastRound()) == 1
+}
+
+// Champion returns the winner of the elimination final or the leader of the finished round robin.
+func (tournament *Tournament) Champion() (string, bool) {
+	if !tournament.Finished() {
+		return "", false
+	}
+	if tournament.config.Format == SingleElimination {
+		return tournament.lastRound()[0].Winner, true
+	}
+	return tournament.Standings()[0].Name, true
+}
+
+// Standings ranks the entrants by the points, then the wins, the score and the name. The byes are not counted.
+func (tournament *Tournament) Standings() []Standing {
+	standings := make(map[string]*Standing, len(tournament.entrants))
+	for _, entrant := range tournament.entrants {
+		standings[entrant.Name] = &Standing{Name: entrant.Name}
+	}
+	for _, match := range tournament.matches {
+		if !match.Played || match.Bye() {
+			continue
+		}
+		for _, name := range []string{match.Home, match.Away} {
+			standing, ok := standings[name]
+			if !ok {
+				continue
+			}
+			standing.Played++
+			standing.Score += match.Scores[name]
+			switch match.Winner {
+			case "":
+				standing.Draws++
+				standing.Points += PointsDraw
+			case name:
+				standing.Wins++
+				standing.Points += PointsWin
+			default:
+				standing.Losses++
+			}
+		}
+	}
+
+	ranked := make([]Standing, 0, len(standings))
+	for _, entrant := range tournament.entrants {
+		ranked = append(ranked, *standings[entrant.Name])
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Name < b.Name
+	})
+	return ranked
+}
+
+func (tournament *Tournament) entrant(name string) (Entrant, bool) {
+	for _, entrant := range tournament.entrants {
+		if entrant.Name == name {
+			return entrant, true
+		}
+	}
+	return Entrant{}, false
+}
+
+// state is the serialized bracket, the bots are registered again on Restore.
+type state struct {
+	Format   Format   `json:"format"`
+	Entrants []string `json:"entrants"`
+	Matches  []Match  `json:"matches"`
+	NextSeed int64    `json:"nextSeed"`
+}
+
+// Marshal serializes the bracket state with the played results, see Restore.
+func (tournament *Tournament) Marshal() ([]byte, error) {
+	names := make([]string, len(tournament.entrants))
+	for i, entrant := range tournament.entrants {
+		names[i] = entrant.Name
+	}
+	return json.Marshal(state{
+		Format:   tournament.config.Format,
+		Entrants: names,
+		Matches:  tournament.matches,
+		NextSeed: tournament.nextSeed,
+	})
+}
+
+// Restore resumes the tournament from its Marshal output. The entrants must be the same ones,
+// in the same order, as when it was saved.
+func Restore(config Config, entrants []Entrant, data []byte) (*Tournament, error) {
+	var saved state
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+	if saved.Format != config.Format {
+		return nil, fmt.Errorf("tournament format mismatch: saved %s, configured %s", saved.Format, config.Format)
+	}
+	tournament, err := New(config)
+	if err != nil {
+		return nil, err
+	}
+	if len(entrants) != len(saved.Entrants) {
+		return nil, fmt.Errorf("expected %d entrants, got %d", len(saved.Entrants), len(entrants))
+	}
+	for i, entrant := range entrants {
+		if entrant.Name != saved.Entrants[i] {
+			return nil, fmt.Errorf("entrant %d mismatch: saved %s, got %s", i, saved.Entrants[i], entrant.Name)
+		}
+		if err := tournament.Register(entrant.Name, entrant.NewBot); err != nil {
+			return nil, err
+		}
+	}
+	tournament.matches = saved.Matches
+	tournament.nextSeed = saved.NextSeed
+	return tournament, nil
+}
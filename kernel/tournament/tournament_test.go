@@ -0,0 +1,146 @@
+package tournament
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func newChaser() game.Bot { return game.ChaserBot{} }
+func newIdle() game.Bot   { return game.IdleBot{} }
+
+func testConfig(format Format) Config {
+	config := DefaultConfig()
+	config.Format = format
+	config.Size = physics.Size{Width: 1000, Height: 1000}
+	config.Seed = 42
+	config.Game.TimeLimitMs = 20000
+	return config
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(DefaultConfig())
+	assert.NoError(t, err)
+
+	config := DefaultConfig()
+	config.Format = "swiss"
+	_, err = New(config)
+	assert.EqualError(t, err, "invalid tournament format: swiss")
+
+	config = DefaultConfig()
+	config.Game.TimeLimitMs = 0
+	_, err = New(config)
+	assert.Error(t, err)
+}
+
+func TestTournament_Register(t *testing.T) {
+	tournament, _ := New(DefaultConfig())
+	assert.NoError(t, tournament.Register("alpha", newChaser))
+	assert.EqualError(t, tournament.Register("alpha", newIdle), "duplicate entrant name: alpha")
+	assert.Error(t, tournament.Register("beta", nil))
+	assert.EqualError(t, tournament.Schedule(), "a tournament needs at least two entrants")
+
+	assert.NoError(t, tournament.Register("beta", newIdle))
+	assert.NoError(t, tournament.Schedule())
+	assert.Equal(t, ErrScheduled, tournament.Register("gamma", newIdle))
+	assert.Equal(t, ErrScheduled, tournament.Schedule())
+	assert.Len(t, tournament.Entrants(), 2)
+}
+
+func TestTournament_RoundRobin(t *testing.T) {
+	tournament, _ := New(testConfig(RoundRobin))
+	_ = tournament.Register("alpha", newChaser)
+	_ = tournament.Register("beta", newIdle)
+	_ = tournament.Register("gamma", newIdle)
+
+	_, err := tournament.PlayNext()
+	assert.Equal(t, ErrNotScheduled, err)
+	assert.NoError(t, tournament.Schedule())
+	assert.False(t, tournament.Finished())
+
+	assert.NoError(t, tournament.Run())
+	assert.True(t, tournament.Finished())
+	_, err = tournament.PlayNext()
+	assert.Equal(t, ErrFinished, err)
+
+	// Rotating seeds
+	for i, match := range tournament.Matches() {
+		assert.True(t, match.Played)
+		assert.Equal(t, int64(42+i), match.Seed)
+		assert.NotEmpty(t, match.EndReason)
+	}
+
+	standings := tournament.Standings()
+	assert.Len(t, standings, 3)
+	for _, standing := range standings {
+		assert.Equal(t, 2, standing.Played)
+	}
+	assert.Equal(t, "alpha", standings[0].Name)
+	assert.Equal(t, 2*PointsWin, standings[0].Points)
+	champion, ok := tournament.Champion()
+	assert.True(t, ok)
+	assert.Equal(t, "alpha", champion)
+}
+
+func TestTournament_SingleElimination(t *testing.T) {
+	tournament, _ := New(testConfig(SingleElimination))
+	_ = tournament.Register("alpha", newChaser)
+	_ = tournament.Register("beta", newIdle)
+	_ = tournament.Register("gamma", newChaser)
+	assert.NoError(t, tournament.Schedule())
+
+	// The top seed gets the bye
+	matches := tournament.Matches()
+	assert.Len(t, matches, 2)
+	assert.True(t, matches[0].Bye())
+	assert.Equal(t, "alpha", matches[0].Winner)
+
+	semifinal, err := tournament.PlayNext()
+	assert.NoError(t, err)
+	assert.Equal(t, "beta", semifinal.Home)
+	assert.Equal(t, "gamma", semifinal.Winner)
+	_, ok := tournament.Champion()
+	assert.False(t, ok)
+
+	final, err := tournament.PlayNext()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, final.Round)
+	assert.Equal(t, "alpha", final.Home)
+	assert.Equal(t, "gamma", final.Away)
+	assert.Equal(t, int64(43), final.Seed)
+
+	champion, ok := tournament.Champion()
+	assert.True(t, ok)
+	assert.Equal(t, final.Winner, champion)
+}
+
+func TestTournament_Restore(t *testing.T) {
+	config := testConfig(RoundRobin)
+	entrants := []Entrant{{Name: "alpha", NewBot: newChaser}, {Name: "beta", NewBot: newIdle}, {Name: "gamma", NewBot: newIdle}}
+	tournament, _ := New(config)
+	for _, entrant := range entrants {
+		_ = tournament.Register(entrant.Name, entrant.NewBot)
+	}
+	_ = tournament.Schedule()
+	_, _ = tournament.PlayNext()
+
+	data, err := tournament.Marshal()
+	assert.NoError(t, err)
+	restored, err := Restore(config, entrants, data)
+	assert.NoError(t, err)
+	assert.Equal(t, tournament.Matches(), restored.Matches())
+
+	// The resumed tournament plays the same as the uninterrupted one
+	assert.NoError(t, tournament.Run())
+	assert.NoError(t, restored.Run())
+	assert.Equal(t, tournament.Standings(), restored.Standings())
+
+	_, err = Restore(config, entrants[:2], data)
+	assert.EqualError(t, err, "expected 3 entrants, got 2")
+	_, err = Restore(config, []Entrant{entrants[1], entrants[0], entrants[2]}, data)
+	assert.EqualError(t, err, "entrant 0 mismatch: saved alpha, got beta")
+	_, err = Restore(testConfig(SingleElimination), entrants, data)
+	assert.EqualError(t, err, "tournament format mismatch: saved roundRobin, configured singleElimination")
+}
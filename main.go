@@ -52,6 +52,25 @@ func main() {
 	pauseGameCb := JsFunc(func() { instance.Pause() })
 	resetGameCb := JsFunc(func() { instance.Reset() })
 	gameStateCb := JsFuncOut(func() any { return instance.Serialize() })
+	stateDeltaCb := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		method := Method("stateDelta", args)
+		since, err := method.IntArg(0, "since")
+		if err != nil {
+			fmt.Println(err)
+		}
+		return instance.SerializeDelta(uint64(since))
+	})
+	// The GameState message of kernel/game/proto/game_state.proto, as a Uint8Array
+	binaryStateCb := JsFuncOut(func() any {
+		encoded, err := game.ProtoSerializer{}.Encode(instance)
+		if err != nil {
+			fmt.Println(err)
+			return nil
+		}
+		array := js.Global().Get("Uint8Array").New(len(encoded))
+		js.CopyBytesToJS(array, encoded)
+		return array
+	})
 	fromStateCb := JsFuncIn(func(args []js.Value) {
 		method := Method("fromState", args)
 		state, err := method.StringArg(0, "state")
@@ -97,53 +116,58 @@ func main() {
 			fmt.Println(err)
 		}
 
-		instance.SpaceshipAction(shipName, func(spaceShip *game.Spaceship, gameManager *game.GameManager) {
-			switch action {
-			case "setEngineThrust":
-				mainEngineThrust, err := method.FloatArg(2, "mainEngineThrust")
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-				leftEngineThrust, err := method.FloatArg(3, "leftEngineThrust")
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-				rightEngineThrust, err := method.FloatArg(4, "rightEngineThrust")
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-
-				spaceShip.SetEngineThrust(mainEngineThrust, leftEngineThrust, rightEngineThrust)
-			case "setStartPosition":
-				x, err := method.FloatArg(2, "x")
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-				y, err := method.FloatArg(3, "y")
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-				rotation, err := method.FloatArg(4, "rotation")
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
+		// The competitive actions go through the spaceship's command queue, applied by the next tick
+		command := game.Command{Ship: shipName, Action: action}
+		switch action {
+		case "setEngineThrust":
+			mainEngineThrust, err := method.FloatArg(2, "mainEngineThrust")
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			leftEngineThrust, err := method.FloatArg(3, "leftEngineThrust")
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			rightEngineThrust, err := method.FloatArg(4, "rightEngineThrust")
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			command.Args = []float64{mainEngineThrust, leftEngineThrust, rightEngineThrust}
+		case "setStartPosition":
+			x, err := method.FloatArg(2, "x")
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			y, err := method.FloatArg(3, "y")
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			rotation, err := method.FloatArg(4, "rotation")
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
 
+			instance.SpaceshipAction(shipName, func(spaceShip *game.Spaceship, gameManager *game.GameManager) {
 				spaceShip.SetStartPosition(physics.Vector2{X: x, Y: y})
 				spaceShip.SetStartRotation(rotation)
-			case "fireLaser":
-				spaceShip.FireLaser(gameManager)
-			case "fireRocket":
-				spaceShip.FireRocket(gameManager)
-			default:
-				fmt.Errorf("invalid action: %s", action)
-			}
-		})
+			})
+			return
+		case "raiseDeflector":
+			command = game.Command{Ship: shipName, Action: game.CommandSetDeflector, Args: []float64{1}}
+		case "lowerDeflector":
+			command = game.Command{Ship: shipName, Action: game.CommandSetDeflector, Args: []float64{0}}
+		}
+
+		if err := instance.EnqueueCommand(command); err != nil {
+			fmt.Println(err)
+		}
 	})
 
 	jsGlobal.Set("spaceWars", map[string]interface{}{
@@ -153,6 +177,8 @@ func main() {
 		"pause":        pauseGameCb,
 		"reset":        resetGameCb,
 		"state":        gameStateCb,
+		"binaryState":  binaryStateCb,
+		"stateDelta":   stateDeltaCb,
 		"fromState":    fromStateCb,
 		"addSpaceship": addSpaceshipCb,
 		"action":       spaceShipActionCb,
@@ -165,6 +191,8 @@ func main() {
 	pauseGameCb.Release()
 	resetGameCb.Release()
 	gameStateCb.Release()
+	binaryStateCb.Release()
+	stateDeltaCb.Release()
 	fromStateCb.Release()
 	addSpaceshipCb.Release()
 	spaceShipActionCb.Release()
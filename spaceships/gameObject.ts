@@ -3,6 +3,7 @@ import {
   Explosion,
   GameObject,
   Laser,
+  Pickup,
   Rocket,
   Spaceship,
 } from "./types";
@@ -16,6 +17,9 @@ export const isExplosion = (gameObject: GameObject): gameObject is Explosion =>
 export const isLaser = (gameObject: GameObject): gameObject is Laser =>
   gameObject.type === "laser";
 
+export const isPickup = (gameObject: GameObject): gameObject is Pickup =>
+  gameObject.type === "pickup";
+
 export const isRocket = (gameObject: GameObject): gameObject is Rocket =>
   gameObject.type === "rocket";
 
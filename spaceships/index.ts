@@ -23,6 +23,7 @@ export {
   isAsteroid,
   isExplosion,
   isLaser,
+  isPickup,
   isRocket,
   isSpaceship,
 } from "./gameObject";
@@ -33,6 +34,7 @@ export type {
   SquareCollider,
   CircleCollider,
   PolygonCollider,
+  CapsuleCollider,
   GameObject,
   Spaceship,
   Log,
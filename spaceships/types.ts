@@ -33,6 +33,18 @@ export type PolygonCollider = {
   vertices: { x: number; y: number }[];
 };
 
+export type CapsuleCollider = {
+  type: "capsule";
+  enabled: boolean;
+  position: {
+    x: number;
+    y: number;
+  };
+  rotation: number;
+  length: number;
+  radius: number;
+};
+
 export type GameObject = {
   id: number;
   type: string;
@@ -46,7 +58,12 @@ export type GameObject = {
 export type Asteroid = GameObject & {
   type: "asteroid";
   enabled: boolean;
+  velocity: {
+    x: number;
+    y: number;
+  };
   radius: number;
+  health: number;
   lifespanSec: number;
   collider: CircleCollider;
 };
@@ -59,6 +76,14 @@ export type Explosion = GameObject & {
   lifespanSec: number;
 };
 
+export type Pickup = GameObject & {
+  type: "pickup";
+  enabled: boolean;
+  pickupType: "health" | "energy" | "weaponBoost" | "shield";
+  lifespanSec: number;
+  collider: CircleCollider;
+};
+
 export type Projectile = GameObject & {
   enabled: boolean;
   rotation: number;
@@ -108,13 +133,31 @@ export type Spaceship = GameObject & {
   laserReloadTimerSec: number;
   rocketReloadTimerSec: number;
   collider: CircleCollider;
+  // Only with the inertial flight model
+  kinematics?: {
+    accelerationSec: number;
+    dragSec: number;
+    maxVelocitySec: number;
+    angularAccelerationSec: number;
+    angularDragSec: number;
+    maxAngularVelocitySec: number;
+  };
+  angularVelocity?: number;
 };
 
 export type Log = {
   id: number;
   logType: "damage" | "kill" | "collision" | "game_state";
+  level: "debug" | "info" | "warning" | "error";
   message: string;
   time: string;
+  tick: number;
+  tickMs: number;
+  objectId: number;
+  position?: {
+    x: number;
+    y: number;
+  };
   meta: Record<string, string>;
 };
 
@@ -158,6 +201,6 @@ export type GameState = {
     width: number;
     height: number;
   };
-  gameObjects: (Asteroid | Explosion | Laser | Rocket | Spaceship)[];
+  gameObjects: (Asteroid | Explosion | Laser | Pickup | Rocket | Spaceship)[];
   logs: Log[];
 };